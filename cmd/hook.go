@@ -41,6 +41,7 @@ var (
 			subcmdHookUpdate,
 			subcmdHookPostReceive,
 			subcmdHookProcReceive,
+			subcmdHookPackObjects,
 		},
 	}
 
@@ -89,6 +90,15 @@ var (
 			},
 		},
 	}
+	// Note: it is configured as uploadpack.packObjectsHook when the pack cache is enabled,
+	// the arguments are the pack-objects command line, e.g. "git pack-objects --revs --thin --stdout"
+	subcmdHookPackObjects = &cli.Command{
+		Name:            "pack-objects",
+		Usage:           "Delegate uploadpack.packObjectsHook",
+		Description:     "This command should only be called by Git",
+		Action:          runHookPackObjects,
+		SkipFlagParsing: true,
+	}
 )
 
 type delayWriter struct {
@@ -205,6 +215,8 @@ Gitea or set your environment appropriately.`, "")
 		PullRequestID:                   prID,
 		DeployKeyID:                     deployKeyID,
 		ActionPerm:                      actionPerm,
+		PushCertID:                      os.Getenv(private.GitPushCert),
+		PushCertNonceStatus:             os.Getenv(private.GitPushCertNonceStatus),
 	}
 
 	scanner := bufio.NewScanner(os.Stdin)
@@ -366,6 +378,8 @@ Gitea or set your environment appropriately.`, "")
 		GitPushOptions:                  pushOptions(),
 		PullRequestID:                   prID,
 		PushTrigger:                     repo_module.PushTrigger(os.Getenv(repo_module.EnvPushTrigger)),
+		PushCertID:                      os.Getenv(private.GitPushCert),
+		PushCertNonceStatus:             os.Getenv(private.GitPushCertNonceStatus),
 	}
 	oldCommitIDs := make([]string, hookBatchSize)
 	newCommitIDs := make([]string, hookBatchSize)
@@ -495,6 +509,33 @@ func pushOptions() map[string]string {
 	return opts
 }
 
+func runHookPackObjects(ctx context.Context, c *cli.Command) error {
+	setup(ctx, false)
+
+	// the environment is set by the http handler or the serv command
+	repoID, _ := strconv.ParseInt(os.Getenv(repo_module.EnvRepoID), 10, 64)
+	isWiki, _ := strconv.ParseBool(os.Getenv(repo_module.EnvRepoIsWiki))
+	if repoID == 0 {
+		return fail(ctx, "Rejecting pack-objects as Gitea environment not set", "")
+	}
+
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fail(ctx, "Unable to read pack-objects input", "Read error: %v", err)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	extra := private.HookPackObjects(ctx, repoID, private.HookPackObjectsOptions{
+		IsWiki: isWiki,
+		Args:   c.Args().Slice(),
+		Input:  input,
+	}, out)
+	if extra.HasError() {
+		return fail(ctx, extra.UserMsg, "HookPackObjects(%d) failed: %v", repoID, extra.Error)
+	}
+	return out.Flush()
+}
+
 func runHookProcReceive(ctx context.Context, c *cli.Command) error {
 	setup(ctx, c.Bool("debug"))
 
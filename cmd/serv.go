@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
@@ -21,6 +23,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/lfstransfer"
 	"code.gitea.io/gitea/modules/log"
@@ -153,18 +156,25 @@ func runServ(ctx context.Context, c *cli.Command) error {
 		}
 	}()
 
+	// "key-<id>" for a public key, "user-<id>" for a user authenticated by an SSH certificate of a trusted user CA
 	keys := strings.Split(c.Args().First(), "-")
-	if len(keys) != 2 || keys[0] != "key" {
+	if len(keys) != 2 || (keys[0] != "key" && keys[0] != "user") {
 		return fail(ctx, "Key ID format error", "Invalid key argument: %s", c.Args().First())
 	}
-	keyID, err := strconv.ParseInt(keys[1], 10, 64)
+	id, err := strconv.ParseInt(keys[1], 10, 64)
 	if err != nil {
 		return fail(ctx, "Key ID parsing error", "Invalid key argument: %s", c.Args().Get(1))
 	}
+	var keyID, userID int64
+	if keys[0] == "user" {
+		userID = id
+	} else {
+		keyID = id
+	}
 
 	cmd := os.Getenv("SSH_ORIGINAL_COMMAND")
 	if len(cmd) == 0 {
-		key, user, err := private.ServNoCommand(ctx, keyID)
+		key, user, err := private.ServNoCommand(ctx, keyID, userID)
 		if err != nil {
 			return fail(ctx, "Key check failed", "Failed to check provided key: %v", err)
 		}
@@ -248,7 +258,7 @@ func runServ(ctx context.Context, c *cli.Command) error {
 
 	requestedMode := getAccessMode(verb, lfsVerb)
 
-	results, extra := private.ServCommand(ctx, keyID, username, reponame, requestedMode, verb, lfsVerb)
+	results, extra := private.ServCommand(ctx, keyID, userID, username, reponame, requestedMode, verb, lfsVerb)
 	if extra.HasError() {
 		return fail(ctx, extra.UserMsg, "ServCommand failed: %s", extra.Error)
 	}
@@ -328,12 +338,40 @@ func runServ(ctx context.Context, c *cli.Command) error {
 		repo_module.EnvKeyID+"="+strconv.FormatInt(results.KeyID, 10),
 		repo_module.EnvAppURL+"="+setting.AppURL,
 	)
+	var policyErr error
+	if verb == git.CmdVerbUploadPack {
+		command.Env = append(command.Env, repo_module.UploadPackEnvironment(results.IsWiki, results.UploadPackPolicy)...)
+		if policy := results.UploadPackPolicy; policy != nil {
+			command.Stdin = git.NewUploadPackRequestReader(os.Stdin, func(req *git.UploadPackRequest) error {
+				policyErr = policy.CheckRequest(req, func(wants []string) (bool, error) {
+					return gitrepo.UploadPackWantsHistory(ctx, repo_model.StorageRepo(repoPath), wants)
+				})
+				return policyErr
+			}, func(req *git.UploadPackRequest) {
+				if !req.IsClone() {
+					return
+				}
+				if err := private.ServCloneStat(ctx, results.RepoID, string(repo_model.CloneKindOfRequest(req)), req.Wants[0]); err != nil {
+					log.Error("ServCloneStat: %v", err)
+				}
+			})
+			// the client may keep its end open after git upload-pack exits, don't wait for the copy of the stdin forever
+			command.WaitDelay = time.Second
+		}
+	}
 	// to avoid breaking, here only use the minimal environment variables for the "gitea serv" command.
 	// it could be re-considered whether to use the same git.CommonGitCmdEnvs() as "git" command later.
 	command.Env = append(command.Env, gitcmd.CommonCmdServEnvs()...)
 
 	if err = command.Run(); err != nil {
-		return fail(ctx, "Failed to execute git command", "Failed to execute git command: %v", err)
+		if policyErr != nil {
+			// git upload-pack hasn't got the whole request, so it is waiting for it without sending anything
+			_, _ = os.Stdout.Write(git.UploadPackErrorPacket(policyErr.Error()))
+			return cli.Exit("", 1)
+		}
+		if !errors.Is(err, exec.ErrWaitDelay) {
+			return fail(ctx, "Failed to execute git command", "Failed to execute git command: %v", err)
+		}
 	}
 
 	// Update user key activity.
@@ -180,6 +180,18 @@ func GetMigratingTask(ctx context.Context, repoID int64) (*Task, error) {
 	return &task, nil
 }
 
+// GetLatestRepoTask returns the latest task of the type of a repository
+func GetLatestRepoTask(ctx context.Context, repoID int64, taskType structs.TaskType) (*Task, error) {
+	task := new(Task)
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND type = ?", repoID, taskType).Desc("id").Get(task)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrTaskDoesNotExist{0, repoID, taskType}
+	}
+	return task, nil
+}
+
 // CreateTask creates a task on database
 func CreateTask(ctx context.Context, task *Task) error {
 	return db.Insert(ctx, task)
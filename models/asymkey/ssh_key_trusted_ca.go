@@ -0,0 +1,140 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"golang.org/x/crypto/ssh"
+	"xorm.io/builder"
+)
+
+// PrincipalMapping represents how the principals of the certificates signed by a trusted user CA are mapped to the users
+type PrincipalMapping int
+
+const (
+	// PrincipalMappingUsername maps a principal to the user with the same name
+	PrincipalMappingUsername PrincipalMapping = iota
+	// PrincipalMappingEmail maps a principal to the user with the same activated email address
+	PrincipalMappingEmail
+)
+
+// String returns the name of the principal mapping, which is the same as the value of SSH_AUTHORIZED_PRINCIPALS_ALLOW
+func (m PrincipalMapping) String() string {
+	if m == PrincipalMappingEmail {
+		return "email"
+	}
+	return "username"
+}
+
+// TrustedUserCAKey represents a certificate authority registered by the admins, the built-in SSH server accepts the user
+// certificates signed by it and maps their principals to the users, without the users registering any key or principal.
+type TrustedUserCAKey struct {
+	ID               int64            `xorm:"pk autoincr"`
+	Name             string           `xorm:"UNIQUE NOT NULL"`
+	Content          string           `xorm:"TEXT NOT NULL"`
+	Fingerprint      string           `xorm:"UNIQUE NOT NULL"`
+	PrincipalMapping PrincipalMapping `xorm:"NOT NULL DEFAULT 0"`
+	// MaxValidity is the longest validity period in seconds of the accepted certificates, 0 means no limit
+	MaxValidity  int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	LastUsedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(TrustedUserCAKey))
+}
+
+// PublicKey parses the key of the certificate authority
+func (ca *TrustedUserCAKey) PublicKey() (ssh.PublicKey, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(ca.Content))
+	return key, err
+}
+
+// GetUserByPrincipal returns the user which a principal of a certificate signed by the certificate authority is mapped to
+func (ca *TrustedUserCAKey) GetUserByPrincipal(ctx context.Context, principal string) (*user_model.User, error) {
+	if ca.PrincipalMapping == PrincipalMappingEmail {
+		return user_model.GetUserByEmail(ctx, principal)
+	}
+	return user_model.GetUserByName(ctx, principal)
+}
+
+// AddTrustedUserCAKey registers a certificate authority whose user certificates are accepted by the built-in SSH server
+func AddTrustedUserCAKey(ctx context.Context, ca *TrustedUserCAKey) error {
+	ca.Name = strings.TrimSpace(ca.Name)
+	if ca.Name == "" {
+		return util.NewInvalidArgumentErrorf("name of the certificate authority is empty")
+	}
+	if ca.MaxValidity < 0 {
+		return util.NewInvalidArgumentErrorf("maximum validity of the certificates is negative")
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(ca.Content))
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid public key of the certificate authority: %v", err)
+	}
+	if _, ok := key.(*ssh.Certificate); ok {
+		return util.NewInvalidArgumentErrorf("the key of a certificate authority must not be a certificate")
+	}
+	ca.Content = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	ca.Fingerprint = ssh.FingerprintSHA256(key)
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"name": ca.Name}.Or(builder.Eq{"fingerprint": ca.Fingerprint})).Exist(new(TrustedUserCAKey))
+		if err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("certificate authority %s or its key has been registered", ca.Name)
+		}
+		return db.Insert(ctx, ca)
+	})
+}
+
+// GetTrustedUserCAKeyByFingerprint returns the certificate authority with the fingerprint of its key
+func GetTrustedUserCAKeyByFingerprint(ctx context.Context, fingerprint string) (*TrustedUserCAKey, error) {
+	ca := new(TrustedUserCAKey)
+	has, err := db.GetEngine(ctx).Where("fingerprint = ?", fingerprint).Get(ca)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("certificate authority with fingerprint %s does not exist", fingerprint)
+	}
+	return ca, nil
+}
+
+// UpdateTrustedUserCAKeyLastUsed updates the last time the certificate authority authenticated a user
+func UpdateTrustedUserCAKeyLastUsed(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("last_used_unix").NoAutoTime().Update(&TrustedUserCAKey{LastUsedUnix: timeutil.TimeStampNow()})
+	return err
+}
+
+// DeleteTrustedUserCAKey removes a certificate authority, the certificates signed by it are not accepted anymore
+func DeleteTrustedUserCAKey(ctx context.Context, id int64) error {
+	n, err := db.DeleteByID[TrustedUserCAKey](ctx, id)
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return util.NewNotExistErrorf("certificate authority %d does not exist", id)
+	}
+	return nil
+}
+
+// FindTrustedUserCAKeysOptions represents the options to find the trusted user certificate authorities
+type FindTrustedUserCAKeysOptions struct {
+	db.ListOptions
+}
+
+func (opts FindTrustedUserCAKeysOptions) ToConds() builder.Cond {
+	return builder.NewCond()
+}
+
+func (opts FindTrustedUserCAKeysOptions) ToOrders() string {
+	return "name ASC"
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestTrustedUserCAKey(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caKey, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	content := string(ssh.MarshalAuthorizedKey(caKey))
+
+	ca := &TrustedUserCAKey{Name: " corp ", Content: content + " comment", PrincipalMapping: PrincipalMappingEmail, MaxValidity: 3600}
+	require.NoError(t, AddTrustedUserCAKey(t.Context(), ca))
+	assert.Equal(t, "corp", ca.Name)
+	assert.Equal(t, ssh.FingerprintSHA256(caKey), ca.Fingerprint)
+
+	// the same name or key can't be registered twice
+	err = AddTrustedUserCAKey(t.Context(), &TrustedUserCAKey{Name: "corp", Content: content})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = AddTrustedUserCAKey(t.Context(), &TrustedUserCAKey{Name: "other", Content: content})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = AddTrustedUserCAKey(t.Context(), &TrustedUserCAKey{Name: "invalid", Content: "ssh-ed25519 invalid"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	got, err := GetTrustedUserCAKeyByFingerprint(t.Context(), ca.Fingerprint)
+	require.NoError(t, err)
+	assert.Equal(t, ca.ID, got.ID)
+	assert.Equal(t, PrincipalMappingEmail, got.PrincipalMapping)
+
+	user, err := got.GetUserByPrincipal(t.Context(), "user2@example.com")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, user.ID)
+	got.PrincipalMapping = PrincipalMappingUsername
+	user, err = got.GetUserByPrincipal(t.Context(), "user2")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, user.ID)
+
+	require.NoError(t, UpdateTrustedUserCAKeyLastUsed(t.Context(), ca.ID))
+	cas, err := db.Find[TrustedUserCAKey](t.Context(), FindTrustedUserCAKeysOptions{})
+	require.NoError(t, err)
+	require.Len(t, cas, 1)
+	assert.NotZero(t, cas[0].LastUsedUnix)
+
+	require.NoError(t, DeleteTrustedUserCAKey(t.Context(), ca.ID))
+	_, err = GetTrustedUserCAKeyByFingerprint(t.Context(), ca.Fingerprint)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	assert.ErrorIs(t, DeleteTrustedUserCAKey(t.Context(), ca.ID), util.ErrNotExist)
+}
@@ -14,6 +14,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 )
 
@@ -152,6 +153,15 @@ func CountLFSLockByRepoID(ctx context.Context, repoID int64) (int64, error) {
 
 // DeleteLFSLockByID deletes a lock by given ID.
 func DeleteLFSLockByID(ctx context.Context, id int64, repo *repo_model.Repository, u *user_model.User, force bool) (*LFSLock, error) {
+	return deleteLFSLock(ctx, id, repo, u, force, "")
+}
+
+// BreakLFSLock deletes a lock which could be owned by another user, the reason is recorded if it is broken
+func BreakLFSLock(ctx context.Context, id int64, repo *repo_model.Repository, u *user_model.User, reason string) (*LFSLock, error) {
+	return deleteLFSLock(ctx, id, repo, u, true, reason)
+}
+
+func deleteLFSLock(ctx context.Context, id int64, repo *repo_model.Repository, u *user_model.User, force bool, reason string) (*LFSLock, error) {
 	return db.WithTx2(ctx, func(ctx context.Context) (*LFSLock, error) {
 		lock, err := GetLFSLockByIDAndRepo(ctx, id, repo.ID)
 		if err != nil {
@@ -166,6 +176,25 @@ func DeleteLFSLockByID(ctx context.Context, id int64, repo *repo_model.Repositor
 			return nil, err
 		}
 
+		if u.ID != lock.OwnerID {
+			if err := db.Insert(ctx, &LFSLockBreak{
+				RepoID:     lock.RepoID,
+				Path:       lock.Path,
+				OwnerID:    lock.OwnerID,
+				BreakerID:  u.ID,
+				Reason:     reason,
+				LockedUnix: timeutil.TimeStamp(lock.Created.Unix()),
+			}); err != nil {
+				return nil, err
+			}
+		}
+
 		return lock, nil
 	})
 }
+
+// FindLFSLocksOfOtherOwners returns the locks of a repository which are not owned by the user
+func FindLFSLocksOfOtherOwners(ctx context.Context, repoID, userID int64) (LFSLockList, error) {
+	locks := make(LFSLockList, 0, 10)
+	return locks, db.GetEngine(ctx).Where("repo_id = ? AND owner_id <> ?", repoID, userID).Find(&locks)
+}
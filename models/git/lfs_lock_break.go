@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// LFSLockBreak records a lock which has been deleted by a user who doesn't own it
+type LFSLockBreak struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL"`
+	Path        string             `xorm:"TEXT"`
+	OwnerID     int64              `xorm:"NOT NULL"`
+	Owner       *user_model.User   `xorm:"-"`
+	BreakerID   int64              `xorm:"NOT NULL"`
+	Breaker     *user_model.User   `xorm:"-"`
+	Reason      string             `xorm:"TEXT"`
+	LockedUnix  timeutil.TimeStamp // when the broken lock was created
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(LFSLockBreak))
+}
+
+// FindLFSLockBreaks returns the broken locks of a repository with their users, the most recent first
+func FindLFSLockBreaks(ctx context.Context, repoID int64, page, pageSize int) ([]*LFSLockBreak, error) {
+	breaks := make([]*LFSLockBreak, 0, pageSize)
+	sess := db.GetEngine(ctx).Where("repo_id = ?", repoID).OrderBy("created_unix DESC, id DESC")
+	if page >= 0 && pageSize > 0 {
+		start := 0
+		if page > 0 {
+			start = (page - 1) * pageSize
+		}
+		sess.Limit(pageSize, start)
+	}
+	if err := sess.Find(&breaks); err != nil {
+		return nil, err
+	}
+
+	userIDs := make(container.Set[int64], len(breaks)*2)
+	for _, b := range breaks {
+		userIDs.Add(b.OwnerID)
+		userIDs.Add(b.BreakerID)
+	}
+	users := make(map[int64]*user_model.User, len(userIDs))
+	if err := db.GetEngine(ctx).In("id", userIDs.Values()).Find(&users); err != nil {
+		return nil, fmt.Errorf("find users: %w", err)
+	}
+	for _, b := range breaks {
+		b.Owner, b.Breaker = users[b.OwnerID], users[b.BreakerID]
+		if b.Owner == nil {
+			b.Owner = user_model.NewGhostUser()
+		}
+		if b.Breaker == nil {
+			b.Breaker = user_model.NewGhostUser()
+		}
+	}
+	return breaks, nil
+}
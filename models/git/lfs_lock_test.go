@@ -80,3 +80,37 @@ func TestDeleteLFSLockByIDRequiresRepoMatch(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, lockRepo1.ID, deleted.ID)
 }
+
+func TestBreakLFSLock(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	ownLock := createTestLock(t, repo1, user2)
+	otherLock := createTestLock(t, repo1, user4)
+
+	locks, err := FindLFSLocksOfOtherOwners(t.Context(), repo1.ID, user2.ID)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, otherLock.ID, locks[0].ID)
+
+	// deleting an own lock isn't recorded
+	_, err = BreakLFSLock(t.Context(), ownLock.ID, repo1, user2, "")
+	require.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &LFSLockBreak{RepoID: repo1.ID, OwnerID: user2.ID})
+
+	_, err = BreakLFSLock(t.Context(), otherLock.ID, repo1, user2, "the owner left")
+	require.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &LFSLock{ID: otherLock.ID})
+
+	breaks, err := FindLFSLockBreaks(t.Context(), repo1.ID, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, otherLock.Path, breaks[0].Path)
+	assert.Equal(t, "the owner left", breaks[0].Reason)
+	assert.Equal(t, user4.ID, breaks[0].Owner.ID)
+	assert.Equal(t, user2.ID, breaks[0].Breaker.ID)
+	assert.Equal(t, otherLock.Created.Unix(), int64(breaks[0].LockedUnix))
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrLFSUploadNotExist represents a "LFSUploadNotExist" kind of error.
+type ErrLFSUploadNotExist struct {
+	RepoID int64
+	Key    string
+}
+
+func (err ErrLFSUploadNotExist) Error() string {
+	return fmt.Sprintf("lfs upload does not exist [rid: %d, key: %s]", err.RepoID, err.Key)
+}
+
+func (err ErrLFSUploadNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// LFSUpload represents an unfinished upload of an LFS object in parts
+type LFSUpload struct {
+	ID          int64  `xorm:"pk autoincr"`
+	UUID        string `xorm:"uuid UNIQUE NOT NULL"`
+	lfs.Pointer `xorm:"extends"`
+	RepoID      int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	PartSize    int64 `xorm:"NOT NULL"`
+	// the id of the multipart upload in the object storage, it is empty if the parts are uploaded through Gitea
+	StorageUploadID string
+	// the parts have been committed, the object is being verified in the background
+	IsVerifying bool `xorm:"NOT NULL DEFAULT false"`
+	CreatorID   int64
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(LFSUpload))
+}
+
+// PartCount returns the number of the parts of the object
+func (u *LFSUpload) PartCount() int {
+	return int((u.Size + u.PartSize - 1) / u.PartSize)
+}
+
+// PartRange returns the position and the size of a part, the parts are numbered from 1
+func (u *LFSUpload) PartRange(number int) (pos, size int64) {
+	pos = int64(number-1) * u.PartSize
+	return pos, min(u.PartSize, u.Size-pos)
+}
+
+// RelativePath returns the path of the upload in the LFS storage.
+// The object storages receive the object at this path, the parts uploaded through Gitea are stored below it.
+func (u *LFSUpload) RelativePath() string {
+	return path.Join(lfs.MultipartUploadDir, u.UUID)
+}
+
+// PartRelativePath returns the path of a part uploaded through Gitea in the LFS storage
+func (u *LFSUpload) PartRelativePath(number int) string {
+	return fmt.Sprintf("%s/%05d", u.RelativePath(), number)
+}
+
+// CreateLFSUpload inserts a new upload
+func CreateLFSUpload(ctx context.Context, u *LFSUpload) error {
+	return db.Insert(ctx, u)
+}
+
+// GetLFSUpload returns the unfinished upload of an object to a repository
+func GetLFSUpload(ctx context.Context, repoID int64, oid string) (*LFSUpload, error) {
+	u := &LFSUpload{}
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND oid=?", repoID, oid).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrLFSUploadNotExist{RepoID: repoID, Key: oid}
+	}
+	return u, nil
+}
+
+// GetLFSUploadByID returns an upload by its ID
+func GetLFSUploadByID(ctx context.Context, id int64) (*LFSUpload, error) {
+	u := &LFSUpload{}
+	has, err := db.GetEngine(ctx).ID(id).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrLFSUploadNotExist{Key: fmt.Sprint(id)}
+	}
+	return u, nil
+}
+
+// GetLFSUploadByUUID returns an unfinished upload to a repository by its UUID
+func GetLFSUploadByUUID(ctx context.Context, repoID int64, uuid string) (*LFSUpload, error) {
+	u := &LFSUpload{}
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND uuid=?", repoID, uuid).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrLFSUploadNotExist{RepoID: repoID, Key: uuid}
+	}
+	return u, nil
+}
+
+// FindLFSUploadsCreatedBefore returns the unfinished uploads created before the time
+func FindLFSUploadsCreatedBefore(ctx context.Context, before timeutil.TimeStamp, limit int) ([]*LFSUpload, error) {
+	uploads := make([]*LFSUpload, 0, limit)
+	return uploads, db.GetEngine(ctx).Where("created_unix<?", before).OrderBy("id").Limit(limit).Find(&uploads)
+}
+
+// SetLFSUploadVerifying marks the upload as committed or not, it returns false if it has been marked already
+func SetLFSUploadVerifying(ctx context.Context, u *LFSUpload, verifying bool) (bool, error) {
+	u.IsVerifying = verifying
+	n, err := db.GetEngine(ctx).Where("id=? AND is_verifying=?", u.ID, !verifying).Cols("is_verifying").Update(u)
+	return n > 0, err
+}
+
+// DeleteLFSUpload deletes an upload
+func DeleteLFSUpload(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id=?", id).Delete(new(LFSUpload))
+	return err
+}
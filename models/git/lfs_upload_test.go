@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLFSUpload(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	upload := &git_model.LFSUpload{
+		UUID:     "e3c7a7d1-3f1e-4c5b-9a5e-0b0c6f3d7a10",
+		Pointer:  lfs.Pointer{Oid: "d6f175817f886ec6fbbc1515326465fa96c3bfd54a4ea06cfd6dbbd8340e0153", Size: 10},
+		RepoID:   1,
+		PartSize: 4,
+	}
+	assert.Equal(t, 3, upload.PartCount())
+	pos, size := upload.PartRange(3)
+	assert.EqualValues(t, 8, pos)
+	assert.EqualValues(t, 2, size)
+	assert.Equal(t, "uploads/e3c7a7d1-3f1e-4c5b-9a5e-0b0c6f3d7a10/00002", upload.PartRelativePath(2))
+
+	require.NoError(t, git_model.CreateLFSUpload(ctx, upload))
+	// there is only one upload of an object to a repository
+	assert.Error(t, git_model.CreateLFSUpload(ctx, &git_model.LFSUpload{UUID: "other", Pointer: upload.Pointer, RepoID: 1, PartSize: 4}))
+
+	got, err := git_model.GetLFSUpload(ctx, 1, upload.Oid)
+	require.NoError(t, err)
+	assert.Equal(t, upload.UUID, got.UUID)
+	got, err = git_model.GetLFSUploadByUUID(ctx, 1, upload.UUID)
+	require.NoError(t, err)
+	assert.Equal(t, upload.ID, got.ID)
+	_, err = git_model.GetLFSUploadByUUID(ctx, 2, upload.UUID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	expired, err := git_model.FindLFSUploadsCreatedBefore(ctx, upload.CreatedUnix, 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+	expired, err = git_model.FindLFSUploadsCreatedBefore(ctx, upload.CreatedUnix+timeutil.TimeStamp(1), 10)
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+
+	got, err = git_model.GetLFSUploadByID(ctx, upload.ID)
+	require.NoError(t, err)
+	assert.Equal(t, upload.UUID, got.UUID)
+	marked, err := git_model.SetLFSUploadVerifying(ctx, got, true)
+	require.NoError(t, err)
+	assert.True(t, marked)
+	marked, err = git_model.SetLFSUploadVerifying(ctx, upload, true)
+	require.NoError(t, err)
+	assert.False(t, marked, "the upload has been marked already")
+	got, err = git_model.GetLFSUploadByID(ctx, upload.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsVerifying)
+
+	require.NoError(t, git_model.DeleteLFSUpload(ctx, upload.ID))
+	_, err = git_model.GetLFSUpload(ctx, 1, upload.Oid)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ObjectIDTranslation maps the ID of a commit before the repository was converted to another object format
+// to its ID after the conversion, so the links with the old IDs keep resolving
+type ObjectIDTranslation struct {
+	ID     int64  `xorm:"pk autoincr"`
+	RepoID int64  `xorm:"UNIQUE(repo_old_id) NOT NULL"`
+	OldID  string `xorm:"UNIQUE(repo_old_id) VARCHAR(64) NOT NULL"`
+	NewID  string `xorm:"INDEX VARCHAR(64) NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ObjectIDTranslation))
+}
+
+// InsertObjectIDTranslations stores the translation of the commit IDs of a converted repository
+func InsertObjectIDTranslations(ctx context.Context, repoID int64, translation map[string]string) error {
+	const batchSize = 100
+	batch := make([]*ObjectIDTranslation, 0, batchSize)
+	for oldID, newID := range translation {
+		batch = append(batch, &ObjectIDTranslation{RepoID: repoID, OldID: oldID, NewID: newID})
+		if len(batch) == batchSize {
+			if err := db.Insert(ctx, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return db.Insert(ctx, batch)
+}
+
+// GetTranslatedObjectID returns the ID after the conversion of the repository of a commit ID,
+// which may be abbreviated, from before the conversion
+func GetTranslatedObjectID(ctx context.Context, repoID int64, oldID string) (string, error) {
+	var translations []*ObjectIDTranslation
+	cond := builder.Eq{"repo_id": repoID}.And(builder.Like{"old_id", oldID + "%"})
+	if err := db.GetEngine(ctx).Where(cond).Limit(2).Find(&translations); err != nil {
+		return "", err
+	}
+	// an abbreviated ID must not be ambiguous
+	if len(translations) != 1 {
+		return "", util.NewNotExistErrorf("no translation of object %s in repository %d", oldID, repoID)
+	}
+	return translations[0].NewID, nil
+}
@@ -28,41 +28,43 @@ var ErrBranchIsProtected = util.ErrorWrap(util.ErrPermissionDenied, "branch is p
 
 // ProtectedBranch struct
 type ProtectedBranch struct {
-	ID                            int64                  `xorm:"pk autoincr"`
-	RepoID                        int64                  `xorm:"UNIQUE(s)"`
-	Repo                          *repo_model.Repository `xorm:"-"`
-	RuleName                      string                 `xorm:"'branch_name' UNIQUE(s)"` // a branch name or a glob match to branch name
-	Priority                      int64                  `xorm:"NOT NULL DEFAULT 0"`
-	globRule                      glob.Glob              `xorm:"-"`
-	isPlainName                   bool                   `xorm:"-"`
-	CanPush                       bool                   `xorm:"NOT NULL DEFAULT false"`
-	EnableWhitelist               bool
-	WhitelistUserIDs              []int64  `xorm:"JSON TEXT"`
-	WhitelistTeamIDs              []int64  `xorm:"JSON TEXT"`
-	EnableMergeWhitelist          bool     `xorm:"NOT NULL DEFAULT false"`
-	WhitelistDeployKeys           bool     `xorm:"NOT NULL DEFAULT false"`
-	MergeWhitelistUserIDs         []int64  `xorm:"JSON TEXT"`
-	MergeWhitelistTeamIDs         []int64  `xorm:"JSON TEXT"`
-	CanForcePush                  bool     `xorm:"NOT NULL DEFAULT false"`
-	EnableForcePushAllowlist      bool     `xorm:"NOT NULL DEFAULT false"`
-	ForcePushAllowlistUserIDs     []int64  `xorm:"JSON TEXT"`
-	ForcePushAllowlistTeamIDs     []int64  `xorm:"JSON TEXT"`
-	ForcePushAllowlistDeployKeys  bool     `xorm:"NOT NULL DEFAULT false"`
-	EnableStatusCheck             bool     `xorm:"NOT NULL DEFAULT false"`
-	StatusCheckContexts           []string `xorm:"JSON TEXT"`
-	EnableApprovalsWhitelist      bool     `xorm:"NOT NULL DEFAULT false"`
-	ApprovalsWhitelistUserIDs     []int64  `xorm:"JSON TEXT"`
-	ApprovalsWhitelistTeamIDs     []int64  `xorm:"JSON TEXT"`
-	RequiredApprovals             int64    `xorm:"NOT NULL DEFAULT 0"`
-	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
-	DismissStaleApprovals         bool     `xorm:"NOT NULL DEFAULT false"`
-	IgnoreStaleApprovals          bool     `xorm:"NOT NULL DEFAULT false"`
-	RequireSignedCommits          bool     `xorm:"NOT NULL DEFAULT false"`
-	ProtectedFilePatterns         string   `xorm:"TEXT"`
-	UnprotectedFilePatterns       string   `xorm:"TEXT"`
-	BlockAdminMergeOverride       bool     `xorm:"NOT NULL DEFAULT false"`
+	ID                             int64                  `xorm:"pk autoincr"`
+	RepoID                         int64                  `xorm:"UNIQUE(s)"`
+	Repo                           *repo_model.Repository `xorm:"-"`
+	RuleName                       string                 `xorm:"'branch_name' UNIQUE(s)"` // a branch name or a glob match to branch name
+	Priority                       int64                  `xorm:"NOT NULL DEFAULT 0"`
+	globRule                       glob.Glob              `xorm:"-"`
+	isPlainName                    bool                   `xorm:"-"`
+	CanPush                        bool                   `xorm:"NOT NULL DEFAULT false"`
+	EnableWhitelist                bool
+	WhitelistUserIDs               []int64  `xorm:"JSON TEXT"`
+	WhitelistTeamIDs               []int64  `xorm:"JSON TEXT"`
+	EnableMergeWhitelist           bool     `xorm:"NOT NULL DEFAULT false"`
+	WhitelistDeployKeys            bool     `xorm:"NOT NULL DEFAULT false"`
+	MergeWhitelistUserIDs          []int64  `xorm:"JSON TEXT"`
+	MergeWhitelistTeamIDs          []int64  `xorm:"JSON TEXT"`
+	CanForcePush                   bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableForcePushAllowlist       bool     `xorm:"NOT NULL DEFAULT false"`
+	ForcePushAllowlistUserIDs      []int64  `xorm:"JSON TEXT"`
+	ForcePushAllowlistTeamIDs      []int64  `xorm:"JSON TEXT"`
+	ForcePushAllowlistDeployKeys   bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableStatusCheck              bool     `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts            []string `xorm:"JSON TEXT"`
+	EnableApprovalsWhitelist       bool     `xorm:"NOT NULL DEFAULT false"`
+	ApprovalsWhitelistUserIDs      []int64  `xorm:"JSON TEXT"`
+	ApprovalsWhitelistTeamIDs      []int64  `xorm:"JSON TEXT"`
+	RequiredApprovals              int64    `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews         bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOfficialReviewRequests  bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOutdatedBranch          bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockOnUnresolvedConversations bool     `xorm:"NOT NULL DEFAULT false"`
+	DismissStaleApprovals          bool     `xorm:"NOT NULL DEFAULT false"`
+	IgnoreStaleApprovals           bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits           bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedPushes            bool     `xorm:"NOT NULL DEFAULT false"`
+	ProtectedFilePatterns          string   `xorm:"TEXT"`
+	UnprotectedFilePatterns        string   `xorm:"TEXT"`
+	BlockAdminMergeOverride        bool     `xorm:"NOT NULL DEFAULT false"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
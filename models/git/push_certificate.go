@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// PushCertificate represents a push certificate received by a repository from "git push --signed".
+// It records who pushed which ref updates, independently of the authors and committers of the pushed commits.
+type PushCertificate struct {
+	ID          int64                        `xorm:"pk autoincr"`
+	RepoID      int64                        `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	BlobID      string                       `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"` // the object ID of the certificate in the repository
+	PusherID    int64                        `xorm:"INDEX NOT NULL"`
+	Pusher      *user_model.User             `xorm:"-"`
+	Signer      string                       // the pusher line of the certificate
+	Nonce       string                       `xorm:"VARCHAR(255)"`
+	NonceStatus string                       `xorm:"VARCHAR(20)"`
+	Updates     []*git.PushCertificateUpdate `xorm:"TEXT JSON"`
+	Certificate string                       `xorm:"LONGTEXT"`
+	Verified    bool                         `xorm:"NOT NULL DEFAULT false"`
+	Reason      string                       `xorm:"TEXT"` // the signer and the key if verified, otherwise why the certificate couldn't be verified
+	SigningKey  string                       // the GPG key ID or the SSH key fingerprint
+	CreatedUnix timeutil.TimeStamp           `xorm:"created INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(PushCertificate))
+}
+
+// InsertPushCertificate stores a push certificate, a certificate which has been stored is ignored
+func InsertPushCertificate(ctx context.Context, cert *PushCertificate) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Exist(&PushCertificate{RepoID: cert.RepoID, BlobID: cert.BlobID})
+		if err != nil || exist {
+			return err
+		}
+		return db.Insert(ctx, cert)
+	})
+}
+
+// FindPushCertificatesOptions represents the options to find push certificates
+type FindPushCertificatesOptions struct {
+	db.ListOptions
+	RepoID   int64
+	PusherID int64
+}
+
+func (opts FindPushCertificatesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.PusherID > 0 {
+		cond = cond.And(builder.Eq{"pusher_id": opts.PusherID})
+	}
+	return cond
+}
+
+func (opts FindPushCertificatesOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
+
+// PushCertificateList is a list of PushCertificate
+type PushCertificateList []*PushCertificate
+
+// LoadPushers loads the pushers of the certificates
+func (certs PushCertificateList) LoadPushers(ctx context.Context) error {
+	if len(certs) == 0 {
+		return nil
+	}
+
+	userIDs := container.FilterSlice(certs, func(cert *PushCertificate) (int64, bool) {
+		return cert.PusherID, true
+	})
+	users := make(map[int64]*user_model.User, len(userIDs))
+	if err := db.GetEngine(ctx).In("id", userIDs).Find(&users); err != nil {
+		return fmt.Errorf("find users: %w", err)
+	}
+	for _, cert := range certs {
+		cert.Pusher = users[cert.PusherID]
+		if cert.Pusher == nil {
+			cert.Pusher = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
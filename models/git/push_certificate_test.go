@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertPushCertificate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	cert := &PushCertificate{
+		RepoID:   1,
+		BlobID:   "a3a107df2ca729918d021d02a412fee2feed8a8c",
+		PusherID: 2,
+		Updates: []*git.PushCertificateUpdate{
+			{RefName: "refs/heads/master", OldCommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d", NewCommitID: "3ba2dc422e9942a8cb833a131fad1c1c07945c9e"},
+		},
+		Verified: true,
+	}
+	require.NoError(t, InsertPushCertificate(t.Context(), cert))
+	// the post-receive hook is called for every batch of refs, the same certificate is stored once
+	require.NoError(t, InsertPushCertificate(t.Context(), &PushCertificate{RepoID: 1, BlobID: cert.BlobID, PusherID: 2}))
+	require.NoError(t, InsertPushCertificate(t.Context(), &PushCertificate{RepoID: 1, BlobID: "b3a107df2ca729918d021d02a412fee2feed8a8c", PusherID: 4}))
+
+	certs, err := db.Find[PushCertificate](t.Context(), FindPushCertificatesOptions{RepoID: 1})
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	require.NoError(t, PushCertificateList(certs).LoadPushers(t.Context()))
+	assert.Equal(t, "user4", certs[0].Pusher.Name)
+	assert.Equal(t, "user2", certs[1].Pusher.Name)
+	assert.Equal(t, cert.Updates, certs[1].Updates)
+	assert.True(t, certs[1].Verified)
+
+	certs, err = db.Find[PushCertificate](t.Context(), FindPushCertificatesOptions{RepoID: 1, PusherID: 2})
+	require.NoError(t, err)
+	assert.Len(t, certs, 1)
+}
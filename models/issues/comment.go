@@ -245,6 +245,12 @@ type CommentMetaData struct {
 	ProjectTitle       string `json:"project_title,omitempty"`
 
 	SpecialDoerName SpecialDoerNameType `json:"special_doer_name,omitempty"` // e.g. "CODEOWNERS" for CODEOWNERS-triggered review requests
+
+	// for review requests assigned to a member by the review assignment policy of a team
+	ReviewTeamID           int64  `json:"review_team_id,omitempty"`
+	ReviewTeamName         string `json:"review_team_name,omitempty"`
+	ReassignedFromUserID   int64  `json:"reassigned_from_user_id,omitempty"`
+	ReassignedFromUserName string `json:"reassigned_from_user_name,omitempty"`
 }
 
 // Comment represents a comment in commit and issue page.
@@ -287,7 +293,8 @@ type Comment struct {
 
 	CommitID        int64
 	Line            int64         // - previous line / + proposed line
-	TreePath        string        `xorm:"VARCHAR(4000)"` // SQLServer only supports up to 4000
+	StartLine       int64         `xorm:"NOT NULL DEFAULT 0"` // first line of a multi-line code comment, same side as Line, 0 for single line comments
+	TreePath        string        `xorm:"VARCHAR(4000)"`      // SQLServer only supports up to 4000
 	Content         string        `xorm:"LONGTEXT"`
 	ContentVersion  int           `xorm:"NOT NULL DEFAULT 0"`
 	RenderedContent template.HTML `xorm:"-"`
@@ -341,6 +348,9 @@ func init() {
 type PushActionContent struct {
 	IsForcePush bool     `json:"is_force_push"`
 	CommitIDs   []string `json:"commit_ids"`
+	// OldCommitID and NewCommitID are the heads of the pull request before and after the push
+	OldCommitID string `json:"old_commit_id,omitempty"`
+	NewCommitID string `json:"new_commit_id,omitempty"`
 }
 
 // LoadIssue loads the issue reference for the comment
@@ -756,6 +766,22 @@ func (c *Comment) UnsignedLine() uint64 {
 	return uint64(c.Line)
 }
 
+// UnsignedStartLine returns the first line of a multi-line code comment, for single line comments it's the same as UnsignedLine
+func (c *Comment) UnsignedStartLine() uint64 {
+	if c.StartLine == 0 {
+		return c.UnsignedLine()
+	}
+	if c.StartLine < 0 {
+		return uint64(c.StartLine * -1)
+	}
+	return uint64(c.StartLine)
+}
+
+// IsMultiLine returns true if the code comment spans more than one line
+func (c *Comment) IsMultiLine() bool {
+	return c.StartLine != 0 && c.StartLine != c.Line
+}
+
 // CodeCommentLink returns the url to a comment in code
 func (c *Comment) CodeCommentLink(ctx context.Context) string {
 	err := c.LoadIssue(ctx)
@@ -790,6 +816,12 @@ func (c *Comment) TimelineRequestedReviewTr(locale translation.Locale, createdSt
 			}
 			return locale.Tr("repo.issues.review.remove_review_request", c.Assignee.GetDisplayName(), createdStr)
 		}
+		if c.CommentMetaData != nil && c.CommentMetaData.ReviewTeamID > 0 {
+			if c.CommentMetaData.ReassignedFromUserID > 0 {
+				return locale.Tr("repo.issues.review.reassign_review_request_for_team", c.CommentMetaData.ReassignedFromUserName, c.Assignee.GetDisplayName(), c.CommentMetaData.ReviewTeamName, createdStr)
+			}
+			return locale.Tr("repo.issues.review.add_review_request_for_team", c.Assignee.GetDisplayName(), c.CommentMetaData.ReviewTeamName, createdStr)
+		}
 		return locale.Tr("repo.issues.review.add_review_request", c.Assignee.GetDisplayName(), createdStr)
 	}
 	teamName := "Ghost Team"
@@ -823,6 +855,17 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 				SpecialDoerName: opts.SpecialDoerName,
 			}
 		}
+		if opts.ReviewTeam != nil {
+			if commentMetaData == nil {
+				commentMetaData = &CommentMetaData{}
+			}
+			commentMetaData.ReviewTeamID = opts.ReviewTeam.ID
+			commentMetaData.ReviewTeamName = opts.ReviewTeam.Name
+			if opts.ReassignedFrom != nil {
+				commentMetaData.ReassignedFromUserID = opts.ReassignedFrom.ID
+				commentMetaData.ReassignedFromUserName = opts.ReassignedFrom.Name
+			}
+		}
 
 		comment := &Comment{
 			Type:             opts.Type,
@@ -841,6 +884,7 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 			CommitID:         opts.CommitID,
 			CommitSHA:        opts.CommitSHA,
 			Line:             opts.LineNum,
+			StartLine:        opts.StartLineNum,
 			Content:          opts.Content,
 			OldTitle:         opts.OldTitle,
 			NewTitle:         opts.NewTitle,
@@ -1008,6 +1052,7 @@ type CreateCommentOptions struct {
 	CommitSHA          string
 	Patch              string
 	LineNum            int64
+	StartLineNum       int64
 	TreePath           string
 	ReviewID           int64
 	Content            string
@@ -1020,6 +1065,8 @@ type CreateCommentOptions struct {
 	IsForcePush        bool
 	Invalidated        bool
 	SpecialDoerName    SpecialDoerNameType // e.g. "CODEOWNERS" for CODEOWNERS-triggered review requests
+	ReviewTeam         *organization.Team  // the team on whose behalf the review is requested from AssigneeID
+	ReassignedFrom     *user_model.User    // the team member who had the review request before
 }
 
 // GetCommentByID returns the comment by given ID.
@@ -1137,6 +1184,12 @@ func UpdateCommentInvalidate(ctx context.Context, c *Comment) error {
 	return err
 }
 
+// UpdateCommentLines updates the lines of a code comment which has been moved by later changes
+func UpdateCommentLines(ctx context.Context, c *Comment) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("line", "start_line").NoAutoTime().Update(c)
+	return err
+}
+
 // UpdateComment updates information of comment.
 func UpdateComment(ctx context.Context, c *Comment, contentVersion int, doer *user_model.User) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
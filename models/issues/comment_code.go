@@ -136,3 +136,35 @@ func FetchCodeCommentsByLine(ctx context.Context, issue *Issue, currentUser *use
 	}
 	return findCodeComments(ctx, opts, issue, currentUser, nil, showOutdatedComments)
 }
+
+// CountUnresolvedConversations returns the number of code conversations of a pull request which haven't been resolved.
+// Comments of pending reviews are ignored, a conversation is resolved by marking its first comment.
+func CountUnresolvedConversations(ctx context.Context, issueID int64) (int64, error) {
+	var comments []*Comment
+	if err := db.GetEngine(ctx).Table("comment").
+		Join("LEFT", "review", "review.id = comment.review_id").
+		Where("comment.issue_id = ? AND comment.type = ?", issueID, CommentTypeCode).
+		And(builder.Or(builder.IsNull{"review.id"}, builder.Neq{"review.type": ReviewTypePending})).
+		Asc("comment.created_unix").
+		Asc("comment.id").
+		Cols("comment.id", "comment.tree_path", "comment.line", "comment.resolve_doer_id").
+		Find(&comments); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	seen := make(map[string]map[int64]bool)
+	for _, comment := range comments {
+		if seen[comment.TreePath] == nil {
+			seen[comment.TreePath] = make(map[int64]bool)
+		}
+		if seen[comment.TreePath][comment.Line] {
+			continue
+		}
+		seen[comment.TreePath][comment.Line] = true
+		if comment.ResolveDoerID == 0 {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -81,6 +81,21 @@ func TestFetchCodeComments(t *testing.T) {
 	assert.Len(t, res, 1)
 }
 
+func TestCountUnresolvedConversations(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// comment 4 belongs to a pending review, comments 5 and 6 are the same conversation
+	count, err := issues_model.CountUnresolvedConversations(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	_, err = db.GetEngine(t.Context()).ID(5).Cols("resolve_doer_id").Update(&issues_model.Comment{ResolveDoerID: 1})
+	assert.NoError(t, err)
+	count, err = issues_model.CountUnresolvedConversations(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
+
 func TestAsCommentType(t *testing.T) {
 	assert.Equal(t, issues_model.CommentTypeComment, issues_model.CommentType(0))
 	assert.Equal(t, issues_model.CommentTypeUndefined, issues_model.AsCommentType(""))
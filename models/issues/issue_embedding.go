@@ -0,0 +1,110 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// IssueEmbedding represents the embedding vector of the title and the content of an issue,
+// it's used by the semantic search of the issue indexer
+type IssueEmbedding struct {
+	ID      int64  `xorm:"pk autoincr"`
+	IssueID int64  `xorm:"UNIQUE NOT NULL"`
+	RepoID  int64  `xorm:"INDEX NOT NULL"`
+	Model   string `xorm:"VARCHAR(255) NOT NULL"`
+	// the sha256 of the embedded text, the issue is not embedded again if it doesn't change
+	ContentHash string             `xorm:"VARCHAR(64) NOT NULL"`
+	Vector      []byte             `xorm:"BLOB"` // little-endian float32 values
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueEmbedding))
+}
+
+// GetIssueEmbedding returns the embedding of an issue, it returns nil if the issue is not embedded
+func GetIssueEmbedding(ctx context.Context, issueID int64) (*IssueEmbedding, error) {
+	e := &IssueEmbedding{}
+	has, err := db.GetEngine(ctx).Where("issue_id=?", issueID).Get(e)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// SaveIssueEmbedding inserts or updates the embedding of an issue
+func SaveIssueEmbedding(ctx context.Context, e *IssueEmbedding) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("issue_id=?", e.IssueID).Exist(new(IssueEmbedding))
+		if err != nil {
+			return err
+		}
+		if !has {
+			return db.Insert(ctx, e)
+		}
+		_, err = db.GetEngine(ctx).Where("issue_id=?", e.IssueID).Cols("repo_id", "model", "content_hash", "vector").Update(e)
+		return err
+	})
+}
+
+// DeleteIssueEmbeddings deletes the embeddings of the issues
+func DeleteIssueEmbeddings(ctx context.Context, issueIDs ...int64) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("issue_id", issueIDs).Delete(new(IssueEmbedding))
+	return err
+}
+
+// HasIssueEmbeddings returns whether any issue is embedded by the model
+func HasIssueEmbeddings(ctx context.Context, model string) (bool, error) {
+	return db.GetEngine(ctx).Where("model=?", model).Exist(new(IssueEmbedding))
+}
+
+// IterateIssueEmbeddings iterates at most limit embeddings of the model in the repositories and in all the public repositories
+// if allPublic is true, like IssuesOptions does. All the repositories are iterated if repoIDs is nil and allPublic is false.
+// The embeddings of the newest issues are iterated first.
+func IterateIssueEmbeddings(ctx context.Context, model string, repoIDs []int64, allPublic bool, limit int, f func(ctx context.Context, e *IssueEmbedding) error) error {
+	var repoCond builder.Cond
+	if len(repoIDs) > 0 || (repoIDs != nil && !allPublic) {
+		repoCond = builder.In("repo_id", repoIDs)
+	}
+	if allPublic {
+		if repoCond == nil {
+			repoCond = builder.NewCond()
+		}
+		repoCond = repoCond.Or(builder.In("repo_id", builder.Select("id").From("repository").Where(builder.Eq{"is_private": false})))
+	}
+	cond := builder.NewCond().And(builder.Eq{"model": model})
+	if repoCond != nil {
+		cond = cond.And(repoCond)
+	}
+
+	batchSize := setting.Database.IterateBufferSize
+	for start := 0; start < limit; start += batchSize {
+		size := min(batchSize, limit-start)
+		embeddings := make([]*IssueEmbedding, 0, size)
+		if err := db.GetEngine(ctx).Where(cond).Desc("issue_id").Limit(size, start).Find(&embeddings); err != nil {
+			return err
+		}
+		for _, e := range embeddings {
+			if err := f(ctx, e); err != nil {
+				return err
+			}
+		}
+		if len(embeddings) < size {
+			break
+		}
+	}
+	return nil
+}
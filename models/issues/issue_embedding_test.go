@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"context"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueEmbedding(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	e, err := issues_model.GetIssueEmbedding(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	has, err := issues_model.HasIssueEmbeddings(t.Context(), "model")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	require.NoError(t, issues_model.SaveIssueEmbedding(t.Context(), &issues_model.IssueEmbedding{IssueID: 1, RepoID: 1, Model: "model", ContentHash: "a", Vector: []byte{1, 2, 3, 4}}))
+	require.NoError(t, issues_model.SaveIssueEmbedding(t.Context(), &issues_model.IssueEmbedding{IssueID: 1, RepoID: 1, Model: "model", ContentHash: "b", Vector: []byte{5, 6, 7, 8}}))
+	require.NoError(t, issues_model.SaveIssueEmbedding(t.Context(), &issues_model.IssueEmbedding{IssueID: 4, RepoID: 2, Model: "model", ContentHash: "c"}))
+	unittest.AssertCount(t, &issues_model.IssueEmbedding{}, 2)
+
+	e, err = issues_model.GetIssueEmbedding(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "b", e.ContentHash)
+	assert.Equal(t, []byte{5, 6, 7, 8}, e.Vector)
+
+	iterate := func(t *testing.T, repoIDs []int64, allPublic bool) []int64 {
+		var issueIDs []int64
+		require.NoError(t, issues_model.IterateIssueEmbeddings(t.Context(), "model", repoIDs, allPublic, 10, func(ctx context.Context, e *issues_model.IssueEmbedding) error {
+			issueIDs = append(issueIDs, e.IssueID)
+			return nil
+		}))
+		return issueIDs
+	}
+	assert.Equal(t, []int64{1}, iterate(t, []int64{1}, false))
+	assert.Empty(t, iterate(t, []int64{}, false))
+	assert.ElementsMatch(t, []int64{1, 4}, iterate(t, nil, false))
+	// the repository 2 is private
+	assert.Equal(t, []int64{1}, iterate(t, nil, true))
+	assert.ElementsMatch(t, []int64{1, 4}, iterate(t, []int64{2}, true))
+
+	// the newest issues are iterated first, at most limit of them
+	var issueIDs []int64
+	require.NoError(t, issues_model.IterateIssueEmbeddings(t.Context(), "model", nil, false, 1, func(ctx context.Context, e *issues_model.IssueEmbedding) error {
+		issueIDs = append(issueIDs, e.IssueID)
+		return nil
+	}))
+	assert.Equal(t, []int64{4}, issueIDs)
+
+	require.NoError(t, issues_model.DeleteIssueEmbeddings(t.Context(), 1, 4))
+	unittest.AssertCount(t, &issues_model.IssueEmbedding{}, 0)
+}
@@ -351,6 +351,19 @@ func GetLabelIDsInOrgByNames(ctx context.Context, orgID int64, labelNames []stri
 		Find(&labelIDs)
 }
 
+// GetLabelIDsInReposByName returns the ids of the labels with the name which belong to the repositories or their owners,
+// the name is matched case-insensitively like the milestones. The labels of all the repositories and organizations
+// are returned if repoIDs is empty.
+func GetLabelIDsInReposByName(ctx context.Context, repoIDs []int64, name string) ([]int64, error) {
+	cond := db.BuildCaseInsensitiveIn("name", []string{name})
+	if len(repoIDs) > 0 {
+		cond = cond.And(builder.In("repo_id", repoIDs).
+			Or(builder.In("org_id", builder.Select("owner_id").From("repository").Where(builder.In("id", repoIDs)))))
+	}
+	labelIDs := make([]int64, 0, 2)
+	return labelIDs, db.GetEngine(ctx).Table("label").Where(cond).Cols("id").Find(&labelIDs)
+}
+
 // BuildLabelNamesIssueIDsCondition returns a builder where get issue ids match label names
 func BuildLabelNamesIssueIDsCondition(labelNames []string) *builder.Builder {
 	return builder.Select("issue_label.issue_id").
@@ -124,6 +124,23 @@ func TestGetLabelInRepoByNamesDiscardsNonExistentLabels(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetLabelIDsInReposByName(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	// the names are matched case-insensitively like the milestones
+	labelIDs, err := issues_model.GetLabelIDsInReposByName(t.Context(), []int64{1}, "LABEL1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, labelIDs)
+
+	// the labels of the owner of the repository are included
+	labelIDs, err = issues_model.GetLabelIDsInReposByName(t.Context(), []int64{3}, "OrgLabel3")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, labelIDs)
+
+	labelIDs, err = issues_model.GetLabelIDsInReposByName(t.Context(), []int64{3}, "label1")
+	assert.NoError(t, err)
+	assert.Empty(t, labelIDs)
+}
+
 func TestGetLabelInRepoByID(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 	label, err := issues_model.GetLabelInRepoByID(t.Context(), 1, 1)
@@ -101,6 +101,17 @@ func GetMilestoneIDsByNames(ctx context.Context, names []string) ([]int64, error
 		Find(&ids)
 }
 
+// GetMilestoneIDsInReposByName returns the ids of the milestones with the name in the repositories,
+// the milestones of all the repositories are returned if repoIDs is empty.
+func GetMilestoneIDsInReposByName(ctx context.Context, repoIDs []int64, name string) ([]int64, error) {
+	cond := db.BuildCaseInsensitiveIn("name", []string{name})
+	if len(repoIDs) > 0 {
+		cond = cond.And(builder.In("repo_id", repoIDs))
+	}
+	var ids []int64
+	return ids, db.GetEngine(ctx).Table("milestone").Where(cond).Cols("id").Find(&ids)
+}
+
 // LoadTotalTrackedTimes loads for every milestone in the list the TotalTrackedTime by a batch request
 func (milestones MilestoneList) LoadTotalTrackedTimes(ctx context.Context) error {
 	type totalTimesByMilestone struct {
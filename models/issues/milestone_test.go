@@ -284,6 +284,17 @@ func TestGetMilestonesByRepoIDs(t *testing.T) {
 	})
 }
 
+func TestGetMilestoneIDsInReposByName(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ids, err := issues_model.GetMilestoneIDsInReposByName(t.Context(), []int64{1}, "MILESTONE1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+
+	ids, err = issues_model.GetMilestoneIDsInReposByName(t.Context(), []int64{2}, "milestone1")
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
 func TestNewMilestone(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 	milestone := &issues_model.Milestone{
@@ -126,6 +126,9 @@ type PullRequest struct {
 
 	ChangedProtectedFiles []string `xorm:"TEXT JSON"`
 
+	// RequiredChecklist are the labels of the task list items of the pull request template which must be checked before merging
+	RequiredChecklist []string `xorm:"TEXT JSON"`
+
 	IssueID                    int64  `xorm:"INDEX"`
 	Issue                      *Issue `xorm:"-"`
 	Index                      int64
@@ -824,6 +827,19 @@ func MergeBlockedByOutdatedBranch(protectBranch *git_model.ProtectedBranch, pr *
 	return protectBranch.BlockOnOutdatedBranch && pr.CommitsBehind > 0
 }
 
+// MergeBlockedByUnresolvedConversations returns true if merge is blocked by unresolved code conversations
+func MergeBlockedByUnresolvedConversations(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) bool {
+	if !protectBranch.BlockOnUnresolvedConversations {
+		return false
+	}
+	count, err := CountUnresolvedConversations(ctx, pr.IssueID)
+	if err != nil {
+		log.Error("MergeBlockedByUnresolvedConversations: %v", err)
+		return true
+	}
+	return count > 0
+}
+
 // GetCodeOwnersFromContent returns the code owners configuration
 // Return empty slice if files missing
 // Return warning messages on parsing errors
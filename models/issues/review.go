@@ -644,6 +644,21 @@ func InsertReviews(ctx context.Context, reviews []*Review) error {
 
 // AddReviewRequest add a review request from one reviewer
 func AddReviewRequest(ctx context.Context, issue *Issue, reviewer, doer *user_model.User, isCodeOwners bool) (*Comment, error) {
+	return addReviewRequest(ctx, issue, reviewer, doer, &CreateCommentOptions{
+		SpecialDoerName: util.Iif(isCodeOwners, SpecialDoerNameCodeOwners, ""),
+	})
+}
+
+// AddTeamMemberReviewRequest add a review request from a member on behalf of the team, reassignedFrom is the member who had the request before
+func AddTeamMemberReviewRequest(ctx context.Context, issue *Issue, reviewer, doer *user_model.User, team *organization.Team, reassignedFrom *user_model.User) (*Comment, error) {
+	return addReviewRequest(ctx, issue, reviewer, doer, &CreateCommentOptions{
+		ReviewTeam:     team,
+		ReassignedFrom: reassignedFrom,
+	})
+}
+
+// addReviewRequest add a review request from one reviewer, the metadata of the timeline comment is taken from commentOpts
+func addReviewRequest(ctx context.Context, issue *Issue, reviewer, doer *user_model.User, commentOpts *CreateCommentOptions) (*Comment, error) {
 	return db.WithTx2(ctx, func(ctx context.Context) (*Comment, error) {
 		sess := db.GetEngine(ctx)
 
@@ -702,7 +717,9 @@ func AddReviewRequest(ctx context.Context, issue *Issue, reviewer, doer *user_mo
 			RemovedAssignee: false,       // Use RemovedAssignee as !isRequest
 			AssigneeID:      reviewer.ID, // Use AssigneeID as reviewer ID
 			ReviewID:        review.ID,
-			SpecialDoerName: util.Iif(isCodeOwners, SpecialDoerNameCodeOwners, ""),
+			SpecialDoerName: commentOpts.SpecialDoerName,
+			ReviewTeam:      commentOpts.ReviewTeam,
+			ReassignedFrom:  commentOpts.ReassignedFrom,
 		})
 		if err != nil {
 			return nil, err
@@ -751,6 +768,21 @@ func RemoveReviewRequest(ctx context.Context, issue *Issue, reviewer, doer *user
 	})
 }
 
+// GetLatestReviewRequestComment returns the timeline comment which requested the review from the reviewer the last time
+func GetLatestReviewRequestComment(ctx context.Context, issueID, reviewerID int64) (*Comment, error) {
+	comment := new(Comment)
+	has, err := db.GetEngine(ctx).
+		Where("issue_id = ? AND type = ? AND assignee_id = ? AND removed_assignee = ?", issueID, CommentTypeReviewRequest, reviewerID, false).
+		Desc("id").
+		Get(comment)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrCommentNotExist{0, issueID}
+	}
+	return comment, nil
+}
+
 // Recalculate the latest official review for reviewer
 func restoreLatestOfficialReview(ctx context.Context, issueID, reviewerID int64) error {
 	review, err := GetReviewByIssueIDAndUserID(ctx, issueID, reviewerID)
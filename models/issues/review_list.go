@@ -155,6 +155,32 @@ func CountReviews(ctx context.Context, opts FindReviewOptions) (int64, error) {
 	return db.GetEngine(ctx).Where(opts.toCond()).Count(&Review{})
 }
 
+// CountPendingReviewRequests returns the number of review requests of open pull requests for each of the given users
+func CountPendingReviewRequests(ctx context.Context, userIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+	results := make([]struct {
+		ReviewerID int64
+		Count      int64
+	}, 0, len(userIDs))
+	if err := db.GetEngine(ctx).Table("review").
+		Join("INNER", "issue", "issue.id = review.issue_id").
+		Where(builder.In("review.reviewer_id", userIDs)).
+		And("review.type = ?", ReviewTypeRequest).
+		And("issue.is_closed = ?", false).
+		GroupBy("review.reviewer_id").
+		Select("review.reviewer_id AS reviewer_id, COUNT(*) AS count").
+		Find(&results); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		counts[result.ReviewerID] = result.Count
+	}
+	return counts, nil
+}
+
 // GetReviewsByIssueID gets the latest review of each reviewer for a pull request
 // The first returned parameter is the latest review of each individual reviewer or team
 // The second returned parameter is the latest review of each original author which is migrated from other systems
@@ -0,0 +1,347 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SavedSearch is a named issue or pull request search of a user, it could be shared with a team of an organization
+type SavedSearch struct {
+	ID      int64            `xorm:"pk autoincr"`
+	OwnerID int64            `xorm:"UNIQUE(s) NOT NULL"`
+	Owner   *user_model.User `xorm:"-"`
+	Name    string           `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	// the search query, it could contain qualifiers like "is:open label:bug no:assignee"
+	Query  string `xorm:"TEXT NOT NULL"`
+	IsPull bool   `xorm:"NOT NULL DEFAULT false"`
+	// only the repositories of the organization are searched if it's not 0
+	OrgID       int64              `xorm:"NOT NULL DEFAULT 0"`
+	Org         *user_model.User   `xorm:"-"`
+	TeamID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"` // the team which the search is shared with
+	Team        *organization.Team `xorm:"-"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SavedSearchFrequency is how often the subscribers of a saved search are notified
+type SavedSearchFrequency int
+
+const (
+	SavedSearchFrequencyImmediate SavedSearchFrequency = iota // the new matches are sent as soon as they are found
+	SavedSearchFrequencyDaily                                 // 1, the new matches are sent in a daily digest
+)
+
+// String returns the name of the frequency used by the forms
+func (f SavedSearchFrequency) String() string {
+	if f == SavedSearchFrequencyDaily {
+		return "daily"
+	}
+	return "immediate"
+}
+
+// SavedSearchNotifyMethod is how the subscribers of a saved search are notified
+type SavedSearchNotifyMethod int
+
+const (
+	SavedSearchNotifyMethodNotification SavedSearchNotifyMethod = iota // the new matches are added to the notifications of the subscriber
+	SavedSearchNotifyMethodEmail                                       // 1, the new matches are sent by email
+)
+
+// String returns the name of the method used by the forms
+func (m SavedSearchNotifyMethod) String() string {
+	if m == SavedSearchNotifyMethodEmail {
+		return "email"
+	}
+	return "notification"
+}
+
+// SavedSearchSubscription represents a user who is notified when new issues match a saved search
+type SavedSearchSubscription struct {
+	ID            int64                   `xorm:"pk autoincr"`
+	SavedSearchID int64                   `xorm:"UNIQUE(s) NOT NULL"`
+	UserID        int64                   `xorm:"UNIQUE(s) NOT NULL"`
+	Frequency     SavedSearchFrequency    `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Method        SavedSearchNotifyMethod `xorm:"NOT NULL DEFAULT 0"`
+	// the issues updated before this time have been checked
+	LastCheckedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"created"`
+}
+
+// SavedSearchMatch represents an issue which has been reported to the subscriber as a match of a saved search,
+// an issue is reported only once for a subscription
+type SavedSearchMatch struct {
+	ID             int64              `xorm:"pk autoincr"`
+	SubscriptionID int64              `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID        int64              `xorm:"UNIQUE(s) NOT NULL"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SavedSearch))
+	db.RegisterModel(new(SavedSearchSubscription))
+	db.RegisterModel(new(SavedSearchMatch))
+}
+
+// ErrSavedSearchNotExist represents a "SavedSearchNotExist" kind of error.
+type ErrSavedSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedSearchNotExist checks if an error is a ErrSavedSearchNotExist.
+func IsErrSavedSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedSearchNotExist) Error() string {
+	return fmt.Sprintf("saved search does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedSearchNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// LoadAttributes loads the owner, the organization and the team of the saved search
+func (s *SavedSearch) LoadAttributes(ctx context.Context) (err error) {
+	if s.Owner == nil {
+		if s.Owner, err = user_model.GetPossibleUserByID(ctx, s.OwnerID); err != nil {
+			return err
+		}
+	}
+	if s.Org == nil && s.OrgID > 0 {
+		if s.Org, err = user_model.GetPossibleUserByID(ctx, s.OrgID); err != nil {
+			return err
+		}
+	}
+	if s.Team == nil && s.TeamID > 0 {
+		if s.Team, err = organization.GetTeamByID(ctx, s.TeamID); err != nil && !organization.IsErrTeamNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Link returns the link of the dashboard page listing the issues matching the saved search, the organization must be loaded
+func (s *SavedSearch) Link() string {
+	link := setting.AppSubURL
+	if s.Org != nil {
+		link += "/org/" + url.PathEscape(s.Org.Name)
+	}
+	return link + util.Iif(s.IsPull, "/pulls", "/issues") + "?type=your_repositories&q=" + url.QueryEscape(s.Query)
+}
+
+// HTMLURL returns the absolute URL of the dashboard page listing the issues matching the saved search
+func (s *SavedSearch) HTMLURL() string {
+	return setting.AppURL + strings.TrimPrefix(s.Link(), setting.AppSubURL+"/")
+}
+
+// CanBeUsedBy returns whether the user owns the saved search or is a member of the team which it's shared with
+func (s *SavedSearch) CanBeUsedBy(ctx context.Context, user *user_model.User) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+	if s.OwnerID == user.ID {
+		return true, nil
+	}
+	if s.TeamID == 0 {
+		return false, nil
+	}
+	return organization.IsUserInTeams(ctx, user.ID, []int64{s.TeamID})
+}
+
+// GetSavedSearchByID returns the saved search by its ID
+func GetSavedSearchByID(ctx context.Context, id int64) (*SavedSearch, error) {
+	s := &SavedSearch{}
+	has, err := db.GetEngine(ctx).ID(id).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSavedSearchNotExist{ID: id}
+	}
+	return s, nil
+}
+
+// GetSavedSearchesByUser returns the saved searches owned by the user and the ones shared with the teams of the user
+func GetSavedSearchesByUser(ctx context.Context, userID int64) ([]*SavedSearch, error) {
+	searches := make([]*SavedSearch, 0, 10)
+	return searches, db.GetEngine(ctx).Where(builder.Eq{"owner_id": userID}.Or(
+		builder.In("team_id", builder.Select("team_id").From("team_user").Where(builder.Eq{"uid": userID})),
+	)).OrderBy("name").Find(&searches)
+}
+
+// CreateSavedSearch creates a saved search, the name must be unique for the owner
+func CreateSavedSearch(ctx context.Context, s *SavedSearch) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("owner_id=? AND name=?", s.OwnerID, s.Name).Exist(new(SavedSearch))
+		if err != nil {
+			return err
+		} else if has {
+			return util.NewAlreadyExistErrorf("saved search %q already exists", s.Name)
+		}
+		return db.Insert(ctx, s)
+	})
+}
+
+// DeleteSavedSearch deletes a saved search with its subscriptions
+func DeleteSavedSearch(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		subscriptionIDs := make([]int64, 0, 10)
+		if err := db.GetEngine(ctx).Table("saved_search_subscription").Where("saved_search_id=?", id).Cols("id").Find(&subscriptionIDs); err != nil {
+			return err
+		}
+		for _, subID := range subscriptionIDs {
+			if err := deleteSavedSearchSubscription(ctx, subID); err != nil {
+				return err
+			}
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(new(SavedSearch))
+		return err
+	})
+}
+
+// GetSavedSearchSubscription returns the subscription of the user to the saved search, it returns nil if there is none
+func GetSavedSearchSubscription(ctx context.Context, savedSearchID, userID int64) (*SavedSearchSubscription, error) {
+	sub := &SavedSearchSubscription{}
+	has, err := db.GetEngine(ctx).Where("saved_search_id=? AND user_id=?", savedSearchID, userID).Get(sub)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return sub, nil
+}
+
+// GetSavedSearchSubscriptionsByUser returns the subscriptions of the user, indexed by the IDs of the saved searches
+func GetSavedSearchSubscriptionsByUser(ctx context.Context, userID int64) (map[int64]*SavedSearchSubscription, error) {
+	subscriptions := make([]*SavedSearchSubscription, 0, 10)
+	if err := db.GetEngine(ctx).Where("user_id=?", userID).Find(&subscriptions); err != nil {
+		return nil, err
+	}
+	ret := make(map[int64]*SavedSearchSubscription, len(subscriptions))
+	for _, sub := range subscriptions {
+		ret[sub.SavedSearchID] = sub
+	}
+	return ret, nil
+}
+
+// GetSavedSearchSubscriptionsByFrequency returns the subscriptions notified at the frequency
+func GetSavedSearchSubscriptionsByFrequency(ctx context.Context, frequency SavedSearchFrequency) ([]*SavedSearchSubscription, error) {
+	subscriptions := make([]*SavedSearchSubscription, 0, 10)
+	return subscriptions, db.GetEngine(ctx).Where("frequency=?", frequency).OrderBy("id").Find(&subscriptions)
+}
+
+// SubscribeSavedSearch subscribes the user to the saved search or updates the existing subscription,
+// the issues which already match the saved search are recorded so that only the new matches are notified
+func SubscribeSavedSearch(ctx context.Context, sub *SavedSearchSubscription, matchedIssueIDs []int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetSavedSearchSubscription(ctx, sub.SavedSearchID, sub.UserID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			sub.ID = existing.ID
+			_, err = db.GetEngine(ctx).ID(sub.ID).Cols("frequency", "method").Update(sub)
+			return err
+		}
+		if err := db.Insert(ctx, sub); err != nil {
+			return err
+		}
+		return AddSavedSearchMatches(ctx, sub.ID, matchedIssueIDs)
+	})
+}
+
+// UnsubscribeSavedSearch deletes the subscription of the user to the saved search
+func UnsubscribeSavedSearch(ctx context.Context, savedSearchID, userID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		sub, err := GetSavedSearchSubscription(ctx, savedSearchID, userID)
+		if err != nil || sub == nil {
+			return err
+		}
+		return deleteSavedSearchSubscription(ctx, sub.ID)
+	})
+}
+
+func deleteSavedSearchSubscription(ctx context.Context, id int64) error {
+	if _, err := db.GetEngine(ctx).Where("subscription_id=?", id).Delete(new(SavedSearchMatch)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(id).Delete(new(SavedSearchSubscription))
+	return err
+}
+
+// UpdateSavedSearchSubscriptionLastChecked updates the time before which the issues have been checked for the subscription
+func UpdateSavedSearchSubscriptionLastChecked(ctx context.Context, id int64, lastChecked timeutil.TimeStamp) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("last_checked_unix").Update(&SavedSearchSubscription{LastCheckedUnix: lastChecked})
+	return err
+}
+
+// FilterNewSavedSearchMatches returns the issues which haven't been reported for the subscription, the order is kept
+func FilterNewSavedSearchMatches(ctx context.Context, subscriptionID int64, issueIDs []int64) ([]int64, error) {
+	if len(issueIDs) == 0 {
+		return nil, nil
+	}
+	reported := make([]int64, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).Table("saved_search_match").Where("subscription_id=?", subscriptionID).
+		In("issue_id", issueIDs).Cols("issue_id").Find(&reported); err != nil {
+		return nil, err
+	}
+	ret := make([]int64, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		if !slices.Contains(reported, id) {
+			ret = append(ret, id)
+		}
+	}
+	return ret, nil
+}
+
+// AddSavedSearchMatches records the issues as reported for the subscription
+func AddSavedSearchMatches(ctx context.Context, subscriptionID int64, issueIDs []int64) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	matches := make([]*SavedSearchMatch, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		matches = append(matches, &SavedSearchMatch{SubscriptionID: subscriptionID, IssueID: id})
+	}
+	return db.Insert(ctx, matches)
+}
+
+// DeleteSavedSearchesByUser deletes the saved searches owned by the user and the subscriptions of the user
+func DeleteSavedSearchesByUser(ctx context.Context, userID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		searchIDs := make([]int64, 0, 10)
+		if err := db.GetEngine(ctx).Table("saved_search").Where("owner_id=?", userID).Cols("id").Find(&searchIDs); err != nil {
+			return err
+		}
+		for _, id := range searchIDs {
+			if err := DeleteSavedSearch(ctx, id); err != nil {
+				return err
+			}
+		}
+		subscriptionIDs := make([]int64, 0, 10)
+		if err := db.GetEngine(ctx).Table("saved_search_subscription").Where("user_id=?", userID).Cols("id").Find(&subscriptionIDs); err != nil {
+			return err
+		}
+		for _, id := range subscriptionIDs {
+			if err := deleteSavedSearchSubscription(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
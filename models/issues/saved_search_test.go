@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	// team 2 of org 3 has the members user2 and user4
+	search := &issues_model.SavedSearch{OwnerID: user2.ID, Name: "bugs", Query: "label:bug", OrgID: 3, TeamID: 2}
+	require.NoError(t, issues_model.CreateSavedSearch(t.Context(), search))
+	err := issues_model.CreateSavedSearch(t.Context(), &issues_model.SavedSearch{OwnerID: user2.ID, Name: "bugs"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	for _, user := range []*user_model.User{user2, user4} {
+		searches, err := issues_model.GetSavedSearchesByUser(t.Context(), user.ID)
+		require.NoError(t, err)
+		if assert.Len(t, searches, 1) {
+			assert.Equal(t, search.ID, searches[0].ID)
+		}
+		ok, err := search.CanBeUsedBy(t.Context(), user)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	searches, err := issues_model.GetSavedSearchesByUser(t.Context(), user5.ID)
+	require.NoError(t, err)
+	assert.Empty(t, searches)
+	ok, err := search.CanBeUsedBy(t.Context(), user5)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, search.LoadAttributes(t.Context()))
+	assert.Equal(t, "/org/org3/issues?type=your_repositories&q=label%3Abug", search.Link())
+}
+
+func TestSavedSearchSubscription(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	search := &issues_model.SavedSearch{OwnerID: 2, Name: "mine", Query: "author:@me"}
+	require.NoError(t, issues_model.CreateSavedSearch(t.Context(), search))
+
+	// the issues matching when subscribing aren't new matches
+	sub := &issues_model.SavedSearchSubscription{SavedSearchID: search.ID, UserID: 2}
+	require.NoError(t, issues_model.SubscribeSavedSearch(t.Context(), sub, []int64{1, 2}))
+	ids, err := issues_model.FilterNewSavedSearchMatches(t.Context(), sub.ID, []int64{3, 2, 1, 4})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, ids)
+
+	require.NoError(t, issues_model.AddSavedSearchMatches(t.Context(), sub.ID, []int64{3}))
+	ids, err = issues_model.FilterNewSavedSearchMatches(t.Context(), sub.ID, []int64{3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, ids)
+
+	// subscribing again updates the subscription and keeps the matches
+	require.NoError(t, issues_model.SubscribeSavedSearch(t.Context(), &issues_model.SavedSearchSubscription{
+		SavedSearchID: search.ID,
+		UserID:        2,
+		Frequency:     issues_model.SavedSearchFrequencyDaily,
+		Method:        issues_model.SavedSearchNotifyMethodEmail,
+	}, nil))
+	subs, err := issues_model.GetSavedSearchSubscriptionsByFrequency(t.Context(), issues_model.SavedSearchFrequencyDaily)
+	require.NoError(t, err)
+	if assert.Len(t, subs, 1) {
+		assert.Equal(t, sub.ID, subs[0].ID)
+		assert.Equal(t, issues_model.SavedSearchNotifyMethodEmail, subs[0].Method)
+	}
+	unittest.AssertCount(t, &issues_model.SavedSearchMatch{SubscriptionID: sub.ID}, 3)
+
+	require.NoError(t, issues_model.DeleteSavedSearch(t.Context(), search.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearchSubscription{ID: sub.ID})
+	unittest.AssertCount(t, &issues_model.SavedSearchMatch{SubscriptionID: sub.ID}, 0)
+}
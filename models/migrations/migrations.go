@@ -401,6 +401,20 @@ func prepareMigrationTasks() []*migration {
 		newMigration(324, "Fix closed milestone completeness for milestones with no issues", v1_26.FixClosedMilestoneCompleteness),
 		newMigration(325, "Fix missed repo_id when migrate attachments", v1_26.FixMissedRepoIDWhenMigrateAttachments),
 		newMigration(326, "Add issue graph features (dependencies and PageRank cache)", v1_26.AddGraphCache),
+		newMigration(327, "Add review assignment settings to team", v1_26.AddTeamReviewAssignment),
+		newMigration(328, "Add multi-line code comments and blocking on unresolved conversations", v1_26.AddCommentStartLineAndBlockOnUnresolvedConversations),
+		newMigration(329, "Add required checklist to pull requests", v1_26.AddPullRequestRequiredChecklist),
+		newMigration(330, "Add clone bundle table", v1_26.AddCloneBundleTable),
+		newMigration(331, "Add repository maintenance table", v1_26.AddRepoMaintenanceTable),
+		newMigration(332, "Add push certificates and require signed pushes to protected branches", v1_26.AddPushCertificates),
+		newMigration(333, "Add trusted user CA key table", v1_26.AddTrustedUserCAKeyTable),
+		newMigration(334, "Add object ID translation table", v1_26.AddObjectIDTranslationTable),
+		newMigration(335, "Add repository clone policy and stat tables", v1_26.AddRepoClonePolicyAndStatTables),
+		newMigration(336, "Add LFS upload table", v1_26.AddLFSUploadTable),
+		newMigration(337, "Add LFS lock enforcement and lock break records", v1_26.AddLFSLockEnforcement),
+		newMigration(338, "Add the branches and tags indexed by the code indexer", v1_26.AddCodeIndexerRefs),
+		newMigration(339, "Add the embeddings of issues for semantic search", v1_26.AddIssueEmbedding),
+		newMigration(340, "Add saved searches and their subscriptions", v1_26.AddSavedSearchTables),
 	}
 	return preparedMigrations
 }
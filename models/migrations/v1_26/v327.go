@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddTeamReviewAssignment(x *xorm.Engine) error {
+	type Team struct {
+		ReviewAssignPolicy     string `xorm:"NOT NULL DEFAULT ''"`
+		ReviewAssignCount      int    `xorm:"NOT NULL DEFAULT 0"`
+		ReviewAssignLastUserID int64  `xorm:"NOT NULL DEFAULT 0"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(Team))
+	return err
+}
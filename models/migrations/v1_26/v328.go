@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddCommentStartLineAndBlockOnUnresolvedConversations(x *xorm.Engine) error {
+	type Comment struct {
+		StartLine int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+	type ProtectedBranch struct {
+		BlockOnUnresolvedConversations bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(Comment), new(ProtectedBranch))
+	return err
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddPullRequestRequiredChecklist(x *xorm.Engine) error {
+	type PullRequest struct {
+		RequiredChecklist []string `xorm:"TEXT JSON"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(PullRequest))
+	return err
+}
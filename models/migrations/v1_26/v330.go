@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddCloneBundleTable(x *xorm.Engine) error {
+	type CloneBundle struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		IsFull      bool               `xorm:"NOT NULL DEFAULT false"`
+		Tips        []string           `xorm:"TEXT JSON"`
+		Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL created"`
+	}
+	return x.Sync(new(CloneBundle))
+}
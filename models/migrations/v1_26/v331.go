@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRepoMaintenanceTable(x *xorm.Engine) error {
+	type RepoMaintenance struct {
+		ID                  int64              `xorm:"pk autoincr"`
+		RepoID              int64              `xorm:"UNIQUE NOT NULL"`
+		PushCount           int64              `xorm:"NOT NULL DEFAULT 0"`
+		LooseObjects        int64              `xorm:"NOT NULL DEFAULT 0"`
+		PackCount           int64              `xorm:"NOT NULL DEFAULT 0"`
+		Tasks               []string           `xorm:"TEXT JSON"`
+		Status              int                `xorm:"NOT NULL DEFAULT 0"`
+		Error               string             `xorm:"TEXT"`
+		DurationMs          int64              `xorm:"NOT NULL DEFAULT 0"`
+		LastCheckedUnix     timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		LastMaintenanceUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(RepoMaintenance))
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPushCertificates(x *xorm.Engine) error {
+	type ProtectedBranch struct {
+		RequireSignedPushes bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(ProtectedBranch)); err != nil {
+		return err
+	}
+
+	type PushCertificateUpdate struct {
+		RefName     string `json:"ref_name"`
+		OldCommitID string `json:"old_commit_id"`
+		NewCommitID string `json:"new_commit_id"`
+	}
+	type PushCertificate struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		BlobID      string `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+		PusherID    int64  `xorm:"INDEX NOT NULL"`
+		Signer      string
+		Nonce       string                   `xorm:"VARCHAR(255)"`
+		NonceStatus string                   `xorm:"VARCHAR(20)"`
+		Updates     []*PushCertificateUpdate `xorm:"TEXT JSON"`
+		Certificate string                   `xorm:"LONGTEXT"`
+		Verified    bool                     `xorm:"NOT NULL DEFAULT false"`
+		Reason      string                   `xorm:"TEXT"`
+		SigningKey  string
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+	return x.Sync(new(PushCertificate))
+}
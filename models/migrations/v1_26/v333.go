@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddTrustedUserCAKeyTable(x *xorm.Engine) error {
+	type TrustedUserCAKey struct {
+		ID               int64              `xorm:"pk autoincr"`
+		Name             string             `xorm:"UNIQUE NOT NULL"`
+		Content          string             `xorm:"TEXT NOT NULL"`
+		Fingerprint      string             `xorm:"UNIQUE NOT NULL"`
+		PrincipalMapping int                `xorm:"NOT NULL DEFAULT 0"`
+		MaxValidity      int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix      timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix      timeutil.TimeStamp `xorm:"updated"`
+		LastUsedUnix     timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(TrustedUserCAKey))
+}
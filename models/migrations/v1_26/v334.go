@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddObjectIDTranslationTable(x *xorm.Engine) error {
+	type ObjectIDTranslation struct {
+		ID     int64  `xorm:"pk autoincr"`
+		RepoID int64  `xorm:"UNIQUE(repo_old_id) NOT NULL"`
+		OldID  string `xorm:"UNIQUE(repo_old_id) VARCHAR(64) NOT NULL"`
+		NewID  string `xorm:"INDEX VARCHAR(64) NOT NULL"`
+	}
+	return x.Sync(new(ObjectIDTranslation))
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRepoClonePolicyAndStatTables(x *xorm.Engine) error {
+	type RepoClonePolicy struct {
+		ID                int64              `xorm:"pk autoincr"`
+		RepoID            int64              `xorm:"UNIQUE NOT NULL"`
+		PartialClone      int                `xorm:"NOT NULL DEFAULT 0"`
+		AnonymousMaxDepth int                `xorm:"NOT NULL DEFAULT 0"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+	}
+	type RepoCloneStat struct {
+		ID           int64              `xorm:"pk autoincr"`
+		RepoID       int64              `xorm:"UNIQUE(repo_day) NOT NULL"`
+		Day          timeutil.TimeStamp `xorm:"UNIQUE(repo_day) NOT NULL"`
+		FullCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+		PartialCount int64              `xorm:"NOT NULL DEFAULT 0"`
+		ShallowCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(RepoClonePolicy), new(RepoCloneStat))
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddLFSUploadTable(x *xorm.Engine) error {
+	type LFSUpload struct {
+		ID              int64  `xorm:"pk autoincr"`
+		UUID            string `xorm:"uuid UNIQUE NOT NULL"`
+		Oid             string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Size            int64  `xorm:"NOT NULL"`
+		RepoID          int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		PartSize        int64  `xorm:"NOT NULL"`
+		StorageUploadID string
+		IsVerifying     bool `xorm:"NOT NULL DEFAULT false"`
+		CreatorID       int64
+		CreatedUnix     timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+	return x.Sync(new(LFSUpload))
+}
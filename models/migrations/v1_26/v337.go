@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddLFSLockEnforcement(x *xorm.Engine) error {
+	type Repository struct {
+		EnforceLFSLocks bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	type LFSLockBreak struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"INDEX NOT NULL"`
+		Path        string `xorm:"TEXT"`
+		OwnerID     int64  `xorm:"NOT NULL"`
+		BreakerID   int64  `xorm:"NOT NULL"`
+		Reason      string `xorm:"TEXT"`
+		LockedUnix  timeutil.TimeStamp
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+	return x.Sync(new(Repository), new(LFSLockBreak))
+}
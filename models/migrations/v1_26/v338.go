@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddCodeIndexerRefs(x *xorm.Engine) error {
+	type RepoIndexerStatus struct {
+		RefName string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+	type RepoCodeIndexerRefs struct {
+		ID             int64              `xorm:"pk autoincr"`
+		RepoID         int64              `xorm:"UNIQUE NOT NULL"`
+		BranchPatterns string             `xorm:"TEXT"`
+		TagPatterns    string             `xorm:"TEXT"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(RepoIndexerStatus), new(RepoCodeIndexerRefs))
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIssueEmbedding(x *xorm.Engine) error {
+	type IssueEmbedding struct {
+		ID          int64              `xorm:"pk autoincr"`
+		IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		Model       string             `xorm:"VARCHAR(255) NOT NULL"`
+		ContentHash string             `xorm:"VARCHAR(64) NOT NULL"`
+		Vector      []byte             `xorm:"BLOB"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(IssueEmbedding))
+}
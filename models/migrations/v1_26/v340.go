@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSavedSearchTables(x *xorm.Engine) error {
+	type SavedSearch struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Name        string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		Query       string             `xorm:"TEXT NOT NULL"`
+		IsPull      bool               `xorm:"NOT NULL DEFAULT false"`
+		OrgID       int64              `xorm:"NOT NULL DEFAULT 0"`
+		TeamID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	type SavedSearchSubscription struct {
+		ID              int64              `xorm:"pk autoincr"`
+		SavedSearchID   int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID          int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Frequency       int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Method          int                `xorm:"NOT NULL DEFAULT 0"`
+		LastCheckedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix     timeutil.TimeStamp `xorm:"created"`
+	}
+	type SavedSearchMatch struct {
+		ID             int64              `xorm:"pk autoincr"`
+		SubscriptionID int64              `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID        int64              `xorm:"UNIQUE(s) NOT NULL"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(SavedSearch), new(SavedSearchSubscription), new(SavedSearchMatch))
+}
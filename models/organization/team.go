@@ -84,6 +84,10 @@ type Team struct {
 	Units                   []*TeamUnit `xorm:"-"`
 	IncludesAllRepositories bool        `xorm:"NOT NULL DEFAULT false"`
 	CanCreateOrgRepo        bool        `xorm:"NOT NULL DEFAULT false"`
+
+	ReviewAssignPolicy     ReviewAssignPolicy `xorm:"NOT NULL DEFAULT ''"`
+	ReviewAssignCount      int                `xorm:"NOT NULL DEFAULT 0"`
+	ReviewAssignLastUserID int64              `xorm:"NOT NULL DEFAULT 0"` // the member which got the last review request by round-robin
 }
 
 func init() {
@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+)
+
+// ReviewAssignPolicy represents how a review request for a team is assigned to its members
+type ReviewAssignPolicy string
+
+const (
+	// ReviewAssignPolicyNone requests the review from the whole team
+	ReviewAssignPolicyNone ReviewAssignPolicy = ""
+	// ReviewAssignPolicyRoundRobin assigns the review to the members of the team in turn
+	ReviewAssignPolicyRoundRobin ReviewAssignPolicy = "round_robin"
+	// ReviewAssignPolicyLoadBalance assigns the review to the members with the fewest pending review requests
+	ReviewAssignPolicyLoadBalance ReviewAssignPolicy = "load_balance"
+)
+
+// ParseReviewAssignPolicy returns the review assign policy of the given name, unknown names fall back to ReviewAssignPolicyNone
+func ParseReviewAssignPolicy(s string) ReviewAssignPolicy {
+	switch policy := ReviewAssignPolicy(s); policy {
+	case ReviewAssignPolicyRoundRobin, ReviewAssignPolicyLoadBalance:
+		return policy
+	}
+	return ReviewAssignPolicyNone
+}
+
+// IsReviewAssignEnabled returns true if review requests for the team are assigned to some of its members
+func (t *Team) IsReviewAssignEnabled() bool {
+	return t.ReviewAssignPolicy != ReviewAssignPolicyNone && t.ReviewAssignCount > 0
+}
+
+// GetTeamReviewAssignLastUserID returns the member which got the last review request of the team by round-robin
+func GetTeamReviewAssignLastUserID(ctx context.Context, teamID int64) (int64, error) {
+	var lastUserID int64
+	has, err := db.GetEngine(ctx).Table("team").Where("id = ?", teamID).Cols("review_assign_last_user_id").Get(&lastUserID)
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, ErrTeamNotExist{TeamID: teamID}
+	}
+	return lastUserID, nil
+}
+
+// UpdateTeamReviewAssignLastUser remembers the member which got the last review request of the team by round-robin.
+// The team is only updated if its last member is still oldUserID, it returns false if another request changed it in the meantime.
+func UpdateTeamReviewAssignLastUser(ctx context.Context, t *Team, oldUserID, userID int64) (bool, error) {
+	if oldUserID == userID {
+		// some databases report no affected rows if the value doesn't change
+		t.ReviewAssignLastUserID = userID
+		return true, nil
+	}
+	affected, err := db.GetEngine(ctx).Table("team").
+		Where("id = ? AND review_assign_last_user_id = ?", t.ID, oldUserID).
+		Update(map[string]any{"review_assign_last_user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	t.ReviewAssignLastUserID = userID
+	return true, nil
+}
@@ -206,3 +206,26 @@ func TestIsUsableTeamName(t *testing.T) {
 	assert.NoError(t, organization.IsUsableTeamName("usable"))
 	assert.True(t, db.IsErrNameReserved(organization.IsUsableTeamName("new")))
 }
+
+func TestUpdateTeamReviewAssignLastUser(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 9})
+	lastUserID, err := organization.GetTeamReviewAssignLastUserID(t.Context(), team.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, lastUserID)
+
+	updated, err := organization.UpdateTeamReviewAssignLastUser(t.Context(), team, 0, 15)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.EqualValues(t, 15, team.ReviewAssignLastUserID)
+
+	// another request has moved the turn in the meantime
+	updated, err = organization.UpdateTeamReviewAssignLastUser(t.Context(), team, 0, 20)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 9, ReviewAssignLastUserID: 15})
+
+	_, err = organization.GetTeamReviewAssignLastUserID(t.Context(), unittest.NonexistentID)
+	assert.True(t, organization.IsErrTeamNotExist(err))
+}
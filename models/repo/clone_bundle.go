@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// CloneBundle represents a pre-generated git bundle of a repository advertised by the bundle-uri capability.
+// A full bundle contains all the branches and tags, the following incremental bundles only contain the objects
+// which are not reachable from the tips of the previous bundles.
+type CloneBundle struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL"`
+	IsFull      bool               `xorm:"NOT NULL DEFAULT false"`
+	Tips        []string           `xorm:"TEXT JSON"` // the object IDs of the branches and tags when the bundle was generated
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL created"`
+}
+
+func init() {
+	db.RegisterModel(new(CloneBundle))
+}
+
+// RelativePath returns the bundle path relative to the clone bundle storage root.
+func (b *CloneBundle) RelativePath() string {
+	return fmt.Sprintf("%d/%d.bundle", b.RepoID, b.ID)
+}
+
+// CreationToken returns the token used by the "creationToken" heuristic of the bundle list,
+// the bundles must be applied in the order of the tokens
+func (b *CloneBundle) CreationToken() int64 {
+	return b.ID
+}
+
+// GetCloneBundles returns the bundles of a repository in the order of the generation
+func GetCloneBundles(ctx context.Context, repoID int64) ([]*CloneBundle, error) {
+	bundles := make([]*CloneBundle, 0, 10)
+	return bundles, db.GetEngine(ctx).Where("repo_id=?", repoID).Asc("id").Find(&bundles)
+}
+
+// GetCloneBundle returns a bundle of a repository
+func GetCloneBundle(ctx context.Context, repoID, id int64) (*CloneBundle, error) {
+	bundle := &CloneBundle{}
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(bundle)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, db.ErrNotExist{Resource: "clone_bundle", ID: id}
+	}
+	return bundle, nil
+}
+
+// AddCloneBundle records a generated bundle
+func AddCloneBundle(ctx context.Context, bundle *CloneBundle) error {
+	return db.Insert(ctx, bundle)
+}
+
+// DeleteCloneBundles deletes the records of the bundles
+func DeleteCloneBundles(ctx context.Context, bundles []*CloneBundle) error {
+	ids := make([]int64, 0, len(bundles))
+	for _, b := range bundles {
+		ids = append(ids, b.ID)
+	}
+	_, err := db.GetEngine(ctx).In("id", ids).Delete(&CloneBundle{})
+	return err
+}
+
+// GetCloneBundleRepoIDs returns the IDs of the repositories which have bundles
+func GetCloneBundleRepoIDs(ctx context.Context) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).Table("clone_bundle").Distinct("repo_id").Find(&repoIDs)
+}
+
+// GetRepositoriesForCloneBundles returns the non-empty repositories whose git size is larger than the size
+func GetRepositoriesForCloneBundles(ctx context.Context, minGitSize int64) ([]*Repository, error) {
+	repos := make([]*Repository, 0, 10)
+	return repos, db.GetEngine(ctx).Where("git_size > ? AND is_empty = ?", minGitSize, false).Find(&repos)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// PartialClonePolicy represents whether the partial clones of a repository are allowed
+type PartialClonePolicy int
+
+const (
+	PartialCloneDefault  PartialClonePolicy = iota // follows the instance setting
+	PartialCloneDisabled                           // 1
+	PartialCloneAllowed                            // 2
+	PartialCloneRequired                           // 3
+)
+
+// RepoClonePolicy represents the policy of the clones and fetches of a repository
+type RepoClonePolicy struct { //revive:disable-line:exported
+	ID           int64              `xorm:"pk autoincr"`
+	RepoID       int64              `xorm:"UNIQUE NOT NULL"`
+	PartialClone PartialClonePolicy `xorm:"NOT NULL DEFAULT 0"`
+	// the anonymous fetches must be shallow with at most this depth, 0 follows the instance setting and -1 means no limit
+	AnonymousMaxDepth int                `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoClonePolicy))
+}
+
+// GetRepoClonePolicy returns the clone policy of a repository, the default policy is returned if there is none
+func GetRepoClonePolicy(ctx context.Context, repoID int64) (*RepoClonePolicy, error) {
+	p := &RepoClonePolicy{RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Get(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SaveRepoClonePolicy inserts or updates the clone policy of a repository
+func SaveRepoClonePolicy(ctx context.Context, p *RepoClonePolicy) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("repo_id=?", p.RepoID).Exist(new(RepoClonePolicy))
+		if err != nil {
+			return err
+		}
+		if !has {
+			return db.Insert(ctx, p)
+		}
+		_, err = db.GetEngine(ctx).Where("repo_id=?", p.RepoID).Cols("partial_clone", "anonymous_max_depth").Update(p)
+		return err
+	})
+}
+
+// UploadPackPolicy returns the policy applied to the git upload-pack requests of the repository
+func (p *RepoClonePolicy) UploadPackPolicy(anonymous bool) *git.UploadPackPolicy {
+	policy := &git.UploadPackPolicy{Filters: setting.Git.PartialCloneFilters}
+	switch p.PartialClone {
+	case PartialCloneDefault:
+		policy.AllowFilter = !setting.Git.DisablePartialClone
+	case PartialCloneAllowed:
+		policy.AllowFilter = true
+	case PartialCloneRequired:
+		policy.AllowFilter, policy.RequireFilter = true, true
+	}
+	if anonymous {
+		switch {
+		case p.AnonymousMaxDepth == 0:
+			policy.MaxShallowDepth = setting.Git.AnonymousMaxShallowDepth
+		case p.AnonymousMaxDepth > 0:
+			policy.MaxShallowDepth = p.AnonymousMaxDepth
+		}
+	}
+	return policy
+}
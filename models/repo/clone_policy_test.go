@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoClonePolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Git.AnonymousMaxShallowDepth, 10)()
+
+	p, err := repo_model.GetRepoClonePolicy(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Zero(t, p.ID)
+	assert.Equal(t, &git.UploadPackPolicy{AllowFilter: true, MaxShallowDepth: 10, Filters: []string{}}, p.UploadPackPolicy(true))
+	assert.Zero(t, p.UploadPackPolicy(false).MaxShallowDepth)
+
+	p.PartialClone = repo_model.PartialCloneRequired
+	p.AnonymousMaxDepth = -1
+	require.NoError(t, repo_model.SaveRepoClonePolicy(t.Context(), p))
+	p, err = repo_model.GetRepoClonePolicy(t.Context(), 1)
+	require.NoError(t, err)
+	assert.NotZero(t, p.ID)
+	assert.Equal(t, &git.UploadPackPolicy{AllowFilter: true, RequireFilter: true, Filters: []string{}}, p.UploadPackPolicy(true))
+
+	p.PartialClone = repo_model.PartialCloneDisabled
+	p.AnonymousMaxDepth = 1
+	require.NoError(t, repo_model.SaveRepoClonePolicy(t.Context(), p))
+	p, err = repo_model.GetRepoClonePolicy(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &git.UploadPackPolicy{MaxShallowDepth: 1, Filters: []string{}}, p.UploadPackPolicy(true))
+}
+
+func TestRepoCloneStat(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.Equal(t, repo_model.CloneKindPartial, repo_model.CloneKindOfRequest(&git.UploadPackRequest{Filter: "blob:none", Deepen: 1}))
+	assert.Equal(t, repo_model.CloneKindShallow, repo_model.CloneKindOfRequest(&git.UploadPackRequest{Deepen: 1}))
+	assert.Equal(t, repo_model.CloneKindFull, repo_model.CloneKindOfRequest(&git.UploadPackRequest{}))
+
+	require.NoError(t, repo_model.IncreaseRepoCloneStat(t.Context(), 1, repo_model.CloneKindFull))
+	require.NoError(t, repo_model.IncreaseRepoCloneStat(t.Context(), 1, repo_model.CloneKindPartial))
+	require.NoError(t, repo_model.IncreaseRepoCloneStat(t.Context(), 1, repo_model.CloneKindPartial))
+	stats, err := repo_model.GetRepoCloneStats(t.Context(), 1, 0)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.EqualValues(t, 1, stats[0].FullCount)
+	assert.EqualValues(t, 2, stats[0].PartialCount)
+	assert.EqualValues(t, 3, stats[0].TotalCount())
+}
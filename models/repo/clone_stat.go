@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// CloneKind represents how a repository has been cloned
+type CloneKind string
+
+const (
+	CloneKindFull    CloneKind = "full"
+	CloneKindPartial CloneKind = "partial" // a partial clone is counted as partial even if it is shallow
+	CloneKindShallow CloneKind = "shallow"
+)
+
+// IsValid returns whether the kind is known
+func (k CloneKind) IsValid() bool {
+	return k == CloneKindFull || k == CloneKindPartial || k == CloneKindShallow
+}
+
+// CloneKindOfRequest returns the kind of the clone made by a git upload-pack request
+func CloneKindOfRequest(req *git.UploadPackRequest) CloneKind {
+	switch {
+	case req.Filter != "":
+		return CloneKindPartial
+	case req.Deepen > 0 || req.DeepenOther:
+		return CloneKindShallow
+	}
+	return CloneKindFull
+}
+
+// RepoCloneStat represents the number of clones of a repository in a day
+type RepoCloneStat struct { //revive:disable-line:exported
+	ID           int64              `xorm:"pk autoincr"`
+	RepoID       int64              `xorm:"UNIQUE(repo_day) NOT NULL"`
+	Day          timeutil.TimeStamp `xorm:"UNIQUE(repo_day) NOT NULL"` // the start of the day in UTC
+	FullCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+	PartialCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	ShallowCount int64              `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoCloneStat))
+}
+
+// TotalCount returns the number of all the clones
+func (s *RepoCloneStat) TotalCount() int64 {
+	return s.FullCount + s.PartialCount + s.ShallowCount
+}
+
+// IncreaseRepoCloneStat counts a clone of a repository
+func IncreaseRepoCloneStat(ctx context.Context, repoID int64, kind CloneKind) error {
+	day := timeutil.TimeStamp(time.Now().UTC().Truncate(24 * time.Hour).Unix())
+	column := string(kind) + "_count"
+	affected, err := db.GetEngine(ctx).Where("repo_id=? AND day=?", repoID, day).Incr(column).NoAutoTime().Update(new(RepoCloneStat))
+	if err != nil || affected > 0 {
+		return err
+	}
+	stat := &RepoCloneStat{RepoID: repoID, Day: day}
+	switch kind {
+	case CloneKindFull:
+		stat.FullCount = 1
+	case CloneKindPartial:
+		stat.PartialCount = 1
+	case CloneKindShallow:
+		stat.ShallowCount = 1
+	}
+	if err = db.Insert(ctx, stat); err != nil {
+		// the stat could have been inserted by a concurrent clone
+		_, err = db.GetEngine(ctx).Where("repo_id=? AND day=?", repoID, day).Incr(column).NoAutoTime().Update(new(RepoCloneStat))
+	}
+	return err
+}
+
+// GetRepoCloneStats returns the daily clone stats of a repository since the time, the most recent day first
+func GetRepoCloneStats(ctx context.Context, repoID int64, since timeutil.TimeStamp) ([]*RepoCloneStat, error) {
+	stats := make([]*RepoCloneStat, 0, 30)
+	return stats, db.GetEngine(ctx).Where("repo_id=? AND day>=?", repoID, since).OrderBy("day DESC").Find(&stats)
+}
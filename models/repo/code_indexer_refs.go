@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// RepoCodeIndexerRefs represents the branches and the tags of a repository indexed by the code indexer besides its default branch
+type RepoCodeIndexerRefs struct { //revive:disable-line:exported
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// the semicolon separated globs of the names of the indexed branches and tags
+	BranchPatterns string             `xorm:"TEXT"`
+	TagPatterns    string             `xorm:"TEXT"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoCodeIndexerRefs))
+}
+
+// GetRepoCodeIndexerRefs returns the indexed refs of a repository, nothing matches if there are no patterns
+func GetRepoCodeIndexerRefs(ctx context.Context, repoID int64) (*RepoCodeIndexerRefs, error) {
+	r := &RepoCodeIndexerRefs{RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Get(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SaveRepoCodeIndexerRefs inserts or updates the indexed refs of a repository
+func SaveRepoCodeIndexerRefs(ctx context.Context, r *RepoCodeIndexerRefs) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("repo_id=?", r.RepoID).Exist(new(RepoCodeIndexerRefs))
+		if err != nil {
+			return err
+		}
+		if !has {
+			return db.Insert(ctx, r)
+		}
+		_, err = db.GetEngine(ctx).Where("repo_id=?", r.RepoID).Cols("branch_patterns", "tag_patterns").Update(r)
+		return err
+	})
+}
+
+// ValidateRefPatterns checks the semicolon separated globs of the names of branches or tags
+func ValidateRefPatterns(patterns string) error {
+	for expr := range strings.SplitSeq(patterns, ";") {
+		if expr = strings.TrimSpace(expr); expr == "" {
+			continue
+		}
+		if _, err := glob.Compile(expr, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid glob %q: %v", expr, err)
+		}
+	}
+	return nil
+}
+
+func compileRefPatterns(patterns string) []glob.Glob {
+	globs := make([]glob.Glob, 0, 2)
+	for expr := range strings.SplitSeq(patterns, ";") {
+		if expr = strings.TrimSpace(expr); expr == "" {
+			continue
+		}
+		if g, err := glob.Compile(expr, '/'); err != nil {
+			log.Info("Invalid glob expression '%s' (skipped): %v", expr, err)
+		} else {
+			globs = append(globs, g)
+		}
+	}
+	return globs
+}
+
+// IsEmpty returns whether no branch or tag is indexed besides the default branch
+func (r *RepoCodeIndexerRefs) IsEmpty() bool {
+	return strings.TrimSpace(r.BranchPatterns) == "" && strings.TrimSpace(r.TagPatterns) == ""
+}
+
+// Matcher returns a function which reports whether a branch or a tag is indexed
+func (r *RepoCodeIndexerRefs) Matcher() func(ref git.RefName) bool {
+	branchGlobs, tagGlobs := compileRefPatterns(r.BranchPatterns), compileRefPatterns(r.TagPatterns)
+	return func(ref git.RefName) bool {
+		var globs []glob.Glob
+		var name string
+		switch {
+		case ref.IsBranch():
+			globs, name = branchGlobs, ref.BranchName()
+		case ref.IsTag():
+			globs, name = tagGlobs, ref.TagName()
+		}
+		for _, g := range globs {
+			if g.Match(name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Match returns whether a branch or a tag is indexed
+func (r *RepoCodeIndexerRefs) Match(ref git.RefName) bool {
+	return r.Matcher()(ref)
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoCodeIndexerRefs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	r, err := repo_model.GetRepoCodeIndexerRefs(t.Context(), 1)
+	require.NoError(t, err)
+	assert.True(t, r.IsEmpty())
+	assert.False(t, r.Match(git.RefNameFromBranch("master")))
+
+	r.BranchPatterns = "release/*; develop"
+	r.TagPatterns = "v1.*"
+	require.NoError(t, repo_model.SaveRepoCodeIndexerRefs(t.Context(), r))
+	r, err = repo_model.GetRepoCodeIndexerRefs(t.Context(), 1)
+	require.NoError(t, err)
+	assert.False(t, r.IsEmpty())
+	assert.True(t, r.Match(git.RefNameFromBranch("release/1.0")))
+	assert.False(t, r.Match(git.RefNameFromBranch("release/1.0/fix")))
+	assert.True(t, r.Match(git.RefNameFromBranch("develop")))
+	assert.True(t, r.Match(git.RefNameFromTag("v1.1")))
+	assert.False(t, r.Match(git.RefNameFromTag("develop")))
+	assert.False(t, r.Match(git.RefNameFromBranch("v1.1")))
+
+	assert.NoError(t, repo_model.ValidateRefPatterns("release/*;v[0-9]*"))
+	assert.Error(t, repo_model.ValidateRefPatterns("release/[*"))
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// MaintenanceStatus represents the result of the last git maintenance of a repository
+type MaintenanceStatus int
+
+const (
+	MaintenanceStatusNone    MaintenanceStatus = iota // no maintenance has been run yet
+	MaintenanceStatusSuccess                          // 1
+	MaintenanceStatusFailed                           // 2
+)
+
+// RepoMaintenance represents the git maintenance state of a repository, the maintenance tasks are chosen
+// by the number of pushes since the last maintenance and the loose objects and packs in the repository
+type RepoMaintenance struct { //revive:disable-line:exported
+	ID                  int64              `xorm:"pk autoincr"`
+	RepoID              int64              `xorm:"UNIQUE NOT NULL"`
+	Repo                *Repository        `xorm:"-"`
+	PushCount           int64              `xorm:"NOT NULL DEFAULT 0"` // the number of updated refs since the last maintenance
+	LooseObjects        int64              `xorm:"NOT NULL DEFAULT 0"`
+	PackCount           int64              `xorm:"NOT NULL DEFAULT 0"`
+	Tasks               []string           `xorm:"TEXT JSON"` // the tasks run by the last maintenance
+	Status              MaintenanceStatus  `xorm:"NOT NULL DEFAULT 0"`
+	Error               string             `xorm:"TEXT"`
+	DurationMs          int64              `xorm:"NOT NULL DEFAULT 0"`
+	LastCheckedUnix     timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	LastMaintenanceUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoMaintenance))
+}
+
+// GetRepoMaintenance returns the maintenance state of a repository, an empty state is returned if there is none
+func GetRepoMaintenance(ctx context.Context, repoID int64) (*RepoMaintenance, error) {
+	m := &RepoMaintenance{RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Get(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IncreaseRepoMaintenancePushCount adds the updated refs of a push to the maintenance state of a repository
+func IncreaseRepoMaintenancePushCount(ctx context.Context, repoID, count int64) error {
+	affected, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Incr("push_count", count).NoAutoTime().Update(new(RepoMaintenance))
+	if err != nil || affected > 0 {
+		return err
+	}
+	if err = db.Insert(ctx, &RepoMaintenance{RepoID: repoID, PushCount: count}); err != nil {
+		// the state could have been inserted by a concurrent push
+		_, err = db.GetEngine(ctx).Where("repo_id=?", repoID).Incr("push_count", count).NoAutoTime().Update(new(RepoMaintenance))
+	}
+	return err
+}
+
+// UpdateRepoMaintenance saves the maintenance state of a repository, the handled pushes are subtracted from the push count
+// so the pushes during the maintenance are kept for the next one
+func UpdateRepoMaintenance(ctx context.Context, m *RepoMaintenance, handledPushes int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("repo_id=?", m.RepoID).Exist(new(RepoMaintenance))
+		if err != nil {
+			return err
+		}
+		if !has {
+			m.PushCount = 0
+			return db.Insert(ctx, m)
+		}
+		if _, err := db.GetEngine(ctx).Where("repo_id=?", m.RepoID).
+			Cols("loose_objects", "pack_count", "tasks", "status", "error", "duration_ms", "last_checked_unix", "last_maintenance_unix").
+			Update(m); err != nil {
+			return err
+		}
+		_, err = db.GetEngine(ctx).Where("repo_id=?", m.RepoID).Decr("push_count", handledPushes).NoAutoTime().Update(new(RepoMaintenance))
+		return err
+	})
+}
+
+// FindRepoMaintenances returns the maintenance states of the repositories, the most recently maintained first
+func FindRepoMaintenances(ctx context.Context, opts db.ListOptions) ([]*RepoMaintenance, int64, error) {
+	sess := db.GetEngine(ctx).Where("last_checked_unix > 0").OrderBy("last_maintenance_unix DESC, id DESC")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	states := make([]*RepoMaintenance, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&states)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	repoIDs := make([]int64, 0, len(states))
+	for _, m := range states {
+		repoIDs = append(repoIDs, m.RepoID)
+	}
+	repos := make(map[int64]*Repository, len(repoIDs))
+	if err := db.GetEngine(ctx).In("id", repoIDs).Find(&repos); err != nil {
+		return nil, 0, err
+	}
+	for _, m := range states {
+		m.Repo = repos[m.RepoID]
+	}
+	return states, count, nil
+}
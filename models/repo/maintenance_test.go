@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoMaintenance(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	m, err := repo_model.GetRepoMaintenance(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Zero(t, m.ID)
+	assert.Equal(t, repo_model.MaintenanceStatusNone, m.Status)
+
+	require.NoError(t, repo_model.IncreaseRepoMaintenancePushCount(t.Context(), 1, 3))
+	require.NoError(t, repo_model.IncreaseRepoMaintenancePushCount(t.Context(), 1, 2))
+	m, err = repo_model.GetRepoMaintenance(t.Context(), 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, m.PushCount)
+
+	// the pushes during the maintenance are kept for the next one
+	handled := m.PushCount
+	require.NoError(t, repo_model.IncreaseRepoMaintenancePushCount(t.Context(), 1, 1))
+	m.Status = repo_model.MaintenanceStatusSuccess
+	m.Tasks = []string{"commit-graph"}
+	m.LooseObjects = 7
+	m.LastCheckedUnix = timeutil.TimeStampNow()
+	m.LastMaintenanceUnix = m.LastCheckedUnix
+	require.NoError(t, repo_model.UpdateRepoMaintenance(t.Context(), m, handled))
+
+	m = unittest.AssertExistsAndLoadBean(t, &repo_model.RepoMaintenance{RepoID: 1})
+	assert.EqualValues(t, 1, m.PushCount)
+	assert.EqualValues(t, 7, m.LooseObjects)
+	assert.Equal(t, []string{"commit-graph"}, m.Tasks)
+	assert.Equal(t, repo_model.MaintenanceStatusSuccess, m.Status)
+
+	// the state of a repository without pushes is inserted by the first check
+	require.NoError(t, repo_model.UpdateRepoMaintenance(t.Context(), &repo_model.RepoMaintenance{RepoID: 2, LastCheckedUnix: timeutil.TimeStampNow()}, 0))
+
+	states, count, err := repo_model.FindRepoMaintenances(t.Context(), db.ListOptions{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	require.Len(t, states, 2)
+	assert.EqualValues(t, 1, states[0].RepoID)
+	assert.Equal(t, "repo1", states[0].Repo.Name)
+	assert.EqualValues(t, 2, states[1].RepoID)
+}
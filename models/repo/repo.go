@@ -148,6 +148,7 @@ const (
 	RepositoryBeingMigrated                           // repository is migrating
 	RepositoryPendingTransfer                         // repository pending in ownership transfer state
 	RepositoryBroken                                  // repository is in a permanently broken state
+	RepositoryBeingConverted                          // repository is being converted to another object format
 )
 
 // Repository represents a git repository.
@@ -208,6 +209,7 @@ type Repository struct {
 	StatsIndexerStatus              *RepoIndexerStatus `xorm:"-"`
 	IsFsckEnabled                   bool               `xorm:"NOT NULL DEFAULT true"`
 	CloseIssuesViaCommitInAnyBranch bool               `xorm:"NOT NULL DEFAULT false"`
+	EnforceLFSLocks                 bool               `xorm:"NOT NULL DEFAULT false"` // the pushes changing the files locked by other users are rejected
 	Topics                          []string           `xorm:"TEXT JSON"`
 	ObjectFormatName                string             `xorm:"VARCHAR(6) NOT NULL DEFAULT 'sha1'"`
 
@@ -303,6 +305,19 @@ func (repo *Repository) IsBeingCreated() bool {
 	return repo.IsBeingMigrated()
 }
 
+// IsBeingConverted indicates that repository is being converted to another object format, it can't be written
+func (repo *Repository) IsBeingConverted() bool {
+	return repo.Status == RepositoryBeingConverted
+}
+
+// GetRepositoriesByStatus returns all repositories with the given status
+func GetRepositoriesByStatus(ctx context.Context, status RepositoryStatus) ([]*Repository, error) {
+	repos := make([]*Repository, 0, 10)
+	return repos, db.GetEngine(ctx).
+		Where("status=?", status).
+		Find(&repos)
+}
+
 // IsBroken indicates that repository is broken
 func (repo *Repository) IsBroken() bool {
 	return repo.Status == RepositoryBroken
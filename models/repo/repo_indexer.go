@@ -20,15 +20,18 @@ const (
 	RepoIndexerTypeCode RepoIndexerType = iota // 0
 	// RepoIndexerTypeStats repository stats indexer
 	RepoIndexerTypeStats // 1
+	// RepoIndexerTypeContent wiki, releases and commit messages indexer
+	RepoIndexerTypeContent // 2
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
-// For now, implicitly refers to default branch
+// The status of the default branch has an empty ref name, the code indexer could also index other branches and tags.
 type RepoIndexerStatus struct { //revive:disable-line:exported
 	ID          int64           `xorm:"pk autoincr"`
 	RepoID      int64           `xorm:"INDEX(s)"`
 	CommitSha   string          `xorm:"VARCHAR(64)"`
 	IndexerType RepoIndexerType `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	RefName     string          `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
 }
 
 func init() {
@@ -43,7 +46,7 @@ func GetUnindexedRepos(ctx context.Context, indexerType RepoIndexerType, maxRepo
 	}).And(builder.Eq{
 		"repository.is_empty": false,
 	})
-	sess := db.GetEngine(ctx).Table("repository").Join("LEFT OUTER", "repo_indexer_status", "repository.id = repo_indexer_status.repo_id AND repo_indexer_status.indexer_type = ?", indexerType)
+	sess := db.GetEngine(ctx).Table("repository").Join("LEFT OUTER", "repo_indexer_status", "repository.id = repo_indexer_status.repo_id AND repo_indexer_status.indexer_type = ? AND repo_indexer_status.ref_name = ''", indexerType)
 	if maxRepoID > 0 {
 		cond = builder.And(cond, builder.Lte{
 			"repository.id": maxRepoID,
@@ -75,7 +78,7 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		}
 	}
 	status := &RepoIndexerStatus{RepoID: repo.ID}
-	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Get(status); err != nil {
+	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ? AND `ref_name` = ''", indexerType).Get(status); err != nil {
 		return nil, err
 	} else if !has {
 		status.IndexerType = indexerType
@@ -112,3 +115,60 @@ func UpdateIndexerStatus(ctx context.Context, repo *Repository, indexerType Repo
 	}
 	return nil
 }
+
+// GetIndexerRefStatuses returns the statuses of the refs indexed besides the default branch
+func GetIndexerRefStatuses(ctx context.Context, repoID int64, indexerType RepoIndexerType) ([]*RepoIndexerStatus, error) {
+	statuses := make([]*RepoIndexerStatus, 0, 5)
+	if err := db.GetEngine(ctx).Where("repo_id = ? AND indexer_type = ? AND ref_name <> ''", repoID, indexerType).
+		OrderBy("ref_name").Find(&statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// UpdateIndexerRefStatus updates the indexer status of a ref indexed besides the default branch
+func UpdateIndexerRefStatus(ctx context.Context, repoID int64, indexerType RepoIndexerType, refName, sha string) error {
+	status := &RepoIndexerStatus{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND indexer_type = ? AND ref_name = ?", repoID, indexerType, refName).Get(status)
+	if err != nil {
+		return err
+	} else if !has {
+		return db.Insert(ctx, &RepoIndexerStatus{RepoID: repoID, IndexerType: indexerType, RefName: refName, CommitSha: sha})
+	}
+	status.CommitSha = sha
+	_, err = db.GetEngine(ctx).ID(status.ID).Cols("commit_sha").Update(status)
+	return err
+}
+
+// DeleteIndexerRefStatus deletes the indexer status of a ref which isn't indexed anymore
+func DeleteIndexerRefStatus(ctx context.Context, repoID int64, indexerType RepoIndexerType, refName string) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ? AND indexer_type = ? AND ref_name = ?", repoID, indexerType, refName).
+		Delete(new(RepoIndexerStatus))
+	return err
+}
+
+// DeleteIndexerStatuses deletes the indexer statuses of the default branch and of the refs of a repository,
+// so that the repository is indexed again from scratch
+func DeleteIndexerStatuses(ctx context.Context, repoID int64, indexerType RepoIndexerType) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ? AND indexer_type = ?", repoID, indexerType).
+		Delete(new(RepoIndexerStatus))
+	return err
+}
+
+// GetIndexerStatusesByRepoIDs returns the indexer statuses of the default branches of the repositories,
+// the repositories which haven't been indexed yet are not in the returned map
+func GetIndexerStatusesByRepoIDs(ctx context.Context, indexerType RepoIndexerType, repoIDs []int64) (map[int64]*RepoIndexerStatus, error) {
+	statuses := make(map[int64]*RepoIndexerStatus, len(repoIDs))
+	if len(repoIDs) == 0 {
+		return statuses, nil
+	}
+	list := make([]*RepoIndexerStatus, 0, len(repoIDs))
+	if err := db.GetEngine(ctx).Where("indexer_type = ? AND ref_name = ''", indexerType).
+		In("repo_id", repoIDs).Find(&list); err != nil {
+		return nil, err
+	}
+	for _, status := range list {
+		statuses[status.RepoID] = status
+	}
+	return statuses, nil
+}
@@ -113,6 +113,20 @@ func GetUserAllSettings(ctx context.Context, uid int64) (map[string]*Setting, er
 	return settingsMap, nil
 }
 
+// GetUserIDsBySettingValues returns the users of the given list whose setting of the key is one of the values
+func GetUserIDsBySettingValues(ctx context.Context, userIDs []int64, key string, values ...string) ([]int64, error) {
+	ids := make([]int64, 0, len(userIDs))
+	if len(userIDs) == 0 || len(values) == 0 {
+		return ids, nil
+	}
+	return ids, db.GetEngine(ctx).Table("user_setting").
+		Where(builder.In("user_id", userIDs)).
+		And("setting_key=?", key).
+		And(builder.In("setting_value", values)).
+		Cols("user_id").
+		Find(&ids)
+}
+
 func validateUserSettingKey(key string) error {
 	if len(key) == 0 {
 		return errors.New("setting key must be set")
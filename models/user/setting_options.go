@@ -22,6 +22,12 @@ const (
 
 	SettingsKeyCodeViewShowFileTree = "code_view.show_file_tree"
 
+	// SettingsKeyReviewAvailability is the setting key whether the user can be assigned to review requests of teams
+	SettingsKeyReviewAvailability   = "review.availability"
+	SettingReviewAvailabilityBusy   = "busy"
+	SettingReviewAvailabilityAway   = "away"
+	SettingReviewAvailabilityActive = "" // Default, the user is available for team review requests
+
 	SettingsKeyEmailNotificationGiteaActions        = "email_notification.gitea_actions"
 	SettingEmailNotificationGiteaActionsAll         = "all"
 	SettingEmailNotificationGiteaActionsFailureOnly = "failure-only" // Default for actions email preference
@@ -5,10 +5,14 @@ package git
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"code.gitea.io/gitea/modules/git/gitcmd"
@@ -101,6 +105,26 @@ func syncGitConfig(ctx context.Context) (err error) {
 		}
 	}
 
+	// Advertise the push certificate capability, the nonce seed must be the same for all the processes of an instance
+	// so that the nonces of HTTP pushes (two requests) can be checked
+	if setting.Git.EnableSignedPush {
+		mac := hmac.New(sha256.New, []byte(setting.SecretKey))
+		_, _ = mac.Write([]byte("receive.certNonceSeed"))
+		if err := configSet(ctx, "receive.certNonceSeed", hex.EncodeToString(mac.Sum(nil))); err != nil {
+			return err
+		}
+		if err := configSet(ctx, "receive.certNonceSlop", strconv.Itoa(setting.Git.SignedPushNonceSlop)); err != nil {
+			return err
+		}
+	} else {
+		if err := configUnsetAll(ctx, "receive.certNonceSeed", ""); err != nil {
+			return err
+		}
+		if err := configUnsetAll(ctx, "receive.certNonceSlop", ""); err != nil {
+			return err
+		}
+	}
+
 	// By default partial clones are disabled, enable them from git v2.22
 	if !setting.Git.DisablePartialClone && DefaultFeatures().CheckVersionAtLeast("2.22") {
 		if err = configSet(ctx, "uploadpack.allowfilter", "true"); err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -66,3 +67,15 @@ func TestSyncConfig(t *testing.T) {
 	assert.True(t, gitConfigContains("[sync-test]"))
 	assert.True(t, gitConfigContains("cfg-key-a = CfgValA"))
 }
+
+func TestSyncConfigSignedPush(t *testing.T) {
+	defer test.MockVariableValue(&setting.Git.EnableSignedPush, true)()
+	assert.NoError(t, syncGitConfig(t.Context()))
+	assert.True(t, gitConfigContains("certNonceSeed = "))
+	assert.True(t, gitConfigContains("certNonceSlop = 300"))
+
+	setting.Git.EnableSignedPush = false
+	assert.NoError(t, syncGitConfig(t.Context()))
+	assert.False(t, gitConfigContains("certNonceSeed"))
+	assert.False(t, gitConfigContains("certNonceSlop"))
+}
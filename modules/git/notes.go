@@ -3,12 +3,47 @@
 
 package git
 
+import (
+	"context"
+	"strings"
+)
+
 // NotesRef is the git ref where Gitea will look for git-notes data.
 // The value ("refs/notes/commits") is the default ref used by git-notes.
 const NotesRef = "refs/notes/commits"
 
+// NotesRefPrefix is the prefix of the git-notes refs
+const NotesRefPrefix = "refs/notes/"
+
 // Note stores information about a note created using git-notes.
 type Note struct {
 	Message []byte
 	Commit  *Commit
 }
+
+// NotesRefName returns the notes ref of a notes namespace, the namespace could be a short name like "commits"
+// or a full ref name like "refs/notes/commits", an empty namespace is the default one.
+// It returns false if the namespace is not a valid notes ref.
+func NotesRefName(namespace string) (string, bool) {
+	if namespace == "" {
+		return NotesRef, true
+	}
+	refName := namespace
+	if !strings.HasPrefix(refName, NotesRefPrefix) {
+		refName = NotesRefPrefix + refName
+	}
+	if len(refName) == len(NotesRefPrefix) || !IsValidRefPattern(refName) {
+		return "", false
+	}
+	return refName, true
+}
+
+// NotesNamespace returns the short name of a notes ref
+func NotesNamespace(notesRef string) string {
+	return strings.TrimPrefix(notesRef, NotesRefPrefix)
+}
+
+// GetNote retrieves the git-notes data for a given commit from the default notes ref.
+func GetNote(ctx context.Context, repo *Repository, commitID string, note *Note) error {
+	return GetNoteFromRef(ctx, repo, NotesRef, commitID, note)
+}
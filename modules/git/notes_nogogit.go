@@ -13,16 +13,16 @@ import (
 	"code.gitea.io/gitea/modules/log"
 )
 
-// GetNote retrieves the git-notes data for a given commit.
+// GetNoteFromRef retrieves the git-notes data for a given commit from a notes ref.
 // FIXME: Add LastCommitCache support
-func GetNote(ctx context.Context, repo *Repository, commitID string, note *Note) error {
-	log.Trace("Searching for git note corresponding to the commit %q in the repository %q", commitID, repo.Path)
-	notes, err := repo.GetCommit(NotesRef)
+func GetNoteFromRef(ctx context.Context, repo *Repository, notesRef, commitID string, note *Note) error {
+	log.Trace("Searching for git note in %q corresponding to the commit %q in the repository %q", notesRef, commitID, repo.Path)
+	notes, err := repo.GetCommit(notesRef)
 	if err != nil {
 		if IsErrNotExist(err) {
 			return err
 		}
-		log.Error("Unable to get commit from ref %q. Error: %v", NotesRef, err)
+		log.Error("Unable to get commit from ref %q. Error: %v", notesRef, err)
 		return err
 	}
 
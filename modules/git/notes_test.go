@@ -49,3 +49,21 @@ func TestGetNonExistentNotes(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorAs(t, err, &ErrNotExist{})
 }
+
+func TestNotesRefName(t *testing.T) {
+	for namespace, expected := range map[string]string{
+		"":                 NotesRef,
+		"commits":          NotesRef,
+		"refs/notes/build": "refs/notes/build",
+		"ci/test-results":  "refs/notes/ci/test-results",
+	} {
+		refName, ok := NotesRefName(namespace)
+		assert.True(t, ok, namespace)
+		assert.Equal(t, expected, refName)
+	}
+	for _, namespace := range []string{"refs/notes/", "a..b", "a b", "build/", "x:y"} {
+		_, ok := NotesRefName(namespace)
+		assert.False(t, ok, namespace)
+	}
+	assert.Equal(t, "ci/test-results", NotesNamespace("refs/notes/ci/test-results"))
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/git/gitcmd"
+)
+
+// ConvertObjectFormat writes all the refs of the repository at srcPath into a new bare repository at dstPath which
+// uses the object format "to". It returns the translation of the IDs of all the converted commits, from the old
+// object format to the new one. The notes are rewritten because their trees are named after the old object IDs,
+// the signatures of the commits and tags are dropped because they can't be valid anymore.
+func ConvertObjectFormat(ctx context.Context, srcPath, dstPath string, to ObjectFormat) (map[string]string, error) {
+	if err := os.MkdirAll(dstPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	if _, _, err := gitcmd.NewCommand("init", "--bare").AddOptionValues("--object-format", to.Name()).WithDir(dstPath).RunStdString(ctx); err != nil {
+		return nil, fmt.Errorf("init repository: %w", err)
+	}
+
+	// the intermediate files are kept in the new repository and removed once it is ready
+	streamFile := filepath.Join(dstPath, "fast-export.stream")
+	srcMarksFile := filepath.Join(dstPath, "fast-export.marks")
+	dstMarksFile := filepath.Join(dstPath, "fast-import.marks")
+	defer func() {
+		_ = os.Remove(streamFile)
+		_ = os.Remove(srcMarksFile)
+		_ = os.Remove(dstMarksFile)
+	}()
+
+	stream, err := os.Create(streamFile)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	err = gitcmd.NewCommand("fast-export", "--signed-tags=strip", "--mark-tags", "--reencode=no", "--exclude=refs/notes/*", "--all").
+		AddOptionFormat("--export-marks=%s", srcMarksFile).
+		WithDir(srcPath).
+		WithStdoutCopy(stream).
+		RunWithStderr(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fast-export: %w", err)
+	}
+	if _, err := stream.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	err = gitcmd.NewCommand("fast-import", "--quiet").
+		AddOptionFormat("--export-marks=%s", dstMarksFile).
+		WithDir(dstPath).
+		WithStdinCopy(stream).
+		RunWithStderr(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fast-import: %w", err)
+	}
+
+	translation, err := readObjectFormatTranslation(srcMarksFile, dstMarksFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := convertNotes(ctx, srcPath, dstPath, translation); err != nil {
+		return nil, fmt.Errorf("convert notes: %w", err)
+	}
+	return translation, nil
+}
+
+// readMarks reads a marks file written by fast-export or fast-import, which contains a ":<mark> <object id>" per line
+func readMarks(marksFile string) (map[string]string, error) {
+	f, err := os.Open(marksFile)
+	if err != nil {
+		if os.IsNotExist(err) { // nothing has been exported
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	marks := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		mark, objectID, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid line in marks file %s: %q", marksFile, scanner.Text())
+		}
+		marks[mark] = objectID
+	}
+	return marks, scanner.Err()
+}
+
+func readObjectFormatTranslation(srcMarksFile, dstMarksFile string) (map[string]string, error) {
+	srcMarks, err := readMarks(srcMarksFile)
+	if err != nil {
+		return nil, err
+	}
+	dstMarks, err := readMarks(dstMarksFile)
+	if err != nil {
+		return nil, err
+	}
+	translation := make(map[string]string, len(srcMarks))
+	for mark, oldID := range srcMarks {
+		newID, ok := dstMarks[mark]
+		if !ok {
+			return nil, fmt.Errorf("object %s has not been imported", oldID)
+		}
+		translation[oldID] = newID
+	}
+	return translation, nil
+}
+
+// convertNotes writes every notes ref of the repository at srcPath as one commit in the repository at dstPath,
+// the notes of the objects which have not been converted are dropped
+func convertNotes(ctx context.Context, srcPath, dstPath string, translation map[string]string) error {
+	refs, _, err := gitcmd.NewCommand("for-each-ref", "--format=%(refname)", "refs/notes/").WithDir(srcPath).RunStdString(ctx)
+	if err != nil {
+		return err
+	}
+
+	var importStream bytes.Buffer
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for ref := range strings.FieldsSeq(refs) {
+		list, _, err := gitcmd.NewCommand("notes").AddOptionFormat("--ref=%s", ref).AddArguments("list").WithDir(srcPath).RunStdString(ctx)
+		if err != nil {
+			return err
+		}
+		type note struct{ blobID, objectID string }
+		var notes []note
+		var blobIDs strings.Builder
+		for line := range strings.SplitSeq(strings.TrimSpace(list), "\n") {
+			blobID, objectID, ok := strings.Cut(line, " ")
+			if !ok {
+				continue
+			}
+			if newID, ok := translation[objectID]; ok {
+				notes = append(notes, note{blobID: blobID, objectID: newID})
+				blobIDs.WriteString(blobID + "\n")
+			}
+		}
+		if len(notes) == 0 {
+			continue
+		}
+
+		contents, _, err := gitcmd.NewCommand("cat-file", "--batch").WithDir(srcPath).WithStdinBytes([]byte(blobIDs.String())).RunStdBytes(ctx)
+		if err != nil {
+			return err
+		}
+		rd := bufio.NewReader(bytes.NewReader(contents))
+
+		message := "Convert the notes to the new object format"
+		fmt.Fprintf(&importStream, "commit %s\ncommitter Gitea <noreply@gitea.io> %s +0000\ndata %d\n%s\n", ref, now, len(message), message)
+		for _, n := range notes {
+			info, err := catFileBatchParseInfoLine(rd)
+			if err != nil {
+				return err
+			}
+			content := make([]byte, info.Size+1) // the content is followed by a LF
+			if _, err := io.ReadFull(rd, content); err != nil {
+				return err
+			}
+			fmt.Fprintf(&importStream, "N inline %s\ndata %d\n", n.objectID, info.Size)
+			importStream.Write(content)
+		}
+		importStream.WriteString("\n")
+	}
+	if importStream.Len() == 0 {
+		return nil
+	}
+	return gitcmd.NewCommand("fast-import", "--quiet").WithDir(dstPath).WithStdinBytes(importStream.Bytes()).RunWithStderr(ctx)
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/git/gitcmd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertObjectFormat(t *testing.T) {
+	srcPath := filepath.Join(testReposDir, "repo1_bare")
+	dstPath := filepath.Join(t.TempDir(), "repo1_sha256.git")
+
+	translation, err := ConvertObjectFormat(t.Context(), srcPath, dstPath, Sha256ObjectFormat)
+	require.NoError(t, err)
+
+	objectFormat, _, err := gitcmd.NewCommand("rev-parse", "--show-object-format").WithDir(dstPath).RunStdString(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "sha256", strings.TrimSpace(objectFormat))
+
+	// every ref points to the translation of its old commit
+	srcRefs, _, err := gitcmd.NewCommand("for-each-ref", "--format=%(refname) %(objectname) %(*objectname)").WithDir(srcPath).RunStdString(t.Context())
+	require.NoError(t, err)
+	for line := range strings.SplitSeq(strings.TrimSpace(srcRefs), "\n") {
+		fields := strings.Fields(line)
+		if strings.HasPrefix(fields[0], "refs/notes/") {
+			continue
+		}
+		commitID := fields[1]
+		if len(fields) == 3 { // annotated tag
+			commitID = fields[2]
+		}
+		newID, ok := translation[commitID]
+		require.True(t, ok, "commit %s of %s has not been converted", commitID, fields[0])
+		peeled, _, err := gitcmd.NewCommand("rev-parse").AddDynamicArguments(fields[0] + "^{commit}").WithDir(dstPath).RunStdString(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, newID, strings.TrimSpace(peeled), fields[0])
+	}
+
+	oldMessage, _, err := gitcmd.NewCommand("log", "-1", "--format=%B").AddDynamicArguments("ce064814f4a0d337b333e646ece456cd39fab612").WithDir(srcPath).RunStdString(t.Context())
+	require.NoError(t, err)
+	newID := translation["ce064814f4a0d337b333e646ece456cd39fab612"]
+	assert.Len(t, newID, Sha256ObjectFormat.FullLength())
+	newMessage, _, err := gitcmd.NewCommand("log", "-1", "--format=%B").AddDynamicArguments(newID).WithDir(dstPath).RunStdString(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, oldMessage, newMessage)
+
+	// the notes are attached to the converted commits
+	note, _, err := gitcmd.NewCommand("notes", "show").AddDynamicArguments(translation["95bb4d39648ee7e325106df01a621c530863a653"]).WithDir(dstPath).RunStdString(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Note contents\n", note)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PushCertificateVersion is the only push certificate version supported by git
+const PushCertificateVersion = "0.1"
+
+// PushCertificate represents a push certificate sent by "git push --signed",
+// see the "push-cert" section of gitprotocol-pack(5)
+type PushCertificate struct {
+	Version     string
+	Pusher      string // the identity of the signing key followed by the timestamp of the push
+	Pushee      string
+	Nonce       string
+	PushOptions []string
+	Updates     []*PushCertificateUpdate
+	Payload     string // the signed part of the certificate
+	Signature   string
+}
+
+// PushCertificateUpdate represents a ref update listed in a push certificate
+type PushCertificateUpdate struct {
+	RefName     string `json:"ref_name"`
+	OldCommitID string `json:"old_commit_id"`
+	NewCommitID string `json:"new_commit_id"`
+}
+
+// IsSSHSigned returns true if the certificate is signed with an SSH key instead of a GPG key
+func (cert *PushCertificate) IsSSHSigned() bool {
+	return strings.HasPrefix(cert.Signature, "-----BEGIN SSH SIGNATURE-----")
+}
+
+// HasUpdate returns true if the certificate contains the given ref update
+func (cert *PushCertificate) HasUpdate(refName, oldCommitID, newCommitID string) bool {
+	for _, update := range cert.Updates {
+		if update.RefName == refName && update.OldCommitID == oldCommitID && update.NewCommitID == newCommitID {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePushCertificate parses the content of a push certificate blob
+func ParsePushCertificate(data []byte) (*PushCertificate, error) {
+	cert := &PushCertificate{}
+
+	// the signature starts at the first armor line and lasts until the end of the certificate
+	signStart := -1
+	for _, armor := range []string{"-----BEGIN PGP SIGNATURE-----", "-----BEGIN SSH SIGNATURE-----"} {
+		idx := bytes.Index(data, []byte("\n"+armor))
+		if idx != -1 && (signStart == -1 || idx+1 < signStart) {
+			signStart = idx + 1
+		}
+	}
+	if signStart == -1 {
+		return nil, errors.New("push certificate is not signed")
+	}
+	cert.Payload, cert.Signature = string(data[:signStart]), string(data[signStart:])
+
+	header, updates, ok := strings.Cut(cert.Payload, "\n\n")
+	if !ok {
+		return nil, errors.New("push certificate has no ref updates")
+	}
+	for line := range strings.SplitSeq(header, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "certificate":
+			cert.Version, _ = strings.CutPrefix(value, "version ")
+		case "pusher":
+			cert.Pusher = value
+		case "pushee":
+			cert.Pushee = value
+		case "nonce":
+			cert.Nonce = value
+		case "push-option":
+			cert.PushOptions = append(cert.PushOptions, value)
+		}
+	}
+	if cert.Version != PushCertificateVersion {
+		return nil, fmt.Errorf("unsupported push certificate version %q", cert.Version)
+	}
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(updates, "\n"), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid ref update %q in push certificate", line)
+		}
+		cert.Updates = append(cert.Updates, &PushCertificateUpdate{
+			OldCommitID: fields[0],
+			NewCommitID: fields[1],
+			RefName:     fields[2],
+		})
+	}
+	return cert, nil
+}
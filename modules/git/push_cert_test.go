@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePushCertificate(t *testing.T) {
+	payload := `certificate version 0.1
+pusher SHA256:Tr1oQx8sEV8TXWoyfDBXCUEM0dBOfS6VaQezfOtEcAg  1792341093 +0000
+pushee http://localhost:3000/user2/repo1.git
+nonce 1792341093-ced7368d32e724992d6dfbf7891cb85cfce9ffc2
+push-option ci.skip
+
+0000000000000000000000000000000000000000 3ba2dc422e9942a8cb833a131fad1c1c07945c9e refs/heads/main
+65f1bf27bc3bf70f64657658635e66094edbcb4d 3ba2dc422e9942a8cb833a131fad1c1c07945c9e refs/heads/develop
+`
+	signature := `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgUGNb0lbU920/opmJv8tRIbwVT4
+3WYFxBY8Clmq6d3SouRAo=
+-----END SSH SIGNATURE-----
+`
+	cert, err := ParsePushCertificate([]byte(payload + signature))
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", cert.Version)
+	assert.Equal(t, "SHA256:Tr1oQx8sEV8TXWoyfDBXCUEM0dBOfS6VaQezfOtEcAg  1792341093 +0000", cert.Pusher)
+	assert.Equal(t, "http://localhost:3000/user2/repo1.git", cert.Pushee)
+	assert.Equal(t, "1792341093-ced7368d32e724992d6dfbf7891cb85cfce9ffc2", cert.Nonce)
+	assert.Equal(t, []string{"ci.skip"}, cert.PushOptions)
+	assert.Equal(t, payload, cert.Payload)
+	assert.Equal(t, signature, cert.Signature)
+	assert.True(t, cert.IsSSHSigned())
+	assert.Len(t, cert.Updates, 2)
+	assert.True(t, cert.HasUpdate("refs/heads/develop", "65f1bf27bc3bf70f64657658635e66094edbcb4d", "3ba2dc422e9942a8cb833a131fad1c1c07945c9e"))
+	assert.False(t, cert.HasUpdate("refs/heads/main", "65f1bf27bc3bf70f64657658635e66094edbcb4d", "3ba2dc422e9942a8cb833a131fad1c1c07945c9e"))
+
+	_, err = ParsePushCertificate([]byte(payload))
+	assert.Error(t, err)
+
+	_, err = ParsePushCertificate([]byte("certificate version 0.2\n\n" + signature))
+	assert.Error(t, err)
+}
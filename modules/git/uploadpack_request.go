@@ -0,0 +1,241 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// UploadPackRequest is a request sent to git upload-pack, only the arguments deciding what is fetched are kept
+type UploadPackRequest struct {
+	Command     string // the command of protocol v2, it is empty for protocol v0 and v1
+	Wants       []string
+	Haves       int
+	Shallows    int    // the number of shallow commits the client already has
+	Deepen      int    // the depth of "deepen"
+	DeepenOther bool   // "deepen-since" or "deepen-not" is requested
+	Filter      string // the filter spec of a partial clone
+	Done        bool
+}
+
+// IsClone returns whether the request fetches into an empty repository
+func (r *UploadPackRequest) IsClone() bool {
+	return len(r.Wants) > 0 && r.Haves == 0 && r.Shallows == 0 && r.Done
+}
+
+// UploadPackPolicy decides which requests are accepted by git upload-pack
+type UploadPackPolicy struct {
+	AllowFilter     bool     // the partial clones are allowed
+	RequireFilter   bool     // only the partial clones are allowed
+	Filters         []string // the allowed filter kinds, all the kinds are allowed if it is empty
+	MaxShallowDepth int      // the fetches must be shallow with at most this depth, 0 means no limit
+}
+
+// GitConfig returns the git config applying the policy to git upload-pack
+func (p *UploadPackPolicy) GitConfig() [][2]string {
+	if !p.AllowFilter && !p.RequireFilter {
+		return [][2]string{{"uploadpack.allowFilter", "false"}, {"uploadpack.allowAnySHA1InWant", "false"}}
+	}
+	// the objects missing in a partial clone are fetched by their IDs later
+	config := [][2]string{{"uploadpack.allowFilter", "true"}, {"uploadpack.allowAnySHA1InWant", "true"}}
+	if len(p.Filters) > 0 {
+		config = append(config, [2]string{"uploadpackfilter.allow", "false"})
+		for _, kind := range p.Filters {
+			config = append(config, [2]string{"uploadpackfilter." + kind + ".allow", "true"})
+		}
+	}
+	return config
+}
+
+// ErrUploadPackPolicy is the error of a request rejected by the policy, the message is shown to the client
+type ErrUploadPackPolicy struct {
+	Message string
+}
+
+func (err ErrUploadPackPolicy) Error() string {
+	return err.Message
+}
+
+// IsErrUploadPackPolicy checks if an error is a ErrUploadPackPolicy
+func IsErrUploadPackPolicy(err error) bool {
+	return errors.As(err, &ErrUploadPackPolicy{})
+}
+
+// CheckRequest checks a request which fetches objects. wantsHistory returns whether a wanted object is not a blob
+// or a tree, it is only called for a request of a shallow repository without a depth.
+func (p *UploadPackPolicy) CheckRequest(req *UploadPackRequest, wantsHistory func(wants []string) (bool, error)) error {
+	if p.RequireFilter && req.Filter == "" {
+		return ErrUploadPackPolicy{Message: "this repository only allows partial clones, use e.g. --filter=blob:none"}
+	}
+	if p.MaxShallowDepth <= 0 {
+		return nil
+	}
+	shallowErr := ErrUploadPackPolicy{Message: fmt.Sprintf("anonymous fetches of this repository must be shallow, use e.g. --depth=%d", p.MaxShallowDepth)}
+	if req.DeepenOther || req.Deepen > p.MaxShallowDepth {
+		return shallowErr
+	}
+	if req.Deepen > 0 {
+		return nil
+	}
+	// the shallow commits are claimed by the client, they may be any commits, e.g. the root commit, so a fetch
+	// without a depth would send the whole history. Only a partial clone fetching its missing blobs and trees lazily
+	// is allowed to omit the depth.
+	if req.Shallows == 0 {
+		return shallowErr
+	}
+	history, err := wantsHistory(req.Wants)
+	if err != nil {
+		return err
+	}
+	if history {
+		return shallowErr
+	}
+	return nil
+}
+
+// uploadPackRequestReader passes the requests sent to git upload-pack through and parses them.
+// The flush packet ending the wanted objects is only passed after they have been checked,
+// so git upload-pack never sends anything for a rejected request.
+type uploadPackRequestReader struct {
+	rd      *bufio.Reader
+	pending []byte
+	err     error
+
+	req     *UploadPackRequest
+	checked bool
+	check   func(*UploadPackRequest) error
+	done    func(*UploadPackRequest)
+}
+
+// NewUploadPackRequestReader returns a reader passing r through. The requests fetching objects are passed to check
+// before git upload-pack handles them, the reader fails with the error returned by check.
+// The complete requests are passed to done.
+func NewUploadPackRequestReader(r io.Reader, check func(*UploadPackRequest) error, done func(*UploadPackRequest)) io.Reader {
+	return &uploadPackRequestReader{rd: bufio.NewReader(r), check: check, done: done}
+}
+
+func (r *uploadPackRequestReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.readPacket()
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *uploadPackRequestReader) readPacket() error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r.rd, header); err != nil {
+		return err
+	}
+	length, err := strconv.ParseUint(string(header), 16, 16)
+	if err != nil {
+		return fmt.Errorf("invalid packet length %q", header)
+	}
+	if r.req == nil {
+		r.req = &UploadPackRequest{}
+	}
+
+	switch {
+	case length == 0: // flush
+		if err := r.endSection(); err != nil {
+			return err
+		}
+		r.pending = header
+		return nil
+	case length <= 2: // delim and response-end
+		r.pending = header
+		return nil
+	case length == 3:
+		return fmt.Errorf("invalid packet length %q", header)
+	}
+
+	packet := make([]byte, length)
+	copy(packet, header)
+	if _, err := io.ReadFull(r.rd, packet[4:]); err != nil {
+		return err
+	}
+	r.parseLine(strings.TrimSuffix(string(packet[4:]), "\n"))
+	r.pending = packet
+	return nil
+}
+
+func (r *uploadPackRequestReader) parseLine(line string) {
+	req := r.req
+	key, value, _ := strings.Cut(line, " ")
+	switch key {
+	case "want", "want-ref":
+		wanted, _, _ := strings.Cut(value, " ") // the first want of protocol v0 is followed by the capabilities
+		req.Wants = append(req.Wants, wanted)
+	case "have":
+		req.Haves++
+	case "shallow":
+		req.Shallows++
+	case "deepen":
+		req.Deepen, _ = strconv.Atoi(value)
+	case "deepen-since", "deepen-not":
+		req.DeepenOther = true
+	case "filter":
+		req.Filter = value
+	case "done":
+		req.Done = true
+		if req.Command == "" {
+			// protocol v0 and v1 end the request with "done" after the negotiation
+			r.endRequest()
+		}
+	default:
+		if command, ok := strings.CutPrefix(line, "command="); ok {
+			req.Command = command
+		}
+	}
+}
+
+func (r *uploadPackRequestReader) endSection() error {
+	req := r.req
+	if req.Command == "" {
+		// protocol v0 and v1 send the wanted objects in the first section and negotiate in the following ones
+		if r.checked {
+			return nil
+		}
+		r.checked = true
+		if len(req.Wants) == 0 {
+			r.endRequest()
+			return nil
+		}
+		return r.check(req)
+	}
+
+	// protocol v2 sends a whole request in one section
+	if req.Command == "fetch" && len(req.Wants) > 0 {
+		if err := r.check(req); err != nil {
+			return err
+		}
+	}
+	if req.Done {
+		r.done(req)
+	}
+	r.req = nil
+	return nil
+}
+
+func (r *uploadPackRequestReader) endRequest() {
+	if len(r.req.Wants) > 0 {
+		r.done(r.req)
+	}
+	r.req = nil
+	r.checked = false
+}
+
+// UploadPackErrorPacket returns the packet reporting the error to the client of git upload-pack
+func UploadPackErrorPacket(message string) []byte {
+	return fmt.Appendf(nil, "%04xERR %s\n", len(message)+9, message)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pktLines(lines ...string) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		switch line {
+		case "0000", "0001":
+			sb.WriteString(line)
+		default:
+			fmt.Fprintf(&sb, "%04x%s\n", len(line)+5, line)
+		}
+	}
+	return sb.String()
+}
+
+func readUploadPackRequests(stream string, policy *UploadPackPolicy) (checked, done []*UploadPackRequest, passed string, err error) {
+	rd := NewUploadPackRequestReader(strings.NewReader(stream), func(req *UploadPackRequest) error {
+		checked = append(checked, req)
+		return policy.CheckRequest(req, func([]string) (bool, error) { return true, nil })
+	}, func(req *UploadPackRequest) {
+		done = append(done, req)
+	})
+	bs, err := io.ReadAll(rd)
+	return checked, done, string(bs), err
+}
+
+func TestUploadPackRequestReader(t *testing.T) {
+	const oid = "95bb4d39648ee7e325106df01a621c530863a653"
+
+	t.Run("V0Clone", func(t *testing.T) {
+		stream := pktLines("want "+oid+" multi_ack_detailed side-band-64k", "filter blob:none", "0000", "done")
+		checked, done, passed, err := readUploadPackRequests(stream, &UploadPackPolicy{})
+		require.NoError(t, err)
+		assert.Equal(t, stream, passed)
+		require.Len(t, checked, 1)
+		require.Len(t, done, 1)
+		assert.Equal(t, "blob:none", done[0].Filter)
+		assert.Equal(t, []string{oid}, done[0].Wants)
+		assert.True(t, done[0].IsClone())
+	})
+
+	t.Run("V0Fetch", func(t *testing.T) {
+		stream := pktLines("want "+oid, "shallow "+oid, "deepen 1", "0000", "have "+oid, "0000", "done")
+		checked, done, _, err := readUploadPackRequests(stream, &UploadPackPolicy{MaxShallowDepth: 1})
+		require.NoError(t, err)
+		require.Len(t, checked, 1)
+		require.Len(t, done, 1)
+		assert.Equal(t, 1, done[0].Haves)
+		assert.False(t, done[0].IsClone())
+	})
+
+	t.Run("V2", func(t *testing.T) {
+		stream := pktLines("command=ls-refs", "0001", "peel", "0000", "command=fetch", "0001", "want "+oid, "deepen 1", "done", "0000")
+		checked, done, passed, err := readUploadPackRequests(stream, &UploadPackPolicy{MaxShallowDepth: 1})
+		require.NoError(t, err)
+		assert.Equal(t, stream, passed)
+		require.Len(t, checked, 1)
+		require.Len(t, done, 1)
+		assert.Equal(t, "fetch", done[0].Command)
+		assert.Equal(t, 1, done[0].Deepen)
+		assert.True(t, done[0].IsClone())
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		stream := pktLines("command=fetch", "0001", "want "+oid, "deepen 10", "done", "0000")
+		_, done, passed, err := readUploadPackRequests(stream, &UploadPackPolicy{MaxShallowDepth: 1})
+		assert.True(t, IsErrUploadPackPolicy(err))
+		assert.Empty(t, done)
+		// git upload-pack must not get the end of the rejected request
+		assert.Equal(t, strings.TrimSuffix(stream, "0000"), passed)
+
+		stream = pktLines("want "+oid, "0000", "done")
+		_, _, _, err = readUploadPackRequests(stream, &UploadPackPolicy{RequireFilter: true})
+		assert.True(t, IsErrUploadPackPolicy(err))
+
+		// a shallow commit claimed without a depth would fetch the whole history behind it
+		stream = pktLines("want "+oid, "shallow "+oid, "0000", "done")
+		_, done, _, err = readUploadPackRequests(stream, &UploadPackPolicy{MaxShallowDepth: 1})
+		assert.True(t, IsErrUploadPackPolicy(err))
+		assert.Empty(t, done)
+	})
+}
+
+func TestUploadPackPolicy(t *testing.T) {
+	history := func([]string) (bool, error) { return true, nil }
+	blobs := func([]string) (bool, error) { return false, nil }
+	policy := &UploadPackPolicy{MaxShallowDepth: 5}
+	assert.NoError(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}, Deepen: 5}, history))
+	assert.NoError(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}, Shallows: 1, Deepen: 1}, history))
+	assert.Error(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}}, history))
+	assert.Error(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}, Deepen: 6}, history))
+	assert.Error(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}, Shallows: 1, DeepenOther: true}, history))
+	// a shallow commit claimed without a depth, e.g. the root commit, would fetch the whole history
+	assert.Error(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}, Shallows: 1}, history))
+	// but a partial clone fetches its missing blobs without a depth
+	assert.NoError(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}, Shallows: 1, Filter: "blob:none"}, blobs))
+	assert.Error(t, policy.CheckRequest(&UploadPackRequest{Wants: []string{"a"}}, blobs))
+
+	assert.Equal(t, [][2]string{{"uploadpack.allowFilter", "false"}, {"uploadpack.allowAnySHA1InWant", "false"}}, policy.GitConfig())
+	policy = &UploadPackPolicy{RequireFilter: true, Filters: []string{"blob:none"}}
+	assert.Equal(t, [][2]string{
+		{"uploadpack.allowFilter", "true"},
+		{"uploadpack.allowAnySHA1InWant", "true"},
+		{"uploadpackfilter.allow", "false"},
+		{"uploadpackfilter.blob:none.allow", "true"},
+	}, policy.GitConfig())
+
+	assert.Equal(t, "000fERR denied\n", string(UploadPackErrorPacket("denied")))
+}
@@ -20,6 +20,20 @@ func GitConfigGet(ctx context.Context, repo Repository, key string) (string, err
 	return strings.TrimSpace(result), nil
 }
 
+// GitConfigGetAll returns all the values of a multi-valued git configuration key, it is empty if the key does not exist.
+func GitConfigGetAll(ctx context.Context, repo Repository, key string) ([]string, error) {
+	result, _, err := RunCmdString(ctx, repo, gitcmd.NewCommand("config", "--get-all").
+		AddDynamicArguments(key))
+	if err != nil {
+		if gitcmd.IsErrorExitCode(err, 1) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// git prints one value per line, the values may contain spaces
+	return strings.Split(strings.TrimSuffix(result, "\n"), "\n"), nil
+}
+
 func getRepoConfigLockKey(repoStoragePath string) string {
 	return "repo-config:" + repoStoragePath
 }
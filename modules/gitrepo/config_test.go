@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitrepo
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/git/gitcmd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitConfigGetAll(t *testing.T) {
+	repo := &mockRepository{path: t.TempDir()}
+	_, _, runErr := gitcmd.NewCommand("init", "--bare").WithDir(repo.path).RunStdString(t.Context())
+	require.NoError(t, runErr)
+
+	values, err := GitConfigGetAll(t.Context(), repo, "remote.origin.push")
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	require.NoError(t, GitConfigAdd(t.Context(), repo, "remote.origin.push", "+refs/heads/*:refs/heads/*"))
+	require.NoError(t, GitConfigAdd(t.Context(), repo, "remote.origin.push", "value with spaces"))
+	values, err = GitConfigGetAll(t.Context(), repo, "remote.origin.push")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"+refs/heads/*:refs/heads/*", "value with spaces"}, values)
+}
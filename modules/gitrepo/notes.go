@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ListNotesRefs returns the git-notes refs of the repository
+func ListNotesRefs(ctx context.Context, repo Repository) ([]string, error) {
+	stdout, _, err := RunCmdString(ctx, repo, gitcmd.NewCommand("for-each-ref", "--format=%(refname)").AddDynamicArguments(git.NotesRefPrefix))
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		if line != "" {
+			refs = append(refs, line)
+		}
+	}
+	return refs, nil
+}
+
+func notesEnv(sig *git.Signature) []string {
+	return append(os.Environ(),
+		"GIT_AUTHOR_NAME="+sig.Name,
+		"GIT_AUTHOR_EMAIL="+sig.Email,
+		"GIT_COMMITTER_NAME="+sig.Name,
+		"GIT_COMMITTER_EMAIL="+sig.Email,
+	)
+}
+
+// SetNote adds the note of a commit to the notes ref, an existing note is overwritten
+func SetNote(ctx context.Context, repo Repository, notesRef, commitID string, message []byte, sig *git.Signature) error {
+	cmd := gitcmd.NewCommand("notes").AddOptionFormat("--ref=%s", notesRef).
+		AddArguments("add", "-f", "--allow-empty", "-F", "-").
+		AddDynamicArguments(commitID).
+		WithStdinBytes(message).
+		WithEnv(notesEnv(sig))
+	return RunCmdWithStderr(ctx, repo, cmd)
+}
+
+// RemoveNote removes the note of a commit from the notes ref, it returns util.ErrNotExist if the commit has no note
+func RemoveNote(ctx context.Context, repo Repository, notesRef, commitID string, sig *git.Signature) error {
+	cmd := gitcmd.NewCommand("notes").AddOptionFormat("--ref=%s", notesRef).
+		AddArguments("remove").
+		AddDynamicArguments(commitID).
+		WithEnv(notesEnv(sig))
+	if err := RunCmdWithStderr(ctx, repo, cmd); err != nil {
+		if strings.Contains(err.Stderr(), "has no note") {
+			return util.NewNotExistErrorf("commit %s has no note in %s", commitID, notesRef)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrObjectFormatNotRestored is returned when the repository can't be restored after its replacement by the converted one failed
+var ErrObjectFormatNotRestored = errors.New("the repository could not be restored")
+
+// ConvertObjectFormat rewrites the repository with the object format "to" next to it. The translation of the commit IDs
+// is passed to onConverted, which must call replace to replace the repository by the converted one, e.g. in the
+// transaction which stores the translation. The old repository is restored if onConverted returns an error after
+// replace succeeded. The hooks, the config and the description of the repository are kept.
+func ConvertObjectFormat(ctx context.Context, repo Repository, to git.ObjectFormat, onConverted func(translation map[string]string, replace func() error) error) error {
+	return globallock.LockAndDo(ctx, getRepoWriteLockKey(repo.RelativePath()), func(ctx context.Context) error {
+		srcPath := repoPath(repo)
+		dstPath := srcPath + ".converting"
+		oldPath := srcPath + ".old"
+		if err := util.RemoveAll(dstPath); err != nil {
+			return err
+		}
+		defer func() {
+			if err := util.RemoveAll(dstPath); err != nil {
+				log.Error("Unable to remove the conversion %s: %v", dstPath, err)
+			}
+		}()
+
+		translation, err := git.ConvertObjectFormat(ctx, srcPath, dstPath, to)
+		if err != nil {
+			return err
+		}
+		if err := copyRepositorySettings(ctx, srcPath, dstPath, to); err != nil {
+			return err
+		}
+
+		replaced := false
+		replace := func() error {
+			// the old repository is moved aside before the converted one takes its place, so it is never half replaced
+			if err := util.RemoveAll(oldPath); err != nil {
+				return err
+			}
+			if err := util.Rename(srcPath, oldPath); err != nil {
+				return fmt.Errorf("move the old repository aside: %w", err)
+			}
+			if err := util.Rename(dstPath, srcPath); err != nil {
+				if errRestore := util.Rename(oldPath, srcPath); errRestore != nil {
+					log.Critical("Unable to restore the repository %s from %s: %v", srcPath, oldPath, errRestore)
+					return fmt.Errorf("%w: %v", ErrObjectFormatNotRestored, errRestore)
+				}
+				return fmt.Errorf("replace the repository: %w", err)
+			}
+			replaced = true
+			return nil
+		}
+		if err := onConverted(translation, replace); err != nil {
+			if replaced {
+				if errRestore := restoreRepository(srcPath, dstPath, oldPath); errRestore != nil {
+					log.Critical("Unable to restore the repository %s from %s: %v", srcPath, oldPath, errRestore)
+					return fmt.Errorf("%w: %v", ErrObjectFormatNotRestored, errRestore)
+				}
+			}
+			return err
+		}
+		if !replaced {
+			return errors.New("the repository has not been replaced by the converted one")
+		}
+		if err := util.RemoveAll(oldPath); err != nil {
+			log.Error("Unable to remove the old repository %s: %v", oldPath, err)
+		}
+		return nil
+	})
+}
+
+// RecoverObjectFormatConversion cleans up after a conversion which was interrupted, e.g. by a crash. The repository
+// is expected to use the object format stored in the database, the old repository is put back in place if the
+// converted one replaced it but the conversion was never committed.
+func RecoverObjectFormatConversion(ctx context.Context, repo Repository, objectFormat git.ObjectFormat) error {
+	return globallock.LockAndDo(ctx, getRepoWriteLockKey(repo.RelativePath()), func(ctx context.Context) error {
+		srcPath := repoPath(repo)
+		dstPath := srcPath + ".converting"
+		oldPath := srcPath + ".old"
+		hasOld, err := util.IsDir(oldPath)
+		if err != nil {
+			return err
+		}
+		if hasOld {
+			hasSrc, err := util.IsDir(srcPath)
+			if err != nil {
+				return err
+			}
+			if !hasSrc {
+				// the old repository was moved aside, but the converted one didn't take its place
+				if err := util.Rename(oldPath, srcPath); err != nil {
+					return fmt.Errorf("%w: %v", ErrObjectFormatNotRestored, err)
+				}
+			} else if err := restoreUncommittedConversion(ctx, srcPath, dstPath, oldPath, objectFormat); err != nil {
+				return err
+			}
+		}
+		if err := util.RemoveAll(dstPath); err != nil {
+			return err
+		}
+		return util.RemoveAll(oldPath)
+	})
+}
+
+// restoreUncommittedConversion restores the old repository if the repository in place doesn't use the object format
+// stored in the database, then the converted repository replaced it but the transaction was never committed
+func restoreUncommittedConversion(ctx context.Context, srcPath, dstPath, oldPath string, objectFormat git.ObjectFormat) error {
+	stdout, _, err := gitcmd.NewCommand("rev-parse", "--show-object-format").WithDir(srcPath).RunStdString(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(stdout) == objectFormat.Name() {
+		return nil
+	}
+	if err := util.RemoveAll(dstPath); err != nil {
+		return err
+	}
+	if err := restoreRepository(srcPath, dstPath, oldPath); err != nil {
+		return fmt.Errorf("%w: %v", ErrObjectFormatNotRestored, err)
+	}
+	return nil
+}
+
+// restoreRepository moves the converted repository back aside and the old repository back in place
+func restoreRepository(srcPath, dstPath, oldPath string) error {
+	if err := util.Rename(srcPath, dstPath); err != nil {
+		return err
+	}
+	return util.Rename(oldPath, srcPath)
+}
+
+func copyRepositorySettings(ctx context.Context, srcPath, dstPath string, to git.ObjectFormat) error {
+	// the config contains the remotes of the mirrors, only the object format differs
+	for _, name := range []string{"config", "description"} {
+		if err := util.CopyFile(filepath.Join(srcPath, name), filepath.Join(dstPath, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	// the extension is only allowed by the version 1 of the repository format
+	for _, kv := range [][2]string{{"core.repositoryformatversion", "1"}, {"extensions.objectformat", to.Name()}} {
+		if _, _, err := gitcmd.NewCommand("config").AddDynamicArguments(kv[0], kv[1]).WithDir(dstPath).RunStdString(ctx); err != nil {
+			return fmt.Errorf("set %s: %w", kv[0], err)
+		}
+	}
+
+	// the hooks may contain custom hooks besides the delegate hooks of Gitea
+	if err := util.RemoveAll(filepath.Join(dstPath, "hooks")); err != nil {
+		return err
+	}
+	return filepath.WalkDir(filepath.Join(srcPath, "hooks"), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == filepath.Join(srcPath, "hooks") {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(srcPath, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dstPath, rel), os.ModePerm)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := util.CopyFile(path, filepath.Join(dstPath, rel)); err != nil {
+			return err
+		}
+		return os.Chmod(filepath.Join(dstPath, rel), info.Mode())
+	})
+}
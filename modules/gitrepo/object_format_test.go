@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitrepo
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertObjectFormat(t *testing.T) {
+	repo := &mockRepository{path: filepath.Join(t.TempDir(), "repo.git")}
+	_, _, runErr := gitcmd.NewCommand("clone", "--bare").AddDynamicArguments(repoPath(&mockRepository{path: "repo1_bare"}), repo.path).RunStdString(t.Context())
+	require.NoError(t, runErr)
+
+	objectFormat := func(t *testing.T) string {
+		stdout, _, err := RunCmdString(t.Context(), repo, gitcmd.NewCommand("rev-parse", "--show-object-format"))
+		require.NoError(t, err)
+		return strings.TrimSpace(stdout)
+	}
+	assertNoLeftover := func(t *testing.T) {
+		for _, suffix := range []string{".converting", ".old"} {
+			exist, err := util.IsExist(repo.path + suffix)
+			require.NoError(t, err)
+			assert.False(t, exist, suffix)
+		}
+	}
+
+	// the old repository is restored if the callback fails after the replacement
+	err := ConvertObjectFormat(t.Context(), repo, git.Sha256ObjectFormat, func(_ map[string]string, replace func() error) error {
+		require.NoError(t, replace())
+		assert.Equal(t, "sha256", objectFormat(t))
+		return errors.New("rollback")
+	})
+	assert.EqualError(t, err, "rollback")
+	assert.Equal(t, "sha1", objectFormat(t))
+	assertNoLeftover(t)
+
+	// the conversion fails if the repository is not replaced
+	err = ConvertObjectFormat(t.Context(), repo, git.Sha256ObjectFormat, func(map[string]string, func() error) error { return nil })
+	assert.Error(t, err)
+	assert.Equal(t, "sha1", objectFormat(t))
+	assertNoLeftover(t)
+
+	var translation map[string]string
+	err = ConvertObjectFormat(t.Context(), repo, git.Sha256ObjectFormat, func(tr map[string]string, replace func() error) error {
+		translation = tr
+		return replace()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sha256", objectFormat(t))
+	assert.Len(t, translation["8006ff9adbf0cb94da7dad9e537e53817f9fa5c0"], 64)
+	assertNoLeftover(t)
+}
+
+func TestRecoverObjectFormatConversion(t *testing.T) {
+	repo := &mockRepository{path: filepath.Join(t.TempDir(), "repo.git")}
+	objectFormat := func(t *testing.T) string {
+		stdout, _, err := RunCmdString(t.Context(), repo, gitcmd.NewCommand("rev-parse", "--show-object-format"))
+		require.NoError(t, err)
+		return strings.TrimSpace(stdout)
+	}
+	// prepare puts the old sha1 repository aside and, if replaced, the converted sha256 one in place
+	prepare := func(t *testing.T, replaced bool) {
+		require.NoError(t, util.RemoveAll(repo.path))
+		_, _, err := gitcmd.NewCommand("clone", "--bare").AddDynamicArguments(repoPath(&mockRepository{path: "repo1_bare"}), repo.path+".old").RunStdString(t.Context())
+		require.NoError(t, err)
+		require.NoError(t, gitcmd.NewCommand("init", "--bare", "--object-format=sha256").AddDynamicArguments(repo.path+".converting").Run(t.Context()))
+		if replaced {
+			require.NoError(t, gitcmd.NewCommand("init", "--bare", "--object-format=sha256").AddDynamicArguments(repo.path).Run(t.Context()))
+		}
+	}
+	assertNoLeftover := func(t *testing.T) {
+		for _, suffix := range []string{".converting", ".old"} {
+			exist, err := util.IsExist(repo.path + suffix)
+			require.NoError(t, err)
+			assert.False(t, exist, suffix)
+		}
+	}
+
+	// interrupted between moving the old repository aside and moving the converted one in place
+	prepare(t, false)
+	require.NoError(t, RecoverObjectFormatConversion(t.Context(), repo, git.Sha1ObjectFormat))
+	assert.Equal(t, "sha1", objectFormat(t))
+	assertNoLeftover(t)
+
+	// interrupted before the transaction was committed
+	prepare(t, true)
+	require.NoError(t, RecoverObjectFormatConversion(t.Context(), repo, git.Sha1ObjectFormat))
+	assert.Equal(t, "sha1", objectFormat(t))
+	assertNoLeftover(t)
+
+	// interrupted after the transaction was committed
+	prepare(t, true)
+	require.NoError(t, RecoverObjectFormatConversion(t.Context(), repo, git.Sha256ObjectFormat))
+	assert.Equal(t, "sha256", objectFormat(t))
+	assertNoLeftover(t)
+}
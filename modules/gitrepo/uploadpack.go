@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitrepo
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/modules/git/gitcmd"
+)
+
+// UploadPackWantsHistory returns whether one of the objects wanted from git upload-pack is not a blob or a tree
+func UploadPackWantsHistory(ctx context.Context, repo Repository, wants []string) (bool, error) {
+	stdout, _, err := RunCmdString(ctx, repo, gitcmd.NewCommand("cat-file", "--batch-check=%(objecttype)").
+		WithStdinBytes([]byte(strings.Join(wants, "\n")+"\n")))
+	if err != nil {
+		return false, err
+	}
+	for objectType := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		// the missing objects and the names of the refs of "want-ref" are not blobs or trees either
+		if objectType != "blob" && objectType != "tree" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
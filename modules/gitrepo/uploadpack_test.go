@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitrepo
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/git/gitcmd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadPackWantsHistory(t *testing.T) {
+	repo := &mockRepository{path: "repo1_bare"}
+	stdout, _, runErr := RunCmdString(t.Context(), repo, gitcmd.NewCommand("rev-parse", "HEAD", "HEAD^{tree}"))
+	require.NoError(t, runErr)
+	ids := strings.Fields(stdout)
+	require.Len(t, ids, 2)
+	commitID, objectIDs := ids[0], ids[1:]
+	stdout, _, runErr = RunCmdString(t.Context(), repo, gitcmd.NewCommand("ls-tree", "HEAD"))
+	require.NoError(t, runErr)
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		if fields := strings.Fields(line); fields[1] == "blob" {
+			objectIDs = append(objectIDs, fields[2])
+		}
+	}
+	require.Greater(t, len(objectIDs), 1)
+
+	history, err := UploadPackWantsHistory(t.Context(), repo, objectIDs)
+	require.NoError(t, err)
+	assert.False(t, history)
+
+	history, err = UploadPackWantsHistory(t.Context(), repo, append(objectIDs, commitID))
+	require.NoError(t, err)
+	assert.True(t, history)
+
+	history, err = UploadPackWantsHistory(t.Context(), repo, []string{"refs/heads/master"})
+	require.NoError(t, err)
+	assert.True(t, history)
+}
@@ -7,6 +7,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"regexp/syntax"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +23,7 @@ import (
 	"code.gitea.io/gitea/modules/indexer"
 	path_filter "code.gitea.io/gitea/modules/indexer/code/bleve/token/path"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/symbols"
 	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
 	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
 	"code.gitea.io/gitea/modules/setting"
@@ -31,19 +35,27 @@ import (
 	analyzer_custom "github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
 	analyzer_keyword "github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
 	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/ngram"
 	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/token/unique"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/letter"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/go-enry/go-enry/v2"
 )
 
 const (
 	unicodeNormalizeName = "unicodeNormalize"
+	trigramFilterName    = "trigram"
 	maxBatchSize         = 16
 )
 
+// deletePageSize is the number of the documents matched at once when documents are deleted by a query
+var deletePageSize = 1000
+
 func addUnicodeNormalizeTokenFilter(m *mapping.IndexMappingImpl) error {
 	return m.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
 		"type": unicodenorm.Name,
@@ -59,6 +71,11 @@ type RepoIndexerData struct {
 	Filename  string
 	Language  string
 	UpdatedAt time.Time
+	// the names of the symbols defined and referenced by the file, only for the languages supported by the symbols package
+	Symbols    []string
+	References []string
+	// the refs containing the file at this version, the file is deleted when there is none
+	Refs []string
 }
 
 // Type returns the document type, for bleve's mapping.Classifier interface.
@@ -70,8 +87,9 @@ const (
 	repoIndexerAnalyzer      = "repoIndexerAnalyzer"
 	filenameIndexerAnalyzer  = "filenameIndexerAnalyzer"
 	filenameIndexerTokenizer = "filenameIndexerTokenizer"
+	trigramIndexerAnalyzer   = "trigramIndexerAnalyzer"
 	repoIndexerDocType       = "repoIndexerDocType"
-	repoIndexerLatestVersion = 9
+	repoIndexerLatestVersion = 13
 )
 
 // generateBleveIndexMapping generates a bleve index mapping for the repo indexer
@@ -83,18 +101,36 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 
 	textFieldMapping := bleve.NewTextFieldMapping()
 	textFieldMapping.IncludeInAll = false
-	docMapping.AddFieldMappingsAt("Content", textFieldMapping)
+	// the trigrams of the contents pre-filter the regexp searches
+	trigramFieldMapping := bleve.NewTextFieldMapping()
+	trigramFieldMapping.Name = "ContentTrigrams"
+	trigramFieldMapping.IncludeInAll = false
+	trigramFieldMapping.Store = false
+	trigramFieldMapping.IncludeTermVectors = false
+	trigramFieldMapping.DocValues = false
+	trigramFieldMapping.Analyzer = trigramIndexerAnalyzer
+	docMapping.AddFieldMappingsAt("Content", textFieldMapping, trigramFieldMapping)
 
 	fileNamedMapping := bleve.NewTextFieldMapping()
 	fileNamedMapping.IncludeInAll = false
 	fileNamedMapping.Analyzer = filenameIndexerAnalyzer
-	docMapping.AddFieldMappingsAt("Filename", fileNamedMapping)
+	// the whole filenames are matched by the path patterns
+	filenameKeywordMapping := bleve.NewTextFieldMapping()
+	filenameKeywordMapping.Name = "FilenameKeyword"
+	filenameKeywordMapping.IncludeInAll = false
+	filenameKeywordMapping.Store = false
+	filenameKeywordMapping.IncludeTermVectors = false
+	filenameKeywordMapping.Analyzer = analyzer_keyword.Name
+	docMapping.AddFieldMappingsAt("Filename", fileNamedMapping, filenameKeywordMapping)
 
 	termFieldMapping := bleve.NewTextFieldMapping()
 	termFieldMapping.IncludeInAll = false
 	termFieldMapping.Analyzer = analyzer_keyword.Name
 	docMapping.AddFieldMappingsAt("Language", termFieldMapping)
 	docMapping.AddFieldMappingsAt("CommitID", termFieldMapping)
+	docMapping.AddFieldMappingsAt("Symbols", termFieldMapping)
+	docMapping.AddFieldMappingsAt("References", termFieldMapping)
+	docMapping.AddFieldMappingsAt("Refs", termFieldMapping)
 
 	timeFieldMapping := bleve.NewDateTimeFieldMapping()
 	timeFieldMapping.IncludeInAll = false
@@ -122,6 +158,22 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 		return nil, err
 	}
 
+	if err := mapping.AddCustomTokenFilter(trigramFilterName, map[string]any{
+		"type": ngram.Name,
+		"min":  3.0,
+		"max":  3.0,
+	}); err != nil {
+		return nil, err
+	}
+	if err := mapping.AddCustomAnalyzer(trigramIndexerAnalyzer, map[string]any{
+		"type":          analyzer_custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     single.Name,
+		"token_filters": []string{unicodeNormalizeName, lowercase.Name, trigramFilterName, unique.Name},
+	}); err != nil {
+		return nil, err
+	}
+
 	mapping.DefaultAnalyzer = repoIndexerAnalyzer
 	mapping.AddDocumentMapping(repoIndexerDocType, docMapping)
 	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
@@ -138,7 +190,7 @@ type Indexer struct {
 }
 
 func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
-	return indexer.SearchModesExactWords()
+	return indexer.SearchModesExactWordsRegexp()
 }
 
 // NewIndexer creates a new bleve local indexer
@@ -150,19 +202,28 @@ func NewIndexer(indexDir string) *Indexer {
 	}
 }
 
-func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, commitSha string,
-	update internal.FileUpdate, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch,
+// addUpdate indexes a version of a file for the ref, doc is its stored document if it's indexed already
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, commitSha, ref string,
+	update internal.FileUpdate, repo *repo_model.Repository, id string, doc *RepoIndexerData, batch *inner_bleve.FlushingBatch,
 ) error {
 	// Ignore vendored files in code search
 	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
 		return nil
 	}
 
-	size := update.Size
+	if doc != nil {
+		// the same version of the file is already indexed for another ref
+		if slices.Contains(doc.Refs, ref) {
+			return nil
+		}
+		doc.Refs = append(doc.Refs, ref)
+		return batch.Index(id, doc)
+	}
 
-	var err error
+	size := update.Size
 	if !update.Sized {
 		var stdout string
+		var err error
 		stdout, _, err = gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha))
 		if err != nil {
 			return err
@@ -172,40 +233,100 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 		}
 	}
 
-	if size > setting.Indexer.MaxIndexerFileSize {
-		return b.addDelete(update.Filename, repo, batch)
+	// the files which are too large or not text are indexed without their contents, so their paths are searchable
+	var fileContents []byte
+	if size <= setting.Indexer.MaxIndexerFileSize {
+		info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
+		if err != nil {
+			return err
+		}
+		if fileContents, err = io.ReadAll(io.LimitReader(batchReader, info.Size)); err != nil {
+			return err
+		} else if !typesniffer.DetectContentType(fileContents).IsText() {
+			// FIXME: UTF-16 files will probably fail here
+			fileContents = nil
+		}
+		if _, err = batchReader.Discard(1); err != nil {
+			return err
+		}
 	}
+	content := charset.ToUTF8DropErrors(fileContents)
+	language := analyze.GetCodeLanguage(update.Filename, fileContents)
+	defs, refs := symbols.Extract(language, content)
+	return batch.Index(id, &RepoIndexerData{
+		RepoID:     repo.ID,
+		CommitID:   commitSha,
+		Filename:   update.Filename,
+		Content:    string(content),
+		Language:   language,
+		UpdatedAt:  time.Now().UTC(),
+		Symbols:    symbols.Names(defs),
+		References: refs,
+		Refs:       []string{ref},
+	})
+}
 
-	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
-	if err != nil {
-		return err
+// removeRef removes the ref from a version of a file, which is deleted if it isn't in any ref anymore
+func (b *Indexer) removeRef(id, ref string, doc *RepoIndexerData, batch *inner_bleve.FlushingBatch) error {
+	doc.Refs = slices.DeleteFunc(doc.Refs, func(r string) bool { return r == ref })
+	if len(doc.Refs) == 0 {
+		return batch.Delete(id)
+	}
+	return batch.Index(id, doc)
+}
+
+// getDocuments returns the stored documents of the indexed versions of the files by their IDs
+func (b *Indexer) getDocuments(ctx context.Context, ids []string) (map[string]*RepoIndexerData, error) {
+	if len(ids) == 0 {
+		return nil, nil
 	}
-	fileContents, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
+	searchRequest := bleve.NewSearchRequestOptions(bleve.NewDocIDQuery(ids), len(ids), 0, false)
+	searchRequest.Fields = []string{"*"}
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
 	if err != nil {
-		return err
-	} else if !typesniffer.DetectContentType(fileContents).IsText() {
-		// FIXME: UTF-16 files will probably fail here
-		// Even if the file is not recognized as a "text file", we could still put its name into the indexers to make the filename become searchable, while leave the content to empty.
-		fileContents = nil
+		return nil, err
 	}
+	docs := make(map[string]*RepoIndexerData, len(result.Hits))
+	for _, hit := range result.Hits {
+		docs[hit.ID] = documentOfHit(hit)
+	}
+	return docs, nil
+}
 
-	if _, err = batchReader.Discard(1); err != nil {
-		return err
+// documentOfHit converts the stored fields of a hit back to its document
+func documentOfHit(hit *search.DocumentMatch) *RepoIndexerData {
+	doc := &RepoIndexerData{
+		RepoID:     int64(hit.Fields["RepoID"].(float64)),
+		Filename:   internal.FilenameOfIndexerID(hit.ID),
+		Content:    stringField(hit.Fields["Content"]),
+		Language:   stringField(hit.Fields["Language"]),
+		CommitID:   stringField(hit.Fields["CommitID"]),
+		Symbols:    stringsField(hit.Fields["Symbols"]),
+		References: stringsField(hit.Fields["References"]),
+		Refs:       stringsField(hit.Fields["Refs"]),
 	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
-	return batch.Index(id, &RepoIndexerData{
-		RepoID:    repo.ID,
-		CommitID:  commitSha,
-		Filename:  update.Filename,
-		Content:   string(charset.ToUTF8DropErrors(fileContents)),
-		Language:  analyze.GetCodeLanguage(update.Filename, fileContents),
-		UpdatedAt: time.Now().UTC(),
-	})
+	doc.UpdatedAt, _ = time.Parse(time.RFC3339, stringField(hit.Fields["UpdatedAt"]))
+	return doc
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch) error {
-	id := internal.FilenameIndexerID(repo.ID, filename)
-	return batch.Delete(id)
+func stringField(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// stringsField returns the values of a stored array, bleve returns a single value as it is
+func stringsField(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			values = append(values, stringField(value))
+		}
+		return values
+	}
+	return nil
 }
 
 // Index indexes the data
@@ -218,72 +339,177 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		}
 		defer catfileBatch.Close()
 
-		for _, update := range changes.Updates {
-			if err := b.addUpdate(ctx, catfileBatch, sha, update, repo, batch); err != nil {
+		// the stored documents of the files are looked up by batches
+		for i := 0; i < len(changes.Updates); i += maxBatchSize {
+			updates := changes.Updates[i:min(i+maxBatchSize, len(changes.Updates))]
+			ids := make([]string, 0, len(updates))
+			for _, update := range updates {
+				ids = append(ids, internal.FilenameIndexerID(repo.ID, update.BlobSha, update.Filename))
+			}
+			docs, err := b.getDocuments(ctx, ids)
+			if err != nil {
 				return err
 			}
+			for j, update := range updates {
+				if err := b.addUpdate(ctx, catfileBatch, sha, changes.Ref, update, repo, ids[j], docs[ids[j]], batch); err != nil {
+					return err
+				}
+			}
 		}
 	}
-	for _, filename := range changes.RemovedFilenames {
-		if err := b.addDelete(filename, repo, batch); err != nil {
+	for i := 0; i < len(changes.Removals); i += maxBatchSize {
+		removals := changes.Removals[i:min(i+maxBatchSize, len(changes.Removals))]
+		ids := make([]string, 0, len(removals))
+		for _, removal := range removals {
+			ids = append(ids, internal.FilenameIndexerID(repo.ID, removal.BlobSha, removal.Filename))
+		}
+		docs, err := b.getDocuments(ctx, ids)
+		if err != nil {
 			return err
 		}
+		for _, id := range ids {
+			if doc := docs[id]; doc != nil {
+				if err := b.removeRef(id, changes.Ref, doc, batch); err != nil {
+					return err
+				}
+			}
+		}
 	}
 	return batch.Flush()
 }
 
+// DeleteRef removes a ref from the indexed files of a repo. The files are processed by pages, only the ones which
+// are in other refs too are loaded to be indexed again without the ref, the other ones are deleted.
+func (b *Indexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
+	refQuery := bleve.NewTermQuery(ref)
+	refQuery.FieldVal = "Refs"
+	query := bleve.NewConjunctionQuery(inner_bleve.NumericEqualityQuery(repoID, "RepoID"), refQuery)
+	for {
+		// the processed files don't match the query anymore, so the next page starts from the beginning again
+		searchRequest := bleve.NewSearchRequestOptions(query, deletePageSize, 0, false)
+		searchRequest.Fields = []string{"Refs"}
+		result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+		if err != nil {
+			return err
+		}
+
+		batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+		sharedIDs := make([]string, 0, len(result.Hits))
+		for _, hit := range result.Hits {
+			if len(stringsField(hit.Fields["Refs"])) > 1 {
+				sharedIDs = append(sharedIDs, hit.ID)
+			} else if err := batch.Delete(hit.ID); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < len(sharedIDs); i += maxBatchSize {
+			ids := sharedIDs[i:min(i+maxBatchSize, len(sharedIDs))]
+			docs, err := b.getDocuments(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if doc := docs[id]; doc != nil {
+					if err := b.removeRef(id, ref, doc, batch); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if err := batch.Flush(); err != nil {
+			return err
+		}
+		if len(result.Hits) < deletePageSize {
+			return nil
+		}
+	}
+}
+
 // Delete deletes indexes by ids
-func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
 	query := inner_bleve.NumericEqualityQuery(repoID, "RepoID")
-	searchRequest := bleve.NewSearchRequestOptions(query, 2147483647, 0, false)
-	result, err := b.inner.Indexer.Search(searchRequest)
-	if err != nil {
-		return err
-	}
-	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
-	for _, hit := range result.Hits {
-		if err = batch.Delete(hit.ID); err != nil {
+	for {
+		// the deleted files don't match the query anymore, so the next page starts from the beginning again
+		searchRequest := bleve.NewSearchRequestOptions(query, deletePageSize, 0, false)
+		result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+		if err != nil {
 			return err
 		}
+		batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+		for _, hit := range result.Hits {
+			if err := batch.Delete(hit.ID); err != nil {
+				return err
+			}
+		}
+		if err := batch.Flush(); err != nil {
+			return err
+		}
+		if len(result.Hits) < deletePageSize {
+			return nil
+		}
 	}
-	return batch.Flush()
 }
 
 // Search searches for files in the specified repo.
 // Returns the matching file-paths
-func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, bool, error) {
 	var (
 		indexerQuery query.Query
 		keywordQuery query.Query
 		contentQuery query.Query
+		re           *regexp.Regexp
 	)
 
-	pathQuery := bleve.NewPrefixQuery(strings.ToLower(opts.Keyword))
-	pathQuery.FieldVal = "Filename"
-	pathQuery.SetBoost(10)
-
 	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
-	if searchMode == indexer.SearchModeExact {
-		// 1.21 used NewPrefixQuery, but it seems not working well, and later releases changed to NewMatchPhraseQuery
-		q := bleve.NewMatchPhraseQuery(opts.Keyword)
-		q.Analyzer = repoIndexerAnalyzer
-		q.FieldVal = "Content"
-		contentQuery = q
-	} else /* words */ {
-		q := bleve.NewMatchQuery(opts.Keyword)
-		q.FieldVal = "Content"
-		q.Analyzer = repoIndexerAnalyzer
-		if searchMode == indexer.SearchModeFuzzy {
-			// this logic doesn't seem right, it is only used to pass the test-case `Keyword:    "dESCRIPTION"`, which doesn't seem to be a real-life use-case.
-			q.Fuzziness = inner_bleve.GuessFuzzinessByKeyword(opts.Keyword)
-		} else {
-			q.Operator = query.MatchQueryOperatorAnd
+	switch {
+	case opts.PathOnly:
+		// the paths containing the keyword are ranked before the ones only containing its characters
+		fuzzyQuery := bleve.NewRegexpQuery("(?i)" + internal.FuzzyPathRegexp(opts.Keyword))
+		fuzzyQuery.FieldVal = "FilenameKeyword"
+		substringQuery := bleve.NewRegexpQuery("(?i)" + internal.SubstringPathRegexp(opts.Keyword))
+		substringQuery.FieldVal = "FilenameKeyword"
+		substringQuery.SetBoost(10)
+		keywordQuery = bleve.NewDisjunctionQuery(fuzzyQuery, substringQuery)
+	case opts.Symbol != internal.SymbolSearchNone:
+		// the names of the symbols are case-sensitive
+		q := bleve.NewTermQuery(opts.Keyword)
+		q.FieldVal = util.Iif(opts.Symbol == internal.SymbolSearchDefinition, "Symbols", "References")
+		keywordQuery = q
+	case searchMode == indexer.SearchModeRegexp:
+		var parsed *syntax.Regexp
+		var err error
+		if re, parsed, err = internal.ParseRegexp(opts.Keyword); err != nil {
+			return 0, nil, nil, false, err
+		}
+		keywordQuery = trigramQuery(internal.RegexpTrigramQuery(parsed))
+	default:
+		pathQuery := bleve.NewPrefixQuery(strings.ToLower(opts.Keyword))
+		pathQuery.FieldVal = "Filename"
+		pathQuery.SetBoost(10)
+
+		if searchMode == indexer.SearchModeExact {
+			// 1.21 used NewPrefixQuery, but it seems not working well, and later releases changed to NewMatchPhraseQuery
+			q := bleve.NewMatchPhraseQuery(opts.Keyword)
+			q.Analyzer = repoIndexerAnalyzer
+			q.FieldVal = "Content"
+			contentQuery = q
+		} else /* words */ {
+			q := bleve.NewMatchQuery(opts.Keyword)
+			q.FieldVal = "Content"
+			q.Analyzer = repoIndexerAnalyzer
+			if searchMode == indexer.SearchModeFuzzy {
+				// this logic doesn't seem right, it is only used to pass the test-case `Keyword:    "dESCRIPTION"`, which doesn't seem to be a real-life use-case.
+				q.Fuzziness = inner_bleve.GuessFuzzinessByKeyword(opts.Keyword)
+			} else {
+				q.Operator = query.MatchQueryOperatorAnd
+			}
+			contentQuery = q
 		}
-		contentQuery = q
-	}
 
-	keywordQuery = bleve.NewDisjunctionQuery(contentQuery, pathQuery)
+		keywordQuery = bleve.NewDisjunctionQuery(contentQuery, pathQuery)
+	}
 
+	indexerQuery = keywordQuery
 	if len(opts.RepoIDs) > 0 {
 		repoQueries := make([]query.Query, 0, len(opts.RepoIDs))
 		for _, repoID := range opts.RepoIDs {
@@ -292,10 +518,44 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 
 		indexerQuery = bleve.NewConjunctionQuery(
 			bleve.NewDisjunctionQuery(repoQueries...),
-			keywordQuery,
+			indexerQuery,
 		)
-	} else {
-		indexerQuery = keywordQuery
+	}
+	if len(opts.PathPatterns) > 0 {
+		pathQueries := make([]query.Query, 0, len(opts.PathPatterns))
+		for _, pattern := range opts.PathPatterns {
+			q := bleve.NewWildcardQuery(internal.PathPatternToWildcard(pattern))
+			q.FieldVal = "FilenameKeyword"
+			pathQueries = append(pathQueries, q)
+		}
+
+		indexerQuery = bleve.NewConjunctionQuery(
+			bleve.NewDisjunctionQuery(pathQueries...),
+			indexerQuery,
+		)
+	}
+	if len(opts.Extensions) > 0 {
+		extensionQuery := bleve.NewRegexpQuery("(?i)" + internal.ExtensionsRegexp(opts.Extensions))
+		extensionQuery.FieldVal = "FilenameKeyword"
+		indexerQuery = bleve.NewConjunctionQuery(extensionQuery, indexerQuery)
+	}
+
+	if re != nil {
+		// the regexp confirms the candidates, which are fetched in a stable order
+		return internal.SearchRegexp(ctx, opts, re, func(ctx context.Context, from, size int) ([]*internal.SearchResult, error) {
+			searchRequest := bleve.NewSearchRequestOptions(indexerQuery, size, from, false)
+			searchRequest.Fields = []string{"Content", "RepoID", "Language", "CommitID", "Refs", "UpdatedAt"}
+			searchRequest.SortBy([]string{"-UpdatedAt", "_id"})
+			result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]*internal.SearchResult, len(result.Hits))
+			for i, hit := range result.Hits {
+				candidates[i] = convertHit(hit, -1, -1)
+			}
+			return candidates, nil
+		})
 	}
 
 	// Save for reuse without language filter
@@ -313,8 +573,13 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 
 	from, pageSize := opts.GetSkipTake()
 	searchRequest := bleve.NewSearchRequestOptions(indexerQuery, pageSize, from, false)
-	searchRequest.Fields = []string{"Content", "Filename", "RepoID", "Language", "CommitID", "UpdatedAt"}
-	searchRequest.IncludeLocations = true
+	if opts.PathOnly {
+		// the contents aren't shown by the path searches
+		searchRequest.Fields = []string{"RepoID", "Language", "CommitID", "Refs", "UpdatedAt"}
+	} else {
+		searchRequest.Fields = []string{"Content", "Filename", "RepoID", "Language", "CommitID", "Refs", "UpdatedAt"}
+		searchRequest.IncludeLocations = true
+	}
 
 	if len(opts.Language) == 0 {
 		searchRequest.AddFacet("languages", bleve.NewFacetRequest("Language", 10))
@@ -324,7 +589,7 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 
 	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
 	if err != nil {
-		return 0, nil, nil, err
+		return 0, nil, nil, false, err
 	}
 
 	total := int64(result.Total)
@@ -343,38 +608,26 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 				endIndex = locationEnd
 			}
 		}
-		if len(hit.Locations["Filename"]) > 0 {
+		if opts.PathOnly {
+			startIndex, endIndex = 0, 0
+		} else if opts.Symbol != internal.SymbolSearchNone {
+			startIndex, endIndex = internal.SymbolMatchIndexPos(opts, hit.Fields["Language"].(string), hit.Fields["Content"].(string))
+		} else if len(hit.Locations["Filename"]) > 0 {
 			startIndex, endIndex = internal.FilenameMatchIndexPos(hit.Fields["Content"].(string))
 		}
-
-		language := hit.Fields["Language"].(string)
-		var updatedUnix timeutil.TimeStamp
-		if t, err := time.Parse(time.RFC3339, hit.Fields["UpdatedAt"].(string)); err == nil {
-			updatedUnix = timeutil.TimeStamp(t.Unix())
-		}
-		searchResults[i] = &internal.SearchResult{
-			RepoID:      int64(hit.Fields["RepoID"].(float64)),
-			StartIndex:  startIndex,
-			EndIndex:    endIndex,
-			Filename:    internal.FilenameOfIndexerID(hit.ID),
-			Content:     hit.Fields["Content"].(string),
-			CommitID:    hit.Fields["CommitID"].(string),
-			UpdatedUnix: updatedUnix,
-			Language:    language,
-			Color:       enry.GetColor(language),
-		}
+		searchResults[i] = convertHit(hit, startIndex, endIndex)
 	}
 
 	searchResultLanguages := make([]*internal.SearchResultLanguages, 0, 10)
 	if len(opts.Language) > 0 {
 		// Use separate query to go get all language counts
 		facetRequest := bleve.NewSearchRequestOptions(facetQuery, 1, 0, false)
-		facetRequest.Fields = []string{"Content", "RepoID", "Language", "CommitID", "UpdatedAt"}
+		facetRequest.Fields = []string{"Content", "RepoID", "Language", "CommitID", "Refs", "UpdatedAt"}
 		facetRequest.IncludeLocations = true
 		facetRequest.AddFacet("languages", bleve.NewFacetRequest("Language", 10))
 
 		if result, err = b.inner.Indexer.Search(facetRequest); err != nil {
-			return 0, nil, nil, err
+			return 0, nil, nil, false, err
 		}
 	}
 	languagesFacet := result.Facets["languages"]
@@ -388,5 +641,45 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 			Count:    term.Count,
 		})
 	}
-	return total, searchResults, searchResultLanguages, nil
+	return total, searchResults, searchResultLanguages, false, nil
+}
+
+func convertHit(hit *search.DocumentMatch, startIndex, endIndex int) *internal.SearchResult {
+	language := hit.Fields["Language"].(string)
+	var updatedUnix timeutil.TimeStamp
+	if t, err := time.Parse(time.RFC3339, hit.Fields["UpdatedAt"].(string)); err == nil {
+		updatedUnix = timeutil.TimeStamp(t.Unix())
+	}
+	return &internal.SearchResult{
+		RepoID:      int64(hit.Fields["RepoID"].(float64)),
+		StartIndex:  startIndex,
+		EndIndex:    endIndex,
+		Filename:    internal.FilenameOfIndexerID(hit.ID),
+		Content:     stringField(hit.Fields["Content"]),
+		CommitID:    hit.Fields["CommitID"].(string),
+		Refs:        stringsField(hit.Fields["Refs"]),
+		UpdatedUnix: updatedUnix,
+		Language:    language,
+		Color:       enry.GetColor(language),
+	}
+}
+
+// trigramQuery converts a trigram query to a query of the trigrams of the contents
+func trigramQuery(q *internal.TrigramQuery) query.Query {
+	if q.Op == internal.TrigramAll {
+		return bleve.NewMatchAllQuery()
+	}
+	subs := make([]query.Query, 0, len(q.Trigrams)+len(q.Subs))
+	for _, trigram := range q.Trigrams {
+		tq := bleve.NewTermQuery(trigram)
+		tq.FieldVal = "ContentTrigrams"
+		subs = append(subs, tq)
+	}
+	for _, sub := range q.Subs {
+		subs = append(subs, trigramQuery(sub))
+	}
+	if q.Op == internal.TrigramAnd {
+		return bleve.NewConjunctionQuery(subs...)
+	}
+	return bleve.NewDisjunctionQuery(subs...)
 }
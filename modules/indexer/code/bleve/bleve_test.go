@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"fmt"
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteRef(t *testing.T) {
+	defer test.MockVariableValue(&deletePageSize, 3)()
+
+	b := NewIndexer(t.TempDir())
+	_, err := b.Init(t.Context())
+	require.NoError(t, err)
+	defer b.Close()
+
+	// the files of the repo 1 are in the branch, half of them are in the tag too
+	for i := range 10 {
+		refs := []string{"refs/heads/branch"}
+		if i%2 == 0 {
+			refs = append(refs, "refs/tags/tag")
+		}
+		for repoID := int64(1); repoID <= 2; repoID++ {
+			filename := fmt.Sprintf("file%d.txt", i)
+			require.NoError(t, b.inner.Indexer.Index(internal.FilenameIndexerID(repoID, fmt.Sprintf("%040d", i), filename), &RepoIndexerData{
+				RepoID:   repoID,
+				Filename: filename,
+				Content:  fmt.Sprintf("content %d", i),
+				Refs:     refs,
+			}))
+		}
+	}
+
+	refsOfFiles := func(t *testing.T, repoID int64) map[string][]string {
+		searchRequest := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), 100, 0, false)
+		searchRequest.Fields = []string{"*"}
+		result, err := b.inner.Indexer.SearchInContext(t.Context(), searchRequest)
+		require.NoError(t, err)
+		refs := make(map[string][]string)
+		for _, hit := range result.Hits {
+			if doc := documentOfHit(hit); doc.RepoID == repoID {
+				refs[doc.Filename] = doc.Refs
+				assert.NotEmpty(t, doc.Content)
+			}
+		}
+		return refs
+	}
+
+	require.NoError(t, b.DeleteRef(t.Context(), 1, "refs/heads/branch"))
+	refs := refsOfFiles(t, 1)
+	assert.Len(t, refs, 5)
+	for i := 0; i < 10; i += 2 {
+		assert.Equal(t, []string{"refs/tags/tag"}, refs[fmt.Sprintf("file%d.txt", i)])
+	}
+	assert.Len(t, refsOfFiles(t, 2), 10, "the files of the other repo are kept")
+
+	require.NoError(t, b.Delete(t.Context(), 2))
+	assert.Empty(t, refsOfFiles(t, 2))
+	assert.Len(t, refsOfFiles(t, 1), 5)
+}
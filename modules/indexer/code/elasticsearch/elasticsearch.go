@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"regexp/syntax"
 	"strconv"
 	"strings"
 
@@ -18,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/symbols"
 	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
 	inner_elasticsearch "code.gitea.io/gitea/modules/indexer/internal/elasticsearch"
 	"code.gitea.io/gitea/modules/json"
@@ -32,7 +35,7 @@ import (
 )
 
 const (
-	esRepoIndexerLatestVersion = 3
+	esRepoIndexerLatestVersion = 7
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -48,7 +51,7 @@ type Indexer struct {
 }
 
 func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
-	return indexer.SearchModesExactWords()
+	return indexer.SearchModesExactWordsRegexp()
 }
 
 // NewIndexer creates a new elasticsearch indexer
@@ -75,7 +78,11 @@ const (
         			},
         			"reversed_filename_path_analyzer": {
           				"tokenizer": "reversed_path_tokenizer"
-        			}
+        			},
+					"trigram_analyzer": {
+						"tokenizer": "trigram_tokenizer",
+						"filter" : ["lowercase"]
+					}
       			},
 				"tokenizer": {
 					"content_tokenizer": {
@@ -90,6 +97,11 @@ const (
 						"type": "path_hierarchy",
 						"delimiter": "/",
 						"reverse": true
+					},
+					"trigram_tokenizer": {
+						"type": "ngram",
+						"min_gram": 3,
+						"max_gram": 3
 					}
 				}
 			}
@@ -112,14 +124,24 @@ const (
           				"path_reversed": {
             				"type": "text",
             				"analyzer": "filename_path_analyzer"
-          				}
+          				},
+						"keyword": {
+							"type": "keyword"
+						}
         			}
 				},
 				"content": {
 					"type": "text",
 					"term_vector": "with_positions_offsets",
 					"index": true,
-					"analyzer": "content_analyzer"
+					"analyzer": "content_analyzer",
+					"fields": {
+						"trigram": {
+							"type": "text",
+							"analyzer": "trigram_analyzer",
+							"index_options": "docs"
+						}
+					}
 				},
 				"commit_id": {
 					"type": "keyword",
@@ -129,6 +151,18 @@ const (
 					"type": "keyword",
 					"index": true
 				},
+				"symbols": {
+					"type": "keyword",
+					"index": true
+				},
+				"references": {
+					"type": "keyword",
+					"index": true
+				},
+				"refs": {
+					"type": "keyword",
+					"index": true
+				},
 				"updated_at": {
 					"type": "long",
 					"index": true
@@ -138,12 +172,30 @@ const (
 	}`
 )
 
-func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, sha string, update internal.FileUpdate, repo *repo_model.Repository) ([]elastic.BulkableRequest, error) {
+const (
+	// addRefScript adds a ref to an indexed version of a file
+	addRefScript = `if (!ctx._source.refs.contains(params.ref)) { ctx._source.refs.add(params.ref) } else { ctx.op = 'noop' }`
+	// removeRefScript removes a ref from an indexed version of a file, which is deleted if it isn't in any ref anymore
+	removeRefScript = `ctx._source.refs.removeIf(r -> r == params.ref); if (ctx._source.refs.isEmpty()) { ctx.op = 'delete' }`
+)
+
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, sha, ref string, update internal.FileUpdate, repo *repo_model.Repository, indexed bool) ([]elastic.BulkableRequest, error) {
 	// Ignore vendored files in code search
 	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
 		return nil, nil
 	}
 
+	id := internal.FilenameIndexerID(repo.ID, update.BlobSha, update.Filename)
+	if indexed {
+		// the same version of the file is already indexed for another ref
+		return []elastic.BulkableRequest{
+			elastic.NewBulkUpdateRequest().
+				Index(b.inner.VersionedIndexName()).
+				Id(id).
+				Script(elastic.NewScript(addRefScript).Param("ref", ref)),
+		}, nil
+	}
+
 	size := update.Size
 	var err error
 	if !update.Sized {
@@ -157,27 +209,26 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 		}
 	}
 
-	if size > setting.Indexer.MaxIndexerFileSize {
-		return []elastic.BulkableRequest{b.addDelete(update.Filename, repo)}, nil
-	}
-
-	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
-	if err != nil {
-		return nil, err
-	}
-
-	fileContents, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
-	if err != nil {
-		return nil, err
-	} else if !typesniffer.DetectContentType(fileContents).IsText() {
-		// FIXME: UTF-16 files will probably fail here
-		return nil, nil
-	}
-
-	if _, err = batchReader.Discard(1); err != nil {
-		return nil, err
+	// the files which are too large or not text are indexed without their contents, so their paths are searchable
+	var fileContents []byte
+	if size <= setting.Indexer.MaxIndexerFileSize {
+		info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
+		if err != nil {
+			return nil, err
+		}
+		if fileContents, err = io.ReadAll(io.LimitReader(batchReader, info.Size)); err != nil {
+			return nil, err
+		} else if !typesniffer.DetectContentType(fileContents).IsText() {
+			// FIXME: UTF-16 files will probably fail here
+			fileContents = nil
+		}
+		if _, err = batchReader.Discard(1); err != nil {
+			return nil, err
+		}
 	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
+	content := charset.ToUTF8DropErrors(fileContents)
+	language := analyze.GetCodeLanguage(update.Filename, fileContents)
+	defs, refs := symbols.Extract(language, content)
 
 	return []elastic.BulkableRequest{
 		elastic.NewBulkIndexRequest().
@@ -186,21 +237,49 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 			Doc(map[string]any{
 				"repo_id":    repo.ID,
 				"filename":   update.Filename,
-				"content":    string(charset.ToUTF8DropErrors(fileContents)),
+				"content":    string(content),
 				"commit_id":  sha,
-				"language":   analyze.GetCodeLanguage(update.Filename, fileContents),
+				"language":   language,
+				"symbols":    symbols.Names(defs),
+				"references": refs,
+				"refs":       []string{ref},
 				"updated_at": timeutil.TimeStampNow(),
 			}),
 	}, nil
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository) elastic.BulkableRequest {
-	id := internal.FilenameIndexerID(repo.ID, filename)
-	return elastic.NewBulkDeleteRequest().
+func (b *Indexer) addDelete(ref string, removal internal.FileRemoval, repo *repo_model.Repository) elastic.BulkableRequest {
+	id := internal.FilenameIndexerID(repo.ID, removal.BlobSha, removal.Filename)
+	return elastic.NewBulkUpdateRequest().
 		Index(b.inner.VersionedIndexName()).
-		Id(id)
+		Id(id).
+		Script(elastic.NewScript(removeRefScript).Param("ref", ref))
+}
+
+// indexedIDs returns which of the versions of the files are already indexed
+func (b *Indexer) indexedIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	items := make([]*elastic.MultiGetItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, elastic.NewMultiGetItem().
+			Index(b.inner.VersionedIndexName()).
+			Id(id).
+			FetchSource(elastic.NewFetchSourceContext(false)))
+	}
+	result, err := b.inner.Client.Mget().Add(items...).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make(map[string]bool, len(result.Docs))
+	for _, doc := range result.Docs {
+		if doc.Found {
+			indexed[doc.Id] = true
+		}
+	}
+	return indexed, nil
 }
 
+const esBatchSize = 50
+
 // Index will save the index data
 func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
 	reqs := make([]elastic.BulkableRequest, 0)
@@ -211,24 +290,33 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		}
 		defer batch.Close()
 
-		for _, update := range changes.Updates {
-			updateReqs, err := b.addUpdate(ctx, batch, sha, update, repo)
+		for i := 0; i < len(changes.Updates); i += esBatchSize {
+			updates := changes.Updates[i:min(i+esBatchSize, len(changes.Updates))]
+			ids := make([]string, 0, len(updates))
+			for _, update := range updates {
+				ids = append(ids, internal.FilenameIndexerID(repo.ID, update.BlobSha, update.Filename))
+			}
+			indexed, err := b.indexedIDs(ctx, ids)
 			if err != nil {
 				return err
 			}
-			if len(updateReqs) > 0 {
-				reqs = append(reqs, updateReqs...)
+			for j, update := range updates {
+				updateReqs, err := b.addUpdate(ctx, batch, sha, changes.Ref, update, repo, indexed[ids[j]])
+				if err != nil {
+					return err
+				}
+				if len(updateReqs) > 0 {
+					reqs = append(reqs, updateReqs...)
+				}
 			}
 		}
 	}
 
-	for _, filename := range changes.RemovedFilenames {
-		reqs = append(reqs, b.addDelete(filename, repo))
+	for _, removal := range changes.Removals {
+		reqs = append(reqs, b.addDelete(changes.Ref, removal, repo))
 	}
 
 	if len(reqs) > 0 {
-		esBatchSize := 50
-
 		for i := 0; i < len(reqs); i += esBatchSize {
 			_, err := b.inner.Client.Bulk().
 				Index(b.inner.VersionedIndexName()).
@@ -242,6 +330,19 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 	return nil
 }
 
+// DeleteRef removes a ref from the indexed files of a repo
+func (b *Indexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
+	_, err := b.inner.Client.UpdateByQuery(b.inner.VersionedIndexName()).
+		Query(elastic.NewBoolQuery().Filter(
+			elastic.NewTermQuery("repo_id", repoID),
+			elastic.NewTermQuery("refs", ref),
+		)).
+		Script(elastic.NewScript(removeRefScript).Param("ref", ref)).
+		ProceedOnVersionConflict().
+		Do(ctx)
+	return err
+}
+
 // Delete entries by repoId
 func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
 	if err := b.doDelete(ctx, repoID); err != nil {
@@ -291,51 +392,90 @@ func contentMatchIndexPos(content, start, end string) (int, int) {
 	return startIdx, (startIdx + len(start) + endIdx + len(end)) - 9 // remove the length <em></em> since we give Content the original data
 }
 
-func convertResult(searchResult *elastic.SearchResult, kw string, pageSize int) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+func convertResult(searchResult *elastic.SearchResult, opts *internal.SearchOptions, kw string, pageSize int) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
 	hits := make([]*internal.SearchResult, 0, pageSize)
 	for _, hit := range searchResult.Hits.Hits {
-		repoID, fileName := internal.ParseIndexerID(hit.Id)
-		res := make(map[string]any)
-		if err := json.Unmarshal(hit.Source, &res); err != nil {
+		result, err := convertHit(hit)
+		if err != nil {
 			return 0, nil, nil, err
 		}
 
 		// FIXME: There is no way to get the position the keyword on the content currently on the same request.
 		// So we get it from content, this may made the query slower. See
 		// https://discuss.elastic.co/t/fetching-position-of-keyword-in-matched-document/94291
-		var startIndex, endIndex int
-		if c, ok := hit.Highlight["filename"]; ok && len(c) > 0 {
-			startIndex, endIndex = internal.FilenameMatchIndexPos(res["content"].(string))
+		if opts.PathOnly {
+			result.StartIndex, result.EndIndex = 0, 0
+		} else if opts.Symbol != internal.SymbolSearchNone {
+			// the symbols are not highlighted, the positions are found by extracting them again
+			result.StartIndex, result.EndIndex = internal.SymbolMatchIndexPos(opts, result.Language, result.Content)
+		} else if c, ok := hit.Highlight["filename"]; ok && len(c) > 0 {
+			result.StartIndex, result.EndIndex = internal.FilenameMatchIndexPos(result.Content)
 		} else if c, ok := hit.Highlight["content"]; ok && len(c) > 0 {
 			// FIXME: Since the highlighting content will include <em> and </em> for the keywords,
 			// now we should find the positions. But how to avoid html content which contains the
 			// <em> and </em> tags? If elastic search has handled that?
-			startIndex, endIndex = contentMatchIndexPos(c[0], "<em>", "</em>")
-			if startIndex == -1 {
+			result.StartIndex, result.EndIndex = contentMatchIndexPos(c[0], "<em>", "</em>")
+			if result.StartIndex == -1 {
 				panic(fmt.Sprintf("1===%s,,,%#v,,,%s", kw, hit.Highlight, c[0]))
 			}
 		} else {
 			panic(fmt.Sprintf("2===%#v", hit.Highlight))
 		}
-
-		language := res["language"].(string)
-
-		hits = append(hits, &internal.SearchResult{
-			RepoID:      repoID,
-			Filename:    fileName,
-			CommitID:    res["commit_id"].(string),
-			Content:     res["content"].(string),
-			UpdatedUnix: timeutil.TimeStamp(res["updated_at"].(float64)),
-			Language:    language,
-			StartIndex:  startIndex,
-			EndIndex:    endIndex,
-			Color:       enry.GetColor(language),
-		})
+		hits = append(hits, result)
 	}
 
 	return searchResult.TotalHits(), hits, extractAggs(searchResult), nil
 }
 
+func convertHit(hit *elastic.SearchHit) (*internal.SearchResult, error) {
+	repoID, fileName := internal.ParseIndexerID(hit.Id)
+	res := make(map[string]any)
+	if err := json.Unmarshal(hit.Source, &res); err != nil {
+		return nil, err
+	}
+	language := res["language"].(string)
+	content, _ := res["content"].(string) // the contents aren't fetched by the path searches
+	return &internal.SearchResult{
+		RepoID:      repoID,
+		Filename:    fileName,
+		CommitID:    res["commit_id"].(string),
+		Refs:        stringsOfSource(res["refs"]),
+		Content:     content,
+		UpdatedUnix: timeutil.TimeStamp(res["updated_at"].(float64)),
+		Language:    language,
+		Color:       enry.GetColor(language),
+	}, nil
+}
+
+func stringsOfSource(v any) []string {
+	values, _ := v.([]any)
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// trigramQuery converts a trigram query to a query of the trigrams of the contents
+func trigramQuery(q *internal.TrigramQuery) elastic.Query {
+	if q.Op == internal.TrigramAll {
+		return elastic.NewMatchAllQuery()
+	}
+	subs := make([]elastic.Query, 0, len(q.Trigrams)+len(q.Subs))
+	for _, trigram := range q.Trigrams {
+		subs = append(subs, elastic.NewTermQuery("content.trigram", trigram))
+	}
+	for _, sub := range q.Subs {
+		subs = append(subs, trigramQuery(sub))
+	}
+	if q.Op == internal.TrigramAnd {
+		return elastic.NewBoolQuery().Filter(subs...)
+	}
+	return elastic.NewBoolQuery().Should(subs...).MinimumNumberShouldMatch(1)
+}
+
 func extractAggs(searchResult *elastic.SearchResult) []*internal.SearchResultLanguages {
 	var searchResultLanguages []*internal.SearchResultLanguages
 	agg, found := searchResult.Aggregations.Terms("language")
@@ -354,19 +494,43 @@ func extractAggs(searchResult *elastic.SearchResult) []*internal.SearchResultLan
 }
 
 // Search searches for codes and language stats by given conditions.
-func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
-	var contentQuery elastic.Query
-	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
-	if searchMode == indexer.SearchModeExact {
-		// 1.21 used NewMultiMatchQuery().Type(esMultiMatchTypePhrasePrefix), but later releases changed to NewMatchPhraseQuery
-		contentQuery = elastic.NewMatchPhraseQuery("content", opts.Keyword)
-	} else /* words */ {
-		contentQuery = elastic.NewMultiMatchQuery("content", opts.Keyword).Type(esMultiMatchTypeBestFields).Operator("and")
-	}
-	kwQuery := elastic.NewBoolQuery().Should(
-		contentQuery,
-		elastic.NewMultiMatchQuery(opts.Keyword, "filename^10").Type(esMultiMatchTypePhrasePrefix),
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, bool, error) {
+	var (
+		kwQuery elastic.Query
+		re      *regexp.Regexp
 	)
+	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+	switch {
+	case opts.PathOnly:
+		// the paths containing the keyword are ranked before the ones only containing its characters
+		kwQuery = elastic.NewBoolQuery().Should(
+			elastic.NewRegexpQuery("filename.keyword", internal.FuzzyPathRegexp(opts.Keyword)).CaseInsensitive(true),
+			elastic.NewRegexpQuery("filename.keyword", internal.SubstringPathRegexp(opts.Keyword)).CaseInsensitive(true).Boost(10),
+		).MinimumNumberShouldMatch(1)
+	case opts.Symbol == internal.SymbolSearchDefinition:
+		kwQuery = elastic.NewTermQuery("symbols", opts.Keyword)
+	case opts.Symbol == internal.SymbolSearchReference:
+		kwQuery = elastic.NewTermQuery("references", opts.Keyword)
+	case searchMode == indexer.SearchModeRegexp:
+		var parsed *syntax.Regexp
+		var err error
+		if re, parsed, err = internal.ParseRegexp(opts.Keyword); err != nil {
+			return 0, nil, nil, false, err
+		}
+		kwQuery = trigramQuery(internal.RegexpTrigramQuery(parsed))
+	default:
+		var contentQuery elastic.Query
+		if searchMode == indexer.SearchModeExact {
+			// 1.21 used NewMultiMatchQuery().Type(esMultiMatchTypePhrasePrefix), but later releases changed to NewMatchPhraseQuery
+			contentQuery = elastic.NewMatchPhraseQuery("content", opts.Keyword)
+		} else /* words */ {
+			contentQuery = elastic.NewMultiMatchQuery("content", opts.Keyword).Type(esMultiMatchTypeBestFields).Operator("and")
+		}
+		kwQuery = elastic.NewBoolQuery().Should(
+			contentQuery,
+			elastic.NewMultiMatchQuery(opts.Keyword, "filename^10").Type(esMultiMatchTypePhrasePrefix),
+		)
+	}
 	query := elastic.NewBoolQuery()
 	query = query.Must(kwQuery)
 	if len(opts.RepoIDs) > 0 {
@@ -377,18 +541,60 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		repoQuery := elastic.NewTermsQuery("repo_id", repoStrs...)
 		query = query.Must(repoQuery)
 	}
+	if len(opts.PathPatterns) > 0 {
+		pathQuery := elastic.NewBoolQuery().MinimumNumberShouldMatch(1)
+		for _, pattern := range opts.PathPatterns {
+			pathQuery = pathQuery.Should(elastic.NewWildcardQuery("filename.keyword", internal.PathPatternToWildcard(pattern)))
+		}
+		query = query.Must(pathQuery)
+	}
+	if len(opts.Extensions) > 0 {
+		query = query.Must(elastic.NewRegexpQuery("filename.keyword", internal.ExtensionsRegexp(opts.Extensions)).CaseInsensitive(true))
+	}
+
+	if re != nil {
+		// the regexp confirms the candidates, which are fetched in a stable order
+		return internal.SearchRegexp(ctx, opts, re, func(ctx context.Context, from, size int) ([]*internal.SearchResult, error) {
+			searchResult, err := b.inner.Client.Search().
+				Index(b.inner.VersionedIndexName()).
+				Query(query).
+				Sort("updated_at", false).
+				Sort("repo_id", true).
+				Sort("filename.keyword", true).
+				From(from).Size(size).
+				Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]*internal.SearchResult, 0, len(searchResult.Hits.Hits))
+			for _, hit := range searchResult.Hits.Hits {
+				candidate, err := convertHit(hit)
+				if err != nil {
+					return nil, err
+				}
+				candidates = append(candidates, candidate)
+			}
+			return candidates, nil
+		})
+	}
 
 	var (
 		start, pageSize = opts.GetSkipTake()
 		kw              = "<em>" + opts.Keyword + "</em>"
 		aggregation     = elastic.NewTermsAggregation().Field("language").Size(10).OrderByCountDesc()
+		fetchSource     = elastic.NewFetchSourceContext(true)
 	)
+	if opts.PathOnly {
+		// the contents aren't shown by the path searches
+		fetchSource.Exclude("content")
+	}
 
 	if len(opts.Language) == 0 {
 		searchResult, err := b.inner.Client.Search().
 			Index(b.inner.VersionedIndexName()).
 			Aggregation("language", aggregation).
 			Query(query).
+			FetchSourceContext(fetchSource).
 			Highlight(
 				elastic.NewHighlight().
 					Field("content").
@@ -401,10 +607,11 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 			From(start).Size(pageSize).
 			Do(ctx)
 		if err != nil {
-			return 0, nil, nil, err
+			return 0, nil, nil, false, err
 		}
 
-		return convertResult(searchResult, kw, pageSize)
+		total, hits, languages, err := convertResult(searchResult, opts, kw, pageSize)
+		return total, hits, languages, false, err
 	}
 
 	langQuery := elastic.NewMatchQuery("language", opts.Language)
@@ -415,13 +622,14 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		Size(0). // We only need stats information
 		Do(ctx)
 	if err != nil {
-		return 0, nil, nil, err
+		return 0, nil, nil, false, err
 	}
 
 	query = query.Must(langQuery)
 	searchResult, err := b.inner.Client.Search().
 		Index(b.inner.VersionedIndexName()).
 		Query(query).
+		FetchSourceContext(fetchSource).
 		Highlight(
 			elastic.NewHighlight().
 				Field("content").
@@ -434,10 +642,10 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		From(start).Size(pageSize).
 		Do(ctx)
 	if err != nil {
-		return 0, nil, nil, err
+		return 0, nil, nil, false, err
 	}
 
-	total, hits, _, err := convertResult(searchResult, kw, pageSize)
+	total, hits, _, err := convertResult(searchResult, opts, kw, pageSize)
 
-	return total, hits, extractAggs(countResult), err
+	return total, hits, extractAggs(countResult), false, err
 }
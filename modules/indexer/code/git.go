@@ -25,24 +25,60 @@ func getDefaultBranchSha(ctx context.Context, repo *repo_model.Repository) (stri
 	return strings.TrimSpace(stdout), nil
 }
 
-// getRepoChanges returns changes to repo since last indexer update
-func getRepoChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+// maxIndexedRefs is the maximum number of the branches and tags of a repository indexed besides the default branch
+const maxIndexedRefs = 100
+
+// getIndexedRefs returns the commits of the branches and tags matching the code indexer refs of the repo,
+// the default branch is not included because it is always indexed
+func getIndexedRefs(ctx context.Context, repo *repo_model.Repository, indexerRefs *repo_model.RepoCodeIndexerRefs) (map[string]string, error) {
+	refs := make(map[string]string)
+	if indexerRefs.IsEmpty() {
+		return refs, nil
+	}
+	// the tags are peeled to their commits
+	cmd := gitcmd.NewCommand("for-each-ref", "--format=%(if)%(*objectname)%(then)%(*objectname)%(else)%(objectname)%(end) %(refname)", git.BranchPrefix, git.TagPrefix)
+	stdout, _, err := gitrepo.RunCmdString(ctx, repo, cmd)
 	if err != nil {
 		return nil, err
 	}
+	match := indexerRefs.Matcher()
+	for line := range strings.SplitSeq(stdout, "\n") {
+		sha, refName, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		ref := git.RefName(refName)
+		if ref == git.RefNameFromBranch(repo.DefaultBranch) || !match(ref) {
+			continue
+		}
+		if len(refs) >= maxIndexedRefs {
+			log.Warn("Too many refs of repo %s match the code indexer refs, only %d of them are indexed", repo.FullName(), maxIndexedRefs)
+			break
+		}
+		refs[refName] = sha
+	}
+	return refs, nil
+}
 
-	needGenesis := len(status.CommitSha) == 0
+// getRefChanges returns the changes of a ref since it was indexed at indexedSha, all the files of the ref are added
+// if it hasn't been indexed yet or if its history has been rewritten.
+func getRefChanges(ctx context.Context, repo *repo_model.Repository, ref, indexedSha, revision string) (*internal.RepoChanges, error) {
+	needGenesis := len(indexedSha) == 0
 	if !needGenesis {
-		hasAncestorCmd := gitcmd.NewCommand("merge-base").AddDynamicArguments(status.CommitSha, revision)
+		hasAncestorCmd := gitcmd.NewCommand("merge-base").AddDynamicArguments(indexedSha, revision)
 		stdout, _, _ := gitrepo.RunCmdString(ctx, repo, hasAncestorCmd) // FIXME: error is not handled
-		needGenesis = len(stdout) == 0
+		if needGenesis = len(stdout) == 0; needGenesis {
+			// the files which were in the ref before the history was rewritten must not keep it
+			if err := (*globalIndexer.Load()).DeleteRef(ctx, repo.ID, ref); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if needGenesis {
-		return genesisChanges(ctx, repo, revision)
+		return genesisChanges(ctx, repo, ref, revision)
 	}
-	return nonGenesisChanges(ctx, repo, revision)
+	return nonGenesisChanges(ctx, repo, ref, indexedSha, revision)
 }
 
 func isIndexable(entry *git.TreeEntry) bool {
@@ -85,9 +121,9 @@ func parseGitLsTreeOutput(stdout []byte) ([]internal.FileUpdate, error) {
 	return updates[:idxCount], nil
 }
 
-// genesisChanges get changes to add repo to the indexer for the first time
-func genesisChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	var changes internal.RepoChanges
+// genesisChanges get changes to add a ref of a repo to the indexer for the first time
+func genesisChanges(ctx context.Context, repo *repo_model.Repository, ref, revision string) (*internal.RepoChanges, error) {
+	changes := internal.RepoChanges{Ref: ref}
 	stdout, _, runErr := gitrepo.RunCmdBytes(ctx, repo, gitcmd.NewCommand("ls-tree", "--full-tree", "-l", "-r").AddDynamicArguments(revision))
 	if runErr != nil {
 		return nil, runErr
@@ -98,21 +134,21 @@ func genesisChanges(ctx context.Context, repo *repo_model.Repository, revision s
 	return &changes, err
 }
 
-// nonGenesisChanges get changes since the previous indexer update
-func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	diffCmd := gitcmd.NewCommand("diff", "--name-status").AddDynamicArguments(repo.CodeIndexerStatus.CommitSha, revision)
+// nonGenesisChanges get changes of a ref since the previous indexer update
+func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, ref, indexedSha, revision string) (*internal.RepoChanges, error) {
+	diffCmd := gitcmd.NewCommand("diff", "--raw", "--no-abbrev").AddDynamicArguments(indexedSha, revision)
 	stdout, _, runErr := gitrepo.RunCmdString(ctx, repo, diffCmd)
 	if runErr != nil {
 		// previous commit sha may have been removed by a force push, so
 		// try rebuilding from scratch
 		log.Warn("git diff: %v", runErr)
-		if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		if err := (*globalIndexer.Load()).DeleteRef(ctx, repo.ID, ref); err != nil {
 			return nil, err
 		}
-		return genesisChanges(ctx, repo, revision)
+		return genesisChanges(ctx, repo, ref, revision)
 	}
 
-	var changes internal.RepoChanges
+	changes := internal.RepoChanges{Ref: ref}
 	var err error
 	updatedFilenames := make([]string, 0, 10)
 
@@ -137,11 +173,14 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 		if len(line) == 0 {
 			continue
 		}
+		// :<old mode> <new mode> <old blob> <new blob> <status>\t<path>[\t<new path>]
 		fields := strings.Split(line, "\t")
-		if len(fields) < 2 {
-			log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+		meta := strings.Fields(fields[0])
+		if len(fields) < 2 || len(meta) < 5 {
+			log.Warn("Unparseable output for diff --raw: `%s`)", line)
 			continue
 		}
+		oldBlobSha, newBlobSha, status := meta[2], meta[3], meta[4]
 		filename := fields[1]
 		if len(filename) == 0 {
 			continue
@@ -152,19 +191,26 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 			}
 		}
 
-		switch status := fields[0][0]; status {
-		case 'M', 'A':
+		switch status := status[0]; status {
+		case 'M':
+			if oldBlobSha == newBlobSha {
+				// only the mode has changed
+				continue
+			}
+			changes.Removals = append(changes.Removals, internal.FileRemoval{Filename: filename, BlobSha: oldBlobSha})
+			updatedFilenames = append(updatedFilenames, filename)
+		case 'A':
 			updatedFilenames = append(updatedFilenames, filename)
 		case 'D':
-			changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+			changes.Removals = append(changes.Removals, internal.FileRemoval{Filename: filename, BlobSha: oldBlobSha})
 		case 'R', 'C':
 			if len(fields) < 3 {
-				log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+				log.Warn("Unparseable output for diff --raw: `%s`)", line)
 				continue
 			}
 			dest := fields[2]
 			if len(dest) == 0 {
-				log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+				log.Warn("Unparseable output for diff --raw: `%s`)", line)
 				continue
 			}
 			if dest[0] == '"' {
@@ -174,7 +220,7 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revisio
 				}
 			}
 			if status == 'R' {
-				changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+				changes.Removals = append(changes.Removals, internal.FileRemoval{Filename: filename, BlobSha: oldBlobSha})
 			}
 			updatedFilenames = append(updatedFilenames, dest)
 		default:
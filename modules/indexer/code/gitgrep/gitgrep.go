@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/gitea/modules/indexer"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 )
 
 func indexSettingToGitGrepPathspecList() (list []string) {
@@ -24,7 +25,18 @@ func indexSettingToGitGrepPathspecList() (list []string) {
 	return list
 }
 
-func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Repository, ref git.RefName, keyword string, searchMode indexer.SearchModeType) (searchResults []*code_indexer.Result, total int, err error) {
+// pathPatternsToGitGrepPathspecList limits the search to the path patterns, git's default pathspecs match "/" by "*" like the indexers
+func pathPatternsToGitGrepPathspecList(pathPatterns []string) (list []string) {
+	for _, pattern := range pathPatterns {
+		list = append(list, strings.TrimPrefix(pattern, "/"))
+	}
+	for _, expr := range setting.Indexer.ExcludePatterns {
+		list = append(list, ":(glob,exclude)"+expr.PatternString())
+	}
+	return list
+}
+
+func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Repository, ref git.RefName, keyword string, searchMode indexer.SearchModeType, pathPatterns []string) (searchResults []*code_indexer.Result, total int, err error) {
 	grepMode := git.GrepModeWords
 	switch searchMode {
 	case indexer.SearchModeExact:
@@ -36,7 +48,7 @@ func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Rep
 		ContextLineNumber: 1,
 		GrepMode:          grepMode,
 		RefName:           ref.String(),
-		PathspecList:      indexSettingToGitGrepPathspecList(),
+		PathspecList:      util.Iif(len(pathPatterns) > 0, pathPatternsToGitGrepPathspecList(pathPatterns), indexSettingToGitGrepPathspecList()),
 	})
 	if err != nil {
 		// TODO: if no branch exists, it reports: exit status 128, fatal: this operation must be run in a work tree.
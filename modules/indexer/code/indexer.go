@@ -5,6 +5,7 @@ package code
 
 import (
 	"context"
+	"maps"
 	"os"
 	"runtime/pprof"
 	"slices"
@@ -31,6 +32,8 @@ var (
 	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
 	// So it's always safe use it as *globalIndexer.Load() and call its methods.
 	globalIndexer atomic.Pointer[internal.Indexer]
+	// lastError is the last error of the queue handler
+	lastError indexer.ErrorState
 )
 
 func init() {
@@ -77,18 +80,72 @@ func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
 	if err != nil {
 		return err
 	}
-	changes, err := getRepoChanges(ctx, repo, sha)
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+	changes, err := getRefChanges(ctx, repo, internal.DefaultBranchRef, status.CommitSha, sha)
 	if err != nil {
 		return err
-	} else if changes == nil {
-		return nil
 	}
 
 	if err := indexer.Index(ctx, repo, sha, changes); err != nil {
 		return err
 	}
 
-	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha)
+	if err := repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha); err != nil {
+		return err
+	}
+	return indexRefs(ctx, indexer, repo)
+}
+
+// indexRefs indexes the branches and tags of the repo matching its code indexer refs,
+// the refs which don't match them anymore or which have been deleted are removed from the indexer.
+func indexRefs(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository) error {
+	indexerRefs, err := repo_model.GetRepoCodeIndexerRefs(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	refs, err := getIndexedRefs(ctx, repo, indexerRefs)
+	if err != nil {
+		return err
+	}
+	statuses, err := repo_model.GetIndexerRefStatuses(ctx, repo.ID, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+
+	indexedShas := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		if _, ok := refs[status.RefName]; ok {
+			indexedShas[status.RefName] = status.CommitSha
+			continue
+		}
+		if err := indexer.DeleteRef(ctx, repo.ID, status.RefName); err != nil {
+			return err
+		}
+		if err := repo_model.DeleteIndexerRefStatus(ctx, repo.ID, repo_model.RepoIndexerTypeCode, status.RefName); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range slices.Sorted(maps.Keys(refs)) {
+		sha := refs[ref]
+		if indexedShas[ref] == sha {
+			continue
+		}
+		changes, err := getRefChanges(ctx, repo, ref, indexedShas[ref], sha)
+		if err != nil {
+			return err
+		}
+		if err := indexer.Index(ctx, repo, sha, changes); err != nil {
+			return err
+		}
+		if err := repo_model.UpdateIndexerRefStatus(ctx, repo.ID, repo_model.RepoIndexerTypeCode, ref, sha); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Init initialize the repo indexer
@@ -123,9 +180,12 @@ func Init() {
 			for _, indexerData := range items {
 				log.Trace("IndexerData Process Repo: %d", indexerData.RepoID)
 				if err := index(ctx, indexer, indexerData.RepoID); err != nil {
+					lastError.Record(err)
 					if !setting.IsInTesting {
 						log.Error("Codes indexer handler: index error for repo %v: %v", indexerData.RepoID, err)
 					}
+				} else {
+					lastError.Clear()
 				}
 			}
 			return nil // do not re-queue the failed items, otherwise some broken repo will block the queue
@@ -242,6 +302,26 @@ func UpdateRepoIndexer(repo *repo_model.Repository) {
 	}
 }
 
+// ReindexRepo removes a repository from the indexer and indexes it again from scratch
+func ReindexRepo(ctx context.Context, repo *repo_model.Repository) error {
+	if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		return err
+	}
+	if err := repo_model.DeleteIndexerStatuses(ctx, repo.ID, repo_model.RepoIndexerTypeCode); err != nil {
+		return err
+	}
+	UpdateRepoIndexer(repo)
+	return nil
+}
+
+// Status returns the status of the code indexer
+func Status(ctx context.Context) *indexer.Status {
+	if !setting.Indexer.RepoIndexerEnabled || indexerQueue == nil {
+		return &indexer.Status{Name: "code", Type: setting.Indexer.RepoType}
+	}
+	return indexer.NewStatus(ctx, "code", setting.Indexer.RepoType, *globalIndexer.Load(), indexerQueue.GetQueueItemNumber(), &lastError)
+}
+
 // IsAvailable checks if issue indexer is available
 func IsAvailable(ctx context.Context) bool {
 	return (*globalIndexer.Load()).Ping(ctx) == nil
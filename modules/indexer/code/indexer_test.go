@@ -5,11 +5,13 @@ package code
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"slices"
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	indexer_module "code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
@@ -41,11 +43,12 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 		assert.NoError(t, setupRepositoryIndexes(t.Context(), indexer))
 
 		keywords := []struct {
-			RepoIDs    []int64
-			Keyword    string
-			Langs      int
-			SearchMode indexer_module.SearchModeType
-			Results    []codeSearchResult
+			RepoIDs      []int64
+			Keyword      string
+			PathPatterns []string
+			Langs        int
+			SearchMode   indexer_module.SearchModeType
+			Results      []codeSearchResult
 		}{
 			// Search for an exact match on the contents of a file
 			// This scenario yields a single result (the file README.md on the repo '1')
@@ -234,20 +237,82 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 					},
 				},
 			},
+			// Search for matches on the contents of files by a regexp.
+			{
+				RepoIDs:    []int64{62},
+				Keyword:    "pineaple p[a-z]e",
+				Langs:      1,
+				SearchMode: indexer_module.SearchModeRegexp,
+				Results: []codeSearchResult{
+					{
+						Filename: "avocado.md",
+						Content:  "# repo1\n\npineaple pie of cucumber juice",
+					},
+				},
+			},
+			// Search for matches on the contents of files by a case-insensitive regexp.
+			{
+				RepoIDs:    nil,
+				Keyword:    "(?i)HELLO, w(o|0)rld",
+				Langs:      1,
+				SearchMode: indexer_module.SearchModeRegexp,
+				Results: []codeSearchResult{
+					{
+						Filename: "example-file.js",
+						Content:  "console.log(\"Hello, World!\")",
+					},
+				},
+			},
+			// Search for matches of a regexp which doesn't match the trigrams it contains.
+			{
+				RepoIDs:    []int64{62},
+				Keyword:    "cheese pie",
+				Langs:      0,
+				SearchMode: indexer_module.SearchModeRegexp,
+			},
+			// Search for matches of a regexp within a path.
+			{
+				RepoIDs:      []int64{62},
+				Keyword:      "not cheese$",
+				PathPatterns: []string{"potato/*"},
+				Langs:        1,
+				SearchMode:   indexer_module.SearchModeRegexp,
+				Results: []codeSearchResult{
+					{
+						Filename: "potato/ham.md",
+						Content:  "This is not cheese",
+					},
+				},
+			},
+			// Search for matches on the contents of files within a path.
+			{
+				RepoIDs:      []int64{62},
+				Keyword:      "cheese",
+				PathPatterns: []string{"**/ham.md", "/x/*"},
+				Langs:        1,
+				Results: []codeSearchResult{
+					{
+						Filename: "potato/ham.md",
+						Content:  "This is not cheese",
+					},
+				},
+			},
 		}
 
 		for _, kw := range keywords {
 			t.Run(kw.Keyword, func(t *testing.T) {
-				total, res, langs, err := indexer.Search(t.Context(), &internal.SearchOptions{
-					RepoIDs:    kw.RepoIDs,
-					Keyword:    kw.Keyword,
-					SearchMode: util.IfZero(kw.SearchMode, indexer_module.SearchModeWords),
+				total, res, langs, truncated, err := indexer.Search(t.Context(), &internal.SearchOptions{
+					RepoIDs:      kw.RepoIDs,
+					Keyword:      kw.Keyword,
+					PathPatterns: kw.PathPatterns,
+					SearchMode:   util.IfZero(kw.SearchMode, indexer_module.SearchModeWords),
 					Paginator: &db.ListOptions{
 						Page:     1,
 						PageSize: 10,
 					},
 				})
 				require.NoError(t, err)
+				assert.False(t, truncated)
 				require.Len(t, langs, kw.Langs)
 
 				hits := make([]codeSearchResult, 0, len(res))
@@ -278,10 +343,144 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 			})
 		}
 
+		t.Run("paths", func(t *testing.T) {
+			testIndexerPaths(t, indexer)
+		})
+
+		t.Run("refs", func(t *testing.T) {
+			testIndexerRefs(t, indexer)
+		})
+
 		assert.NoError(t, tearDownRepositoryIndexes(t.Context(), indexer))
 	})
 }
 
+func testIndexerPaths(t *testing.T, indexer internal.Indexer) {
+	search := func(keyword string, extensions ...string) []string {
+		total, res, _, truncated, err := searchPaths(t.Context(), indexer, &internal.SearchOptions{
+			RepoIDs:    []int64{62},
+			Keyword:    keyword,
+			Extensions: extensions,
+			Paginator:  &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		require.False(t, truncated)
+		require.Len(t, res, total)
+		filenames := make([]string, 0, len(res))
+		for _, r := range res {
+			filenames = append(filenames, r.Filename)
+		}
+		return filenames
+	}
+
+	// the best matches are listed first, the shorter paths among the ones matching equally well
+	assert.Equal(t, []string{"ham.md", "potato/ham.md"}, search("HAM"))
+	assert.Equal(t, []string{"potato/ham.md"}, search("pthm"))
+	assert.Equal(t, []string{"example-file.js"}, search("e", ".js"))
+	assert.Empty(t, search("mah"))
+
+	_, res, _, _, err := searchPaths(t.Context(), indexer, &internal.SearchOptions{RepoIDs: []int64{62}, Keyword: "pthm"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, []string{"", "p", "o", "t", "ato/", "h", "a", "m", ".md"}, res[0].FilenameParts)
+
+	// the paths of the files which are too large to index their contents are still searchable
+	defer test.MockVariableValue(&setting.Indexer.MaxIndexerFileSize, 20)()
+	repo, err := repo_model.GetRepositoryByID(t.Context(), 62)
+	require.NoError(t, err)
+	sha, err := getDefaultBranchSha(t.Context(), repo)
+	require.NoError(t, err)
+	changes, err := getRefChanges(t.Context(), repo, internal.DefaultBranchRef, "", sha)
+	require.NoError(t, err)
+	require.NoError(t, indexer.Delete(t.Context(), repo.ID))
+	require.NoError(t, indexer.Index(t.Context(), repo, sha, changes))
+
+	assert.Equal(t, []string{"avocado.md"}, search("avocado"))
+	_, contentRes, _, _, err := indexer.Search(t.Context(), &internal.SearchOptions{
+		RepoIDs:    []int64{62},
+		Keyword:    "pineaple",
+		SearchMode: indexer_module.SearchModeWords,
+		Paginator:  &db.ListOptions{Page: 1, PageSize: 10},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, contentRes)
+}
+
+// pathCandidatesIndexer returns more matching files than the candidates of a path search
+type pathCandidatesIndexer struct {
+	internal.Indexer
+}
+
+func (pathCandidatesIndexer) Search(_ context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, bool, error) {
+	_, take := opts.GetSkipTake()
+	results := make([]*internal.SearchResult, take)
+	for i := range results {
+		results[i] = &internal.SearchResult{RepoID: 1, Filename: fmt.Sprintf("dir/file%d.go", i)}
+	}
+	return int64(take + 1), results, nil, false, nil
+}
+
+func TestSearchPathsTruncated(t *testing.T) {
+	total, res, _, truncated, err := searchPaths(t.Context(), pathCandidatesIndexer{internal.NewDummyIndexer()}, &internal.SearchOptions{
+		Keyword:   "file",
+		Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+	})
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, maxPathSearchCandidates, total)
+	assert.Len(t, res, 10)
+}
+
+func testIndexerRefs(t *testing.T, indexer internal.Indexer) {
+	search := func(keyword string) []*internal.SearchResult {
+		_, res, _, _, err := indexer.Search(t.Context(), &internal.SearchOptions{
+			RepoIDs:    []int64{1},
+			Keyword:    keyword,
+			SearchMode: indexer_module.SearchModeWords,
+			Paginator:  &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	// the branch "DefaultBranch" adds a LICENSE, the tag "v1.1" is the default branch
+	require.NoError(t, repo_model.SaveRepoCodeIndexerRefs(t.Context(), &repo_model.RepoCodeIndexerRefs{
+		RepoID:         1,
+		BranchPatterns: "Default*",
+		TagPatterns:    "v*",
+	}))
+	require.NoError(t, index(t.Context(), indexer, 1))
+
+	res := search("Description")
+	require.Len(t, res, 1, "the files shared by the refs are only indexed once")
+	assert.Equal(t, "README.md", res[0].Filename)
+	assert.ElementsMatch(t, []string{internal.DefaultBranchRef, "refs/heads/DefaultBranch", "refs/tags/v1.1"}, res[0].Refs)
+
+	res = search("Permission")
+	require.Len(t, res, 1)
+	assert.Equal(t, "LICENSE", res[0].Filename)
+	assert.Equal(t, []string{"refs/heads/DefaultBranch"}, res[0].Refs)
+
+	statuses, err := repo_model.GetIndexerRefStatuses(t.Context(), 1, repo_model.RepoIndexerTypeCode)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "refs/heads/DefaultBranch", statuses[0].RefName)
+	assert.Equal(t, "refs/tags/v1.1", statuses[1].RefName)
+
+	// the refs which aren't indexed anymore are removed
+	require.NoError(t, repo_model.SaveRepoCodeIndexerRefs(t.Context(), &repo_model.RepoCodeIndexerRefs{RepoID: 1}))
+	require.NoError(t, index(t.Context(), indexer, 1))
+
+	res = search("Description")
+	require.Len(t, res, 1)
+	assert.Equal(t, []string{internal.DefaultBranchRef}, res[0].Refs)
+	assert.Empty(t, search("Permission"))
+
+	statuses, err = repo_model.GetIndexerRefStatuses(t.Context(), 1, repo_model.RepoIndexerTypeCode)
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
 func TestBleveIndexAndSearch(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 	defer test.MockVariableValue(&setting.Indexer.TypeBleveMaxFuzzniess, 2)()
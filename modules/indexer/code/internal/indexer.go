@@ -16,16 +16,39 @@ import (
 // Indexer defines an interface to index and search code contents
 type Indexer interface {
 	internal.Indexer
+	// Index adds the ref of the changes to the files it contains at the commit sha, and removes it from the files it doesn't contain anymore.
+	// A file is only stored once for all the refs containing the same version of it.
 	Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *RepoChanges) error
 	Delete(ctx context.Context, repoID int64) error
-	Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, []*SearchResultLanguages, error)
+	// DeleteRef removes the ref from all the files of the repo, the files which aren't in any ref anymore are deleted
+	DeleteRef(ctx context.Context, repoID int64, ref string) error
+	// Search returns the total of the results, the page of the results and the languages of all the results,
+	// the search is truncated if it stopped at a limit of candidates, then the total is a lower bound.
+	Search(ctx context.Context, opts *SearchOptions) (total int64, results []*SearchResult, languages []*SearchResultLanguages, truncated bool, err error)
 	SupportedSearchModes() []indexer.SearchMode
 }
 
+// SymbolSearch is the kind of symbol searched by the keyword
+type SymbolSearch string
+
+const (
+	SymbolSearchNone       SymbolSearch = ""    // the keyword is searched in the contents and the filenames
+	SymbolSearchDefinition SymbolSearch = "sym" // the keyword is the name of a symbol definition
+	SymbolSearchReference  SymbolSearch = "ref" // the keyword is the name of a referenced symbol
+)
+
 type SearchOptions struct {
 	RepoIDs  []int64
 	Keyword  string
 	Language string
+	Symbol   SymbolSearch
+	// PathPatterns limits the search to the files matching any of the globs, "*" and "**" match any characters
+	PathPatterns []string
+	// Extensions limits the search to the files with any of the extensions, like "yaml"
+	Extensions []string
+	// PathOnly searches the paths of the files instead of their contents, the characters of the keyword
+	// must appear in the same order in the path, case-insensitively, like the "Go to file" finder of a repository
+	PathOnly bool
 
 	SearchMode indexer.SearchModeType
 
@@ -55,6 +78,10 @@ func (d *dummyIndexer) Delete(ctx context.Context, repoID int64) error {
 	return errors.New("indexer is not ready")
 }
 
-func (d *dummyIndexer) Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, []*SearchResultLanguages, error) {
-	return 0, nil, nil, errors.New("indexer is not ready")
+func (d *dummyIndexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
+	return errors.New("indexer is not ready")
+}
+
+func (d *dummyIndexer) Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, []*SearchResultLanguages, bool, error) {
+	return 0, nil, nil, false, errors.New("indexer is not ready")
 }
@@ -12,10 +12,21 @@ type FileUpdate struct {
 	Sized    bool
 }
 
-// RepoChanges changes (file additions/updates/removals) to a repo
+// FileRemoval is a file removed from a ref, or replaced by another blob
+type FileRemoval struct {
+	Filename string
+	BlobSha  string
+}
+
+// DefaultBranchRef is the ref of the files of the default branch in the indexer,
+// so they don't need to be updated when the default branch is changed
+const DefaultBranchRef = "HEAD"
+
+// RepoChanges changes (file additions/updates/removals) to a ref of a repo
 type RepoChanges struct {
-	Updates          []FileUpdate
-	RemovedFilenames []string
+	Ref      string // the full name of the ref, or DefaultBranchRef
+	Updates  []FileUpdate
+	Removals []FileRemoval
 }
 
 // IndexerData represents data stored in the code indexer
@@ -31,6 +42,7 @@ type SearchResult struct {
 	Filename    string
 	Content     string
 	CommitID    string
+	Refs        []string // the refs containing the file at this version
 	UpdatedUnix timeutil.TimeStamp
 	Language    string
 	Color       string
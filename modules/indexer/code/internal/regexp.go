@@ -0,0 +1,104 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"context"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+const (
+	// RegexpCandidatesBatchSize is the number of candidates fetched at once by a regexp search
+	RegexpCandidatesBatchSize = 50
+	// RegexpMaxCandidates is the maximum number of candidates confirmed by a regexp search,
+	// the search is reported as truncated if there are more candidates, and its total is a lower bound
+	RegexpMaxCandidates = 1000
+)
+
+// ParseRegexp parses the keyword of a regexp search, "^" and "$" match at the beginnings and the ends of the lines like grep
+func ParseRegexp(keyword string) (*regexp.Regexp, *syntax.Regexp, error) {
+	parsed, err := syntax.Parse(keyword, syntax.Perl&^syntax.OneLine)
+	if err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("invalid regexp: %v", err)
+	}
+	re, err := regexp.Compile("(?m)" + keyword)
+	if err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("invalid regexp: %v", err)
+	}
+	return re, parsed, nil
+}
+
+// RegexpCandidatesFunc fetches the candidates of a regexp search in a stable order, the candidates must have their contents
+type RegexpCandidatesFunc func(ctx context.Context, from, size int) ([]*SearchResult, error)
+
+// SearchRegexp confirms the candidates pre-filtered by the trigram query of the regexp,
+// then it returns the page of the matched results and the languages of all the matched results.
+// Only the first RegexpMaxCandidates candidates are confirmed, the search is truncated if there are more.
+// The candidates must not be filtered by the language of the options, the language is filtered after the regexp is confirmed.
+func SearchRegexp(ctx context.Context, opts *SearchOptions, re *regexp.Regexp, fetch RegexpCandidatesFunc) (int64, []*SearchResult, []*SearchResultLanguages, bool, error) {
+	skip, take := opts.GetSkipTake()
+	var (
+		total     int64
+		results   []*SearchResult
+		languages []*SearchResultLanguages
+		truncated bool
+	)
+	languageIndex := make(map[string]*SearchResultLanguages)
+	for from := 0; ; from += RegexpCandidatesBatchSize {
+		if from >= RegexpMaxCandidates {
+			// one more candidate tells whether the search has been truncated
+			more, err := fetch(ctx, from, 1)
+			if err != nil {
+				return 0, nil, nil, false, err
+			}
+			truncated = len(more) > 0
+			break
+		}
+		candidates, err := fetch(ctx, from, RegexpCandidatesBatchSize)
+		if err != nil {
+			return 0, nil, nil, false, err
+		}
+		for _, candidate := range candidates {
+			loc := re.FindStringIndex(candidate.Content)
+			if loc == nil {
+				continue
+			}
+			if candidate.Language != "" {
+				lang, ok := languageIndex[candidate.Language]
+				if !ok {
+					lang = &SearchResultLanguages{Language: candidate.Language, Color: enry.GetColor(candidate.Language)}
+					languageIndex[candidate.Language] = lang
+					languages = append(languages, lang)
+				}
+				lang.Count++
+			}
+			if opts.Language != "" && candidate.Language != opts.Language {
+				continue
+			}
+			if total >= int64(skip) && len(results) < take {
+				candidate.StartIndex, candidate.EndIndex = loc[0], loc[1]
+				results = append(results, candidate)
+			}
+			total++
+		}
+		if len(candidates) < RegexpCandidatesBatchSize {
+			break
+		}
+	}
+	return total, results, topSearchResultLanguages(languages), truncated, nil
+}
+
+// topSearchResultLanguages returns the 10 most frequent languages like the facets of the indexers
+func topSearchResultLanguages(languages []*SearchResultLanguages) []*SearchResultLanguages {
+	slices.SortStableFunc(languages, func(a, b *SearchResultLanguages) int {
+		return b.Count - a.Count
+	})
+	return languages[:min(len(languages), 10)]
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRegexp(t *testing.T) {
+	var candidates []*SearchResult
+	for i := range 120 {
+		candidates = append(candidates, &SearchResult{
+			Filename: fmt.Sprintf("file%d", i),
+			Content:  fmt.Sprintf("line\nvalue = %d\n", i),
+			Language: []string{"Go", "Markdown"}[i%2],
+		})
+	}
+	fetched := 0
+	fetch := func(_ context.Context, from, size int) ([]*SearchResult, error) {
+		fetched++
+		return candidates[min(from, len(candidates)):min(from+size, len(candidates))], nil
+	}
+
+	re, _, err := ParseRegexp(`value = \d*7\n`)
+	require.NoError(t, err)
+	opts := &SearchOptions{Language: "Markdown", Paginator: &db.ListOptions{Page: 2, PageSize: 2}}
+	total, results, languages, truncated, err := SearchRegexp(t.Context(), opts, re, fetch)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+
+	// 7, 17, ... 117 match, the odd ones are Markdown
+	assert.Equal(t, 3, fetched)
+	assert.EqualValues(t, 12, total)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "file27", results[0].Filename)
+		assert.Equal(t, "value = 27\n", results[0].Content[results[0].StartIndex:results[0].EndIndex])
+		assert.Equal(t, "file37", results[1].Filename)
+	}
+	if assert.Len(t, languages, 1) {
+		assert.Equal(t, "Markdown", languages[0].Language)
+		assert.Equal(t, 12, languages[0].Count)
+	}
+}
+
+func TestSearchRegexpTruncated(t *testing.T) {
+	candidates := make([]*SearchResult, RegexpMaxCandidates+1)
+	for i := range candidates {
+		candidates[i] = &SearchResult{Filename: fmt.Sprintf("file%d", i), Content: "value"}
+	}
+	fetch := func(_ context.Context, from, size int) ([]*SearchResult, error) {
+		return candidates[min(from, len(candidates)):min(from+size, len(candidates))], nil
+	}
+	re, _, err := ParseRegexp(`value`)
+	require.NoError(t, err)
+	opts := &SearchOptions{Paginator: &db.ListOptions{Page: 1, PageSize: 10}}
+
+	// the last candidate isn't confirmed
+	total, _, _, truncated, err := SearchRegexp(t.Context(), opts, re, fetch)
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.EqualValues(t, RegexpMaxCandidates, total)
+
+	candidates = candidates[:RegexpMaxCandidates]
+	total, _, _, truncated, err = SearchRegexp(t.Context(), opts, re, fetch)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.EqualValues(t, RegexpMaxCandidates, total)
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// The contents are indexed by their lowercase trigrams, a regexp is turned into a query of the trigrams
+// which a content must contain to match it. The query only pre-filters the candidates, they must be confirmed by the regexp.
+// See "Regular Expression Matching with a Trigram Index" by Russ Cox.
+
+const (
+	trigramMaxExactSet = 16 // the maximum number of exact strings to track
+	trigramMaxClass    = 8  // the maximum number of runes of a character class to expand
+)
+
+// TrigramOp is the operation of a TrigramQuery
+type TrigramOp int
+
+const (
+	TrigramAll TrigramOp = iota // every content matches
+	TrigramAnd                  // the content contains all the trigrams and matches all the sub queries
+	TrigramOr                   // the content contains any of the trigrams or matches any of the sub queries
+)
+
+// TrigramQuery is a boolean query of trigrams
+type TrigramQuery struct {
+	Op       TrigramOp
+	Trigrams []string
+	Subs     []*TrigramQuery
+}
+
+var trigramQueryAll = &TrigramQuery{Op: TrigramAll}
+
+// Trigrams returns the lowercase trigrams of a string
+func Trigrams(s string) []string {
+	runes := []rune(strings.ToLower(s))
+	if len(runes) < 3 {
+		return nil
+	}
+	trigrams := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		trigram := string(runes[i : i+3])
+		if !slices.Contains(trigrams, trigram) {
+			trigrams = append(trigrams, trigram)
+		}
+	}
+	return trigrams
+}
+
+// RegexpTrigramQuery returns the trigram query which pre-filters the contents which could match the regexp
+func RegexpTrigramQuery(re *syntax.Regexp) *TrigramQuery {
+	info := analyzeRegexp(re.Simplify())
+	return info.query()
+}
+
+// regexpInfo is the information of a regexp node: the exact set of strings it matches if it is small, and a query otherwise
+type regexpInfo struct {
+	exact []string // nil if unknown
+	match *TrigramQuery
+}
+
+func (info regexpInfo) query() *TrigramQuery {
+	if info.exact == nil {
+		return info.match
+	}
+	return andTrigramQuery(info.match, exactTrigramQuery(info.exact))
+}
+
+func exactTrigramQuery(exact []string) *TrigramQuery {
+	q := &TrigramQuery{Op: TrigramOr}
+	for _, s := range exact {
+		trigrams := Trigrams(s)
+		if len(trigrams) == 0 {
+			// a string too short can't filter anything
+			return trigramQueryAll
+		}
+		q.Subs = append(q.Subs, &TrigramQuery{Op: TrigramAnd, Trigrams: trigrams})
+	}
+	if len(q.Subs) == 1 {
+		return q.Subs[0]
+	}
+	return q
+}
+
+func andTrigramQuery(a, b *TrigramQuery) *TrigramQuery {
+	if a.Op == TrigramAll {
+		return b
+	} else if b.Op == TrigramAll {
+		return a
+	}
+	return &TrigramQuery{Op: TrigramAnd, Subs: []*TrigramQuery{a, b}}
+}
+
+func orTrigramQuery(a, b *TrigramQuery) *TrigramQuery {
+	if a.Op == TrigramAll || b.Op == TrigramAll {
+		return trigramQueryAll
+	}
+	return &TrigramQuery{Op: TrigramOr, Subs: []*TrigramQuery{a, b}}
+}
+
+func unknownRegexpInfo() regexpInfo {
+	return regexpInfo{match: trigramQueryAll}
+}
+
+func exactRegexpInfo(exact ...string) regexpInfo {
+	return regexpInfo{exact: exact, match: trigramQueryAll}
+}
+
+func analyzeRegexp(re *syntax.Regexp) regexpInfo {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return exactRegexpInfo("")
+	case syntax.OpLiteral:
+		return exactRegexpInfo(strings.ToLower(string(re.Rune)))
+	case syntax.OpCharClass:
+		return analyzeCharClass(re.Rune)
+	case syntax.OpCapture:
+		return analyzeRegexp(re.Sub[0])
+	case syntax.OpPlus:
+		// x+ contains at least one x
+		return regexpInfo{match: analyzeRegexp(re.Sub[0]).query()}
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return regexpInfo{match: analyzeRegexp(re.Sub[0]).query()}
+		}
+		return unknownRegexpInfo()
+	case syntax.OpQuest:
+		sub := analyzeRegexp(re.Sub[0])
+		if sub.exact != nil && len(sub.exact) < trigramMaxExactSet {
+			return exactRegexpInfo(appendUnique(slices.Clone(sub.exact), "")...)
+		}
+		return unknownRegexpInfo()
+	case syntax.OpConcat:
+		return analyzeConcat(re.Sub)
+	case syntax.OpAlternate:
+		return analyzeAlternate(re.Sub)
+	}
+	// OpAnyChar, OpAnyCharNotNL, OpStar, OpNoMatch ...
+	return unknownRegexpInfo()
+}
+
+func analyzeCharClass(ranges []rune) regexpInfo {
+	var exact []string
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i+1]-ranges[i] >= trigramMaxClass {
+			return unknownRegexpInfo()
+		}
+		for r := ranges[i]; r <= ranges[i+1]; r++ {
+			exact = appendUnique(exact, string(unicode.ToLower(r)))
+			if len(exact) > trigramMaxClass {
+				return unknownRegexpInfo()
+			}
+		}
+	}
+	if len(exact) == 0 {
+		return unknownRegexpInfo()
+	}
+	return exactRegexpInfo(exact...)
+}
+
+func analyzeConcat(subs []*syntax.Regexp) regexpInfo {
+	match := trigramQueryAll
+	exact := []string{""}
+	for _, sub := range subs {
+		info := analyzeRegexp(sub)
+		match = andTrigramQuery(match, info.match)
+		if info.exact == nil || len(exact)*len(info.exact) > trigramMaxExactSet {
+			// the exact strings so far can't be extended, they become a query
+			match = andTrigramQuery(match, exactTrigramQuery(exact))
+			exact = info.exact
+			if exact == nil {
+				exact = []string{""}
+			}
+			continue
+		}
+		product := make([]string, 0, len(exact)*len(info.exact))
+		for _, prefix := range exact {
+			for _, suffix := range info.exact {
+				product = appendUnique(product, prefix+suffix)
+			}
+		}
+		exact = product
+	}
+	return regexpInfo{exact: exact, match: match}
+}
+
+func analyzeAlternate(subs []*syntax.Regexp) regexpInfo {
+	var exact []string
+	var match *TrigramQuery
+	for _, sub := range subs {
+		info := analyzeRegexp(sub)
+		if exact != nil && info.exact != nil && len(exact)+len(info.exact) <= trigramMaxExactSet {
+			for _, s := range info.exact {
+				exact = appendUnique(exact, s)
+			}
+			match = orTrigramQuery(match, info.match)
+			continue
+		}
+		if match == nil {
+			exact, match = info.exact, info.match
+			continue
+		}
+		// the alternatives can't be tracked as exact strings anymore
+		match = orTrigramQuery(regexpInfo{exact: exact, match: match}.query(), info.query())
+		exact = nil
+	}
+	if match == nil {
+		return unknownRegexpInfo()
+	}
+	return regexpInfo{exact: exact, match: match}
+}
+
+func appendUnique(list []string, s string) []string {
+	if slices.Contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
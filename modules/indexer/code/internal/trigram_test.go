@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (q *TrigramQuery) String() string {
+	if q.Op == TrigramAll {
+		return "+"
+	}
+	var parts []string
+	for _, trigram := range q.Trigrams {
+		parts = append(parts, fmt.Sprintf("%q", trigram))
+	}
+	for _, sub := range q.Subs {
+		parts = append(parts, sub.String())
+	}
+	return "(" + strings.Join(parts, map[TrigramOp]string{TrigramAnd: " ", TrigramOr: "|"}[q.Op]) + ")"
+}
+
+func TestTrigrams(t *testing.T) {
+	assert.Equal(t, []string{"abc", "bcd"}, Trigrams("ABCD"))
+	assert.Equal(t, []string{"aaa"}, Trigrams("aaaa"))
+	assert.Equal(t, []string{"日本語"}, Trigrams("日本語"))
+	assert.Empty(t, Trigrams("ab"))
+}
+
+func TestRegexpTrigramQuery(t *testing.T) {
+	cases := []struct {
+		regexp string
+		query  string
+	}{
+		{`ab`, `+`},
+		{`abcd`, `("abc" "bcd")`},
+		{`(?i)ABC`, `("abc")`},
+		{`abc.*def`, `(("abc") ("def"))`},
+		{`abc|def`, `(("abc")|("def"))`},
+		{`ab[cd]e`, `(("abc" "bce")|("abd" "bde"))`},
+		{`colou?r`, `(("col" "olo" "lou" "our")|("col" "olo" "lor"))`},
+		{`x+abc`, `("abc")`},
+		{`(abc)+`, `("abc")`},
+		{`(abc)*`, `+`},
+		{`abc|.`, `+`},
+		{`[a-z]+`, `+`},
+		{`^func \w+\(`, `("fun" "unc" "nc ")`},
+	}
+	for _, c := range cases {
+		re, err := syntax.Parse(c.regexp, syntax.Perl)
+		require.NoError(t, err)
+		assert.Equal(t, c.query, RegexpTrigramQuery(re).String(), c.regexp)
+	}
+}
+
+func TestParseRegexp(t *testing.T) {
+	_, _, err := ParseRegexp(`func (`)
+	assert.ErrorContains(t, err, "invalid regexp")
+
+	re, parsed, err := ParseRegexp(`func \w+`)
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("func main"))
+	assert.NotNil(t, parsed)
+
+	// the lines are matched like grep
+	re, _, err = ParseRegexp(`^func$`)
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("package main\nfunc\n"))
+}
@@ -5,28 +5,32 @@ package internal
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"code.gitea.io/gitea/modules/indexer/code/symbols"
 	"code.gitea.io/gitea/modules/indexer/internal"
 	"code.gitea.io/gitea/modules/log"
 )
 
 const filenameMatchNumberOfLines = 7 // Copied from GitHub search
 
-func FilenameIndexerID(repoID int64, filename string) string {
-	return internal.Base36(repoID) + "_" + filename
+// FilenameIndexerID returns the ID of a version of a file, which is shared by all the refs containing it
+func FilenameIndexerID(repoID int64, blobSha, filename string) string {
+	return internal.Base36(repoID) + "_" + blobSha + "_" + filename
 }
 
 func ParseIndexerID(indexerID string) (int64, string) {
-	before, after, ok := strings.Cut(indexerID, "_")
-	if !ok {
-		log.Error("Unexpected ID in repo indexer: %s", indexerID)
-	}
+	before, _, _ := strings.Cut(indexerID, "_")
 	repoID, _ := internal.ParseBase36(before)
-	return repoID, after
+	return repoID, FilenameOfIndexerID(indexerID)
 }
 
 func FilenameOfIndexerID(indexerID string) string {
 	_, after, ok := strings.Cut(indexerID, "_")
+	if ok {
+		_, after, ok = strings.Cut(after, "_")
+	}
 	if !ok {
 		log.Error("Unexpected ID in repo indexer: %s", indexerID)
 	}
@@ -46,3 +50,59 @@ func FilenameMatchIndexPos(content string) (int, int) {
 	}
 	return 0, len(content)
 }
+
+// SymbolMatchIndexPos returns the boundaries of the definition or the first reference of the searched symbol,
+// or of the first lines of the content if it can't be found.
+func SymbolMatchIndexPos(opts *SearchOptions, language, content string) (int, int) {
+	start, end := symbols.MatchIndexPos(language, content, opts.Keyword, opts.Symbol == SymbolSearchReference)
+	if start < 0 {
+		return FilenameMatchIndexPos(content)
+	}
+	return start, end
+}
+
+// PathPatternToWildcard converts a path glob to the wildcard of the indexers, which only know "*" and "?"
+func PathPatternToWildcard(pattern string) string {
+	for strings.Contains(pattern, "**") {
+		pattern = strings.ReplaceAll(pattern, "**", "*")
+	}
+	return strings.TrimPrefix(pattern, "/")
+}
+
+// quotePathRegexp escapes the ASCII punctuations of s, the result is a literal of the regexps of Go and of elasticsearch
+func quotePathRegexp(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if r < utf8.RuneSelf && (unicode.IsPunct(r) || unicode.IsSymbol(r)) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// FuzzyPathRegexp returns the regexp matching the whole paths which contain the characters of the keyword in the same order,
+// the case must be ignored by the indexers
+func FuzzyPathRegexp(keyword string) string {
+	var sb strings.Builder
+	sb.WriteString(".*")
+	for _, r := range keyword {
+		sb.WriteString(quotePathRegexp(string(r)))
+		sb.WriteString(".*")
+	}
+	return sb.String()
+}
+
+// SubstringPathRegexp returns the regexp matching the whole paths which contain the keyword, the case must be ignored by the indexers
+func SubstringPathRegexp(keyword string) string {
+	return ".*" + quotePathRegexp(keyword) + ".*"
+}
+
+// ExtensionsRegexp returns the regexp matching the whole paths which have any of the extensions, the case must be ignored by the indexers
+func ExtensionsRegexp(extensions []string) string {
+	quoted := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		quoted = append(quoted, quotePathRegexp(strings.TrimPrefix(ext, ".")))
+	}
+	return `.*\.(` + strings.Join(quoted, "|") + ")"
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+)
+
+// maxPathSearchCandidates is the maximum number of the files fetched from the indexer by a path search,
+// they are ranked by how well their paths match the keyword before being paginated. The search is reported as
+// truncated if more files match, then its total is a lower bound.
+const maxPathSearchCandidates = 1000
+
+// pathCandidatesPaginator fetches the candidates of a path search, unlike db.ListOptions it isn't capped by the
+// maximum page size of the API
+type pathCandidatesPaginator struct{}
+
+func (pathCandidatesPaginator) GetSkipTake() (skip, take int) { return 0, maxPathSearchCandidates }
+
+func (pathCandidatesPaginator) IsListAll() bool { return false }
+
+// matchPath splits the path into the unmatched and the matched parts like the "Go to file" finder of a repository,
+// the matched parts have odd indexes. The characters of the lower-cased keyword must appear in the same order
+// in the path, otherwise only the path is returned.
+func matchPath(path, keywordLower string) []string {
+	parts := []string{""}
+	i := 0
+	for _, r := range path {
+		if i < len(keywordLower) {
+			kr, size := utf8.DecodeRuneInString(keywordLower[i:])
+			if strings.ToLower(string(r)) == string(kr) {
+				if len(parts)%2 != 0 {
+					parts = append(parts, "")
+				}
+				parts[len(parts)-1] += string(r)
+				i += size
+				continue
+			}
+		}
+		if len(parts)%2 == 0 {
+			parts = append(parts, "")
+		}
+		parts[len(parts)-1] += string(r)
+	}
+	if i < len(keywordLower) {
+		return []string{path}
+	}
+	return parts
+}
+
+// pathMatchWeight returns the weight of the matched parts, the longer parts weigh more than several shorter ones
+func pathMatchWeight(parts []string) int {
+	weight := 0
+	for i := 1; i < len(parts); i += 2 {
+		weight += len(parts[i]) * len(parts[i])
+	}
+	return weight
+}
+
+// SearchPaths searches the files whose paths fuzzily match the keyword, the best matches are returned first.
+// The filters of the options are applied like the content searches, the symbols and the search mode are ignored.
+// Only the best maxPathSearchCandidates files of the indexer are ranked, the search is truncated if there are more.
+func SearchPaths(ctx context.Context, opts *SearchOptions) (int, []*Result, []*SearchResultLanguages, bool, error) {
+	return searchPaths(ctx, *globalIndexer.Load(), opts)
+}
+
+func searchPaths(ctx context.Context, indexer internal.Indexer, opts *SearchOptions) (int, []*Result, []*SearchResultLanguages, bool, error) {
+	if opts == nil || strings.TrimSpace(opts.Keyword) == "" {
+		return 0, nil, nil, false, nil
+	}
+
+	searchOpts := *opts
+	searchOpts.PathOnly = true
+	searchOpts.Symbol = SymbolSearchNone
+	searchOpts.Paginator = pathCandidatesPaginator{}
+	total, candidates, resultLanguages, truncated, err := indexer.Search(ctx, &searchOpts)
+	if err != nil {
+		return 0, nil, nil, false, err
+	}
+	truncated = truncated || total > int64(len(candidates))
+
+	type weightedResult struct {
+		*Result
+		weight int
+	}
+	keywordLower := strings.ToLower(opts.Keyword)
+	matched := make([]weightedResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		parts := matchPath(candidate.Filename, keywordLower)
+		if len(parts) < 2 {
+			// the indexer folds the case differently
+			continue
+		}
+		matched = append(matched, weightedResult{
+			Result: &Result{
+				RepoID:        candidate.RepoID,
+				Filename:      candidate.Filename,
+				FilenameParts: parts,
+				CommitID:      candidate.CommitID,
+				Refs:          candidate.Refs,
+				UpdatedUnix:   candidate.UpdatedUnix,
+				Language:      candidate.Language,
+				Color:         candidate.Color,
+			},
+			weight: pathMatchWeight(parts),
+		})
+	}
+	// the shorter paths are preferred among the ones matching equally well
+	slices.SortStableFunc(matched, func(a, b weightedResult) int {
+		if a.weight != b.weight {
+			return b.weight - a.weight
+		}
+		if len(a.Filename) != len(b.Filename) {
+			return len(a.Filename) - len(b.Filename)
+		}
+		return strings.Compare(a.Filename, b.Filename)
+	})
+
+	start, end := 0, len(matched)
+	if opts.Paginator != nil {
+		skip, take := opts.GetSkipTake()
+		start, end = min(skip, len(matched)), min(skip+take, len(matched))
+	}
+	results := make([]*Result, 0, end-start)
+	for _, r := range matched[start:end] {
+		results = append(results, r.Result)
+	}
+	return len(matched), results, resultLanguages, truncated, nil
+}
@@ -7,8 +7,11 @@ import (
 	"bytes"
 	"context"
 	"html/template"
+	"slices"
 	"strings"
+	"unicode"
 
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/highlight"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
 	"code.gitea.io/gitea/modules/timeutil"
@@ -19,10 +22,41 @@ type Result struct {
 	RepoID      int64
 	Filename    string
 	CommitID    string
+	Refs        []string // the refs containing the file at this version, DefaultBranchRef is the default branch
 	UpdatedUnix timeutil.TimeStamp
 	Language    string
 	Color       string
 	Lines       []*ResultLine
+	// FilenameParts is the filename split into the unmatched and the matched parts by a path search,
+	// the matched parts have odd indexes
+	FilenameParts []string
+}
+
+// ResultRef is a branch or a tag containing a result
+type ResultRef struct {
+	Name  string // the name of the branch or the tag, it is empty for the default branch
+	IsTag bool
+}
+
+// RefLabels returns the branches and the tags containing the result, the default branch is listed first
+func (r *Result) RefLabels() []*ResultRef {
+	labels := make([]*ResultRef, 0, len(r.Refs))
+	if slices.Contains(r.Refs, DefaultBranchRef) {
+		labels = append(labels, &ResultRef{})
+	}
+	for _, ref := range r.Refs {
+		if ref == DefaultBranchRef {
+			continue
+		}
+		refName := git.RefName(ref)
+		labels = append(labels, &ResultRef{Name: refName.ShortName(), IsTag: refName.IsTag()})
+	}
+	return labels
+}
+
+// IsOnlyInDefaultBranch returns whether the result is only in the default branch, it doesn't need to be labelled by its refs then
+func (r *Result) IsOnlyInDefaultBranch() bool {
+	return len(r.Refs) == 0 || len(r.Refs) == 1 && r.Refs[0] == DefaultBranchRef
 }
 
 type ResultLine struct {
@@ -34,6 +68,62 @@ type SearchResultLanguages = internal.SearchResultLanguages
 
 type SearchOptions = internal.SearchOptions
 
+// DefaultBranchRef is the ref of the results in the default branch
+const DefaultBranchRef = internal.DefaultBranchRef
+
+type SymbolSearch = internal.SymbolSearch
+
+const (
+	SymbolSearchNone       = internal.SymbolSearchNone
+	SymbolSearchDefinition = internal.SymbolSearchDefinition
+	SymbolSearchReference  = internal.SymbolSearchReference
+)
+
+// SearchQuery is a code search keyword whose qualifiers have been split, like
+//
+//	path:*.go repo:owner/name ParseLog
+type SearchQuery struct {
+	Keyword      string
+	PathPatterns []string
+	RepoNames    []string
+}
+
+// ParseSearchQuery splits the "path:" and "repo:" qualifiers from a code search keyword
+func ParseSearchQuery(s string) *SearchQuery {
+	q := &SearchQuery{}
+	var words []string
+	for _, word := range strings.Fields(s) {
+		if pattern, ok := strings.CutPrefix(word, "path:"); ok && pattern != "" {
+			q.PathPatterns = append(q.PathPatterns, pattern)
+		} else if name, ok := strings.CutPrefix(word, "repo:"); ok && name != "" {
+			q.RepoNames = append(q.RepoNames, name)
+		} else {
+			words = append(words, word)
+		}
+	}
+	if len(q.PathPatterns) == 0 && len(q.RepoNames) == 0 {
+		// the keyword is kept as it is when there is nothing to split, the spaces could matter to the exact and regexp searches
+		q.Keyword = strings.TrimSpace(s)
+	} else {
+		q.Keyword = strings.Join(words, " ")
+	}
+	return q
+}
+
+// ParseSymbolQuery splits a symbol search like "sym:ParseLog" or "ref:ParseLog" into the kind and the name of the symbol,
+// the keyword is returned as it is if it isn't a symbol search.
+func ParseSymbolQuery(keyword string) (SymbolSearch, string) {
+	prefix, name, ok := strings.Cut(strings.TrimSpace(keyword), ":")
+	if !ok || name == "" || strings.ContainsFunc(name, unicode.IsSpace) {
+		return SymbolSearchNone, keyword
+	}
+	switch symbol := SymbolSearch(prefix); symbol {
+	case SymbolSearchDefinition, SymbolSearchReference:
+		return symbol, name
+	}
+	return SymbolSearchNone, keyword
+}
+
 func indices(content string, selectionStartIndex, selectionEndIndex int) (int, int) {
 	startIndex := selectionStartIndex
 	numLinesBefore := 0
@@ -122,6 +212,7 @@ func searchResult(result *internal.SearchResult, startIndex, endIndex int) (*Res
 		RepoID:      result.RepoID,
 		Filename:    result.Filename,
 		CommitID:    result.CommitID,
+		Refs:        result.Refs,
 		UpdatedUnix: result.UpdatedUnix,
 		Language:    result.Language,
 		Color:       result.Color,
@@ -129,15 +220,21 @@ func searchResult(result *internal.SearchResult, startIndex, endIndex int) (*Res
 	}, nil
 }
 
-// PerformSearch perform a search on a repository
-func PerformSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, []*SearchResultLanguages, error) {
+// PerformSearch perform a search on a repository, the keyword could be a symbol search like "sym:ParseLog".
+// The search is truncated if it stopped at a limit of candidates, like a regexp search, then the total is a lower bound.
+func PerformSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, []*SearchResultLanguages, bool, error) {
 	if opts == nil || len(opts.Keyword) == 0 {
-		return 0, nil, nil, nil
+		return 0, nil, nil, false, nil
+	}
+	if opts.Symbol == SymbolSearchNone {
+		symbolOpts := *opts
+		symbolOpts.Symbol, symbolOpts.Keyword = ParseSymbolQuery(opts.Keyword)
+		opts = &symbolOpts
 	}
 
-	total, results, resultLanguages, err := (*globalIndexer.Load()).Search(ctx, opts)
+	total, results, resultLanguages, truncated, err := (*globalIndexer.Load()).Search(ctx, opts)
 	if err != nil {
-		return 0, nil, nil, err
+		return 0, nil, nil, false, err
 	}
 
 	displayResults := make([]*Result, len(results))
@@ -146,8 +243,8 @@ func PerformSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, []
 		startIndex, endIndex := indices(result.Content, result.StartIndex, result.EndIndex)
 		displayResults[i], err = searchResult(result, startIndex, endIndex)
 		if err != nil {
-			return 0, nil, nil, err
+			return 0, nil, nil, false, err
 		}
 	}
-	return int(total), displayResults, resultLanguages, nil
+	return int(total), displayResults, resultLanguages, truncated, nil
 }
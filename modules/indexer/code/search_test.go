@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSymbolQuery(t *testing.T) {
+	cases := []struct {
+		keyword string
+		symbol  SymbolSearch
+		name    string
+	}{
+		{"sym:ParseLog", SymbolSearchDefinition, "ParseLog"},
+		{" ref:ParseLog ", SymbolSearchReference, "ParseLog"},
+		{"sym:Entry.String", SymbolSearchDefinition, "Entry.String"},
+		{"sym:", SymbolSearchNone, "sym:"},
+		{"sym:Parse Log", SymbolSearchNone, "sym:Parse Log"},
+		{"def:ParseLog", SymbolSearchNone, "def:ParseLog"},
+		{"ParseLog", SymbolSearchNone, "ParseLog"},
+	}
+	for _, c := range cases {
+		symbol, name := ParseSymbolQuery(c.keyword)
+		assert.Equal(t, c.symbol, symbol, c.keyword)
+		assert.Equal(t, c.name, name, c.keyword)
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	q := ParseSearchQuery("path:*.go  repo:user2/repo1 func  main path:docs/**")
+	assert.Equal(t, "func main", q.Keyword)
+	assert.Equal(t, []string{"*.go", "docs/**"}, q.PathPatterns)
+	assert.Equal(t, []string{"user2/repo1"}, q.RepoNames)
+
+	// the keyword is kept as it is without qualifiers
+	q = ParseSearchQuery(" func  main ")
+	assert.Equal(t, "func  main", q.Keyword)
+	assert.Empty(t, q.PathPatterns)
+	assert.Empty(t, q.RepoNames)
+
+	q = ParseSearchQuery("path: repo:")
+	assert.Equal(t, "path: repo:", q.Keyword)
+}
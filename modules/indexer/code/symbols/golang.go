@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbols
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// extractGo extracts the functions, methods, types and package-level constants and variables of a Go file,
+// the other identifiers are the references. A file with syntax errors is extracted as far as it could be parsed.
+func extractGo(content []byte) (defs []*Symbol, refs []string) {
+	fset := token.NewFileSet()
+	file, _ := parser.ParseFile(fset, "", content, parser.SkipObjectResolution)
+	if file == nil {
+		return nil, nil
+	}
+
+	defPositions := make(map[token.Pos]bool)
+	addDef := func(ident *ast.Ident, kind Kind, container string) {
+		if ident == nil || ident.Name == "_" {
+			return
+		}
+		pos := fset.Position(ident.Pos())
+		defs = append(defs, &Symbol{
+			Name:      ident.Name,
+			Kind:      kind,
+			Container: container,
+			Line:      pos.Line,
+			Offset:    pos.Offset,
+		})
+		defPositions[ident.Pos()] = true
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				addDef(decl.Name, KindMethod, goReceiverTypeName(decl.Recv.List[0].Type))
+			} else {
+				addDef(decl.Name, KindFunction, "")
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					addDef(spec.Name, KindType, "")
+				case *ast.ValueSpec:
+					kind := KindVariable
+					if decl.Tok == token.CONST {
+						kind = KindConstant
+					}
+					for _, name := range spec.Names {
+						addDef(name, kind, "")
+					}
+				}
+			}
+		}
+	}
+
+	// the package name is neither a definition nor a reference
+	defPositions[file.Name.Pos()] = true
+	seen := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || ident.Name == "_" || defPositions[ident.Pos()] || seen[ident.Name] {
+			return true
+		}
+		seen[ident.Name] = true
+		refs = append(refs, ident.Name)
+		return true
+	})
+	return defs, refs
+}
+
+// goReceiverTypeName returns the name of the type of a method receiver, like "T" of "*T" or "T[K]"
+func goReceiverTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbols
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a symbol definition
+type Kind string
+
+const (
+	KindFunction Kind = "function"
+	KindMethod   Kind = "method"
+	KindType     Kind = "type"
+	KindConstant Kind = "constant"
+	KindVariable Kind = "variable"
+)
+
+// Symbol is a symbol defined in a file
+type Symbol struct {
+	Name      string
+	Kind      Kind
+	Container string // the receiver type of a method
+	Line      int    // 1-based
+	Offset    int    // the byte offset of the name in the content
+}
+
+// QualifiedName returns the name of the symbol with its container, like "Type.Method"
+func (s *Symbol) QualifiedName() string {
+	if s.Container == "" {
+		return s.Name
+	}
+	return s.Container + "." + s.Name
+}
+
+// extractor extracts the symbols defined in a file and the names referenced by it
+type extractor func(content []byte) (defs []*Symbol, refs []string)
+
+// the extractors of the languages, the keys are the languages detected by analyze.GetCodeLanguage
+var extractors = map[string]extractor{
+	"Go": extractGo,
+}
+
+// IsSupported returns whether the symbols of the language could be extracted
+func IsSupported(language string) bool {
+	_, ok := extractors[language]
+	return ok
+}
+
+// Extract returns the symbols defined in the content and the deduplicated names it references,
+// nothing is returned if the language is not supported.
+func Extract(language string, content []byte) (defs []*Symbol, refs []string) {
+	extract, ok := extractors[language]
+	if !ok {
+		return nil, nil
+	}
+	return extract(content)
+}
+
+// Names returns the names to index for the definitions, the methods are also indexed by their qualified names
+func Names(defs []*Symbol) []string {
+	names := make([]string, 0, len(defs))
+	seen := make(map[string]bool, len(defs))
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, def := range defs {
+		add(def.Name)
+		if def.Container != "" {
+			add(def.QualifiedName())
+		}
+	}
+	return names
+}
+
+// MatchIndexPos returns the boundaries of the definition of the symbol in the content,
+// or of its first reference if reference is true. It returns -1, -1 if nothing is found.
+func MatchIndexPos(language, content, name string, reference bool) (int, int) {
+	defs, _ := Extract(language, []byte(content))
+	defOffset := -1
+	for _, def := range defs {
+		if def.Name == name || def.QualifiedName() == name {
+			defOffset = def.Offset
+			break
+		}
+	}
+	// a qualified method name is referenced by its own name
+	if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+		name = name[idx+1:]
+	}
+	if !reference {
+		if defOffset < 0 {
+			return -1, -1
+		}
+		return defOffset, defOffset + len(name)
+	}
+
+	for start := 0; start < len(content); {
+		idx := strings.Index(content[start:], name)
+		if idx < 0 {
+			break
+		}
+		idx += start
+		if idx != defOffset && isWholeWord(content, idx, idx+len(name)) {
+			return idx, idx + len(name)
+		}
+		start = idx + len(name)
+	}
+	return -1, -1
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isWholeWord(content string, start, end int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(content[:start]); start > 0 && isIdentifierRune(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(content[end:]); end < len(content) && isIdentifierRune(r) {
+		return false
+	}
+	return true
+}
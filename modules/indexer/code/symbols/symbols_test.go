@@ -0,0 +1,104 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbols
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGoFile = `package log
+
+import "strings"
+
+const MaxLevel = 5
+
+var defaultPrefix, _ = "log", 0
+
+type Entry struct {
+	Level int
+}
+
+type List[T any] []T
+
+// ParseLog parses a log line
+func ParseLog(line string) *Entry {
+	return &Entry{Level: len(strings.TrimSpace(line))}
+}
+
+func (e *Entry) String() string {
+	return ParseLog(defaultPrefix).String()
+}
+
+func (l List[T]) Len() int { return len(l) }
+`
+
+func TestExtractGo(t *testing.T) {
+	defs, refs := Extract("Go", []byte(testGoFile))
+
+	type def struct {
+		Name      string
+		Kind      Kind
+		Container string
+		Line      int
+	}
+	var actual []def
+	for _, d := range defs {
+		actual = append(actual, def{d.Name, d.Kind, d.Container, d.Line})
+		assert.Equal(t, d.Name, testGoFile[d.Offset:d.Offset+len(d.Name)])
+	}
+	assert.Equal(t, []def{
+		{"MaxLevel", KindConstant, "", 5},
+		{"defaultPrefix", KindVariable, "", 7},
+		{"Entry", KindType, "", 9},
+		{"List", KindType, "", 13},
+		{"ParseLog", KindFunction, "", 16},
+		{"String", KindMethod, "Entry", 20},
+		{"Len", KindMethod, "List", 24},
+	}, actual)
+
+	assert.Equal(t, []string{"MaxLevel", "defaultPrefix", "Entry", "List", "ParseLog", "String", "Entry.String", "Len", "List.Len"}, Names(defs))
+
+	assert.Contains(t, refs, "ParseLog")
+	assert.Contains(t, refs, "Entry")
+	assert.Contains(t, refs, "TrimSpace")
+	assert.Contains(t, refs, "Level")
+	assert.NotContains(t, refs, "log")
+	assert.NotContains(t, refs, "_")
+	assert.NotContains(t, refs, "MaxLevel")
+}
+
+func TestExtractUnsupported(t *testing.T) {
+	assert.False(t, IsSupported("Markdown"))
+	defs, refs := Extract("Markdown", []byte("# ParseLog"))
+	assert.Empty(t, defs)
+	assert.Empty(t, refs)
+}
+
+func TestExtractGoSyntaxError(t *testing.T) {
+	defs, _ := Extract("Go", []byte("package a\n\nfunc Valid() {}\n\nfunc broken( {\n"))
+	if assert.NotEmpty(t, defs) {
+		assert.Equal(t, "Valid", defs[0].Name)
+	}
+}
+
+func TestMatchIndexPos(t *testing.T) {
+	start, end := MatchIndexPos("Go", testGoFile, "ParseLog", false)
+	assert.Equal(t, "func ParseLog(", testGoFile[start-5:end+1])
+
+	start, end = MatchIndexPos("Go", testGoFile, "ParseLog", true)
+	assert.Equal(t, "// ParseLog parses", testGoFile[start-3:end+7])
+
+	start, end = MatchIndexPos("Go", testGoFile, "Entry.String", false)
+	assert.Equal(t, "(e *Entry) String()", testGoFile[start-11:end+2])
+
+	// "Level" in "MaxLevel" isn't a reference
+	start, end = MatchIndexPos("Go", testGoFile, "Level", true)
+	assert.Equal(t, "\tLevel int", testGoFile[start-1:end+4])
+
+	start, end = MatchIndexPos("Go", testGoFile, "NotExist", true)
+	assert.Equal(t, -1, start)
+	assert.Equal(t, -1, end)
+}
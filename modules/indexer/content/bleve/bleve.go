@@ -0,0 +1,214 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"context"
+	"math"
+
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/content/internal"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/camelcase"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	contentIndexerAnalyzer      = "contentIndexer"
+	contentIndexerDocType       = "contentIndexerDocType"
+	contentIndexerLatestVersion = 1
+
+	unicodeNormalizeName = "unicodeNormalize"
+	maxBatchSize         = 16
+)
+
+// IndexerData an update to the content indexer
+type IndexerData internal.IndexerData
+
+// Type returns the document type, for bleve's mapping.Classifier interface.
+func (d *IndexerData) Type() string {
+	return contentIndexerDocType
+}
+
+// generateContentIndexMapping generates the bleve index mapping for the contents
+func generateContentIndexMapping() (mapping.IndexMapping, error) {
+	mapping := bleve.NewIndexMapping()
+	docMapping := bleve.NewDocumentMapping()
+
+	numericFieldMapping := bleve.NewNumericFieldMapping()
+	numericFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("repo_id", numericFieldMapping)
+	docMapping.AddFieldMappingsAt("updated_unix", numericFieldMapping)
+
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+	keywordFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("kind", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("ref", keywordFieldMapping)
+
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("title", textFieldMapping)
+	docMapping.AddFieldMappingsAt("content", textFieldMapping)
+
+	if err := mapping.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
+		"type": unicodenorm.Name,
+		"form": unicodenorm.NFC,
+	}); err != nil {
+		return nil, err
+	} else if err = mapping.AddCustomAnalyzer(contentIndexerAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{unicodeNormalizeName, camelcase.Name, lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
+	mapping.DefaultAnalyzer = contentIndexerAnalyzer
+	mapping.AddDocumentMapping(contentIndexerDocType, docMapping)
+	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
+	mapping.DefaultMapping = bleve.NewDocumentDisabledMapping() // disable default mapping, avoid indexing unexpected structs
+
+	return mapping, nil
+}
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_bleve.Indexer
+	indexer_internal.Indexer // do not composite inner_bleve.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWordsFuzzy()
+}
+
+// NewIndexer creates a new bleve local indexer
+func NewIndexer(indexDir string) *Indexer {
+	inner := inner_bleve.NewIndexer(indexDir, contentIndexerLatestVersion, generateContentIndexMapping)
+	return &Indexer{
+		Indexer: inner,
+		inner:   inner,
+	}
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, contents ...*internal.IndexerData) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, content := range contents {
+		if err := batch.Index(content.ID, (*IndexerData)(content)); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Delete deletes the contents of the kind of the repository
+func (b *Indexer) Delete(ctx context.Context, repoID int64, kind internal.Kind) error {
+	queries := []query.Query{inner_bleve.NumericEqualityQuery(repoID, "repo_id")}
+	if kind != "" {
+		queries = append(queries, kindQuery(kind))
+	}
+	searchRequest := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(queries...), math.MaxInt32, 0, false)
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return err
+	}
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	for _, hit := range result.Hits {
+		if err := batch.Delete(hit.ID); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+func kindQuery(kind internal.Kind) query.Query {
+	q := bleve.NewTermQuery(string(kind))
+	q.SetField("kind")
+	return q
+}
+
+// Search searches for the contents by the keyword, the most relevant one is the first
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	queries := []query.Query{kindQuery(options.Kind)}
+
+	if options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		if searchMode == indexer.SearchModeWords || searchMode == indexer.SearchModeFuzzy {
+			fuzziness := 0
+			if searchMode == indexer.SearchModeFuzzy {
+				fuzziness = inner_bleve.GuessFuzzinessByKeyword(options.Keyword)
+			}
+			queries = append(queries, bleve.NewDisjunctionQuery(
+				inner_bleve.MatchAndQuery(options.Keyword, "title", contentIndexerAnalyzer, fuzziness),
+				inner_bleve.MatchAndQuery(options.Keyword, "content", contentIndexerAnalyzer, fuzziness),
+			))
+		} else /* exact */ {
+			queries = append(queries, bleve.NewDisjunctionQuery(
+				inner_bleve.MatchPhraseQuery(options.Keyword, "title", contentIndexerAnalyzer, 0),
+				inner_bleve.MatchPhraseQuery(options.Keyword, "content", contentIndexerAnalyzer, 0),
+			))
+		}
+	}
+
+	if len(options.RepoIDs) > 0 {
+		repoQueries := make([]query.Query, 0, len(options.RepoIDs))
+		for _, repoID := range options.RepoIDs {
+			repoQueries = append(repoQueries, inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(repoQueries...))
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator)
+	searchRequest := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(queries...), limit, skip, false)
+	searchRequest.Fields = []string{"*"}
+	searchRequest.SortBy([]string{"-_score", "-updated_unix", "_id"})
+
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &internal.SearchResult{
+		Total: int64(result.Total),
+		Hits:  make([]*internal.IndexerData, 0, len(result.Hits)),
+	}
+	for _, hit := range result.Hits {
+		ret.Hits = append(ret.Hits, convertHit(hit))
+	}
+	return ret, nil
+}
+
+func convertHit(hit *search.DocumentMatch) *internal.IndexerData {
+	stringField := func(name string) string {
+		s, _ := hit.Fields[name].(string)
+		return s
+	}
+	numericField := func(name string) int64 {
+		f, _ := hit.Fields[name].(float64)
+		return int64(f)
+	}
+	return &internal.IndexerData{
+		ID:          hit.ID,
+		Kind:        internal.Kind(stringField("kind")),
+		RepoID:      numericField("repo_id"),
+		Ref:         stringField("ref"),
+		Title:       stringField("title"),
+		Content:     stringField("content"),
+		UpdatedUnix: timeutil.TimeStamp(numericField("updated_unix")),
+	}
+}
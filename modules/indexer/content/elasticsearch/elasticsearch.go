@@ -0,0 +1,157 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package elasticsearch
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/content/internal"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_elasticsearch "code.gitea.io/gitea/modules/indexer/internal/elasticsearch"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	contentIndexerLatestVersion = 1
+	// multi-match-types, currently only 2 types are used
+	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
+	esMultiMatchTypeBestFields   = "best_fields"
+	esMultiMatchTypePhrasePrefix = "phrase_prefix"
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_elasticsearch.Indexer
+	indexer_internal.Indexer // do not composite inner_elasticsearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new elasticsearch indexer
+func NewIndexer(url, indexerName string) *Indexer {
+	inner := inner_elasticsearch.NewIndexer(url, indexerName, contentIndexerLatestVersion, defaultMapping)
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+}
+
+const (
+	defaultMapping = `
+{
+	"mappings": {
+		"properties": {
+			"id": { "type": "keyword", "index": true },
+			"kind": { "type": "keyword", "index": true },
+			"repo_id": { "type": "long", "index": true },
+			"ref": { "type": "keyword", "index": true },
+			"title": { "type": "text", "index": true },
+			"content": { "type": "text", "index": true },
+			"updated_unix": { "type": "long", "index": true }
+		}
+	}
+}
+`
+)
+
+// Index will save the index data
+func (b *Indexer) Index(ctx context.Context, contents ...*internal.IndexerData) error {
+	if len(contents) == 0 {
+		return nil
+	} else if len(contents) == 1 {
+		_, err := b.inner.Client.Index().
+			Index(b.inner.VersionedIndexName()).
+			Id(contents[0].ID).
+			BodyJson(contents[0]).
+			Do(ctx)
+		return err
+	}
+
+	reqs := make([]elastic.BulkableRequest, 0, len(contents))
+	for _, content := range contents {
+		reqs = append(reqs,
+			elastic.NewBulkIndexRequest().
+				Index(b.inner.VersionedIndexName()).
+				Id(content.ID).
+				Doc(content),
+		)
+	}
+
+	_, err := b.inner.Client.Bulk().
+		Index(b.inner.VersionedIndexName()).
+		Add(reqs...).
+		Do(graceful.GetManager().HammerContext())
+	return err
+}
+
+// Delete deletes the contents of the kind of the repository
+func (b *Indexer) Delete(ctx context.Context, repoID int64, kind internal.Kind) error {
+	query := elastic.NewBoolQuery().Filter(elastic.NewTermQuery("repo_id", repoID))
+	if kind != "" {
+		query.Filter(elastic.NewTermQuery("kind", string(kind)))
+	}
+	_, err := b.inner.Client.DeleteByQuery(b.inner.VersionedIndexName()).
+		Query(query).
+		Do(ctx)
+	return err
+}
+
+// Search searches for the contents by the keyword, the most relevant one is the first
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	query := elastic.NewBoolQuery().Filter(elastic.NewTermQuery("kind", string(options.Kind)))
+
+	if options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+		if searchMode == indexer.SearchModeExact {
+			query.Must(elastic.NewMultiMatchQuery(options.Keyword, "title", "content").Type(esMultiMatchTypePhrasePrefix))
+		} else /* words */ {
+			query.Must(elastic.NewMultiMatchQuery(options.Keyword, "title", "content").Type(esMultiMatchTypeBestFields).Operator("and"))
+		}
+	}
+
+	if len(options.RepoIDs) > 0 {
+		repoIDs := make([]any, 0, len(options.RepoIDs))
+		for _, repoID := range options.RepoIDs {
+			repoIDs = append(repoIDs, repoID)
+		}
+		query.Filter(elastic.NewTermsQuery("repo_id", repoIDs...))
+	}
+
+	// See https://stackoverflow.com/questions/35206409/elasticsearch-2-1-result-window-is-too-large-index-max-result-window/35221900
+	const maxPageSize = 10000
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator, maxPageSize)
+	searchResult, err := b.inner.Client.Search().
+		Index(b.inner.VersionedIndexName()).
+		Query(query).
+		SortBy(elastic.NewScoreSort(), elastic.NewFieldSort("updated_unix").Desc(), elastic.NewFieldSort("id")).
+		From(skip).Size(limit).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*internal.IndexerData, 0, len(searchResult.Hits.Hits))
+	for _, hit := range searchResult.Hits.Hits {
+		var content internal.IndexerData
+		if err := json.Unmarshal(hit.Source, &content); err != nil {
+			return nil, err
+		}
+		hits = append(hits, &content)
+	}
+
+	return &internal.SearchResult{
+		Total: searchResult.TotalHits(),
+		Hits:  hits,
+	}, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package content
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer/content/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// index updates the contents of the kind of a repository, all of them are updated if kind is empty
+func index(ctx context.Context, indexer internal.Indexer, repoID int64, kind internal.Kind) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if repo_model.IsErrRepoNotExist(err) {
+		return indexer.Delete(ctx, repoID, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeContent)
+	if err != nil {
+		return err
+	}
+	// a repository which has never been indexed has to be fully indexed whatever has changed
+	if len(status.CommitSha) == 0 {
+		kind = ""
+	}
+
+	if kind == "" || kind == internal.KindWiki {
+		if err := indexWiki(ctx, indexer, repo); err != nil {
+			return err
+		}
+	}
+	if kind == "" || kind == internal.KindRelease {
+		if err := indexReleases(ctx, indexer, repo); err != nil {
+			return err
+		}
+	}
+	if kind == "" || kind == internal.KindCommit {
+		return indexCommits(ctx, indexer, repo, status.CommitSha)
+	}
+	return nil
+}
+
+// wikiPageTitle returns the title of a wiki page from its git path
+func wikiPageTitle(gitPath string) string {
+	title := strings.TrimSuffix(path.Base(gitPath), ".md")
+	if unescaped, err := url.PathUnescape(title); err == nil {
+		title = unescaped
+	}
+	return strings.ReplaceAll(title, "-", " ")
+}
+
+// indexWiki replaces the wiki pages of the repository in the indexer by the ones of its default wiki branch
+func indexWiki(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository) error {
+	if err := indexer.Delete(ctx, repo.ID, internal.KindWiki); err != nil {
+		return err
+	}
+
+	wikiRepo := repo.WikiStorageRepo()
+	if exist, err := gitrepo.IsRepositoryExist(ctx, wikiRepo); err != nil {
+		return err
+	} else if !exist {
+		return nil
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, wikiRepo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultWikiBranch)
+	if git.IsErrNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	entries, err := commit.Tree.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return err
+	}
+
+	updatedUnix := timeutil.TimeStamp(commit.Committer.When.Unix())
+	pages := make([]*internal.IndexerData, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsRegular() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		content, err := entry.Blob().GetBlobContent(setting.Indexer.MaxIndexerFileSize)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, &internal.IndexerData{
+			ID:          internal.DocumentID(internal.KindWiki, repo.ID, entry.Name()),
+			Kind:        internal.KindWiki,
+			RepoID:      repo.ID,
+			Ref:         entry.Name(),
+			Title:       wikiPageTitle(entry.Name()),
+			Content:     content,
+			UpdatedUnix: updatedUnix,
+		})
+	}
+	return indexer.Index(ctx, pages...)
+}
+
+// indexReleases replaces the releases of the repository in the indexer, the drafts and the tags are not indexed
+func indexReleases(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository) error {
+	if err := indexer.Delete(ctx, repo.ID, internal.KindRelease); err != nil {
+		return err
+	}
+
+	releases, err := db.Find[repo_model.Release](ctx, repo_model.FindReleasesOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      repo.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	contents := make([]*internal.IndexerData, 0, len(releases))
+	for _, release := range releases {
+		title := release.Title
+		if title == "" {
+			title = release.TagName
+		}
+		contents = append(contents, &internal.IndexerData{
+			ID:          internal.DocumentID(internal.KindRelease, repo.ID, release.TagName),
+			Kind:        internal.KindRelease,
+			RepoID:      repo.ID,
+			Ref:         release.TagName,
+			Title:       title,
+			Content:     release.Note,
+			UpdatedUnix: release.CreatedUnix,
+		})
+	}
+	return indexer.Index(ctx, contents...)
+}
+
+// indexCommits adds the commits of the default branch since it was indexed at indexedSha,
+// the commits are replaced if it hasn't been indexed yet or if its history has been rewritten.
+func indexCommits(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, indexedSha string) error {
+	if repo.IsEmpty {
+		return indexer.Delete(ctx, repo.ID, internal.KindCommit)
+	}
+
+	stdout, _, err := gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("show-ref", "-s").AddDynamicArguments(git.BranchPrefix+repo.DefaultBranch))
+	if err != nil {
+		return err
+	}
+	sha := strings.TrimSpace(stdout)
+	if sha == indexedSha {
+		return nil
+	}
+
+	revision := sha
+	if indexedSha != "" && gitrepo.RunCmd(ctx, repo, gitcmd.NewCommand("merge-base", "--is-ancestor").AddDynamicArguments(indexedSha, sha)) == nil {
+		revision = indexedSha + ".." + sha
+	} else if err := indexer.Delete(ctx, repo.ID, internal.KindCommit); err != nil {
+		return err
+	}
+
+	cmd := gitcmd.NewCommand("log", "--format=%H%x00%ct%x00%B%x1e").
+		AddOptionFormat("--max-count=%d", setting.Indexer.ContentMaxCommits).
+		AddDynamicArguments(revision)
+	stdout, _, err = gitrepo.RunCmdString(ctx, repo, cmd)
+	if err != nil {
+		return err
+	}
+	if err := indexer.Index(ctx, parseGitLogOutput(repo.ID, stdout)...); err != nil {
+		return err
+	}
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeContent, sha)
+}
+
+// parseGitLogOutput parses the output of `git log --format=%H%x00%ct%x00%B%x1e`
+func parseGitLogOutput(repoID int64, stdout string) []*internal.IndexerData {
+	commits := make([]*internal.IndexerData, 0, 50)
+	for record := range strings.SplitSeq(stdout, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		sha, message := fields[0], strings.TrimSpace(fields[2])
+		committed, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			log.Warn("Unparseable commit time %q of commit %s", fields[1], sha)
+		}
+		title, _, _ := strings.Cut(message, "\n")
+		commits = append(commits, &internal.IndexerData{
+			ID:          internal.DocumentID(internal.KindCommit, repoID, sha),
+			Kind:        internal.KindCommit,
+			RepoID:      repoID,
+			Ref:         sha,
+			Title:       strings.TrimSpace(title),
+			Content:     message,
+			UpdatedUnix: timeutil.TimeStamp(committed),
+		})
+	}
+	return commits
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package content
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/content/bleve"
+	"code.gitea.io/gitea/modules/indexer/content/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/content/internal"
+	"code.gitea.io/gitea/modules/indexer/content/meilisearch"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// IndexerMetadata is used to send data to the queue, so it contains only the ids.
+// The kind is empty if all the contents of the repository have to be indexed.
+type IndexerMetadata struct {
+	RepoID int64         `json:"repo_id"`
+	Kind   internal.Kind `json:"kind"`
+}
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[*IndexerMetadata]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+	// lastError is the last error of the queue handler
+	lastError indexer.ErrorState
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+// Kind is the kind of the indexed contents
+type Kind = internal.Kind
+
+const (
+	KindWiki    = internal.KindWiki
+	KindRelease = internal.KindRelease
+	KindCommit  = internal.KindCommit
+)
+
+// SearchOptions represents the options of searching the contents
+type SearchOptions = internal.SearchOptions
+
+// Init initializes the content indexer
+func Init() {
+	if !setting.Indexer.ContentIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: ContentIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing content indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Content Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.ContentType {
+	case "bleve", "elasticsearch", "meilisearch":
+		handler := func(items ...*IndexerMetadata) (unhandled []*IndexerMetadata) {
+			indexer := *globalIndexer.Load()
+			for _, item := range items {
+				log.Trace("IndexerMetadata Process Repo: %d Kind: %q", item.RepoID, item.Kind)
+				if err := index(ctx, indexer, item.RepoID, item.Kind); err != nil {
+					lastError.Record(err)
+					if !setting.IsInTesting {
+						log.Error("Contents indexer handler: index error for repo %v: %v", item.RepoID, err)
+					}
+				} else {
+					lastError.Clear()
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken repo will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "content_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create contents indexer queue")
+		}
+	default:
+		log.Fatal("Unknown contents indexer type; %s", setting.Indexer.ContentType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			cIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.ContentType {
+		case "bleve":
+			log.Info("PID: %d Initializing Content Indexer at: %s", os.Getpid(), setting.Indexer.ContentPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing content indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.ContentPath)
+				}
+			}()
+			cIndexer = bleve.NewIndexer(setting.Indexer.ContentPath)
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Content Indexer at: %s", os.Getpid(), util.SanitizeCredentialURLs(setting.Indexer.ContentConnStr))
+			cIndexer = elasticsearch.NewIndexer(setting.Indexer.ContentConnStr, setting.Indexer.ContentIndexerName)
+		case "meilisearch":
+			log.Info("PID: %d Initializing Content Indexer at: %s", os.Getpid(), util.SanitizeCredentialURLs(setting.Indexer.ContentConnStr))
+			cIndexer = meilisearch.NewIndexer(setting.Indexer.ContentConnStr, setting.Indexer.ContentConnAuth, setting.Indexer.ContentIndexerName)
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.ContentType)
+		}
+
+		existed, err = cIndexer.Init(ctx)
+		if err != nil {
+			cancel()
+			(*globalIndexer.Load()).Close()
+			close(waitChannel)
+			log.Fatal("PID: %d Unable to initialize the %s Content Indexer: %v", os.Getpid(), setting.Indexer.ContentType, err)
+		}
+
+		globalIndexer.Store(&cIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populateContentIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Content Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Content Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Content Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Content Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+// UpdateRepoIndexer updates the contents of the kind of a repository in the indexer, all of them are updated if kind is empty
+func UpdateRepoIndexer(repoID int64, kind Kind) {
+	if indexerQueue == nil {
+		return
+	}
+	item := &IndexerMetadata{RepoID: repoID, Kind: kind}
+	if err := indexerQueue.Push(item); err != nil {
+		log.Error("Update repo %d contents index data failed: %v", repoID, err)
+	}
+}
+
+// ReindexRepo removes the contents of a repository from the indexer and indexes them again from scratch
+func ReindexRepo(ctx context.Context, repoID int64) error {
+	if err := (*globalIndexer.Load()).Delete(ctx, repoID, ""); err != nil {
+		return err
+	}
+	if err := repo_model.DeleteIndexerStatuses(ctx, repoID, repo_model.RepoIndexerTypeContent); err != nil {
+		return err
+	}
+	UpdateRepoIndexer(repoID, "")
+	return nil
+}
+
+// Status returns the status of the content indexer
+func Status(ctx context.Context) *indexer.Status {
+	if !setting.Indexer.ContentIndexerEnabled || indexerQueue == nil {
+		return &indexer.Status{Name: "contents", Type: setting.Indexer.ContentType}
+	}
+	return indexer.NewStatus(ctx, "contents", setting.Indexer.ContentType, *globalIndexer.Load(), indexerQueue.GetQueueItemNumber(), &lastError)
+}
+
+// IsAvailable checks if content indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// SupportedSearchModes returns the search modes supported by the content indexer
+func SupportedSearchModes() []indexer.SearchMode {
+	return (*globalIndexer.Load()).SupportedSearchModes()
+}
+
+// populateContentIndexer populates the content indexer with pre-existing data. This
+// should only be run when the indexer is created for the first time.
+func populateContentIndexer(ctx context.Context) {
+	log.Info("Populating the content indexer with existing repositories")
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	// the other indexers share the table, so only the statuses of this indexer are deleted
+	if _, err := db.GetEngine(ctx).Where("indexer_type = ?", repo_model.RepoIndexerTypeContent).Delete(new(repo_model.RepoIndexerStatus)); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	var maxRepoID int64
+	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, so that we
+	// don't include repos that are created after gitea starts; such repos will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxRepoID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Content Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeContent, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateContentIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				log.Info("Content Indexer population shutdown before completion")
+				return
+			default:
+			}
+			if err := indexerQueue.Push(&IndexerMetadata{RepoID: id}); err != nil {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done (re)populating the content indexer with existing repositories")
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package content
+
+import (
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/indexer/content/bleve"
+	"code.gitea.io/gitea/modules/indexer/content/internal"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+	_ "code.gitea.io/gitea/models/activities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestBleveIndexAndSearch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	idx := bleve.NewIndexer(t.TempDir())
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, index(t.Context(), idx, 1, ""))
+
+	search := func(kind internal.Kind, keyword string, repoIDs ...int64) []string {
+		res, err := idx.Search(t.Context(), &internal.SearchOptions{Keyword: keyword, Kind: kind, RepoIDs: repoIDs})
+		require.NoError(t, err)
+		refs := make([]string, 0, len(res.Hits))
+		for _, hit := range res.Hits {
+			assert.Equal(t, kind, hit.Kind)
+			refs = append(refs, hit.Ref)
+		}
+		return refs
+	}
+
+	// the fixture "Unescaped File.md" is a copy of the home page
+	assert.ElementsMatch(t, []string{"Home.md", "Unescaped File.md"}, search(internal.KindWiki, "home page"))
+	assert.Equal(t, []string{"Page-With-Spaced-Name.md"}, search(internal.KindWiki, "spaces", 1))
+	assert.Empty(t, search(internal.KindWiki, "spaces", 2))
+	// the drafts and the tags are not indexed
+	assert.Equal(t, []string{"v1.0"}, search(internal.KindRelease, "pre release"))
+	assert.Empty(t, search(internal.KindRelease, "draft"))
+	assert.Equal(t, []string{"65f1bf27bc3bf70f64657658635e66094edbcb4d"}, search(internal.KindCommit, "initial"))
+	assert.Empty(t, search(internal.KindCommit, "home page"))
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeContent)
+	require.NoError(t, err)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+
+	require.NoError(t, idx.Delete(t.Context(), 1, internal.KindWiki))
+	assert.Empty(t, search(internal.KindWiki, "home page"))
+	assert.NotEmpty(t, search(internal.KindRelease, "pre release"))
+
+	require.NoError(t, idx.Delete(t.Context(), 1, ""))
+	assert.Empty(t, search(internal.KindRelease, "pre release"))
+	assert.Empty(t, search(internal.KindCommit, "initial"))
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "short content", snippet("short\n\ncontent", "content"))
+
+	long := strings.Repeat("lorem ipsum ", 50) + "needle" + strings.Repeat(" dolor sit amet", 50)
+	s := snippet(long, "Needle")
+	assert.Contains(t, s, "needle")
+	assert.True(t, strings.HasPrefix(s, "…"))
+	assert.True(t, strings.HasSuffix(s, "…"))
+
+	s = snippet(long, "missing")
+	assert.True(t, strings.HasPrefix(s, "lorem ipsum"))
+	assert.True(t, strings.HasSuffix(s, "…"))
+}
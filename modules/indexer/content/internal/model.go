@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// Kind is the kind of the indexed contents
+type Kind string
+
+const (
+	KindWiki    Kind = "wiki"    // the pages of the wiki
+	KindRelease Kind = "release" // the notes of the releases, the drafts and the tags are not indexed
+	KindCommit  Kind = "commit"  // the messages of the commits of the default branch
+)
+
+// Kinds are all the kinds of the indexed contents
+var Kinds = []Kind{KindWiki, KindRelease, KindCommit}
+
+// IsValid returns whether the kind is known
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindWiki, KindRelease, KindCommit:
+		return true
+	}
+	return false
+}
+
+// IndexerData is a content stored in the indexer
+type IndexerData struct {
+	ID     string `json:"id"`
+	Kind   Kind   `json:"kind"`
+	RepoID int64  `json:"repo_id"`
+	// Ref is the git path of a wiki page, the tag name of a release or the sha of a commit
+	Ref         string             `json:"ref"`
+	Title       string             `json:"title"`
+	Content     string             `json:"content"`
+	UpdatedUnix timeutil.TimeStamp `json:"updated_unix"`
+}
+
+// DocumentID returns the ID of a content in the indexer, the key identifies the content in the repository.
+// It only contains alphanumeric characters and underscores which are allowed by all the indexers.
+func DocumentID(kind Kind, repoID int64, key string) string {
+	sum := sha1.Sum([]byte(key))
+	return fmt.Sprintf("%s_%d_%s", kind, repoID, hex.EncodeToString(sum[:]))
+}
+
+// SearchOptions represents the options of searching the contents
+type SearchOptions struct {
+	Keyword    string
+	SearchMode indexer.SearchModeType
+
+	Kind    Kind    // the kind of the searched contents, it's required
+	RepoIDs []int64 // the repositories of the contents, all the repositories are searched if it's empty
+
+	Paginator *db.ListOptions
+}
+
+// SearchResult represents the contents matching the keyword, the most relevant one is the first
+type SearchResult struct {
+	Total int64
+	Hits  []*IndexerData
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/content/internal"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_meilisearch "code.gitea.io/gitea/modules/indexer/internal/meilisearch"
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	contentIndexerLatestVersion = 1
+
+	// TODO: make this configurable if necessary
+	maxTotalHits = 10000
+)
+
+// ErrMalformedResponse is never expected as we initialize the indexer ourself and so define the types.
+var ErrMalformedResponse = errors.New("meilisearch returned unexpected malformed content")
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_meilisearch.Indexer
+	indexer_internal.Indexer // do not composite inner_meilisearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new meilisearch indexer
+func NewIndexer(url, apiKey, indexerName string) *Indexer {
+	settings := &meilisearch.Settings{
+		// the contents are ranked by their relevance, the latest one is the first if they are equally relevant
+		RankingRules: []string{"words", "typo", "proximity", "attribute", "exactness", "sort"},
+		SearchableAttributes: []string{
+			"title",
+			"content",
+		},
+		DisplayedAttributes: []string{
+			"id",
+			"kind",
+			"repo_id",
+			"ref",
+			"title",
+			"content",
+			"updated_unix",
+		},
+		FilterableAttributes: []string{
+			"kind",
+			"repo_id",
+		},
+		SortableAttributes: []string{
+			"updated_unix",
+		},
+		Pagination: &meilisearch.Pagination{
+			MaxTotalHits: maxTotalHits,
+		},
+	}
+
+	inner := inner_meilisearch.NewIndexer(url, apiKey, indexerName, contentIndexerLatestVersion, settings)
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+}
+
+// kindFilter filters the contents by their kind, the kinds are constants so they don't need escaping
+func kindFilter(kind internal.Kind) inner_meilisearch.FilterEq {
+	return inner_meilisearch.FilterEq(fmt.Sprintf("kind = %q", string(kind)))
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, contents ...*internal.IndexerData) error {
+	if len(contents) == 0 {
+		return nil
+	}
+	// use default primary key which should be "id"
+	_, err := b.inner.Client.Index(b.inner.VersionedIndexName()).AddDocuments(contents, nil)
+	return err
+}
+
+// Delete deletes the contents of the kind of the repository
+func (b *Indexer) Delete(_ context.Context, repoID int64, kind internal.Kind) error {
+	filter := &inner_meilisearch.FilterAnd{}
+	filter.And(inner_meilisearch.NewFilterEq("repo_id", repoID))
+	if kind != "" {
+		filter.And(kindFilter(kind))
+	}
+	_, err := b.inner.Client.Index(b.inner.VersionedIndexName()).DeleteDocumentsByFilter(filter.Statement(), nil)
+	return err
+}
+
+// Search searches for the contents by the keyword, the most relevant one is the first
+func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
+	filter := &inner_meilisearch.FilterAnd{}
+	filter.And(kindFilter(options.Kind))
+	if len(options.RepoIDs) > 0 {
+		filter.And(inner_meilisearch.NewFilterIn("repo_id", options.RepoIDs...))
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(options.Paginator, maxTotalHits)
+
+	counting := limit == 0
+	if counting {
+		// If set limit to 0, it will be 20 by default, and -1 is not allowed.
+		// See https://www.meilisearch.com/docs/reference/api/search#limit
+		// So set limit to 1 to make the cost as low as possible, then clear the result before returning.
+		limit = 1
+	}
+
+	keyword := options.Keyword // default to match "words"
+	if options.SearchMode == indexer.SearchModeExact {
+		// https://www.meilisearch.com/docs/reference/api/search#phrase-search
+		keyword = doubleQuoteKeyword(keyword)
+	}
+
+	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).SearchWithContext(ctx, keyword, &meilisearch.SearchRequest{
+		Filter:           filter.Statement(),
+		Limit:            int64(limit),
+		Offset:           int64(skip),
+		Sort:             []string{"updated_unix:desc"},
+		MatchingStrategy: "all",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if counting {
+		searchRes.Hits = nil
+	}
+
+	hits, err := convertHits(searchRes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &internal.SearchResult{
+		Total: searchRes.EstimatedTotalHits,
+		Hits:  hits,
+	}, nil
+}
+
+func doubleQuoteKeyword(k string) string {
+	kp := strings.Split(k, " ")
+	parts := 0
+	for i := range kp {
+		part := strings.Trim(kp[i], "\"")
+		if part != "" {
+			kp[parts] = fmt.Sprintf(`"%s"`, part)
+			parts++
+		}
+	}
+	return strings.Join(kp[:parts], " ")
+}
+
+func convertHits(searchRes *meilisearch.SearchResponse) ([]*internal.IndexerData, error) {
+	hits := make([]*internal.IndexerData, 0, len(searchRes.Hits))
+	for _, hit := range searchRes.Hits {
+		raw, err := json.Marshal(hit)
+		if err != nil {
+			return nil, err
+		}
+		var content internal.IndexerData
+		if err := json.Unmarshal(raw, &content); err != nil {
+			return nil, ErrMalformedResponse
+		}
+		hits = append(hits, &content)
+	}
+	return hits, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package content
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// snippetRadius is the number of the characters of a snippet around the matched keyword
+const snippetRadius = 100
+
+// Result is a content matching the searched keyword
+type Result struct {
+	Kind   Kind
+	RepoID int64
+	// Ref is the git path of a wiki page, the tag name of a release or the sha of a commit
+	Ref         string
+	Title       string
+	Snippet     string
+	UpdatedUnix timeutil.TimeStamp
+}
+
+// Search searches the contents of a kind, the most relevant one is the first
+func Search(ctx context.Context, opts *SearchOptions) (int64, []*Result, error) {
+	res, err := (*globalIndexer.Load()).Search(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	results := make([]*Result, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		results = append(results, &Result{
+			Kind:        hit.Kind,
+			RepoID:      hit.RepoID,
+			Ref:         hit.Ref,
+			Title:       hit.Title,
+			Snippet:     snippet(hit.Content, opts.Keyword),
+			UpdatedUnix: hit.UpdatedUnix,
+		})
+	}
+	return res.Total, results, nil
+}
+
+// snippet returns the part of the content around the first matched word of the keyword,
+// the beginning of the content is returned if none of them can be found
+func snippet(content, keyword string) string {
+	content = strings.Join(strings.Fields(content), " ")
+	runes := []rune(content)
+	if len(runes) <= 2*snippetRadius {
+		return content
+	}
+
+	lowerContent := strings.ToLower(content)
+	start := 0
+	for word := range strings.FieldsSeq(strings.ToLower(strings.Trim(keyword, `"`))) {
+		if idx := strings.Index(lowerContent, word); idx >= 0 {
+			// lowering the case could change the length of some characters, so the rune index is approximated
+			start = max(len([]rune(lowerContent[:idx]))-snippetRadius/2, 0)
+			break
+		}
+	}
+	end := min(start+2*snippetRadius, len(runes))
+	start = max(end-2*snippetRadius, 0)
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+	sb.WriteString(string(runes[start:end]))
+	if end < len(runes) {
+		sb.WriteString("…")
+	}
+	return sb.String()
+}
@@ -43,7 +43,7 @@ func SearchModesExactWordsFuzzy() []SearchMode {
 	}...)
 }
 
-func GitGrepSupportedSearchModes() []SearchMode {
+func SearchModesExactWordsRegexp() []SearchMode {
 	return append(SearchModesExactWords(), []SearchMode{
 		{
 			ModeValue:    SearchModeRegexp,
@@ -52,3 +52,7 @@ func GitGrepSupportedSearchModes() []SearchMode {
 		},
 	}...)
 }
+
+func GitGrepSupportedSearchModes() []SearchMode {
+	return SearchModesExactWordsRegexp()
+}
@@ -91,6 +91,15 @@ func (i *Indexer) Ping(_ context.Context) error {
 	return nil
 }
 
+// DocCount returns the number of the documents in the index
+func (i *Indexer) DocCount(_ context.Context) (int64, error) {
+	if i == nil || i.Indexer == nil {
+		return 0, errors.New("indexer is not initialized")
+	}
+	count, err := i.Indexer.DocCount()
+	return int64(count), err
+}
+
 func (i *Indexer) Close() {
 	if i == nil || i.Indexer == nil {
 		return
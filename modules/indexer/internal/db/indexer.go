@@ -28,6 +28,11 @@ func (i *Indexer) Ping(_ context.Context) error {
 	return nil
 }
 
+// DocCount returns -1 because the documents are the records of the database
+func (i *Indexer) DocCount(_ context.Context) (int64, error) {
+	return -1, nil
+}
+
 // Close closes the indexer
 func (i *Indexer) Close() {
 	// nothing to do
@@ -85,6 +85,14 @@ func (i *Indexer) Ping(ctx context.Context) error {
 	return nil
 }
 
+// DocCount returns the number of the documents in the index
+func (i *Indexer) DocCount(ctx context.Context) (int64, error) {
+	if i == nil || i.Client == nil {
+		return 0, errors.New("indexer is not initialized")
+	}
+	return i.Client.Count(i.VersionedIndexName()).Do(ctx)
+}
+
 // Close closes the indexer
 func (i *Indexer) Close() {
 	if i == nil {
@@ -15,6 +15,8 @@ type Indexer interface {
 	Init(ctx context.Context) (bool, error)
 	// Ping checks if the indexer is available
 	Ping(ctx context.Context) error
+	// DocCount returns the number of the documents in the index, it's negative if the indexer doesn't store documents
+	DocCount(ctx context.Context) (int64, error)
 	// Close closes the indexer
 	Close()
 }
@@ -34,4 +36,8 @@ func (d *dummyIndexer) Ping(ctx context.Context) error {
 	return errors.New("indexer is not ready")
 }
 
+func (d *dummyIndexer) DocCount(ctx context.Context) (int64, error) {
+	return 0, errors.New("indexer is not ready")
+}
+
 func (d *dummyIndexer) Close() {}
@@ -79,6 +79,18 @@ func (i *Indexer) Ping(ctx context.Context) error {
 	return nil
 }
 
+// DocCount returns the number of the documents in the index
+func (i *Indexer) DocCount(ctx context.Context) (int64, error) {
+	if i == nil || i.Client == nil {
+		return 0, errors.New("indexer is not initialized")
+	}
+	stats, err := i.Client.Index(i.VersionedIndexName()).GetStatsWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return stats.NumberOfDocuments, nil
+}
+
 // Close closes the indexer
 func (i *Indexer) Close() {
 	if i == nil {
@@ -6,6 +6,7 @@ package bleve
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"code.gitea.io/gitea/modules/indexer"
 	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
@@ -21,13 +22,15 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
 	"github.com/blevesearch/bleve/v2/search/query"
 )
 
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 5
+	issueIndexerLatestVersion = 6
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -63,6 +66,14 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	textFieldMapping.Store = false
 	textFieldMapping.IncludeInAll = false
 
+	// the contents and the comments are stored to highlight the matches
+	storedTextFieldMapping := bleve.NewTextFieldMapping()
+	storedTextFieldMapping.IncludeInAll = false
+
+	storedNumberFieldMapping := bleve.NewNumericFieldMapping()
+	storedNumberFieldMapping.Index = false
+	storedNumberFieldMapping.IncludeInAll = false
+
 	boolFieldMapping := bleve.NewBooleanFieldMapping()
 	boolFieldMapping.Store = false
 	boolFieldMapping.IncludeInAll = false
@@ -74,8 +85,9 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("is_public", boolFieldMapping)
 
 	docMapping.AddFieldMappingsAt("title", textFieldMapping)
-	docMapping.AddFieldMappingsAt("content", textFieldMapping)
-	docMapping.AddFieldMappingsAt("comments", textFieldMapping)
+	docMapping.AddFieldMappingsAt("content", storedTextFieldMapping)
+	docMapping.AddFieldMappingsAt("comments", storedTextFieldMapping)
+	docMapping.AddFieldMappingsAt("comment_ids", storedNumberFieldMapping)
 
 	docMapping.AddFieldMappingsAt("is_pull", boolFieldMapping)
 	docMapping.AddFieldMappingsAt("is_closed", boolFieldMapping)
@@ -185,6 +197,14 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}
 	}
 
+	if len(options.IssueIDs) > 0 {
+		ids := make([]string, 0, len(options.IssueIDs))
+		for _, id := range options.IssueIDs {
+			ids = append(ids, indexer_internal.Base36(id))
+		}
+		queries = append(queries, bleve.NewDocIDQuery(ids))
+	}
+
 	if len(options.RepoIDs) > 0 || options.AllPublic {
 		var repoQueries []query.Query
 		for _, repoID := range options.RepoIDs {
@@ -300,6 +320,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 
 	search.SortBy([]string{string(options.SortBy), "-_id"})
 
+	highlight := options.Highlight && options.Keyword != ""
+	if highlight {
+		search.Highlight = bleve.NewHighlightWithStyle(html.Name)
+		search.Highlight.Fields = []string{"content", "comments"}
+		search.Fields = []string{"comments", "comment_ids"}
+	}
+
 	result, err := b.inner.Indexer.SearchInContext(ctx, search)
 	if err != nil {
 		return nil, err
@@ -314,9 +341,48 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		if err != nil {
 			return nil, err
 		}
-		ret.Hits = append(ret.Hits, internal.Match{
-			ID: id,
-		})
+		match := internal.Match{ID: id}
+		if highlight {
+			match.Highlight = hitHighlight(hit)
+		}
+		ret.Hits = append(ret.Hits, match)
 	}
 	return ret, nil
 }
+
+// hitHighlight returns the first fragment of the content or the comments of a hit highlighted by bleve
+func hitHighlight(hit *search.DocumentMatch) *internal.Highlight {
+	// bleve returns the beginning of a field as its fragment even if nothing in the field matches
+	isMatched := func(fragments []string) bool {
+		return len(fragments) > 0 && strings.Contains(fragments[0], internal.HighlightPreTag)
+	}
+	if fragments := hit.Fragments["content"]; isMatched(fragments) {
+		return &internal.Highlight{Fragment: fragments[0]}
+	}
+	fragments := hit.Fragments["comments"]
+	if !isMatched(fragments) {
+		return nil
+	}
+	var comments []string
+	var commentIDs []int64
+	// bleve returns a single value of an array field as it is
+	switch v := hit.Fields["comments"].(type) {
+	case string:
+		comments = []string{v}
+	case []any:
+		for _, comment := range v {
+			s, _ := comment.(string)
+			comments = append(comments, s)
+		}
+	}
+	switch v := hit.Fields["comment_ids"].(type) {
+	case float64:
+		commentIDs = []int64{int64(v)}
+	case []any:
+		for _, id := range v {
+			f, _ := id.(float64)
+			commentIDs = append(commentIDs, int64(f))
+		}
+	}
+	return internal.CommentHighlight(fragments[0], comments, commentIDs)
+}
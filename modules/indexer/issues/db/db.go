@@ -121,7 +121,55 @@ func (i *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}, nil
 	}
 
-	return i.FindWithIssueOptions(ctx, opt, cond)
+	result, err := i.FindWithIssueOptions(ctx, opt, cond)
+	if err != nil {
+		return nil, err
+	}
+	if options.Highlight && options.Keyword != "" {
+		searchMode := util.IfZero(options.SearchMode, i.SupportedSearchModes()[0].ModeValue)
+		if err := highlightHits(ctx, options.Keyword, searchMode, result.Hits); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// highlightHits highlights the contents and the comments of the issues matching the keyword, the database can't
+// highlight the matches so the highlights are emulated
+func highlightHits(ctx context.Context, keyword string, searchMode indexer.SearchModeType, hits []internal.Match) error {
+	if len(hits) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	issues, err := issue_model.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	comments, err := issue_model.FindComments(ctx, &issue_model.FindCommentsOptions{
+		IssueIDs: ids,
+		Type:     issue_model.CommentTypeComment,
+	})
+	if err != nil {
+		return err
+	}
+
+	contents := make(map[int64]string, len(issues))
+	for _, issue := range issues {
+		contents[issue.ID] = issue.Content
+	}
+	issueComments := make(map[int64][]string, len(hits))
+	issueCommentIDs := make(map[int64][]int64, len(hits))
+	for _, comment := range comments {
+		issueComments[comment.IssueID] = append(issueComments[comment.IssueID], comment.Content)
+		issueCommentIDs[comment.IssueID] = append(issueCommentIDs[comment.IssueID], comment.ID)
+	}
+	for i, hit := range hits {
+		hits[i].Highlight = internal.EmulateHighlight(keyword, searchMode, contents[hit.ID], issueComments[hit.ID], issueCommentIDs[hit.ID])
+	}
+	return nil
 }
 
 func (i *Indexer) FindWithIssueOptions(ctx context.Context, opt *issue_model.IssuesOptions, otherConds ...builder.Cond) (*internal.SearchResult, error) {
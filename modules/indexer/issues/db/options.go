@@ -56,6 +56,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 
 	opts := &issue_model.IssuesOptions{
 		Paginator:          options.Paginator,
+		IssueIDs:           options.IssueIDs,
 		RepoIDs:            options.RepoIDs,
 		AllPublic:          options.AllPublic,
 		RepoCond:           nil,
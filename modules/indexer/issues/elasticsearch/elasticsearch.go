@@ -13,13 +13,14 @@ import (
 	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
 	inner_elasticsearch "code.gitea.io/gitea/modules/indexer/internal/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/olivere/elastic/v7"
 )
 
 const (
-	issueIndexerLatestVersion = 2
+	issueIndexerLatestVersion = 3
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -61,6 +62,7 @@ const (
 			"title": {  "type": "text", "index": true },
 			"content": { "type": "text", "index": true },
 			"comments": { "type" : "text", "index": true },
+			"comment_ids": { "type": "integer", "index": false },
 
 			"is_pull": { "type": "boolean", "index": true },
 			"is_closed": { "type": "boolean", "index": true },
@@ -151,6 +153,14 @@ func (b *Indexer) Delete(ctx context.Context, ids ...int64) error {
 func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
 	query := elastic.NewBoolQuery()
 
+	if len(options.IssueIDs) > 0 {
+		ids := make([]string, 0, len(options.IssueIDs))
+		for _, id := range options.IssueIDs {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		query.Must(elastic.NewIdsQuery().Ids(ids...))
+	}
+
 	if options.Keyword != "" {
 		searchMode := util.IfZero(options.SearchMode, b.SupportedSearchModes()[0].ModeValue)
 		if searchMode == indexer.SearchModeExact {
@@ -268,12 +278,24 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	const maxPageSize = 10000
 
 	skip, limit := indexer_internal.ParsePaginator(options.Paginator, maxPageSize)
-	searchResult, err := b.inner.Client.Search().
+	searchService := b.inner.Client.Search().
 		Index(b.inner.VersionedIndexName()).
 		Query(query).
 		SortBy(sortBy...).
-		From(skip).Size(limit).
-		Do(ctx)
+		From(skip).Size(limit)
+	highlight := options.Highlight && options.Keyword != ""
+	if highlight {
+		searchService.
+			Highlight(elastic.NewHighlight().
+				Fields(elastic.NewHighlighterField("content"), elastic.NewHighlighterField("comments")).
+				PreTags(internal.HighlightPreTag).
+				PostTags(internal.HighlightPostTag).
+				Encoder("html").
+				FragmentSize(internal.HighlightFragmentSize).
+				NumOfFragments(1)).
+			FetchSourceContext(elastic.NewFetchSourceContext(true).Include("comments", "comment_ids"))
+	}
+	searchResult, err := searchService.Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -281,9 +303,11 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	hits := make([]internal.Match, 0, limit)
 	for _, hit := range searchResult.Hits.Hits {
 		id, _ := strconv.ParseInt(hit.Id, 10, 64)
-		hits = append(hits, internal.Match{
-			ID: id,
-		})
+		match := internal.Match{ID: id}
+		if highlight {
+			match.Highlight = hitHighlight(hit)
+		}
+		hits = append(hits, match)
 	}
 
 	return &internal.SearchResult{
@@ -292,6 +316,25 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	}, nil
 }
 
+// hitHighlight returns the first fragment of the content or the comments of a hit highlighted by elasticsearch
+func hitHighlight(hit *elastic.SearchHit) *internal.Highlight {
+	if fragments := hit.Highlight["content"]; len(fragments) > 0 {
+		return &internal.Highlight{Fragment: fragments[0]}
+	}
+	fragments := hit.Highlight["comments"]
+	if len(fragments) == 0 {
+		return nil
+	}
+	var source struct {
+		Comments   []string `json:"comments"`
+		CommentIDs []int64  `json:"comment_ids"`
+	}
+	if err := json.Unmarshal(hit.Source, &source); err != nil {
+		return nil
+	}
+	return internal.CommentHighlight(fragments[0], source.Comments, source.CommentIDs)
+}
+
 func toAnySlice[T any](s []T) []any {
 	ret := make([]any, 0, len(s))
 	for _, item := range s {
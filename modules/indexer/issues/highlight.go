@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	db_model "code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer/issues/db"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+)
+
+// SearchHighlight is a fragment of the content or a comment of an issue matching the keyword of a search
+type SearchHighlight struct {
+	CommentID int64 // the comment containing the fragment, it's 0 if the fragment is in the content of the issue
+	// Parts are the unescaped parts of the fragment, the parts with odd indexes are the matched ones
+	Parts []string
+}
+
+// Text returns the plain text of the fragment
+func (h *SearchHighlight) Text() string {
+	return strings.Join(h.Parts, "")
+}
+
+// HighlightIssues returns the highlighted fragments of the issues matching the keyword of the options, by issue ID.
+// The issues are usually the ones of a page of the results of SearchIssues with the same options.
+func HighlightIssues(ctx context.Context, opts *SearchOptions, issueIDs []int64) (map[int64]*SearchHighlight, error) {
+	if opts.Keyword == "" || len(issueIDs) == 0 {
+		return nil, nil
+	}
+
+	ix := *globalIndexer.Load()
+	if opts.IsKeywordNumeric() {
+		// keep consistent with SearchIssues which searches the numeric keywords by db
+		ix = db.GetIndexer()
+	}
+
+	result, err := ix.Search(ctx, opts.Copy(func(options *SearchOptions) {
+		options.IssueIDs = issueIDs
+		options.Paginator = &db_model.ListOptions{ListAll: true}
+		options.Ranking = RankingKeyword
+		options.Highlight = true
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	highlights := make(map[int64]*SearchHighlight, len(result.Hits))
+	for _, hit := range result.Hits {
+		if hit.Highlight == nil || hit.Highlight.Fragment == "" {
+			continue
+		}
+		highlights[hit.ID] = &SearchHighlight{
+			CommentID: hit.Highlight.CommentID,
+			Parts:     splitFragment(hit.Highlight.Fragment),
+		}
+	}
+	return highlights, nil
+}
+
+// splitFragment splits a highlighted fragment into unescaped parts, the parts with odd indexes are the matched ones
+func splitFragment(fragment string) []string {
+	parts := make([]string, 0, 3)
+	for {
+		before, rest, found := strings.Cut(fragment, internal.HighlightPreTag)
+		if !found {
+			return append(parts, html.UnescapeString(fragment))
+		}
+		matched, after, _ := strings.Cut(rest, internal.HighlightPostTag)
+		parts = append(parts, html.UnescapeString(before), html.UnescapeString(matched))
+		fragment = after
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFragment(t *testing.T) {
+	assert.Equal(t, []string{"no match"}, splitFragment("no match"))
+	assert.Equal(t, []string{"", "a", " &lt;b&gt; ", "c", ""}, splitFragment("<mark>a</mark> &amp;lt;b&amp;gt; <mark>c</mark>"))
+	assert.Equal(t, []string{"I <3 ", "avocado", " toast"}, splitFragment("I &lt;3 <mark>avocado</mark> toast"))
+	assert.Equal(t, "I <3 avocado toast", (&SearchHighlight{Parts: splitFragment("I &lt;3 <mark>avocado</mark> toast")}).Text())
+}
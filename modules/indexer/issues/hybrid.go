@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"slices"
+
+	db_model "code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer/issues/db"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/indexer/issues/vector"
+	"code.gitea.io/gitea/modules/setting"
+
+	"xorm.io/builder"
+)
+
+const (
+	// hybridCandidates is the number of the candidates of the keyword and the semantic rankings fused by the hybrid ranking
+	hybridCandidates = 100
+	// hybridRankConstant is the constant of the reciprocal rank fusion, it lowers the weights of the top ranks
+	hybridRankConstant = 60
+)
+
+// searchIssuesHybrid ranks the issues by the reciprocal rank fusion of the keyword and the semantic rankings,
+// an issue ranked by both of them is ranked higher than the ones only ranked by one of them.
+// Only the top candidates of the rankings are fused, so the total is limited.
+func searchIssuesHybrid(ctx context.Context, ix internal.Indexer, vi *vector.Indexer, opts *SearchOptions) ([]int64, int64, error) {
+	keywordResult, err := ix.Search(ctx, opts.Copy(func(options *SearchOptions) {
+		options.Paginator = &db_model.ListOptions{Page: 1, PageSize: hybridCandidates}
+		options.Ranking = RankingKeyword
+	}))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matches, err := vi.Search(ctx, opts.Keyword, opts.RepoIDs, opts.AllPublic, hybridCandidates, setting.Indexer.IssueSemanticMinScore)
+	if err != nil {
+		return nil, 0, err
+	}
+	semanticIDs, err := filterIssueIDs(ctx, opts, matches)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scores := make(map[int64]float64, len(keywordResult.Hits)+len(semanticIDs))
+	for rank, hit := range keywordResult.Hits {
+		scores[hit.ID] += 1.0 / float64(hybridRankConstant+rank+1)
+	}
+	for rank, id := range semanticIDs {
+		scores[id] += 1.0 / float64(hybridRankConstant+rank+1)
+	}
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b int64) int {
+		if scores[a] != scores[b] {
+			if scores[a] > scores[b] {
+				return -1
+			}
+			return 1
+		}
+		return int(b - a)
+	})
+
+	total := int64(len(ids))
+	if opts.Paginator == nil || opts.Paginator.IsListAll() {
+		return ids, total, nil
+	}
+	if opts.Paginator.PageSize == 0 {
+		return nil, total, nil
+	}
+	start := min(max(opts.Paginator.Page-1, 0)*opts.Paginator.PageSize, len(ids))
+	end := min(start+opts.Paginator.PageSize, len(ids))
+	return ids[start:end], total, nil
+}
+
+// filterIssueIDs returns the issues of the semantic matches which match the other options, in the order of the matches
+func filterIssueIDs(ctx context.Context, opts *SearchOptions, matches []internal.Match) ([]int64, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	dbOpts, err := db.ToDBOptions(ctx, opts.Copy(func(options *SearchOptions) {
+		options.Keyword = ""
+		options.Paginator = nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.GetIndexer().FindWithIssueOptions(ctx, dbOpts, builder.In("issue.id", ids))
+	if err != nil {
+		return nil, err
+	}
+	matched := make(map[int64]bool, len(result.Hits))
+	for _, hit := range result.Hits {
+		matched[hit.ID] = true
+	}
+	return slices.DeleteFunc(ids, func(id int64) bool { return !matched[id] }), nil
+}
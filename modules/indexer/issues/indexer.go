@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"runtime/pprof"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/indexer/issues/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/issues/internal"
 	"code.gitea.io/gitea/modules/indexer/issues/meilisearch"
+	"code.gitea.io/gitea/modules/indexer/issues/vector"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/process"
@@ -46,11 +48,18 @@ type IndexerMetadata struct {
 var (
 	// issueIndexerQueue queue of issue ids to be updated
 	issueIndexerQueue *queue.WorkerPoolQueue[*IndexerMetadata]
+	// issueEmbeddingQueue queue of issue ids to be embedded by the vector indexer, it's separated from the issue indexer queue
+	// so the issues are embedded again when the embedding provider fails, without indexing them again
+	issueEmbeddingQueue *queue.WorkerPoolQueue[int64]
 	// globalIndexer is the global indexer, it cannot be nil.
 	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
 	// So it's always safe use it as *globalIndexer.Load() and call its methods.
 	globalIndexer atomic.Pointer[internal.Indexer]
 	dummyIndexer  *internal.Indexer
+	// vectorIndexer is the optional indexer of the semantic search besides the global indexer, it's nil if the semantic search is disabled
+	vectorIndexer atomic.Pointer[vector.Indexer]
+	// lastError is the last error of the queue handler
+	lastError indexer.ErrorState
 )
 
 func init() {
@@ -68,6 +77,7 @@ func InitIssueIndexer(syncReindex bool) {
 
 	// Create the Queue
 	issueIndexerQueue = queue.CreateUniqueQueue(ctx, "issue_indexer", getIssueIndexerQueueHandler(ctx))
+	issueEmbeddingQueue = queue.CreateUniqueQueue(ctx, "issue_embedding", getIssueEmbeddingQueueHandler(ctx))
 
 	graceful.GetManager().RunAtTerminate(finished)
 
@@ -116,14 +126,30 @@ func InitIssueIndexer(syncReindex bool) {
 		}
 		globalIndexer.Store(&issueIndexer)
 
+		provider, err := vector.NewProvider(setting.Indexer.IssueEmbeddingProvider, setting.Indexer.IssueEmbeddingURL,
+			setting.Indexer.IssueEmbeddingModel, setting.Indexer.IssueEmbeddingToken, setting.Indexer.IssueEmbeddingTimeout)
+		if err != nil {
+			log.Fatal("Unable to initialize the embedding provider of the issue indexer: %v", err)
+		}
+		InitVectorIndexer(provider)
+		if vi := vectorIndexer.Load(); vi != nil && existed {
+			// the issues are embedded by populating the index again if the semantic search is just enabled or its model is changed
+			populated, err := vi.IsPopulated(ctx)
+			if err != nil {
+				log.Error("Unable to check the embeddings of the issues: %v", err)
+			}
+			existed = populated
+		}
+
 		graceful.GetManager().RunAtTerminate(func() {
 			log.Debug("Closing issue indexer")
 			(*globalIndexer.Load()).Close()
 			log.Info("PID: %d Issue Indexer closed", os.Getpid())
 		})
 
-		// Start processing the queue
+		// Start processing the queues
 		go graceful.GetManager().RunWithCancel(issueIndexerQueue)
+		go graceful.GetManager().RunWithCancel(issueEmbeddingQueue)
 
 		// Populate the index
 		if !existed {
@@ -168,39 +194,127 @@ func getIssueIndexerQueueHandler(ctx context.Context) func(items ...*IndexerMeta
 		var unhandled []*IndexerMetadata
 
 		indexer := *globalIndexer.Load()
+		vi := vectorIndexer.Load()
 		for _, item := range items {
 			log.Trace("IndexerMetadata Process: %d %v %t", item.ID, item.IDs, item.IsDelete)
 			if item.IsDelete {
 				if err := indexer.Delete(ctx, item.IDs...); err != nil {
 					log.Error("Issue indexer handler: failed to from index: %v Error: %v", item.IDs, err)
+					lastError.Record(err)
 					unhandled = append(unhandled, item)
 				}
+				deleteIssueEmbeddings(ctx, vi, item.IDs...)
 				continue
 			}
 			data, existed, err := getIssueIndexerData(ctx, item.ID)
 			if err != nil {
 				log.Error("Issue indexer handler: failed to get issue data of %d: %v", item.ID, err)
+				lastError.Record(err)
 				unhandled = append(unhandled, item)
 				continue
 			}
 			if !existed {
 				if err := indexer.Delete(ctx, item.ID); err != nil {
 					log.Error("Issue indexer handler: failed to delete issue %d from index: %v", item.ID, err)
+					lastError.Record(err)
 					unhandled = append(unhandled, item)
 				}
+				deleteIssueEmbeddings(ctx, vi, item.ID)
 				continue
 			}
 			if err := indexer.Index(ctx, data); err != nil {
 				log.Error("Issue indexer handler: failed to index issue %d: %v", item.ID, err)
+				lastError.Record(err)
 				unhandled = append(unhandled, item)
 				continue
 			}
+			// the embedding provider could be unavailable for a long time, the issue is embedded by its own queue
+			// to keep the keyword index up to date
+			if vi != nil {
+				if err := issueEmbeddingQueue.Push(item.ID); err != nil {
+					log.Error("Issue indexer handler: failed to push issue %d to the embedding queue: %v", item.ID, err)
+				}
+			}
+			lastError.Clear()
+		}
+
+		return unhandled
+	}
+}
+
+func getIssueEmbeddingQueueHandler(ctx context.Context) func(ids ...int64) []int64 {
+	return func(ids ...int64) []int64 {
+		vi := vectorIndexer.Load()
+		if vi == nil {
+			return nil
 		}
 
+		// the issues are requeued until the embedding provider is available
+		var unhandled []int64
+		for _, id := range ids {
+			data, existed, err := getIssueIndexerData(ctx, id)
+			if err != nil {
+				log.Error("Issue embedding handler: failed to get issue data of %d: %v", id, err)
+				unhandled = append(unhandled, id)
+				continue
+			}
+			if !existed {
+				deleteIssueEmbeddings(ctx, vi, id)
+				continue
+			}
+			if err := vi.Index(ctx, data); err != nil {
+				log.Error("Issue embedding handler: failed to embed issue %d: %v", id, err)
+				lastError.Record(err)
+				unhandled = append(unhandled, id)
+			}
+		}
 		return unhandled
 	}
 }
 
+func deleteIssueEmbeddings(ctx context.Context, vi *vector.Indexer, ids ...int64) {
+	if vi == nil {
+		return
+	}
+	if err := vi.Delete(ctx, ids...); err != nil {
+		log.Error("Issue indexer handler: failed to delete the embeddings of issues %v: %v", ids, err)
+		lastError.Record(err)
+	}
+}
+
+// InitVectorIndexer enables the semantic search with the embedding provider, it's disabled if the provider is nil
+func InitVectorIndexer(provider vector.Provider) {
+	if provider == nil {
+		vectorIndexer.Store(nil)
+		return
+	}
+	log.Info("Semantic issue search is enabled with the embedding model %q", provider.Model())
+	vectorIndexer.Store(vector.NewIndexer(provider))
+}
+
+// IsSemanticSearchEnabled returns whether the issues could be searched by their meanings
+func IsSemanticSearchEnabled() bool {
+	return vectorIndexer.Load() != nil
+}
+
+// SimilarIssues returns at most limit issues of the repository which are semantically similar to the text,
+// the most similar issue is the first. Nothing is returned if the semantic search is disabled.
+func SimilarIssues(ctx context.Context, repoID int64, text string, limit int) ([]int64, error) {
+	vi := vectorIndexer.Load()
+	if vi == nil || strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	matches, err := vi.Search(ctx, text, []int64{repoID}, false, limit, setting.Indexer.IssueSemanticMinScore)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	return ids, nil
+}
+
 // populateIssueIndexer populate the issue indexer with issue data
 func populateIssueIndexer(ctx context.Context) {
 	ctx, _, finished := process.GetManager().AddTypedContext(ctx, "Service: PopulateIssueIndexer", process.SystemProcessType, true)
@@ -248,6 +362,11 @@ func UpdateRepoIndexer(ctx context.Context, repoID int64) {
 	}
 }
 
+// ReindexRepo pushes all the issues of the repository to the issue indexer again
+func ReindexRepo(ctx context.Context, repoID int64) error {
+	return updateRepoIndexer(ctx, repoID)
+}
+
 // UpdateIssueIndexer add/update an issue to the issue indexer
 func UpdateIssueIndexer(ctx context.Context, issueID int64) {
 	if err := updateIssueIndexer(ctx, issueID); err != nil {
@@ -262,6 +381,14 @@ func DeleteRepoIssueIndexer(ctx context.Context, repoID int64) {
 	}
 }
 
+// Status returns the status of the issue indexer
+func Status(ctx context.Context) *indexer.Status {
+	if issueIndexerQueue == nil {
+		return &indexer.Status{Name: "issues", Type: setting.Indexer.IssueType}
+	}
+	return indexer.NewStatus(ctx, "issues", setting.Indexer.IssueType, *globalIndexer.Load(), issueIndexerQueue.GetQueueItemNumber(), &lastError)
+}
+
 // IsAvailable checks if issue indexer is available
 func IsAvailable(ctx context.Context) bool {
 	return (*globalIndexer.Load()).Ping(ctx) == nil
@@ -279,12 +406,21 @@ const (
 	SortByUpdatedAsc   = internal.SortByUpdatedAsc
 	SortByCommentsAsc  = internal.SortByCommentsAsc
 	SortByDeadlineAsc  = internal.SortByDeadlineAsc
+
+	RankingKeyword = internal.RankingKeyword
+	RankingHybrid  = internal.RankingHybrid
 )
 
 // SearchIssues search issues by options.
 func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, error) {
 	ix := *globalIndexer.Load()
 
+	if opts.Ranking == RankingHybrid && opts.Keyword != "" && !opts.IsKeywordNumeric() {
+		if vi := vectorIndexer.Load(); vi != nil {
+			return searchIssuesHybrid(ctx, ix, vi, opts)
+		}
+	}
+
 	if opts.Keyword == "" || opts.IsKeywordNumeric() {
 		// This is a conservative shortcut.
 		// If the keyword is empty or an integer, db has better (at least not worse) performance to filter issues.
@@ -4,14 +4,19 @@
 package issues
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unittest"
 	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/indexer/issues/vector"
 	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 
 	_ "code.gitea.io/gitea/models"
 	_ "code.gitea.io/gitea/models/actions"
@@ -47,6 +52,119 @@ func TestDBSearchIssues(t *testing.T) {
 	t.Run("search issues with any assignee", searchIssueWithAnyAssignee)
 }
 
+func TestSemanticSearchIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Indexer.IssueSemanticMinScore, 0.1)()
+
+	setting.Indexer.IssueType = "db"
+	InitIssueIndexer(true)
+	InitVectorIndexer(&vector.StubProvider{Dimensions: 256})
+	defer InitVectorIndexer(nil)
+	assert.True(t, IsSemanticSearchEnabled())
+
+	vi := vectorIndexer.Load()
+	for _, id := range []int64{1, 2, 3, 4, 5, 11} {
+		data, existed, err := getIssueIndexerData(t.Context(), id)
+		require.NoError(t, err)
+		require.True(t, existed)
+		require.NoError(t, vi.Index(t.Context(), data))
+	}
+
+	t.Run("similar issues", func(t *testing.T) {
+		ids, err := SimilarIssues(t.Context(), 1, "the first issue", 2)
+		require.NoError(t, err)
+		require.Len(t, ids, 2)
+		assert.EqualValues(t, 1, ids[0])
+
+		ids, err = SimilarIssues(t.Context(), 4, "the first issue", 2)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("accessible repositories", func(t *testing.T) {
+		// the issue 4 of the private repository 2 is the most similar, it's only ranked if the repository is accessible
+		matches, err := vi.Search(t.Context(), "issue4\n\ncontent for the fourth issue", nil, true, 1, setting.Indexer.IssueSemanticMinScore)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.NotEqualValues(t, 4, matches[0].ID)
+
+		matches, err = vi.Search(t.Context(), "issue4\n\ncontent for the fourth issue", []int64{2}, true, 1, setting.Indexer.IssueSemanticMinScore)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.EqualValues(t, 4, matches[0].ID)
+	})
+
+	t.Run("hybrid ranking", func(t *testing.T) {
+		// no issue contains all the keywords, but they are semantically similar
+		opts := &SearchOptions{Keyword: "second pull request", RepoIDs: []int64{1}}
+		ids, _, err := SearchIssues(t.Context(), opts)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+
+		opts.Ranking = RankingHybrid
+		ids, total, err := SearchIssues(t.Context(), opts)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{11, 2}, ids)
+		assert.EqualValues(t, 2, total)
+
+		// the semantic matches are filtered by the options too
+		opts.IsPull = optional.Some(false)
+		ids, _, err = SearchIssues(t.Context(), opts)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+
+		// the issue matched by both the keyword and the meaning is the first
+		opts = &SearchOptions{
+			Keyword:   "content first",
+			RepoIDs:   []int64{1},
+			Ranking:   RankingHybrid,
+			Paginator: &db.ListOptions{Page: 1, PageSize: 2},
+		}
+		ids, total, err = SearchIssues(t.Context(), opts)
+		require.NoError(t, err)
+		require.Len(t, ids, 2)
+		assert.EqualValues(t, 1, ids[0])
+		assert.EqualValues(t, 5, total)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, vi.Delete(t.Context(), 1))
+		ids, err := SimilarIssues(t.Context(), 1, "the first issue", 5)
+		require.NoError(t, err)
+		assert.NotContains(t, ids, int64(1))
+	})
+
+	t.Run("embedding queue", func(t *testing.T) {
+		handler := getIssueEmbeddingQueueHandler(t.Context())
+		require.NoError(t, queue.GetManager().FlushAll(t.Context(), 0))
+		require.NoError(t, vi.Delete(t.Context(), 6))
+
+		// the issues are requeued while the embedding provider is unavailable
+		InitVectorIndexer(unavailableProvider{})
+		assert.Equal(t, []int64{6}, handler(6))
+		e, err := issues.GetIssueEmbedding(t.Context(), 6)
+		require.NoError(t, err)
+		assert.Nil(t, e)
+
+		InitVectorIndexer(&vector.StubProvider{Dimensions: 256})
+		assert.Empty(t, handler(6))
+		e, err = issues.GetIssueEmbedding(t.Context(), 6)
+		require.NoError(t, err)
+		assert.NotNil(t, e)
+	})
+}
+
+// unavailableProvider fails to embed any text like an unreachable embedding endpoint
+type unavailableProvider struct{}
+
+func (unavailableProvider) Model() string {
+	return "stub"
+}
+
+func (unavailableProvider) Embed(_ context.Context, _ []string) ([][]float32, error) {
+	return nil, errors.New("the embedding provider is unavailable")
+}
+
 func searchIssueWithKeyword(t *testing.T) {
 	tests := []struct {
 		opts        SearchOptions
@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/modules/indexer"
+)
+
+// Highlight is a fragment of the content or a comment of an issue matching the keyword of a search
+type Highlight struct {
+	CommentID int64 `json:"comment_id"` // the comment containing the fragment, it's 0 if the fragment is in the content of the issue
+	// Fragment is HTML escaped, the matched terms are enclosed in HighlightPreTag and HighlightPostTag
+	Fragment string `json:"fragment"`
+}
+
+const (
+	HighlightPreTag  = "<mark>"
+	HighlightPostTag = "</mark>"
+
+	// HighlightFragmentSize is the approximate size of a fragment
+	HighlightFragmentSize = 150
+
+	// highlightEllipsis is added to the fragments which don't start or end with the highlighted text
+	highlightEllipsis = "…"
+)
+
+// FragmentText returns the plain text of a highlighted fragment
+func FragmentText(fragment string) string {
+	s := strings.NewReplacer(HighlightPreTag, "", HighlightPostTag, "").Replace(fragment)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, highlightEllipsis), highlightEllipsis)
+	return html.UnescapeString(s)
+}
+
+// CommentHighlight returns the highlight of a fragment of the comments, the comment is found by the text of the fragment
+// because the engines don't return which value of an array field a fragment belongs to. It returns nil if no comment
+// contains the fragment.
+func CommentHighlight(fragment string, comments []string, commentIDs []int64) *Highlight {
+	text := FragmentText(fragment)
+	for i, comment := range comments {
+		if i < len(commentIDs) && strings.Contains(comment, text) {
+			return &Highlight{CommentID: commentIDs[i], Fragment: fragment}
+		}
+	}
+	return nil
+}
+
+// EmulateHighlight highlights the first fragment of the content or the comments matching the keyword,
+// it's used by the engines which can't highlight the matches by themselves. It returns nil if nothing matches.
+func EmulateHighlight(keyword string, searchMode indexer.SearchModeType, content string, comments []string, commentIDs []int64) *Highlight {
+	terms := strings.Fields(keyword)
+	if searchMode == indexer.SearchModeExact {
+		terms = []string{strings.TrimSpace(keyword)}
+	}
+	if len(terms) == 0 || terms[0] == "" {
+		return nil
+	}
+
+	if fragment, ok := highlightText(content, terms); ok {
+		return &Highlight{Fragment: fragment}
+	}
+	for i, comment := range comments {
+		if i >= len(commentIDs) {
+			break
+		}
+		if fragment, ok := highlightText(comment, terms); ok {
+			return &Highlight{CommentID: commentIDs[i], Fragment: fragment}
+		}
+	}
+	return nil
+}
+
+// highlightText returns the fragment of the text around the first match of the terms, the matches in the fragment are highlighted
+func highlightText(text string, terms []string) (string, bool) {
+	start, _ := indexAnyFold(text, terms)
+	if start < 0 {
+		return "", false
+	}
+
+	// the fragment shows a few words before the first match
+	begin := max(start-HighlightFragmentSize/4, 0)
+	for begin > 0 && !utf8.RuneStart(text[begin]) {
+		begin++
+	}
+	end := min(begin+HighlightFragmentSize, len(text))
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	var sb strings.Builder
+	if begin > 0 {
+		sb.WriteString(highlightEllipsis)
+	}
+	for window := text[begin:end]; window != ""; {
+		matchStart, matchEnd := indexAnyFold(window, terms)
+		if matchStart < 0 {
+			sb.WriteString(html.EscapeString(window))
+			break
+		}
+		sb.WriteString(html.EscapeString(window[:matchStart]))
+		sb.WriteString(HighlightPreTag)
+		sb.WriteString(html.EscapeString(window[matchStart:matchEnd]))
+		sb.WriteString(HighlightPostTag)
+		window = window[matchEnd:]
+	}
+	if end < len(text) {
+		sb.WriteString(highlightEllipsis)
+	}
+	return sb.String(), true
+}
+
+// indexAnyFold returns the byte range of the first case-insensitive occurrence of any of the terms in s, or -1 if none occurs
+func indexAnyFold(s string, terms []string) (int, int) {
+	for i := range s {
+		for _, term := range terms {
+			if size, ok := hasPrefixFold(s[i:], term); ok {
+				return i, i + size
+			}
+		}
+	}
+	return -1, -1
+}
+
+// hasPrefixFold returns the size of the prefix of s matching the prefix case-insensitively
+func hasPrefixFold(s, prefix string) (int, bool) {
+	size := 0
+	for _, pr := range prefix {
+		if size >= len(s) {
+			return 0, false
+		}
+		sr, n := utf8.DecodeRuneInString(s[size:])
+		if sr != pr && unicode.ToLower(sr) != unicode.ToLower(pr) {
+			return 0, false
+		}
+		size += n
+	}
+	return size, size > 0
+}
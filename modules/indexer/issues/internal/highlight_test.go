@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmulateHighlight(t *testing.T) {
+	comments := []string{"first", "an Avocado & avocado salad"}
+	commentIDs := []int64{10, 11}
+
+	assert.Equal(t, &Highlight{Fragment: "I like &lt;<mark>avocado</mark>&gt; toast"},
+		EmulateHighlight("avocado", indexer.SearchModeFuzzy, "I like <avocado> toast", comments, commentIDs))
+	assert.Equal(t, &Highlight{CommentID: 11, Fragment: "an <mark>Avocado</mark> &amp; <mark>avocado</mark> <mark>salad</mark>"},
+		EmulateHighlight("avocado salad", indexer.SearchModeWords, "nothing", comments, commentIDs))
+	assert.Equal(t, &Highlight{CommentID: 11, Fragment: "an Avocado &amp; <mark>avocado salad</mark>"},
+		EmulateHighlight("avocado salad", indexer.SearchModeExact, "nothing", comments, commentIDs))
+	assert.Nil(t, EmulateHighlight("toast", indexer.SearchModeFuzzy, "nothing", comments, commentIDs))
+	assert.Nil(t, EmulateHighlight(" ", indexer.SearchModeExact, "nothing", comments, commentIDs))
+
+	// the fragment is cut around the match
+	content := strings.Repeat("a", 200) + "ÿ match " + strings.Repeat("b", 200)
+	fragment := EmulateHighlight("match", indexer.SearchModeFuzzy, content, nil, nil).Fragment
+	assert.True(t, strings.HasPrefix(fragment, highlightEllipsis+"aaa"))
+	assert.True(t, strings.HasSuffix(fragment, "bbb"+highlightEllipsis))
+	assert.Contains(t, fragment, "ÿ <mark>match</mark> b")
+	begin := strings.Index(content, "match") - HighlightFragmentSize/4
+	assert.Equal(t, content[begin:begin+HighlightFragmentSize], FragmentText(fragment))
+}
+
+func TestCommentHighlight(t *testing.T) {
+	comments := []string{"first", "I <3 avocado"}
+	commentIDs := []int64{10, 11}
+
+	assert.Equal(t, &Highlight{CommentID: 11, Fragment: "I &lt;3 <mark>avocado</mark>"}, CommentHighlight("I &lt;3 <mark>avocado</mark>", comments, commentIDs))
+	assert.Equal(t, &Highlight{CommentID: 11, Fragment: "…<mark>avocado</mark>"}, CommentHighlight("…<mark>avocado</mark>", comments, commentIDs))
+	assert.Nil(t, CommentHighlight("<mark>salad</mark>", comments, commentIDs))
+}
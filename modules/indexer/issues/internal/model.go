@@ -22,6 +22,8 @@ type IndexerData struct {
 	Title    string   `json:"title"`
 	Content  string   `json:"content"`
 	Comments []string `json:"comments"`
+	// CommentIDs are the IDs of the comments, in the same order as Comments
+	CommentIDs []int64 `json:"comment_ids"`
 
 	// Fields used for filtering
 	IsPull             bool               `json:"is_pull"`
@@ -52,6 +54,9 @@ type IndexerData struct {
 type Match struct {
 	ID    int64   `json:"id"`
 	Score float64 `json:"score"`
+	// Highlight is the fragment of the issue matching the keyword, it's only set if SearchOptions.Highlight is true
+	// and the content or a comment of the issue matches
+	Highlight *Highlight `json:"highlight,omitempty"`
 }
 
 // SearchResult represents search results
@@ -80,6 +85,7 @@ type SearchOptions struct {
 
 	SearchMode indexer.SearchModeType
 
+	IssueIDs  []int64 // the issues to search among, all the issues are searched if it's empty
 	RepoIDs   []int64 // repository IDs which the issues belong to
 	AllPublic bool    // if include all public repositories
 
@@ -113,6 +119,10 @@ type SearchOptions struct {
 	Paginator *db.ListOptions
 
 	SortBy SortBy // sort by field
+
+	Ranking RankingMode // how the issues matching the keyword are ranked, SortBy is ignored if it's not the default
+
+	Highlight bool // if the fragments of the contents and the comments matching the keyword are returned
 }
 
 // Copy returns a copy of the options.
@@ -134,6 +144,14 @@ func (o *SearchOptions) IsKeywordNumeric() bool {
 	return err == nil
 }
 
+// RankingMode is how the issues matching the keyword are ranked
+type RankingMode string
+
+const (
+	RankingKeyword RankingMode = ""       // the issues are matched by the keyword and sorted by SortBy
+	RankingHybrid  RankingMode = "hybrid" // the issues are ranked by both the keyword and the semantic similarities, it falls back to RankingKeyword if the semantic search is disabled
+)
+
 type SortBy string
 
 const (
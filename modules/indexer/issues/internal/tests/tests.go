@@ -129,6 +129,43 @@ var cases = []*testIndexerCase{
 		ExpectedIDs:   []int64{1002, 1001, 1000},
 		ExpectedTotal: 3,
 	},
+	{
+		Name: "Highlight",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1001, Content: "I like <avocado> toast"},
+			{ID: 1002, Content: "no match", Comments: []string{"first", "an avocado salad"}, CommentIDs: []int64{10, 11}},
+		},
+		SearchOptions: &internal.SearchOptions{
+			Keyword:   "avocado",
+			Highlight: true,
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			require.Len(t, result.Hits, 2)
+			highlights := map[int64]*internal.Highlight{}
+			for _, hit := range result.Hits {
+				require.NotNil(t, hit.Highlight)
+				highlights[hit.ID] = hit.Highlight
+			}
+			assert.EqualValues(t, 0, highlights[1001].CommentID)
+			assert.Contains(t, highlights[1001].Fragment, "&lt;<mark>avocado</mark>&gt;")
+			assert.EqualValues(t, 11, highlights[1002].CommentID)
+			assert.Contains(t, highlights[1002].Fragment, "an <mark>avocado</mark> salad")
+		},
+	},
+	{
+		Name: "IssueIDs",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1001, Title: "hello world"},
+			{ID: 1002, Title: "hello world"},
+			{ID: 1003, Title: "hello world"},
+		},
+		SearchOptions: &internal.SearchOptions{
+			Keyword:  "hello",
+			IssueIDs: []int64{1001, 1003},
+		},
+		ExpectedIDs:   []int64{1003, 1001},
+		ExpectedTotal: 2,
+	},
 	{
 		Name: "RepoIDs",
 		ExtraData: []*internal.IndexerData{
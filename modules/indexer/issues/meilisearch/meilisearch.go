@@ -20,7 +20,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 4
+	issueIndexerLatestVersion = 5
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -61,8 +61,10 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"title",
 			"content",
 			"comments",
+			"comment_ids",
 		},
 		FilterableAttributes: []string{
+			"id",
 			"repo_id",
 			"is_public",
 			"is_pull",
@@ -138,6 +140,10 @@ func (b *Indexer) Delete(_ context.Context, ids ...int64) error {
 func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (*internal.SearchResult, error) {
 	query := inner_meilisearch.FilterAnd{}
 
+	if len(options.IssueIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("id", options.IssueIDs...))
+	}
+
 	if len(options.RepoIDs) > 0 {
 		q := &inner_meilisearch.FilterOr{}
 		q.Or(inner_meilisearch.NewFilterIn("repo_id", options.RepoIDs...))
@@ -270,6 +276,12 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 	if err != nil {
 		return nil, err
 	}
+	if options.Highlight && options.Keyword != "" {
+		// meilisearch highlights the matched words but not the comment they belong to, so the highlights are emulated
+		for i, hit := range searchRes.Hits {
+			hits[i].Highlight = hitHighlight(hit, options.Keyword, options.SearchMode)
+		}
+	}
 
 	return &internal.SearchResult{
 		Total: searchRes.EstimatedTotalHits,
@@ -312,3 +324,17 @@ func convertHits(searchRes *meilisearch.SearchResponse) ([]internal.Match, error
 	}
 	return hits, nil
 }
+
+// hitHighlight returns the first fragment of the content or the comments of a hit matching the keyword
+func hitHighlight(hit meilisearch.Hit, keyword string, searchMode indexer.SearchModeType) *internal.Highlight {
+	var (
+		content    string
+		comments   []string
+		commentIDs []int64
+	)
+	// the fields could be missing or null, they are empty then
+	_ = json.Unmarshal(hit["content"], &content)
+	_ = json.Unmarshal(hit["comments"], &comments)
+	_ = json.Unmarshal(hit["comment_ids"], &commentIDs)
+	return internal.EmulateHighlight(keyword, searchMode, content, comments, commentIDs)
+}
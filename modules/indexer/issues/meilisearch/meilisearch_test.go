@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/issues/internal"
 	"code.gitea.io/gitea/modules/indexer/issues/internal/tests"
 	"code.gitea.io/gitea/modules/json"
@@ -94,6 +95,24 @@ func TestConvertHits(t *testing.T) {
 	assert.Equal(t, []internal.Match{{ID: 11}, {ID: 22}, {ID: 33}}, hits)
 }
 
+func TestHitHighlight(t *testing.T) {
+	convert := func(d any) []byte {
+		b, _ := json.Marshal(d)
+		return b
+	}
+
+	hit := meilisearch.Hit{
+		"id":          convert(float64(11)),
+		"content":     convert("issue body with no match"),
+		"comments":    convert([]any{"hey whats up?", "I'm currently Bowling", "nice"}),
+		"comment_ids": convert([]any{1, 2, 3}),
+	}
+	assert.Equal(t, &internal.Highlight{CommentID: 2, Fragment: "I&#39;m currently <mark>Bowling</mark>"}, hitHighlight(hit, "bowling", indexer.SearchModeFuzzy))
+	assert.Equal(t, &internal.Highlight{Fragment: "issue <mark>body</mark> with no match"}, hitHighlight(hit, "body", indexer.SearchModeExact))
+	assert.Nil(t, hitHighlight(hit, "bowl match", indexer.SearchModeExact))
+	assert.Nil(t, hitHighlight(meilisearch.Hit{"id": convert(float64(22))}, "bowling", indexer.SearchModeFuzzy))
+}
+
 func TestDoubleQuoteKeyword(t *testing.T) {
 	assert.Empty(t, doubleQuoteKeyword(""))
 	assert.Equal(t, `"a" "b" "c"`, doubleQuoteKeyword("a b c"))
@@ -0,0 +1,361 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// QueryFilter is a qualifier of a search query, like "label:bug" or "-label:wontfix"
+type QueryFilter struct {
+	Key     string
+	Value   string
+	Negated bool
+}
+
+// Query is a search query whose qualifiers have been split from the keyword, like
+//
+//	is:open label:bug -label:wontfix author:alice updated:>2026-01-01 review-requested:@me crash
+//
+// The words which are not qualifiers are kept in the keyword.
+type Query struct {
+	Keyword string
+	Filters []QueryFilter
+}
+
+// the known qualifiers, the value tells whether the qualifier could be negated by a "-" prefix
+var queryNegatableKeys = map[string]bool{
+	"is":        true,
+	"label":     true,
+	"author":    false,
+	"assignee":  false,
+	"mentions":  false,
+	"milestone": false,
+	"no":        false,
+
+	"review-requested": false,
+	"reviewed-by":      false,
+	"sort":             false,
+	"updated":          false,
+}
+
+// the sort types of the query, with the sort types of issues_model.IssuesOptions
+var querySortTypes = map[string]struct {
+	By   internal.SortBy
+	Type string
+}{
+	"created-desc":  {SortByCreatedDesc, "latest"},
+	"created-asc":   {SortByCreatedAsc, "oldest"},
+	"updated-desc":  {SortByUpdatedDesc, "recentupdate"},
+	"updated-asc":   {SortByUpdatedAsc, "leastupdate"},
+	"comments-desc": {SortByCommentsDesc, "mostcomment"},
+	"comments-asc":  {SortByCommentsAsc, "leastcomment"},
+	"deadline-desc": {SortByDeadlineDesc, "farduedate"},
+	"deadline-asc":  {SortByDeadlineAsc, "nearduedate"},
+}
+
+// queryMe is the user value meaning the signed-in user
+const queryMe = "@me"
+
+// ParseQuery splits the qualifiers from the keyword of a search query, an error is returned if a qualifier is invalid.
+// The values containing spaces could be quoted, like label:"good first issue".
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{}
+	var words []string
+	for _, token := range splitQuery(s) {
+		filter, ok := parseQueryFilter(token)
+		if !ok {
+			words = append(words, token)
+			continue
+		}
+		if err := filter.validate(); err != nil {
+			return nil, err
+		}
+		q.Filters = append(q.Filters, filter)
+	}
+	if len(q.Filters) == 0 {
+		// the keyword is kept as it is when there is nothing to split
+		q.Keyword = strings.TrimSpace(s)
+	} else {
+		q.Keyword = strings.Join(words, " ")
+	}
+	return q, nil
+}
+
+// HasFilters returns whether the query has any qualifier
+func (q *Query) HasFilters() bool {
+	return len(q.Filters) > 0
+}
+
+// SortType returns the sort type of issues_model.IssuesOptions given by the query, it is empty if the query doesn't sort
+func (q *Query) SortType() string {
+	sortType := ""
+	for _, f := range q.Filters {
+		if f.Key == "sort" {
+			sortType = querySortTypes[f.Value].Type
+		}
+	}
+	return sortType
+}
+
+// splitQuery splits a query by the spaces which are not quoted
+func splitQuery(s string) []string {
+	var tokens []string
+	var sb strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if sb.Len() > 0 {
+				tokens = append(tokens, sb.String())
+				sb.Reset()
+			}
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() > 0 {
+		tokens = append(tokens, sb.String())
+	}
+	return tokens
+}
+
+func parseQueryFilter(token string) (QueryFilter, bool) {
+	var filter QueryFilter
+	key, value, ok := strings.Cut(token, ":")
+	if !ok || value == "" {
+		return filter, false
+	}
+	key, filter.Negated = strings.CutPrefix(key, "-")
+	if _, known := queryNegatableKeys[key]; !known {
+		return filter, false
+	}
+	filter.Key = key
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	filter.Value = value
+	return filter, true
+}
+
+func (f QueryFilter) validate() error {
+	if f.Negated && !queryNegatableKeys[f.Key] {
+		return util.NewInvalidArgumentErrorf("%s can't be negated", f.Key)
+	}
+	switch f.Key {
+	case "is":
+		switch f.Value {
+		case "open", "closed", "issue", "pr", "pull", "archived":
+		default:
+			return util.NewInvalidArgumentErrorf("unknown value of is: %q", f.Value)
+		}
+	case "no":
+		switch f.Value {
+		case "label", "milestone", "assignee", "project":
+		default:
+			return util.NewInvalidArgumentErrorf("unknown value of no: %q", f.Value)
+		}
+	case "sort":
+		if _, ok := querySortTypes[f.Value]; !ok {
+			return util.NewInvalidArgumentErrorf("unknown sort type %q", f.Value)
+		}
+	case "updated":
+		if _, _, err := parseQueryDateRange(f.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseQueryDateRange parses a date range like "2026-01-01", ">2026-01-01", "<=2026-01-01" or "2026-01-01..2026-02-01",
+// the returned bounds are inclusive unix times
+func parseQueryDateRange(value string) (after, before optional.Option[int64], err error) {
+	const day = 24 * time.Hour
+	parseDate := func(s string) (time.Time, error) {
+		t, err := time.ParseInLocation(time.DateOnly, s, setting.DefaultUILocation)
+		if err != nil {
+			return t, util.NewInvalidArgumentErrorf("invalid date %q, the format is YYYY-MM-DD", s)
+		}
+		return t, nil
+	}
+	startOf := func(t time.Time) optional.Option[int64] { return optional.Some(t.Unix()) }
+	endOf := func(t time.Time) optional.Option[int64] { return optional.Some(t.Add(day).Unix() - 1) }
+
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		fromDate, err := parseDate(from)
+		if err != nil {
+			return after, before, err
+		}
+		toDate, err := parseDate(to)
+		if err != nil {
+			return after, before, err
+		}
+		return startOf(fromDate), endOf(toDate), nil
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		s, ok := strings.CutPrefix(value, op)
+		if !ok {
+			continue
+		}
+		date, err := parseDate(s)
+		if err != nil {
+			return after, before, err
+		}
+		switch op {
+		case ">=":
+			return startOf(date), before, nil
+		case "<=":
+			return after, endOf(date), nil
+		case ">":
+			return startOf(date.Add(day)), before, nil
+		default:
+			return after, optional.Some(date.Unix() - 1), nil
+		}
+	}
+
+	date, err := parseDate(value)
+	if err != nil {
+		return after, before, err
+	}
+	return startOf(date), endOf(date), nil
+}
+
+// Apply sets the keyword and the filters of the query to the search options, the filters given by the query replace the existing ones
+// except the type of the issues, which could only be narrowed.
+// The names of the labels and milestones are looked up case-insensitively in the repositories of the options, the doer is the user of "@me".
+func (q *Query) Apply(ctx context.Context, opts *SearchOptions, doer *user_model.User) error {
+	opts.Keyword = q.Keyword
+
+	var includedLabels [][]int64
+	var includedLabelNames []string
+	for _, f := range q.Filters {
+		switch f.Key {
+		case "is":
+			switch f.Value {
+			case "open":
+				opts.IsClosed = optional.Some(f.Negated)
+			case "closed":
+				opts.IsClosed = optional.Some(!f.Negated)
+			case "issue", "pr", "pull":
+				// the type could be limited by the permissions of the doer, so the query can't change it
+				isPull := (f.Value != "issue") != f.Negated
+				if opts.IsPull.Has() && opts.IsPull.Value() != isPull {
+					return util.NewInvalidArgumentErrorf("is:%s can't be used when searching %s", f.Value, util.Iif(opts.IsPull.Value(), "pull requests", "issues"))
+				}
+				opts.IsPull = optional.Some(isPull)
+			case "archived":
+				opts.IsArchived = optional.Some(!f.Negated)
+			}
+		case "no":
+			switch f.Value {
+			case "label":
+				opts.NoLabelOnly = true
+			case "milestone":
+				opts.MilestoneIDs = []int64{0}
+			case "assignee":
+				opts.AssigneeID = "(none)"
+			case "project":
+				opts.ProjectID = optional.Some[int64](0)
+			}
+		case "label":
+			ids, err := issues_model.GetLabelIDsInReposByName(ctx, opts.RepoIDs, f.Value)
+			if err != nil {
+				return err
+			}
+			if f.Negated {
+				opts.ExcludedLabelIDs = append(opts.ExcludedLabelIDs, ids...)
+				continue
+			}
+			if len(ids) == 0 {
+				return util.NewInvalidArgumentErrorf("label %q doesn't exist", f.Value)
+			}
+			includedLabels = append(includedLabels, ids)
+			includedLabelNames = append(includedLabelNames, f.Value)
+		case "milestone":
+			ids, err := issues_model.GetMilestoneIDsInReposByName(ctx, opts.RepoIDs, f.Value)
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				return util.NewInvalidArgumentErrorf("milestone %q doesn't exist", f.Value)
+			}
+			opts.MilestoneIDs = ids
+		case "author", "assignee", "mentions", "review-requested", "reviewed-by":
+			userID, err := queryUserID(ctx, f.Value, doer)
+			if err != nil {
+				return err
+			}
+			switch f.Key {
+			case "author":
+				opts.PosterID = strconv.FormatInt(userID, 10)
+			case "assignee":
+				opts.AssigneeID = strconv.FormatInt(userID, 10)
+			case "mentions":
+				opts.MentionID = optional.Some(userID)
+			case "review-requested":
+				opts.ReviewRequestedID = optional.Some(userID)
+			case "reviewed-by":
+				opts.ReviewedID = optional.Some(userID)
+			}
+		case "updated":
+			after, before, err := parseQueryDateRange(f.Value)
+			if err != nil {
+				return err
+			}
+			if after.Has() {
+				opts.UpdatedAfterUnix = after
+			}
+			if before.Has() {
+				opts.UpdatedBeforeUnix = before
+			}
+		case "sort":
+			opts.SortBy = querySortTypes[f.Value].By
+		}
+	}
+
+	// A label name could match the labels of several repositories (or of a repository and its owner),
+	// the issues having any of them are matched if it is the only included label.
+	if len(includedLabels) == 1 && len(includedLabels[0]) > 1 && len(opts.IncludedLabelIDs) == 0 {
+		opts.IncludedAnyLabelIDs = includedLabels[0]
+		return nil
+	}
+	for i, ids := range includedLabels {
+		if len(ids) > 1 {
+			return util.NewInvalidArgumentErrorf("label %q belongs to several repositories, it can't be combined with other labels", includedLabelNames[i])
+		}
+		opts.IncludedLabelIDs = append(opts.IncludedLabelIDs, ids[0])
+	}
+	return nil
+}
+
+func queryUserID(ctx context.Context, name string, doer *user_model.User) (int64, error) {
+	if name == queryMe {
+		if doer == nil {
+			return 0, util.NewInvalidArgumentErrorf("%s is only available when signed in", queryMe)
+		}
+		return doer.ID, nil
+	}
+	user, err := user_model.GetUserByName(ctx, strings.TrimPrefix(name, "@"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return 0, util.NewInvalidArgumentErrorf("user %q doesn't exist", name)
+		}
+		return 0, err
+	}
+	return user.ID, nil
+}
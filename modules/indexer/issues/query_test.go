@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`  "crash report" foo:bar  `)
+	require.NoError(t, err)
+	assert.Equal(t, `"crash report" foo:bar`, q.Keyword)
+	assert.False(t, q.HasFilters())
+
+	q, err = ParseQuery(`is:open label:"good first issue" -label:wontfix crash author:@me updated:>2026-01-01`)
+	require.NoError(t, err)
+	assert.Equal(t, "crash", q.Keyword)
+	assert.Equal(t, []QueryFilter{
+		{Key: "is", Value: "open"},
+		{Key: "label", Value: "good first issue"},
+		{Key: "label", Value: "wontfix", Negated: true},
+		{Key: "author", Value: "@me"},
+		{Key: "updated", Value: ">2026-01-01"},
+	}, q.Filters)
+
+	for _, invalid := range []string{"is:locked", "no:reviewer", "sort:random", "updated:yesterday", "updated:2026-01-01..", "-author:alice"} {
+		_, err = ParseQuery(invalid)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, invalid)
+	}
+}
+
+func TestParseQueryDateRange(t *testing.T) {
+	defer test.MockVariableValue(&setting.DefaultUILocation, time.UTC)()
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	const dayLength = 24 * 60 * 60
+
+	cases := []struct {
+		value         string
+		after, before optional.Option[int64]
+	}{
+		{"2026-01-01", optional.Some(day), optional.Some(day + dayLength - 1)},
+		{">2026-01-01", optional.Some(day + dayLength), optional.None[int64]()},
+		{">=2026-01-01", optional.Some(day), optional.None[int64]()},
+		{"<2026-01-01", optional.None[int64](), optional.Some(day - 1)},
+		{"<=2026-01-01", optional.None[int64](), optional.Some(day + dayLength - 1)},
+		{"2026-01-01..2026-01-02", optional.Some(day), optional.Some(day + 2*dayLength - 1)},
+	}
+	for _, c := range cases {
+		after, before, err := parseQueryDateRange(c.value)
+		require.NoError(t, err, c.value)
+		assert.Equal(t, c.after, after, c.value)
+		assert.Equal(t, c.before, before, c.value)
+	}
+}
+
+func TestQueryApply(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	q, err := ParseQuery("is:closed is:pr label:label1 -label:label2 milestone:milestone1 author:user1 review-requested:@me sort:comments-asc bug")
+	require.NoError(t, err)
+	opts := &SearchOptions{RepoIDs: []int64{1}}
+	require.NoError(t, q.Apply(t.Context(), opts, user2))
+	assert.Equal(t, "bug", opts.Keyword)
+	assert.Equal(t, optional.Some(true), opts.IsClosed)
+	assert.Equal(t, optional.Some(true), opts.IsPull)
+	assert.Equal(t, []int64{1}, opts.IncludedLabelIDs)
+	assert.Equal(t, []int64{2}, opts.ExcludedLabelIDs)
+	assert.Equal(t, []int64{1}, opts.MilestoneIDs)
+	assert.Equal(t, "1", opts.PosterID)
+	assert.Equal(t, optional.Some[int64](2), opts.ReviewRequestedID)
+	assert.Equal(t, SortByCommentsAsc, opts.SortBy)
+	assert.Equal(t, "leastcomment", q.SortType())
+
+	q, err = ParseQuery("no:label no:milestone no:assignee")
+	require.NoError(t, err)
+	opts = &SearchOptions{RepoIDs: []int64{1}}
+	require.NoError(t, q.Apply(t.Context(), opts, nil))
+	assert.True(t, opts.NoLabelOnly)
+	assert.Equal(t, []int64{0}, opts.MilestoneIDs)
+	assert.Equal(t, "(none)", opts.AssigneeID)
+
+	for _, s := range []string{"label:no-such-label", "milestone:no-such-milestone", "author:no-such-user", "assignee:@me", "is:pr"} {
+		q, err = ParseQuery(s)
+		require.NoError(t, err)
+		err = q.Apply(t.Context(), &SearchOptions{RepoIDs: []int64{1}, IsPull: optional.Some(false)}, nil)
+		assert.True(t, errors.Is(err, util.ErrInvalidArgument), s)
+	}
+}
+
+func TestDBSearchIssuesWithQuery(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	setting.Indexer.IssueType = "db"
+	InitIssueIndexer(true)
+
+	q, err := ParseQuery("label:label1 is:open")
+	require.NoError(t, err)
+	opts := &SearchOptions{RepoIDs: []int64{1}}
+	require.NoError(t, q.Apply(t.Context(), opts, nil))
+	ids, _, err := SearchIssues(t.Context(), opts)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2, 1}, ids)
+}
@@ -34,10 +34,12 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 	}
 
 	comments := make([]string, 0, len(issue.Comments))
+	commentIDs := make([]int64, 0, len(issue.Comments))
 	for _, comment := range issue.Comments {
 		if comment.Content != "" {
 			// what ever the comment type is, index the content if it is not empty.
 			comments = append(comments, comment.Content)
+			commentIDs = append(commentIDs, comment.ID)
 		}
 	}
 
@@ -108,6 +110,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		Title:              issue.Title,
 		Content:            issue.Content,
 		Comments:           comments,
+		CommentIDs:         commentIDs,
 		IsPull:             issue.IsPull,
 		IsClosed:           issue.IsClosed,
 		IsArchived:         issue.Repo.IsArchived,
@@ -0,0 +1,100 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/proxy"
+)
+
+// HTTPProvider gets the embeddings from an OpenAI compatible embeddings endpoint
+type HTTPProvider struct {
+	client *http.Client
+	url    string
+	model  string
+	token  string
+}
+
+var _ Provider = (*HTTPProvider)(nil)
+
+// NewHTTPProvider creates an embedding provider which posts the texts to the url
+func NewHTTPProvider(url, model, token string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{Proxy: proxy.Proxy()},
+		},
+		url:   url,
+		model: model,
+		token: token,
+	}
+}
+
+// Model returns the name of the embedding model
+func (p *HTTPProvider) Model() string {
+	return p.model
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns the vectors of the texts
+func (p *HTTPProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(&embeddingRequest{Model: p.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embedding provider responded %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var result embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode the response of the embedding provider: %w", err)
+	}
+	vectors := make([][]float32, len(texts))
+	for _, data := range result.Data {
+		if data.Index < 0 || data.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding provider responded an invalid index %d", data.Index)
+		}
+		vectors[data.Index] = data.Embedding
+	}
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, fmt.Errorf("embedding provider responded no embedding of the text %d", i)
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	issue_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const (
+	embedBatchSize = 16   // the number of texts embedded by a request to the provider
+	maxTextLength  = 8000 // the maximum number of runes of an embedded text
+)
+
+// Indexer stores the embeddings of the issues in the database and finds the issues by the cosine similarities.
+// It's an addition to the keyword indexers, so it only embeds the titles and the contents of the issues.
+type Indexer struct {
+	provider Provider
+}
+
+// NewIndexer creates a vector indexer with the embedding provider
+func NewIndexer(provider Provider) *Indexer {
+	return &Indexer{provider: provider}
+}
+
+// embeddingText returns the text of an issue to embed
+func embeddingText(title, content string) string {
+	text := []rune(title + "\n\n" + content)
+	if len(text) > maxTextLength {
+		text = text[:maxTextLength]
+	}
+	return string(text)
+}
+
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Index embeds the issues, the issues are skipped if their texts and the model don't change
+func (i *Indexer) Index(ctx context.Context, issues ...*internal.IndexerData) error {
+	model := i.provider.Model()
+	pending := make([]*issue_model.IssueEmbedding, 0, len(issues))
+	texts := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		text := embeddingText(issue.Title, issue.Content)
+		hash := contentHash(text)
+		existing, err := issue_model.GetIssueEmbedding(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Model == model && existing.ContentHash == hash && existing.RepoID == issue.RepoID {
+			continue
+		}
+		pending = append(pending, &issue_model.IssueEmbedding{
+			IssueID:     issue.ID,
+			RepoID:      issue.RepoID,
+			Model:       model,
+			ContentHash: hash,
+		})
+		texts = append(texts, text)
+	}
+
+	for start := 0; start < len(pending); start += embedBatchSize {
+		end := min(start+embedBatchSize, len(pending))
+		vectors, err := i.provider.Embed(ctx, texts[start:end])
+		if err != nil {
+			return fmt.Errorf("embed issues: %w", err)
+		}
+		for j, e := range pending[start:end] {
+			e.Vector = encodeVector(normalize(vectors[j]))
+			if err := issue_model.SaveIssueEmbedding(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Delete deletes the embeddings of the issues
+func (i *Indexer) Delete(ctx context.Context, ids ...int64) error {
+	return issue_model.DeleteIssueEmbeddings(ctx, ids...)
+}
+
+// IsPopulated returns whether any issue has been embedded by the model of the provider
+func (i *Indexer) IsPopulated(ctx context.Context) (bool, error) {
+	return issue_model.HasIssueEmbeddings(ctx, i.provider.Model())
+}
+
+// Search returns at most limit issues of the repositories, and of all the public repositories if allPublic is true,
+// whose similarities with the text are not less than minScore. The issues are sorted by the similarities in descending order.
+// Only the issues of these repositories are ranked, so the issues the doer can't access don't take the places of the others.
+// The embeddings are compared in memory, so only the ones of the newest setting.Indexer.IssueSemanticMaxCandidates issues are.
+func (i *Indexer) Search(ctx context.Context, text string, repoIDs []int64, allPublic bool, limit int, minScore float64) ([]internal.Match, error) {
+	vectors, err := i.provider.Embed(ctx, []string{embeddingText(text, "")})
+	if err != nil {
+		return nil, fmt.Errorf("embed the query: %w", err)
+	}
+	query := normalize(vectors[0])
+
+	var matches []internal.Match
+	err = issue_model.IterateIssueEmbeddings(ctx, i.provider.Model(), repoIDs, allPublic, setting.Indexer.IssueSemanticMaxCandidates, func(ctx context.Context, e *issue_model.IssueEmbedding) error {
+		if score := dot(query, decodeVector(e.Vector)); score >= minScore {
+			matches = append(matches, internal.Match{ID: e.IssueID, Score: score})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(matches, func(a, b internal.Match) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return int(b.ID - a.ID)
+	})
+	return matches[:min(len(matches), limit)], nil
+}
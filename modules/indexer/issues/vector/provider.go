@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Provider embeds texts into vectors, the texts with similar meanings have vectors with high cosine similarities
+type Provider interface {
+	// Model returns the name of the embedding model, the vectors of different models can't be compared
+	Model() string
+	// Embed returns the vectors of the texts in the same order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewProvider creates the embedding provider configured by the settings, it returns nil if the semantic search is disabled
+func NewProvider(name, url, model, token string, timeout time.Duration) (Provider, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "http":
+		if url == "" {
+			return nil, fmt.Errorf("the url of the embedding provider is empty")
+		}
+		return NewHTTPProvider(url, model, token, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider: %s", name)
+	}
+}
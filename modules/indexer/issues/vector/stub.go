@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// StubProvider embeds the texts locally by hashing their words into a fixed number of dimensions,
+// the texts sharing words are similar. It's used by tests instead of a real embedding model.
+type StubProvider struct {
+	Dimensions int
+}
+
+var _ Provider = (*StubProvider)(nil)
+
+// Model returns the name of the stub model
+func (p *StubProvider) Model() string {
+	return "stub"
+}
+
+// Embed returns the vectors of the word counts of the texts
+func (p *StubProvider) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	dimensions := p.Dimensions
+	if dimensions <= 0 {
+		dimensions = 64
+	}
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		v := make([]float32, dimensions)
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(word))
+			v[h.Sum32()%uint32(dimensions)]++
+		}
+		vectors = append(vectors, v)
+	}
+	return vectors, nil
+}
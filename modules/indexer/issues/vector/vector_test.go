@@ -0,0 +1,77 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeVector(t *testing.T) {
+	v := []float32{0.5, -1.25, 3}
+	assert.Equal(t, v, decodeVector(encodeVector(v)))
+
+	n := normalize([]float32{3, 4})
+	assert.InDelta(t, 1, dot(n, n), 1e-6)
+	assert.Equal(t, []float32{0, 0}, normalize([]float32{0, 0}))
+	assert.Zero(t, dot([]float32{1}, []float32{1, 0}))
+}
+
+func TestStubProvider(t *testing.T) {
+	p := &StubProvider{Dimensions: 256}
+	vectors, err := p.Embed(t.Context(), []string{"Crash on startup", "the app crashes on startup!", "add dark theme"})
+	require.NoError(t, err)
+	require.Len(t, vectors, 3)
+	a, b, c := normalize(vectors[0]), normalize(vectors[1]), normalize(vectors[2])
+	assert.Greater(t, dot(a, b), dot(a, c))
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var req embeddingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "test-model", req.Model)
+		if len(req.Input) == 0 {
+			http.Error(w, "no input", http.StatusBadRequest)
+			return
+		}
+		// respond in the reverse order, the provider must sort them by the indexes
+		var resp embeddingResponse
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			}{Index: i, Embedding: []float32{float32(len(req.Input[i])), 1}})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	p, err := NewProvider("http", server.URL, "test-model", "secret", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "test-model", p.Model())
+
+	vectors, err := p.Embed(t.Context(), []string{"a", "bcd"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 1}, {3, 1}}, vectors)
+
+	_, err = p.Embed(t.Context(), nil)
+	assert.ErrorContains(t, err, "400 Bad Request: no input")
+
+	p, err = NewProvider("", "", "", "", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+	_, err = NewProvider("unknown", "", "", "", 0)
+	assert.Error(t, err)
+	_, err = NewProvider("http", "", "", "", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.gitea.io/gitea/modules/indexer/internal"
+)
+
+// Status is the state of an indexer shown to the site administrators
+type Status struct {
+	Name    string // the name of the indexer, e.g. "code"
+	Type    string // the backend of the indexer, e.g. "bleve"
+	Enabled bool
+
+	Available        bool
+	UnavailableError string
+
+	DocCount    int64 // it's negative if the indexer doesn't store documents or if they can't be counted
+	QueueLength int   // it's negative if the indexer doesn't have a queue
+
+	LastError     string
+	LastErrorTime time.Time
+}
+
+// ErrorState keeps the last error of an indexer, it's safe for concurrent use
+type ErrorState struct {
+	mu   sync.Mutex
+	err  string
+	time time.Time
+}
+
+// Record records the error as the last one, nil errors are ignored
+func (s *ErrorState) Record(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err, s.time = err.Error(), time.Now()
+}
+
+// Clear clears the last error once the indexer works again
+func (s *ErrorState) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err, s.time = "", time.Time{}
+}
+
+// Last returns the last error and when it happened, the error is empty if there was none
+func (s *ErrorState) Last() (string, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err, s.time
+}
+
+// NewStatus returns the status of an enabled indexer
+func NewStatus(ctx context.Context, name, typ string, indexer internal.Indexer, queueLength int, errState *ErrorState) *Status {
+	status := &Status{
+		Name:        name,
+		Type:        typ,
+		Enabled:     true,
+		DocCount:    -1,
+		QueueLength: queueLength,
+	}
+	if err := indexer.Ping(ctx); err != nil {
+		status.UnavailableError = err.Error()
+	} else {
+		status.Available = true
+		if count, err := indexer.DocCount(ctx); err == nil {
+			status.DocCount = count
+		}
+	}
+	status.LastError, status.LastErrorTime = errState.Last()
+	return status
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorState(t *testing.T) {
+	var s ErrorState
+	s.Record(nil)
+	msg, at := s.Last()
+	assert.Empty(t, msg)
+	assert.True(t, at.IsZero())
+
+	s.Record(errors.New("unavailable"))
+	msg, at = s.Last()
+	assert.Equal(t, "unavailable", msg)
+	assert.False(t, at.IsZero())
+
+	// the error is cleared once the indexer works again
+	s.Clear()
+	msg, at = s.Last()
+	assert.Empty(t, msg)
+	assert.True(t, at.IsZero())
+}
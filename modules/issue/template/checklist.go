@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/container"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// RequiredChecklist returns the labels of the required checkbox options which are rendered to the content,
+// for pull requests these items have to be checked before merging.
+func RequiredChecklist(template *api.IssueTemplate) []string {
+	var labels []string
+	for _, field := range template.Fields {
+		if field.Type != api.IssueFormFieldTypeCheckboxes || field.ID == "" || !field.VisibleInContent() {
+			continue
+		}
+		f := &valuedField{IssueFormField: field}
+		for _, option := range f.Options() {
+			opt, _ := option.data.(map[string]any)
+			if required, _ := opt["required"].(bool); required && option.VisibleInContent() {
+				labels = append(labels, option.Label())
+			}
+		}
+	}
+	return labels
+}
+
+// MissingRequiredFields returns the labels of the required input, textarea and dropdown fields which have no value
+func MissingRequiredFields(template *api.IssueTemplate, values url.Values) []string {
+	var labels []string
+	for _, field := range template.Fields {
+		if required, _ := field.Validations["required"].(bool); !required || !field.VisibleOnForm() {
+			continue
+		}
+		switch field.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea, api.IssueFormFieldTypeDropdown:
+			f := &valuedField{IssueFormField: field, Values: values}
+			if f.Value() == "" {
+				labels = append(labels, f.Label())
+			}
+		}
+	}
+	return labels
+}
+
+var checklistItemRegexp = regexp.MustCompile(`^\s*[-*+] \[([ xX])\]\s+(.*?)\s*$`)
+
+// UncheckedChecklistItems returns the labels which are not checked in the task list of the content,
+// a label which has been removed from the content is unchecked too.
+func UncheckedChecklistItems(content string, labels []string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	checked := make(container.Set[string])
+	for line := range strings.SplitSeq(content, "\n") {
+		m := checklistItemRegexp.FindStringSubmatch(line)
+		if m != nil && m[1] != " " {
+			checked.Add(m[2])
+		}
+	}
+	var unchecked []string
+	for _, label := range labels {
+		if !checked.Contains(strings.TrimSpace(label)) {
+			unchecked = append(unchecked, label)
+		}
+	}
+	return unchecked
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredChecklist(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(`
+name: Release
+about: Release sign-off
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+  - type: textarea
+    id: notes
+    attributes:
+      label: Notes
+  - type: checkboxes
+    id: signoff
+    attributes:
+      label: Sign-off
+      options:
+        - label: Migration has been reviewed
+          required: true
+        - label: Changelog has been updated
+        - label: Hidden but required
+          required: true
+          visible: [form]
+        - label: Docs have been updated
+          required: true
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Migration has been reviewed", "Docs have been updated"}, RequiredChecklist(template))
+
+	assert.Equal(t, []string{"Version"}, MissingRequiredFields(template, url.Values{"form-field-version": {" "}}))
+	assert.Empty(t, MissingRequiredFields(template, url.Values{"form-field-version": {"1.0"}}))
+
+	content := RenderToMarkdown(template, url.Values{
+		"form-field-version":   {"1.0"},
+		"form-field-signoff-0": {"on"},
+	})
+	assert.Equal(t, []string{"Docs have been updated"}, UncheckedChecklistItems(content, RequiredChecklist(template)))
+	assert.Empty(t, UncheckedChecklistItems(content+"\n* [X] Docs have been updated\n", RequiredChecklist(template)))
+	assert.Equal(t, []string{"Migration has been reviewed"}, UncheckedChecklistItems("- [x] Docs have been updated", RequiredChecklist(template)))
+	assert.Empty(t, UncheckedChecklistItems(content, nil))
+}
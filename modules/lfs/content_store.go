@@ -103,6 +103,12 @@ func (s *ContentStore) Verify(pointer Pointer) (bool, error) {
 	return true, nil
 }
 
+// CheckContent reads the content and checks whether its size and hash match the pointer
+func CheckContent(pointer Pointer, r io.Reader) error {
+	_, err := io.Copy(io.Discard, newHashingReader(pointer.Size, pointer.Oid, r))
+	return err
+}
+
 // ReadMetaObject will read a git_model.LFSMetaObject and return a reader
 func ReadMetaObject(pointer Pointer) (io.ReadSeekCloser, error) {
 	contentStore := NewContentStore()
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
@@ -46,11 +47,13 @@ func newHTTPClient(endpoint *url.URL, httpTransport *http.Transport) *HTTPClient
 	}
 
 	basic := &BasicTransferAdapter{hc}
+	multipart := &MultipartTransferAdapter{BasicTransferAdapter: *basic, retries: 3, retryDelay: time.Second}
 	client := &HTTPClient{
 		client:   hc,
 		endpoint: strings.TrimSuffix(endpoint.String(), "/"),
 		transfers: map[string]TransferAdapter{
-			basic.Name(): basic,
+			basic.Name():     basic,
+			multipart.Name(): multipart,
 		},
 	}
 
@@ -186,22 +189,38 @@ func performSingleOperation(ctx context.Context, object *ObjectResponse, dc Down
 			return nil
 		}
 
-		link, ok := object.Actions["upload"]
-		if !ok {
-			return errors.New("missing action 'upload'")
-		}
+		if object.Multipart != nil {
+			multipart, ok := transferAdapter.(*MultipartTransferAdapter)
+			if !ok {
+				return fmt.Errorf("TransferAdapter %s doesn't support multipart uploads", transferAdapter.Name())
+			}
+			content, err := uc(object.Pointer, nil)
+			if err != nil {
+				return err
+			}
+			err = multipart.UploadParts(ctx, object, content)
+			content.Close()
+			if err != nil {
+				return err
+			}
+		} else {
+			link, ok := object.Actions["upload"]
+			if !ok {
+				return errors.New("missing action 'upload'")
+			}
 
-		content, err := uc(object.Pointer, nil)
-		if err != nil {
-			return err
-		}
+			content, err := uc(object.Pointer, nil)
+			if err != nil {
+				return err
+			}
 
-		err = transferAdapter.Upload(ctx, link, object.Pointer, content)
-		if err != nil {
-			return err
+			err = transferAdapter.Upload(ctx, link, object.Pointer, content)
+			if err != nil {
+				return err
+			}
 		}
 
-		link, ok = object.Actions["verify"]
+		link, ok := object.Actions["verify"]
 		if ok {
 			if err := transferAdapter.Verify(ctx, link, object.Pointer); err != nil {
 				return err
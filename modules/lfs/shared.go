@@ -20,6 +20,9 @@ const (
 	// and the version is consistent with the latest version of git lfs can be avoided incompatibilities.
 	// Some lfs servers will check this
 	UserAgentHeader = "git-lfs/3.6.0 (Gitea)"
+
+	// MultipartUploadDir is the directory of the LFS storage containing the unfinished multipart uploads
+	MultipartUploadDir = "uploads"
 )
 
 // BatchRequest contains multiple requests processed in one batch operation.
@@ -54,9 +57,25 @@ type BatchResponse struct {
 // ObjectResponse is object metadata as seen by clients of the LFS server.
 type ObjectResponse struct {
 	Pointer
-	Actions map[string]*Link `json:"actions,omitempty"`
-	Links   map[string]*Link `json:"_links,omitempty"`
-	Error   *ObjectError     `json:"error,omitempty"`
+	Actions   map[string]*Link `json:"actions,omitempty"`
+	Links     map[string]*Link `json:"_links,omitempty"`
+	Multipart *MultipartUpload `json:"multipart,omitempty"`
+	Error     *ObjectError     `json:"error,omitempty"`
+}
+
+// MultipartUpload describes the parts of an object uploaded with the "multipart" transfer adapter.
+// Each part is sent with a PUT request, then the upload is finished by the "commit" action.
+type MultipartUpload struct {
+	PartSize int64       `json:"part_size"`
+	Parts    []*PartLink `json:"parts"` // the parts which haven't been uploaded yet
+}
+
+// PartLink provides the information about how to upload a part of an object.
+type PartLink struct {
+	Link
+	Number int   `json:"number"` // starts from 1
+	Pos    int64 `json:"pos"`
+	Size   int64 `json:"size"`
 }
 
 // Link provides a structure with information about how to access a object.
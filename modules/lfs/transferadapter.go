@@ -5,9 +5,15 @@ package lfs
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
@@ -87,3 +93,200 @@ func (a *BasicTransferAdapter) Verify(ctx context.Context, l *Link, p Pointer) e
 	defer res.Body.Close()
 	return nil
 }
+
+// MultipartTransferAdapter implements the "multipart" adapter.
+// The objects are uploaded in parts and a failed part is sent again without restarting the whole upload,
+// the interrupted downloads are resumed with range requests.
+type MultipartTransferAdapter struct {
+	BasicTransferAdapter
+	retries    int
+	retryDelay time.Duration
+}
+
+// Name returns the name of the adapter.
+func (a *MultipartTransferAdapter) Name() string {
+	return "multipart"
+}
+
+// Download reads the download location and downloads the data, the download is resumed if the connection breaks.
+func (a *MultipartTransferAdapter) Download(ctx context.Context, l *Link) (io.ReadCloser, error) {
+	rd := &resumingReader{ctx: ctx, adapter: a, link: l}
+	if err := rd.open(); err != nil {
+		return nil, err
+	}
+	return rd, nil
+}
+
+// UploadParts sends the parts of the content which haven't been uploaded yet and commits the upload.
+// Every part is kept in memory until it has been sent, so it could be retried.
+func (a *MultipartTransferAdapter) UploadParts(ctx context.Context, object *ObjectResponse, r io.Reader) error {
+	commit, ok := object.Actions["commit"]
+	if !ok {
+		return errors.New("missing action 'commit'")
+	}
+
+	parts := slices.Clone(object.Multipart.Parts)
+	slices.SortFunc(parts, func(a, b *PartLink) int { return cmp.Compare(a.Pos, b.Pos) })
+
+	var pos int64
+	var buf []byte
+	for _, part := range parts {
+		if part.Pos < pos || part.Size < 0 || part.Pos+part.Size > object.Size {
+			return fmt.Errorf("invalid part %d of %s", part.Number, object.Oid)
+		}
+		// the parts uploaded before are skipped
+		if _, err := io.CopyN(io.Discard, r, part.Pos-pos); err != nil {
+			return err
+		}
+		buf = slices.Grow(buf[:0], int(part.Size))[:part.Size]
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return err
+		}
+		pos = part.Pos + part.Size
+
+		if err := a.retry(ctx, func() error {
+			return a.uploadPart(ctx, part, buf)
+		}); err != nil {
+			return fmt.Errorf("upload part %d of %s: %w", part.Number, object.Oid, err)
+		}
+	}
+
+	return a.commit(ctx, commit, object.Pointer)
+}
+
+// commit commits the upload and waits until the server has verified the object, it answers 202 until then
+func (a *MultipartTransferAdapter) commit(ctx context.Context, l *Link, p Pointer) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	for {
+		verified := false
+		if err := a.retry(ctx, func() error {
+			req, err := createRequest(ctx, http.MethodPost, l.Href, l.Header, bytes.NewReader(b))
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", MediaType)
+			res, err := a.client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer res.Body.Close()
+			switch res.StatusCode {
+			case http.StatusOK:
+				verified = true
+			case http.StatusAccepted:
+			default:
+				return handleErrorResponse(res)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("commit %s: %w", p.Oid, err)
+		}
+		if verified {
+			return nil
+		}
+
+		log.Trace("Waiting for the verification of the LFS object %s", p.Oid)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(a.retryDelay):
+		}
+	}
+}
+
+func (a *MultipartTransferAdapter) uploadPart(ctx context.Context, part *PartLink, content []byte) error {
+	req, err := createRequest(ctx, http.MethodPut, part.Href, part.Header, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	// the presigned urls of the object storages don't accept any other headers
+	req.Header.Del("Accept")
+	req.Header.Del("User-Agent")
+	req.ContentLength = int64(len(content))
+
+	res, err := performRequest(ctx, a.client, req)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// retry calls fn until it succeeds, the context is done or all the retries are used up
+func (a *MultipartTransferAdapter) retry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= a.retries; attempt++ {
+		if attempt > 0 {
+			log.Debug("Retrying the LFS request after error: %v", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * a.retryDelay):
+			}
+		}
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// resumingReader reads a download and requests the rest of it if the connection breaks
+type resumingReader struct {
+	ctx     context.Context
+	adapter *MultipartTransferAdapter
+	link    *Link
+	body    io.ReadCloser
+	offset  int64
+	retries int
+}
+
+func (r *resumingReader) open() error {
+	req, err := createRequest(r.ctx, http.MethodGet, r.link.Href, r.link.Header, nil)
+	if err != nil {
+		return err
+	}
+	if r.offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(r.offset, 10)+"-")
+	}
+	res, err := r.adapter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	switch {
+	case res.StatusCode == http.StatusPartialContent && r.offset > 0:
+	case res.StatusCode == http.StatusOK:
+		// the server doesn't support range requests, the received bytes are skipped
+		if _, err := io.CopyN(io.Discard, res.Body, r.offset); err != nil {
+			res.Body.Close()
+			return err
+		}
+	default:
+		defer res.Body.Close()
+		return handleErrorResponse(res)
+	}
+	r.body = res.Body
+	return nil
+}
+
+func (r *resumingReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err == nil || errors.Is(err, io.EOF) || r.ctx.Err() != nil || r.retries >= r.adapter.retries {
+		return n, err
+	}
+
+	log.Debug("Resuming the LFS download at %d after error: %v", r.offset, err)
+	r.retries++
+	_ = r.body.Close()
+	if err := r.open(); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func (r *resumingReader) Close() error {
+	return r.body.Close()
+}
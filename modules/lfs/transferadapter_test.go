@@ -13,6 +13,7 @@ import (
 	"code.gitea.io/gitea/modules/json"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBasicTransferAdapterName(t *testing.T) {
@@ -168,3 +169,98 @@ func TestBasicTransferAdapter(t *testing.T) {
 		}
 	})
 }
+
+type brokenReader struct {
+	rd io.Reader
+}
+
+func (r *brokenReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	if err == io.EOF {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func TestMultipartTransferAdapter(t *testing.T) {
+	content := "0123456789abcdef"
+	p := Pointer{Oid: "b5a2c96250612366ea272ffac6d9744aaf4b45aacd96aa7cfcb931ee3b558259", Size: int64(len(content))}
+
+	uploaded := map[string]string{}
+	failedParts := map[string]bool{}
+	commits := 0
+	roundTripHandler := func(req *http.Request) *http.Response {
+		url := req.URL.String()
+		switch {
+		case strings.Contains(url, "part-request"):
+			assert.Equal(t, "PUT", req.Method)
+			b, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			// every part fails once
+			if !failedParts[url] {
+				failedParts[url] = true
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(`{"message":"failed"}`))}
+			}
+			uploaded[url] = string(b)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}
+		case strings.Contains(url, "commit-request"):
+			assert.Equal(t, "POST", req.Method)
+			var vp Pointer
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&vp))
+			assert.Equal(t, p, vp)
+			// the object is being verified on the first commit
+			commits++
+			if commits == 1 {
+				return &http.Response{StatusCode: http.StatusAccepted, Body: io.NopCloser(strings.NewReader(`{"message":"verifying"}`))}
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}
+		case strings.Contains(url, "download-request"):
+			rng := req.Header.Get("Range")
+			if rng == "" {
+				// the connection breaks after the first 6 bytes
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(&brokenReader{strings.NewReader(content[:6])})}
+			}
+			assert.Equal(t, "bytes=6-", rng)
+			return &http.Response{StatusCode: http.StatusPartialContent, Body: io.NopCloser(strings.NewReader(content[6:]))}
+		}
+		t.Errorf("Unknown test case: %s", url)
+		return nil
+	}
+
+	hc := &http.Client{Transport: RoundTripFunc(roundTripHandler)}
+	a := &MultipartTransferAdapter{BasicTransferAdapter: BasicTransferAdapter{hc}, retries: 1}
+	assert.Equal(t, "multipart", a.Name())
+
+	t.Run("UploadParts", func(t *testing.T) {
+		object := &ObjectResponse{
+			Pointer: p,
+			Actions: map[string]*Link{"commit": {Href: "https://commit-request.io"}},
+			Multipart: &MultipartUpload{
+				PartSize: 6,
+				// the second part has been uploaded before
+				Parts: []*PartLink{
+					{Link: Link{Href: "https://part-request.io/3"}, Number: 3, Pos: 12, Size: 4},
+					{Link: Link{Href: "https://part-request.io/1"}, Number: 1, Pos: 0, Size: 6},
+				},
+			},
+		}
+		require.NoError(t, a.UploadParts(t.Context(), object, strings.NewReader(content)))
+		assert.Equal(t, map[string]string{
+			"https://part-request.io/1": "012345",
+			"https://part-request.io/3": "cdef",
+		}, uploaded)
+		assert.Equal(t, 2, commits)
+
+		object.Multipart.Parts = append(object.Multipart.Parts, &PartLink{Link: Link{Href: "https://part-request.io/4"}, Number: 4, Pos: 16, Size: 6})
+		assert.ErrorContains(t, a.UploadParts(t.Context(), object, strings.NewReader(content)), "invalid part 4")
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		rd, err := a.Download(t.Context(), &Link{Href: "https://download-request.io"})
+		require.NoError(t, err)
+		defer rd.Close()
+		b, err := io.ReadAll(rd)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	})
+}
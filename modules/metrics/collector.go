@@ -35,6 +35,7 @@ type Collector struct {
 	Mirrors            *prometheus.Desc
 	Oauths             *prometheus.Desc
 	Organizations      *prometheus.Desc
+	PackCacheRequests  *prometheus.Desc
 	Projects           *prometheus.Desc
 	ProjectColumns     *prometheus.Desc
 	PublicKeys         *prometheus.Desc
@@ -141,6 +142,11 @@ func NewCollector() Collector {
 			"Number of Organizations",
 			nil, nil,
 		),
+		PackCacheRequests: prometheus.NewDesc(
+			namespace+"pack_cache_requests",
+			"Number of packs requested by git upload-pack from the pack cache",
+			[]string{"result"}, nil,
+		),
 		Projects: prometheus.NewDesc(
 			namespace+"projects",
 			"Number of projects",
@@ -218,6 +224,7 @@ func (c Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Mirrors
 	ch <- c.Oauths
 	ch <- c.Organizations
+	ch <- c.PackCacheRequests
 	ch <- c.Projects
 	ch <- c.ProjectColumns
 	ch <- c.PublicKeys
@@ -330,6 +337,18 @@ func (c Collector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		float64(stats.Counter.Org),
 	)
+	ch <- prometheus.MustNewConstMetric(
+		c.PackCacheRequests,
+		prometheus.CounterValue,
+		float64(packCacheHits.Load()),
+		"hit", // result label
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.PackCacheRequests,
+		prometheus.CounterValue,
+		float64(packCacheMisses.Load()),
+		"miss", // result label
+	)
 	ch <- prometheus.MustNewConstMetric(
 		c.Projects,
 		prometheus.GaugeValue,
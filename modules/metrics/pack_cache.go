@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import "sync/atomic"
+
+var packCacheHits, packCacheMisses atomic.Int64
+
+// IncPackCacheHits counts a pack served from the pack cache
+func IncPackCacheHits() {
+	packCacheHits.Add(1)
+}
+
+// IncPackCacheMisses counts a pack which had to be generated by git pack-objects
+func IncPackCacheMisses() {
+	packCacheMisses.Add(1)
+}
@@ -6,6 +6,8 @@ package private
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 
 	"code.gitea.io/gitea/modules/git"
@@ -20,6 +22,8 @@ const (
 	GitObjectDirectory              = "GIT_OBJECT_DIRECTORY"
 	GitQuarantinePath               = "GIT_QUARANTINE_PATH"
 	GitPushOptionCount              = "GIT_PUSH_OPTION_COUNT"
+	GitPushCert                     = "GIT_PUSH_CERT"
+	GitPushCertNonceStatus          = "GIT_PUSH_CERT_NONCE_STATUS"
 )
 
 // HookOptions represents the options for the Hook calls
@@ -38,6 +42,8 @@ type HookOptions struct {
 	DeployKeyID                     int64 // if the pusher is a DeployKey, then UserID is the repo's org user.
 	IsWiki                          bool
 	ActionPerm                      int
+	PushCertID                      string // the blob of the push certificate if the push is signed by "git push --signed"
+	PushCertNonceStatus             string
 }
 
 // SSHLogOption ssh log options
@@ -46,6 +52,13 @@ type SSHLogOption struct {
 	Message string
 }
 
+// HookPackObjectsOptions represents the options for the pack-objects hook call
+type HookPackObjectsOptions struct {
+	IsWiki bool
+	Args   []string // the command line passed by git upload-pack, e.g. "git pack-objects --revs --thin --stdout"
+	Input  []byte   // the object list passed to pack-objects by git upload-pack
+}
+
 // HookPostReceiveResult represents an individual result from PostReceive
 type HookPostReceiveResult struct {
 	Results      []HookPostReceiveBranchResult
@@ -111,6 +124,18 @@ func HookProcReceive(ctx context.Context, ownerName, repoName string, opts HookO
 	return requestJSONResp(req, &HookProcReceiveResult{})
 }
 
+// HookPackObjects generates the pack for git upload-pack, the pack may be served from the pack cache
+func HookPackObjects(ctx context.Context, repoID int64, opts HookPackObjectsOptions, out io.Writer) ResponseExtra {
+	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/hook/pack-objects/%d", repoID)
+	req := newInternalRequestAPI(ctx, reqURL, "POST", opts)
+	req.SetReadWriteTimeout(0)
+	callback := func(resp *http.Response, extra *ResponseExtra) {
+		_, extra.Error = io.Copy(out, resp.Body)
+	}
+	_, extra := requestJSONResp(req, &responseCallback{callback})
+	return extra
+}
+
 // SetDefaultBranch will set the default branch to the provided branch for the provided repository
 func SetDefaultBranch(ctx context.Context, ownerName, repoName, branch string) ResponseExtra {
 	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/hook/set-default-branch/%s/%s/%s",
@@ -11,6 +11,7 @@ import (
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	"code.gitea.io/gitea/models/perm"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/setting"
 )
 
@@ -20,9 +21,10 @@ type KeyAndOwner struct {
 	Owner *user_model.User         `json:"user"`
 }
 
-// ServNoCommand returns information about the provided key
-func ServNoCommand(ctx context.Context, keyID int64) (*asymkey_model.PublicKey, *user_model.User, error) {
-	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/serv/none/%d", keyID)
+// ServNoCommand returns information about the provided key,
+// userID is the user authenticated by an SSH certificate of a trusted user CA if keyID is 0
+func ServNoCommand(ctx context.Context, keyID, userID int64) (*asymkey_model.PublicKey, *user_model.User, error) {
+	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/serv/none/%d?user_id=%d", keyID, userID)
 	req := newInternalRequestAPI(ctx, reqURL, "GET")
 	keyAndOwner, extra := requestJSONResp(req, &KeyAndOwner{})
 	if extra.HasError() {
@@ -43,19 +45,32 @@ type ServCommandResults struct {
 	OwnerName   string
 	RepoName    string
 	RepoID      int64
+
+	UploadPackPolicy *git.UploadPackPolicy // the policy of git upload-pack in the repository
 }
 
-// ServCommand preps for a serv call
-func ServCommand(ctx context.Context, keyID int64, ownerName, repoName string, mode perm.AccessMode, verb, lfsVerb string) (*ServCommandResults, ResponseExtra) {
+// ServCommand preps for a serv call,
+// userID is the user authenticated by an SSH certificate of a trusted user CA if keyID is 0
+func ServCommand(ctx context.Context, keyID, userID int64, ownerName, repoName string, mode perm.AccessMode, verb, lfsVerb string) (*ServCommandResults, ResponseExtra) {
 	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/serv/command/%d/%s/%s?mode=%d",
 		keyID,
 		url.PathEscape(ownerName),
 		url.PathEscape(repoName),
 		mode,
 	)
+	reqURL += fmt.Sprintf("&user_id=%d", userID)
 	reqURL += "&verb=" + url.QueryEscape(verb)
 	// reqURL += "&lfs_verb=" + url.QueryEscape(lfsVerb) // TODO: actually there is no use of this parameter. In the future, the URL construction should be more flexible
 	_ = lfsVerb
 	req := newInternalRequestAPI(ctx, reqURL, "GET")
 	return requestJSONResp(req, &ServCommandResults{})
 }
+
+// ServCloneStat counts a clone of the repository, the kind is one of "full", "partial" and "shallow"
+func ServCloneStat(ctx context.Context, repoID int64, kind, firstWant string) error {
+	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/serv/clone-stat/%d/%s", repoID, url.PathEscape(kind))
+	req := newInternalRequestAPI(ctx, reqURL, "POST")
+	req.Param("want", firstWant)
+	_, extra := requestJSONResp(req, &ResponseText{})
+	return extra.Error
+}
@@ -4,13 +4,16 @@
 package repository
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 )
 
 // env keys for git hooks need
@@ -87,3 +90,26 @@ func FullPushingEnvironment(author, committer *user_model.User, repo *repo_model
 
 	return environ
 }
+
+// UploadPackEnvironment returns the environment variables of git upload-pack. They make it generate the packs
+// by the "pack-objects" hook command, so they can be served from the pack cache, and apply the policy of the repository.
+func UploadPackEnvironment(isWiki bool, policy *git.UploadPackPolicy) []string {
+	var config [][2]string
+	if setting.PackCache.Enabled {
+		// uploadpack.packObjectsHook is only respected in protected config, the config from environment variables is one of them
+		config = append(config, [2]string{"uploadpack.packObjectsHook", fmt.Sprintf("%s hook --config=%s pack-objects", util.ShellEscape(setting.AppPath), util.ShellEscape(setting.CustomConf))})
+	}
+	if policy != nil {
+		config = append(config, policy.GitConfig()...)
+	}
+	if len(config) == 0 {
+		return nil
+	}
+
+	environ := make([]string, 0, 2*len(config)+2)
+	environ = append(environ, "GIT_CONFIG_COUNT="+strconv.Itoa(len(config)))
+	for i, kv := range config {
+		environ = append(environ, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, kv[0]), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, kv[1]))
+	}
+	return append(environ, EnvRepoIsWiki+"="+strconv.FormatBool(isWiki))
+}
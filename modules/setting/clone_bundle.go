@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import "fmt"
+
+// CloneBundle represents the settings of the pre-generated git bundles advertised by the bundle-uri capability
+var CloneBundle = struct {
+	Storage *Storage
+
+	Enabled               bool
+	Repos                 []string // full names of the repositories to generate bundles for
+	MinRepoSize           int64    `ini:"-"` // the bundles are generated for the repositories larger than this size, -1 means only the listed repositories
+	MaxIncrementalBundles int      // a full bundle is regenerated once there are more incremental bundles
+}{
+	MinRepoSize:           -1,
+	MaxIncrementalBundles: 10,
+}
+
+func loadCloneBundleFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("clone-bundle")
+	if sec == nil {
+		CloneBundle.Storage, err = getStorage(rootCfg, "clone-bundle", "", nil)
+		return err
+	}
+
+	if err := sec.MapTo(&CloneBundle); err != nil {
+		return fmt.Errorf("failed to map clone bundle settings: %v", err)
+	}
+	if sec.HasKey("MIN_REPO_SIZE") {
+		CloneBundle.MinRepoSize = mustBytes(sec, "MIN_REPO_SIZE")
+	}
+
+	CloneBundle.Storage, err = getStorage(rootCfg, "clone-bundle", "", sec)
+	return err
+}
@@ -31,7 +31,11 @@ var Git = struct {
 	LargeObjectThreshold          int64
 	DisableCoreProtectNTFS        bool
 	DisablePartialClone           bool
+	PartialCloneFilters           []string `delim:","` // the allowed filter kinds of the partial clones, empty means all
+	AnonymousMaxShallowDepth      int      // the anonymous fetches must be shallow with at most this depth, 0 means no limit
 	DiffRenameSimilarityThreshold string
+	EnableSignedPush              bool
+	SignedPushNonceSlop           int
 	Timeout                       struct {
 		Migrate int
 		Mirror  int
@@ -51,7 +55,10 @@ var Git = struct {
 	PullRequestPushMessage:        true,
 	LargeObjectThreshold:          1024 * 1024,
 	DisablePartialClone:           false,
+	PartialCloneFilters:           []string{},
 	DiffRenameSimilarityThreshold: "50%",
+	EnableSignedPush:              true,
+	SignedPushNonceSlop:           300,
 	Timeout: struct {
 		Migrate int
 		Mirror  int
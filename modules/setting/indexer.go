@@ -6,6 +6,7 @@ package setting
 import (
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +22,15 @@ var Indexer = struct {
 	IssueIndexerName string
 	StartupTimeout   time.Duration
 
+	IssueEmbeddingProvider string
+	IssueEmbeddingURL      string
+	IssueEmbeddingModel    string
+	IssueEmbeddingToken    string
+	IssueEmbeddingTimeout  time.Duration
+	IssueSemanticMinScore  float64
+	// IssueSemanticMaxCandidates is the maximum number of the embeddings compared with a query by the semantic search
+	IssueSemanticMaxCandidates int
+
 	RepoIndexerEnabled   bool
 	RepoIndexerRepoTypes []string
 	RepoType             string
@@ -32,6 +42,14 @@ var Indexer = struct {
 	ExcludePatterns      []*GlobMatcher
 	ExcludeVendored      bool
 
+	ContentIndexerEnabled bool
+	ContentType           string
+	ContentPath           string
+	ContentConnStr        string
+	ContentConnAuth       string
+	ContentIndexerName    string
+	ContentMaxCommits     int
+
 	TypeBleveMaxFuzzniess int
 }{
 	IssueType:        "bleve",
@@ -40,6 +58,10 @@ var Indexer = struct {
 	IssueConnAuth:    "",
 	IssueIndexerName: "gitea_issues",
 
+	IssueEmbeddingTimeout:      30 * time.Second,
+	IssueSemanticMinScore:      0.6,
+	IssueSemanticMaxCandidates: 10000,
+
 	RepoIndexerEnabled:   false,
 	RepoIndexerRepoTypes: []string{"sources", "forks", "mirrors", "templates"},
 	RepoType:             "bleve",
@@ -48,6 +70,11 @@ var Indexer = struct {
 	RepoIndexerName:      "gitea_codes",
 	MaxIndexerFileSize:   1024 * 1024,
 	ExcludeVendored:      true,
+
+	ContentType:        "bleve",
+	ContentPath:        "indexers/contents.bleve",
+	ContentIndexerName: "gitea_contents",
+	ContentMaxCommits:  1000,
 }
 
 func loadIndexerFrom(rootCfg ConfigProvider) {
@@ -75,6 +102,24 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 
 	Indexer.IssueIndexerName = sec.Key("ISSUE_INDEXER_NAME").MustString(Indexer.IssueIndexerName)
 
+	Indexer.IssueEmbeddingProvider = sec.Key("ISSUE_EMBEDDING_PROVIDER").MustString("")
+	Indexer.IssueEmbeddingURL = sec.Key("ISSUE_EMBEDDING_URL").MustString("")
+	Indexer.IssueEmbeddingModel = sec.Key("ISSUE_EMBEDDING_MODEL").MustString("")
+	Indexer.IssueEmbeddingToken = sec.Key("ISSUE_EMBEDDING_TOKEN").MustString("")
+	Indexer.IssueEmbeddingTimeout = sec.Key("ISSUE_EMBEDDING_TIMEOUT").MustDuration(Indexer.IssueEmbeddingTimeout)
+	if minScore := sec.Key("ISSUE_SEMANTIC_MIN_SCORE").String(); minScore != "" {
+		if v, err := strconv.ParseFloat(minScore, 64); err != nil {
+			log.Warn("Failed to parse ISSUE_SEMANTIC_MIN_SCORE %q: %v", minScore, err)
+		} else {
+			Indexer.IssueSemanticMinScore = v
+		}
+	}
+	if maxCandidates := sec.Key("ISSUE_SEMANTIC_MAX_CANDIDATES").MustInt(Indexer.IssueSemanticMaxCandidates); maxCandidates > 0 {
+		Indexer.IssueSemanticMaxCandidates = maxCandidates
+	} else {
+		log.Warn("ISSUE_SEMANTIC_MAX_CANDIDATES must be positive, %d is used instead", Indexer.IssueSemanticMaxCandidates)
+	}
+
 	Indexer.RepoIndexerEnabled = sec.Key("REPO_INDEXER_ENABLED").MustBool(false)
 	Indexer.RepoIndexerRepoTypes = strings.Split(sec.Key("REPO_INDEXER_REPO_TYPES").MustString("sources,forks,mirrors,templates"), ",")
 	Indexer.RepoType = sec.Key("REPO_INDEXER_TYPE").MustString("bleve")
@@ -90,6 +135,32 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.ExcludeVendored = sec.Key("REPO_INDEXER_EXCLUDE_VENDORED").MustBool(true)
 	Indexer.MaxIndexerFileSize = sec.Key("MAX_FILE_SIZE").MustInt64(1024 * 1024)
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
+
+	Indexer.ContentIndexerEnabled = sec.Key("CONTENT_INDEXER_ENABLED").MustBool(false)
+	Indexer.ContentType = sec.Key("CONTENT_INDEXER_TYPE").MustString("bleve")
+	if Indexer.ContentType == "bleve" {
+		Indexer.ContentPath = filepath.ToSlash(sec.Key("CONTENT_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/contents.bleve"))))
+		if !filepath.IsAbs(Indexer.ContentPath) {
+			Indexer.ContentPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.ContentPath))
+		}
+		if Indexer.ContentIndexerEnabled {
+			checkOverlappedPath("[indexer].CONTENT_INDEXER_PATH", Indexer.ContentPath)
+		}
+	} else {
+		Indexer.ContentConnStr = sec.Key("CONTENT_INDEXER_CONN_STR").MustString("")
+		if Indexer.ContentType == "meilisearch" {
+			u, err := url.Parse(Indexer.ContentConnStr)
+			if err != nil {
+				log.Warn("Failed to parse CONTENT_INDEXER_CONN_STR: %v", err)
+				u = &url.URL{}
+			}
+			Indexer.ContentConnAuth, _ = u.User.Password()
+			u.User = nil
+			Indexer.ContentConnStr = u.String()
+		}
+	}
+	Indexer.ContentIndexerName = sec.Key("CONTENT_INDEXER_NAME").MustString("gitea_contents")
+	Indexer.ContentMaxCommits = sec.Key("CONTENT_INDEXER_MAX_COMMITS").MustInt(1000)
 	Indexer.TypeBleveMaxFuzzniess = sec.Key("TYPE_BLEVE_MAX_FUZZINESS").MustInt(0)
 }
 
@@ -23,6 +23,9 @@ var LFS = struct {
 	LocksPagingNum int           `ini:"LFS_LOCKS_PAGING_NUM"`
 	MaxBatchSize   int           `ini:"LFS_MAX_BATCH_SIZE"`
 
+	MultipartPartSize     int64         `ini:"LFS_MULTIPART_PART_SIZE"`
+	MultipartUploadExpiry time.Duration `ini:"LFS_MULTIPART_UPLOAD_EXPIRY"`
+
 	Storage *Storage
 }{}
 
@@ -63,6 +66,12 @@ func loadLFSFrom(rootCfg ConfigProvider) error {
 		LFS.LocksPagingNum = 50
 	}
 
+	// the object storages like S3 don't accept the parts smaller than 5 MiB except the last one
+	if LFS.MultipartPartSize < 5<<20 {
+		LFS.MultipartPartSize = 64 << 20
+	}
+	LFS.MultipartUploadExpiry = sec.Key("LFS_MULTIPART_UPLOAD_EXPIRY").MustDuration(72 * time.Hour)
+
 	if LFSClient.BatchSize < 1 {
 		LFSClient.BatchSize = 20
 	}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"time"
+)
+
+// PackCache represents the settings of the cache for the packs generated by git upload-pack
+var PackCache = struct {
+	Storage *Storage
+
+	Enabled      bool
+	TTL          time.Duration `ini:"TTL"`
+	MaxSize      int64         `ini:"-"` // -1 means no limit
+	MaxEntrySize int64         `ini:"-"` // -1 means no limit
+}{
+	TTL:          5 * time.Minute,
+	MaxSize:      10 << 30,
+	MaxEntrySize: 1 << 30,
+}
+
+func loadPackCacheFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("pack-cache")
+	if sec == nil {
+		PackCache.Storage, err = getStorage(rootCfg, "pack-cache", "", nil)
+		return err
+	}
+
+	if err := sec.MapTo(&PackCache); err != nil {
+		return fmt.Errorf("failed to map pack cache settings: %v", err)
+	}
+	if sec.HasKey("MAX_SIZE") {
+		PackCache.MaxSize = mustBytes(sec, "MAX_SIZE")
+	}
+	if sec.HasKey("MAX_ENTRY_SIZE") {
+		PackCache.MaxEntrySize = mustBytes(sec, "MAX_ENTRY_SIZE")
+	}
+
+	PackCache.Storage, err = getStorage(rootCfg, "pack-cache", "", sec)
+	return err
+}
@@ -23,6 +23,7 @@ import (
 	"syscall"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
@@ -52,7 +53,11 @@ import (
 // it mitigates the misuse for most cases, it's still good for us to make sure we don't rely on that mitigation
 // and do not misuse the PublicKeyCallback: we should only use the verified keyID from the verified ssh conn.
 
-const giteaPermissionExtensionKeyID = "gitea-perm-ext-key-id"
+const (
+	giteaPermissionExtensionKeyID = "gitea-perm-ext-key-id"
+	// the user authenticated by a certificate of a trusted user CA registered by the admins, there is no public key
+	giteaPermissionExtensionUserID = "gitea-perm-ext-user-id"
+)
 
 func getExitStatusFromError(err error) int {
 	if err == nil {
@@ -103,13 +108,16 @@ func sessionHandler(session ssh.Session) {
 	// here can't use session.Permissions() because it only uses the value from ctx, which might not be the authenticated one.
 	// so we must use the original ssh conn, which always contains the correct (verified) keyID.
 	sshSession := ptr[sessionPartial](session)
-	keyID := sshSession.conn.Permissions.Extensions[giteaPermissionExtensionKeyID]
+	keyArg := "key-" + sshSession.conn.Permissions.Extensions[giteaPermissionExtensionKeyID]
+	if userID, ok := sshSession.conn.Permissions.Extensions[giteaPermissionExtensionUserID]; ok {
+		keyArg = "user-" + userID
+	}
 
 	command := session.RawCommand()
 
 	log.Trace("SSH: Payload: %v", command)
 
-	args := []string{"--config=" + setting.CustomConf, "serv", "key-" + keyID}
+	args := []string{"--config=" + setting.CustomConf, "serv", keyArg}
 	log.Trace("SSH: Arguments: %v", args)
 
 	ctx, cancel := context.WithCancel(session.Context())
@@ -235,6 +243,15 @@ func publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 			log.Debug("Handle Certificate: %s Fingerprint: %s is a certificate", ctx.RemoteAddr(), gossh.FingerprintSHA256(key))
 		}
 
+		// the certificate authorities registered by the admins map the principals to the users directly
+		ca, err := asymkey_model.GetTrustedUserCAKeyByFingerprint(ctx, gossh.FingerprintSHA256(cert.SignatureKey))
+		if err == nil {
+			return trustedUserCAHandler(ctx, cert, ca)
+		} else if !errors.Is(err, util.ErrNotExist) {
+			log.Error("GetTrustedUserCAKeyByFingerprint: %v", err)
+			return false
+		}
+
 		if len(setting.SSH.TrustedUserCAKeys) == 0 {
 			log.Warn("Certificate Rejected: No trusted certificate authorities for this server")
 			log.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
@@ -294,6 +311,8 @@ func publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 				log.Debug("Successfully authenticated: %s Certificate Fingerprint: %s Principal: %s", ctx.RemoteAddr(), gossh.FingerprintSHA256(key), principal)
 			}
 			setPermExt(pkey.ID)
+			// the source-address critical option is enforced by the ssh server
+			ctx.Permissions().Permissions.CriticalOptions = cert.CriticalOptions
 			return true
 		}
 
@@ -324,6 +343,61 @@ func publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 	return true
 }
 
+// trustedUserCAHandler authenticates a user certificate signed by a certificate authority registered by the admins,
+// the first principal which is mapped to a user is used, and the key ID of the certificate is logged for audit
+func trustedUserCAHandler(ctx ssh.Context, cert *gossh.Certificate, ca *asymkey_model.TrustedUserCAKey) bool {
+	if cert.CertType != gossh.UserCert {
+		log.Warn("Certificate Rejected: Not a user certificate")
+		log.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
+		return false
+	}
+
+	for _, principal := range cert.ValidPrincipals {
+		user, err := ca.GetUserByPrincipal(ctx, principal)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				log.Debug("Principal Rejected: %s Unknown Principal: %s of Certificate Authority: %s", ctx.RemoteAddr(), principal, ca.Name)
+				continue
+			}
+			log.Error("GetUserByPrincipal: %v", err)
+			return false
+		}
+
+		// check the validity window, the critical options and the signature of the certificate
+		c := &gossh.CertChecker{}
+		if err := c.CheckCert(principal, cert); err != nil {
+			log.Warn("Invalid Certificate KeyID %q Serial %d of Certificate Authority %s presented for Principal: %s from %s: %v", cert.KeyId, cert.Serial, ca.Name, principal, ctx.RemoteAddr(), err)
+			log.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
+			return false
+		}
+		if ca.MaxValidity > 0 && (cert.ValidBefore == gossh.CertTimeInfinity || cert.ValidBefore-cert.ValidAfter > uint64(ca.MaxValidity)) {
+			log.Warn("Certificate Rejected: KeyID %q Serial %d of Certificate Authority %s is valid for longer than %d seconds", cert.KeyId, cert.Serial, ca.Name, ca.MaxValidity)
+			log.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
+			return false
+		}
+		if !user.IsActive || user.ProhibitLogin {
+			log.Warn("Certificate Rejected: KeyID %q Serial %d for Principal: %s, the account of %s is disabled", cert.KeyId, cert.Serial, principal, user.Name)
+			log.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
+			return false
+		}
+
+		if err := asymkey_model.UpdateTrustedUserCAKeyLastUsed(ctx, ca.ID); err != nil {
+			log.Error("UpdateTrustedUserCAKeyLastUsed: %v", err)
+		}
+		log.Info("SSH certificate KeyID %q Serial %d of Certificate Authority %s for Principal: %s authenticates %s from %s", cert.KeyId, cert.Serial, ca.Name, principal, user.Name, ctx.RemoteAddr())
+		ctx.Permissions().Permissions.Extensions = map[string]string{
+			giteaPermissionExtensionUserID: strconv.FormatInt(user.ID, 10),
+		}
+		// the source-address critical option is enforced by the ssh server
+		ctx.Permissions().Permissions.CriticalOptions = cert.CriticalOptions
+		return true
+	}
+
+	log.Warn("From %s Certificate KeyID %q Serial %d of Certificate Authority %s has no principal of a user", ctx.RemoteAddr(), cert.KeyId, cert.Serial, ca.Name)
+	log.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
+	return false
+}
+
 // sshConnectionFailed logs a failed connection
 // -  this mainly exists to give a nice function name in logging
 func sshConnectionFailed(conn net.Conn, err error) {
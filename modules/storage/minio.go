@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,7 +25,8 @@ import (
 )
 
 var (
-	_ ObjectStorage = &MinioStorage{}
+	_ ObjectStorage    = &MinioStorage{}
+	_ MultipartStorage = &MinioStorage{}
 
 	quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
 )
@@ -320,6 +322,68 @@ func (m *MinioStorage) URL(storePath, name, method string, serveDirectReqParams
 	return u, convertMinioErr(err)
 }
 
+// NewMultipartUpload starts a multipart upload of an object
+func (m *MinioStorage) NewMultipartUpload(path string) (string, error) {
+	core := minio.Core{Client: m.client}
+	uploadID, err := core.NewMultipartUpload(m.ctx, m.bucket, m.buildMinioPath(path), minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	return uploadID, convertMinioErr(err)
+}
+
+// MultipartUploadPartURL returns the presigned url uploading a part of a multipart upload
+func (m *MinioStorage) MultipartUploadPartURL(path, uploadID string, partNumber int, expires time.Duration) (*url.URL, error) {
+	reqParams := url.Values{}
+	reqParams.Set("partNumber", strconv.Itoa(partNumber))
+	reqParams.Set("uploadId", uploadID)
+	u, err := m.client.Presign(m.ctx, http.MethodPut, m.bucket, m.buildMinioPath(path), expires, reqParams)
+	return u, convertMinioErr(err)
+}
+
+// ListMultipartUploadParts returns the parts uploaded in a multipart upload
+func (m *MinioStorage) ListMultipartUploadParts(path, uploadID string) ([]MultipartUploadPart, error) {
+	core := minio.Core{Client: m.client}
+	var parts []MultipartUploadPart
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(m.ctx, m.bucket, m.buildMinioPath(path), uploadID, marker, 1000)
+		if err != nil {
+			return nil, convertMinioErr(err)
+		}
+		for _, part := range result.ObjectParts {
+			parts = append(parts, MultipartUploadPart{Number: part.PartNumber, Size: part.Size, ETag: part.ETag})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteMultipartUpload concatenates the uploaded parts into the object
+func (m *MinioStorage) CompleteMultipartUpload(path, uploadID string, parts []MultipartUploadPart) error {
+	core := minio.Core{Client: m.client}
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: part.Number, ETag: part.ETag})
+	}
+	_, err := core.CompleteMultipartUpload(m.ctx, m.bucket, m.buildMinioPath(path), uploadID, completeParts, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	return convertMinioErr(err)
+}
+
+// AbortMultipartUpload aborts a multipart upload and deletes the uploaded parts
+func (m *MinioStorage) AbortMultipartUpload(path, uploadID string) error {
+	core := minio.Core{Client: m.client}
+	return convertMinioErr(core.AbortMultipartUpload(m.ctx, m.bucket, m.buildMinioPath(path), uploadID))
+}
+
+// CopyObject copies an object on the server side, the objects larger than 5 GiB are copied in parts
+func (m *MinioStorage) CopyObject(dstPath, srcPath string) error {
+	_, err := m.client.ComposeObject(m.ctx,
+		minio.CopyDestOptions{Bucket: m.bucket, Object: m.buildMinioPath(dstPath)},
+		minio.CopySrcOptions{Bucket: m.bucket, Object: m.buildMinioPath(srcPath)},
+	)
+	return convertMinioErr(err)
+}
+
 // IterateObjects iterates across the objects in the miniostorage
 func (m *MinioStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
 	opts := minio.GetObjectOptions{}
@@ -4,16 +4,19 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/modules/setting"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMinioStorageIterator(t *testing.T) {
@@ -94,6 +97,56 @@ func TestS3StorageBadRequest(t *testing.T) {
 	assert.ErrorContains(t, err, message)
 }
 
+func TestMinioStorageMultipartUpload(t *testing.T) {
+	if os.Getenv("CI") == "" {
+		t.Skip("minioStorage not present outside of CI")
+		return
+	}
+	s, err := NewStorage(setting.MinioStorageType, &setting.Storage{
+		MinioConfig: setting.MinioStorageConfig{
+			Endpoint:        "minio:9000",
+			AccessKeyID:     "123456",
+			SecretAccessKey: "12345678",
+			Bucket:          "gitea",
+			Location:        "us-east-1",
+		},
+	})
+	require.NoError(t, err)
+	ms := s.(MultipartStorage)
+
+	uploadID, err := ms.NewMultipartUpload("multipart/upload")
+	require.NoError(t, err)
+	// all the parts except the last one must be at least 5 MiB
+	contents := [][]byte{bytes.Repeat([]byte("a"), 5<<20), []byte("b")}
+	for i, content := range contents {
+		u, err := ms.MultipartUploadPartURL("multipart/upload", uploadID, i+1, time.Minute)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(content))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	parts, err := ms.ListMultipartUploadParts("multipart/upload", uploadID)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.EqualValues(t, 1, parts[1].Size)
+	require.NoError(t, ms.CompleteMultipartUpload("multipart/upload", uploadID, parts))
+	require.NoError(t, ms.CopyObject("multipart/object", "multipart/upload"))
+
+	fi, err := ms.Stat("multipart/object")
+	require.NoError(t, err)
+	assert.EqualValues(t, 5<<20+1, fi.Size())
+	assert.NoError(t, ms.Delete("multipart/upload"))
+	assert.NoError(t, ms.Delete("multipart/object"))
+
+	uploadID, err = ms.NewMultipartUpload("multipart/aborted")
+	require.NoError(t, err)
+	assert.NoError(t, ms.AbortMultipartUpload("multipart/aborted", uploadID))
+}
+
 func TestMinioCredentials(t *testing.T) {
 	const (
 		ExpectedAccessKey       = "ExampleAccessKeyID"
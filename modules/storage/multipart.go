@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"net/url"
+	"time"
+)
+
+// MultipartUploadPart represents a part of an object uploaded in a multipart upload
+type MultipartUploadPart struct {
+	Number int // starts from 1
+	Size   int64
+	ETag   string
+}
+
+// MultipartStorage is implemented by the object storages whose clients could upload the parts of an object directly.
+// The parts of a multipart upload are concatenated into the object when the upload is completed.
+type MultipartStorage interface {
+	ObjectStorage
+
+	NewMultipartUpload(path string) (uploadID string, err error)
+	// MultipartUploadPartURL returns the presigned url uploading a part with an HTTP PUT request
+	MultipartUploadPartURL(path, uploadID string, partNumber int, expires time.Duration) (*url.URL, error)
+	ListMultipartUploadParts(path, uploadID string) ([]MultipartUploadPart, error)
+	CompleteMultipartUpload(path, uploadID string, parts []MultipartUploadPart) error
+	AbortMultipartUpload(path, uploadID string) error
+
+	// CopyObject copies an object inside the storage without downloading it
+	CopyObject(dstPath, srcPath string) error
+}
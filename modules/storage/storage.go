@@ -133,6 +133,12 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+
+	// PackCache represents the storage of the packs cached for git upload-pack
+	PackCache ObjectStorage = uninitializedStorage
+
+	// CloneBundles represents the storage of the pre-generated git bundles advertised by the bundle-uri capability
+	CloneBundles ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -145,6 +151,8 @@ func Init() error {
 		initRepoArchives,
 		initPackages,
 		initActions,
+		initPackCache,
+		initCloneBundles,
 	} {
 		if err := f(); err != nil {
 			return err
@@ -228,3 +236,23 @@ func initActions() (err error) {
 	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
 	return err
 }
+
+func initPackCache() (err error) {
+	if !setting.PackCache.Enabled {
+		PackCache = discardStorage("PackCache isn't enabled")
+		return nil
+	}
+	log.Info("Initialising PackCache storage with type: %s", setting.PackCache.Storage.Type)
+	PackCache, err = NewStorage(setting.PackCache.Storage.Type, setting.PackCache.Storage)
+	return err
+}
+
+func initCloneBundles() (err error) {
+	if !setting.CloneBundle.Enabled {
+		CloneBundles = discardStorage("CloneBundles isn't enabled")
+		return nil
+	}
+	log.Info("Initialising CloneBundles storage with type: %s", setting.CloneBundle.Storage.Type)
+	CloneBundles, err = NewStorage(setting.CloneBundle.Storage.Type, setting.CloneBundle.Storage)
+	return err
+}
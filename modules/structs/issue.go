@@ -82,6 +82,27 @@ type Issue struct {
 	Repo        *RepositoryMeta  `json:"repository"`
 
 	PinOrder int `json:"pin_order"`
+
+	// Highlight is the fragment of the issue matching the keyword, it's only returned by the searches with a keyword
+	Highlight *IssueSearchHighlight `json:"highlight,omitempty"`
+}
+
+// IssueSearchHighlight is a fragment of the body or a comment of an issue matching the keyword of a search
+type IssueSearchHighlight struct {
+	// the comment containing the fragment, it's 0 if the fragment is in the body of the issue
+	CommentID int64 `json:"comment_id"`
+	// the plain text of the fragment
+	Fragment string `json:"fragment"`
+	// the ranges of the fragment matching the keyword
+	Matches []*IssueSearchHighlightMatch `json:"matches"`
+	// the link to the comment or the issue
+	HTMLURL string `json:"html_url"`
+}
+
+// IssueSearchHighlightMatch is a range of a fragment matching the keyword of a search, the offsets count characters
+type IssueSearchHighlightMatch struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
 }
 
 // CreateIssueOption options to create one issue
@@ -3,6 +3,8 @@
 
 package structs
 
+import "time"
+
 // SearchResults results of a successful search
 type SearchResults struct {
 	// OK indicates if the search was successful
@@ -19,6 +21,32 @@ type SearchError struct {
 	Error string `json:"error"`
 }
 
+// ContentSearchResult a wiki page, a release or a commit message matching a search
+type ContentSearchResult struct {
+	// Kind is the kind of the content: "wiki", "release" or "commit"
+	Kind       string          `json:"kind"`
+	Repository *RepositoryMeta `json:"repository"`
+	// Ref is the git path of the wiki page, the tag name of the release or the SHA of the commit
+	Ref     string `json:"ref"`
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+	HTMLURL string `json:"html_url"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// FileSearchResult a file whose path matches a search
+type FileSearchResult struct {
+	Repository *RepositoryMeta `json:"repository"`
+	Path       string          `json:"path"`
+	Language   string          `json:"language"`
+	// CommitID is the SHA of the last indexed commit containing this version of the file
+	CommitID string `json:"commit_id"`
+	// Refs are the branches and the tags containing this version of the file
+	Refs    []string `json:"refs"`
+	HTMLURL string   `json:"html_url"`
+}
+
 // MarkupOption markup options
 type MarkupOption struct {
 	// Text markup to render
@@ -67,6 +67,10 @@ type PullReviewComment struct {
 	DiffHunk     string `json:"diff_hunk"`
 	LineNum      uint64 `json:"position"`
 	OldLineNum   uint64 `json:"original_position"`
+	// the first new file line of a multi-line comment, it's 0 for a single line comment
+	StartLineNum uint64 `json:"start_position"`
+	// the first old file line of a multi-line comment, it's 0 for a single line comment
+	OldStartLineNum uint64 `json:"original_start_position"`
 
 	HTMLURL     string `json:"html_url"`
 	HTMLPullURL string `json:"pull_request_url"`
@@ -89,6 +93,10 @@ type CreatePullReviewComment struct {
 	OldLineNum int64 `json:"old_position"`
 	// if comment to new file line or 0
 	NewLineNum int64 `json:"new_position"`
+	// the first old file line of a multi-line comment on old file lines, or 0
+	OldStartLineNum int64 `json:"old_start_position"`
+	// the first new file line of a multi-line comment on new file lines, or 0
+	NewStartLineNum int64 `json:"new_start_position"`
 }
 
 // SubmitPullReviewOptions are options to submit a pending pull request review
@@ -5,8 +5,25 @@ package structs
 
 // Note contains information related to a git note
 type Note struct {
+	// The notes ref that the note belongs to
+	Ref string `json:"ref"`
 	// The content message of the git note
 	Message string `json:"message"`
 	// The commit that this note is attached to
 	Commit *Commit `json:"commit"`
 }
+
+// NotesRef represents a git notes namespace
+type NotesRef struct {
+	// The short name of the namespace, e.g. "commits"
+	Name string `json:"name"`
+	// The full ref name, e.g. "refs/notes/commits"
+	Ref string `json:"ref"`
+}
+
+// CreateNoteOption options for creating or updating a git note
+type CreateNoteOption struct {
+	// The content message of the git note
+	// required: true
+	Message string `json:"message" binding:"Required"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// PushCertificate represents a push certificate received from "git push --signed",
+// it records who requested which ref updates
+type PushCertificate struct {
+	ID int64 `json:"id"`
+	// The user who pushed
+	Pusher *User `json:"pusher"`
+	// The identity of the signing key and the time of the push, as written in the certificate
+	Signer string `json:"signer"`
+	// The nonce issued by the server to prevent replaying the certificate
+	Nonce string `json:"nonce"`
+	// The nonce status reported by git, e.g. "OK", "BAD" or "SLOP"
+	NonceStatus string `json:"nonce_status"`
+	// Whether the certificate is signed by a verified key of the pusher
+	Verified bool `json:"verified"`
+	// The signer and the key if verified, otherwise why the certificate couldn't be verified
+	Reason string `json:"reason"`
+	// The GPG key ID or the SSH key fingerprint which signed the certificate
+	SigningKey string                   `json:"signing_key"`
+	Updates    []*PushCertificateUpdate `json:"updates"`
+	// The raw certificate, including the signature
+	Certificate string `json:"certificate"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
+
+// PushCertificateUpdate represents a ref update listed in a push certificate
+type PushCertificateUpdate struct {
+	Ref    string `json:"ref"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
@@ -1160,6 +1160,8 @@ func Routes() *web.Router {
 		// Repos (requires repo scope)
 		m.Group("/repos", func() {
 			m.Get("/search", repo.Search)
+			m.Get("/search/files", repo.SearchFiles)
+			m.Get("/search/{type:wiki|releases|commits}", repo.SearchContent)
 
 			// (repo scope)
 			m.Post("/migrate", reqToken(), bind(api.MigrateRepoOptions{}), repo.Migrate)
@@ -1343,6 +1345,7 @@ func Routes() *web.Router {
 						Delete(mustNotBeArchived, repo.DeletePushMirrorByRemoteName).
 						Get(repo.GetPushMirrorByName)
 				}, reqAdmin(), reqToken())
+				m.Get("/push_certificates", reqToken(), reqAdmin(), repo.ListPushCertificates)
 
 				m.Get("/editorconfig/{filename}", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.GetEditorconfig)
 				m.Group("/pulls", func() {
@@ -1406,7 +1409,12 @@ func Routes() *web.Router {
 					m.Get("/trees/{sha}", repo.GetTree)
 					m.Get("/blobs/{sha}", repo.GetBlob)
 					m.Get("/tags/{sha}", repo.GetAnnotatedTag)
+					m.Get("/notes", repo.ListNotesRefs)
 					m.Get("/notes/{sha}", repo.GetNote)
+					m.Group("/notes/{sha}", func() {
+						m.Put("", bind(api.CreateNoteOption{}), repo.SetNote)
+						m.Delete("", repo.DeleteNote)
+					}, reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived)
 				}, context.ReferencesGitRepo(true), reqRepoReader(unit.TypeCode))
 				m.Post("/diffpatch", mustEnableEditor, reqToken(), bind(api.ApplyDiffPatchFileOptions{}), repo.ReqChangeRepoFileOptionsAndCheck, repo.ApplyDiffPatch)
 				m.Group("/contents", func() {
@@ -752,29 +752,31 @@ func CreateBranchProtection(ctx *context.APIContext) {
 	}
 
 	protectBranch = &git_model.ProtectedBranch{
-		RepoID:                        ctx.Repo.Repository.ID,
-		RuleName:                      ruleName,
-		Priority:                      form.Priority,
-		CanPush:                       form.EnablePush,
-		EnableWhitelist:               form.EnablePush && form.EnablePushWhitelist,
-		WhitelistDeployKeys:           form.EnablePush && form.EnablePushWhitelist && form.PushWhitelistDeployKeys,
-		CanForcePush:                  form.EnablePush && form.EnableForcePush,
-		EnableForcePushAllowlist:      form.EnablePush && form.EnableForcePush && form.EnableForcePushAllowlist,
-		ForcePushAllowlistDeployKeys:  form.EnablePush && form.EnableForcePush && form.EnableForcePushAllowlist && form.ForcePushAllowlistDeployKeys,
-		EnableMergeWhitelist:          form.EnableMergeWhitelist,
-		EnableStatusCheck:             form.EnableStatusCheck,
-		StatusCheckContexts:           form.StatusCheckContexts,
-		EnableApprovalsWhitelist:      form.EnableApprovalsWhitelist,
-		RequiredApprovals:             requiredApprovals,
-		BlockOnRejectedReviews:        form.BlockOnRejectedReviews,
-		BlockOnOfficialReviewRequests: form.BlockOnOfficialReviewRequests,
-		DismissStaleApprovals:         form.DismissStaleApprovals,
-		IgnoreStaleApprovals:          form.IgnoreStaleApprovals,
-		RequireSignedCommits:          form.RequireSignedCommits,
-		ProtectedFilePatterns:         form.ProtectedFilePatterns,
-		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
-		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
-		BlockAdminMergeOverride:       form.BlockAdminMergeOverride,
+		RepoID:                         ctx.Repo.Repository.ID,
+		RuleName:                       ruleName,
+		Priority:                       form.Priority,
+		CanPush:                        form.EnablePush,
+		EnableWhitelist:                form.EnablePush && form.EnablePushWhitelist,
+		WhitelistDeployKeys:            form.EnablePush && form.EnablePushWhitelist && form.PushWhitelistDeployKeys,
+		CanForcePush:                   form.EnablePush && form.EnableForcePush,
+		EnableForcePushAllowlist:       form.EnablePush && form.EnableForcePush && form.EnableForcePushAllowlist,
+		ForcePushAllowlistDeployKeys:   form.EnablePush && form.EnableForcePush && form.EnableForcePushAllowlist && form.ForcePushAllowlistDeployKeys,
+		EnableMergeWhitelist:           form.EnableMergeWhitelist,
+		EnableStatusCheck:              form.EnableStatusCheck,
+		StatusCheckContexts:            form.StatusCheckContexts,
+		EnableApprovalsWhitelist:       form.EnableApprovalsWhitelist,
+		RequiredApprovals:              requiredApprovals,
+		BlockOnRejectedReviews:         form.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests:  form.BlockOnOfficialReviewRequests,
+		DismissStaleApprovals:          form.DismissStaleApprovals,
+		IgnoreStaleApprovals:           form.IgnoreStaleApprovals,
+		RequireSignedCommits:           form.RequireSignedCommits,
+		RequireSignedPushes:            form.RequireSignedPushes,
+		ProtectedFilePatterns:          form.ProtectedFilePatterns,
+		UnprotectedFilePatterns:        form.UnprotectedFilePatterns,
+		BlockOnOutdatedBranch:          form.BlockOnOutdatedBranch,
+		BlockOnUnresolvedConversations: form.BlockOnUnresolvedConversations,
+		BlockAdminMergeOverride:        form.BlockAdminMergeOverride,
 	}
 
 	if err := pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
@@ -942,6 +944,10 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.RequireSignedCommits = *form.RequireSignedCommits
 	}
 
+	if form.RequireSignedPushes != nil {
+		protectBranch.RequireSignedPushes = *form.RequireSignedPushes
+	}
+
 	if form.ProtectedFilePatterns != nil {
 		protectBranch.ProtectedFilePatterns = *form.ProtectedFilePatterns
 	}
@@ -954,6 +960,10 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.BlockOnOutdatedBranch = *form.BlockOnOutdatedBranch
 	}
 
+	if form.BlockOnUnresolvedConversations != nil {
+		protectBranch.BlockOnUnresolvedConversations = *form.BlockOnUnresolvedConversations
+	}
+
 	if form.BlockAdminMergeOverride != nil {
 		protectBranch.BlockAdminMergeOverride = *form.BlockAdminMergeOverride
 	}
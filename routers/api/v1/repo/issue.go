@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
@@ -111,7 +112,7 @@ func SearchIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: Search string
+	//   description: Search string, it could contain qualifiers like "is:open label:bug -label:wontfix author:@me updated:>2026-01-01"
 	//   type: string
 	// - name: type
 	//   in: query
@@ -161,6 +162,12 @@ func SearchIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Filter by team (requires organization owner parameter)
 	//   type: string
+	// - name: ranking
+	//   in: query
+	//   description: How the issues matching the search string are ranked, "hybrid" ranks them by both the keywords and the meanings if the semantic search is enabled
+	//   type: string
+	//   enum: [keyword, hybrid]
+	//   default: keyword
 	// - name: page
 	//   in: query
 	//   description: Page number of results to return (1-based)
@@ -249,6 +256,9 @@ func SearchIssues(ctx *context.APIContext) {
 		MilestoneIDs:        includedMilestones,
 		SortBy:              issue_indexer.SortByCreatedDesc,
 	}
+	if ctx.FormString("ranking") == string(issue_indexer.RankingHybrid) {
+		searchOpt.Ranking = issue_indexer.RankingHybrid
+	}
 
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
@@ -276,6 +286,10 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	if !applyIssueSearchQuery(ctx, searchOpt) {
+		return
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -289,7 +303,7 @@ func SearchIssues(ctx *context.APIContext) {
 
 	ctx.SetLinkHeader(int(total), limit)
 	ctx.SetTotalCountHeader(total)
-	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+	ctx.JSON(http.StatusOK, toAPISearchedIssues(ctx, searchOpt, ids, issues))
 }
 
 // ListIssues list the issues of a repository
@@ -321,7 +335,7 @@ func ListIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: search string
+	//   description: search string, it could contain qualifiers like "is:open label:bug -label:wontfix author:@me updated:>2026-01-01"
 	//   type: string
 	// - name: type
 	//   in: query
@@ -369,6 +383,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.APIError(http.StatusUnprocessableEntity, err)
@@ -504,6 +520,10 @@ func ListIssues(ctx *context.APIContext) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
+	if !applyIssueSearchQuery(ctx, searchOpt) {
+		return
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -517,7 +537,7 @@ func ListIssues(ctx *context.APIContext) {
 
 	ctx.SetLinkHeader(int(total), listOptions.PageSize)
 	ctx.SetTotalCountHeader(total)
-	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+	ctx.JSON(http.StatusOK, toAPISearchedIssues(ctx, searchOpt, ids, issues))
 }
 
 func getUserIDForFilter(ctx *context.APIContext, queryName string) int64 {
@@ -1028,3 +1048,36 @@ func closeOrReopenIssue(ctx *context.APIContext, issue *issues_model.Issue, stat
 		}
 	}
 }
+
+// toAPISearchedIssues converts the issues found by a search to API format, with the fragments matching the keyword
+func toAPISearchedIssues(ctx *context.APIContext, opts *issue_indexer.SearchOptions, ids []int64, issues issues_model.IssueList) []*api.Issue {
+	apiIssues := convert.ToAPIIssueList(ctx, ctx.Doer, issues)
+	// the fragments are only a hint of why the issues match, so the issues are still returned without them
+	highlights, err := issue_indexer.HighlightIssues(ctx, opts, ids)
+	if err != nil {
+		log.Warn("HighlightIssues: %v", err)
+		return apiIssues
+	}
+	for i, issue := range issues {
+		if highlight, ok := highlights[issue.ID]; ok {
+			apiIssues[i].Highlight = convert.ToAPIIssueSearchHighlight(ctx, issue, highlight)
+		}
+	}
+	return apiIssues
+}
+
+// applyIssueSearchQuery applies the qualifiers in the keyword of the search options, it writes the error response if it fails
+func applyIssueSearchQuery(ctx *context.APIContext, opts *issue_indexer.SearchOptions) bool {
+	query, err := issue_indexer.ParseQuery(opts.Keyword)
+	if err == nil {
+		err = query.Apply(ctx, opts, ctx.Doer)
+	}
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return false
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	return true
+}
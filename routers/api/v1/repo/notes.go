@@ -8,11 +8,52 @@ import (
 	"net/http"
 
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
+	mirror_service "code.gitea.io/gitea/services/mirror"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
+// ListNotesRefs List the git notes namespaces of a repository
+func ListNotesRefs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/notes repository repoListNotesRefs
+	// ---
+	// summary: List the git notes namespaces of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/NotesRefList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	refs, err := gitrepo.ListNotesRefs(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiRefs := make([]*api.NotesRef, 0, len(refs))
+	for _, ref := range refs {
+		apiRefs = append(apiRefs, &api.NotesRef{Name: git.NotesNamespace(ref), Ref: ref})
+	}
+	ctx.JSON(http.StatusOK, apiRefs)
+}
+
 // GetNote Get a note corresponding to a single commit from a repository
 func GetNote(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/git/notes/{sha} repository repoGetNote
@@ -36,6 +77,10 @@ func GetNote(ctx *context.APIContext) {
 	//   description: a git ref or commit sha
 	//   type: string
 	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: the notes namespace, a short name or a full ref name, default to "commits"
+	//   type: string
 	// - name: verification
 	//   in: query
 	//   description: include verification for every commit (disable for speedup, default 'true')
@@ -52,34 +97,165 @@ func GetNote(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	sha := ctx.PathParam("sha")
-	if !git.IsValidRefPattern(sha) {
-		ctx.APIError(http.StatusUnprocessableEntity, "no valid ref or sha: "+sha)
+	notesRef, commitID := prepareNote(ctx, ctx.FormString("ref"))
+	if ctx.Written() {
+		return
+	}
+	getNote(ctx, notesRef, commitID)
+}
+
+// SetNote Create or update the note of a commit
+func SetNote(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/git/notes/{sha} repository repoSetNote
+	// ---
+	// summary: Create or update the note of a commit
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: the notes namespace, a short name or a full ref name, default to "commits"
+	//   type: string
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateNoteOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Note"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.CreateNoteOption)
+	notesRef, commitID := prepareNote(ctx, ctx.FormString("ref"))
+	if ctx.Written() {
+		return
+	}
+	if err := repo_service.SetCommitNote(ctx, ctx.Doer, ctx.Repo.Repository, notesRef, commitID, form.Message); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	mirror_service.SyncPushMirrorWithSyncOnCommit(ctx, ctx.Repo.Repository.ID)
+	getNote(ctx, notesRef, commitID)
+}
+
+// DeleteNote Delete the note of a commit
+func DeleteNote(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/git/notes/{sha} repository repoDeleteNote
+	// ---
+	// summary: Delete the note of a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: the notes namespace, a short name or a full ref name, default to "commits"
+	//   type: string
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	notesRef, commitID := prepareNote(ctx, ctx.FormString("ref"))
+	if ctx.Written() {
 		return
 	}
-	getNote(ctx, sha)
+	if err := repo_service.DeleteCommitNote(ctx, ctx.Doer, ctx.Repo.Repository, notesRef, commitID); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	mirror_service.SyncPushMirrorWithSyncOnCommit(ctx, ctx.Repo.Repository.ID)
+	ctx.Status(http.StatusNoContent)
 }
 
-func getNote(ctx *context.APIContext, identifier string) {
+// prepareNote returns the notes ref of the namespace and the commit ID of the "sha" path parameter
+func prepareNote(ctx *context.APIContext, namespace string) (notesRef, commitID string) {
 	if ctx.Repo.GitRepo == nil {
 		ctx.APIErrorInternal(errors.New("no open git repo"))
-		return
+		return "", ""
+	}
+
+	notesRef, ok := git.NotesRefName(namespace)
+	if !ok {
+		ctx.APIError(http.StatusUnprocessableEntity, "no valid notes ref: "+namespace)
+		return "", ""
 	}
 
-	commitID, err := ctx.Repo.GitRepo.ConvertToGitID(identifier)
+	sha := ctx.PathParam("sha")
+	if !git.IsValidRefPattern(sha) {
+		ctx.APIError(http.StatusUnprocessableEntity, "no valid ref or sha: "+sha)
+		return "", ""
+	}
+	id, err := ctx.Repo.GitRepo.ConvertToGitID(sha)
 	if err != nil {
 		if git.IsErrNotExist(err) {
 			ctx.APIErrorNotFound(err)
 		} else {
 			ctx.APIErrorInternal(err)
 		}
-		return
+		return "", ""
 	}
+	return notesRef, id.String()
+}
 
+func getNote(ctx *context.APIContext, notesRef, commitID string) {
 	var note git.Note
-	if err := git.GetNote(ctx, ctx.Repo.GitRepo, commitID.String(), &note); err != nil {
+	if err := git.GetNoteFromRef(ctx, ctx.Repo.GitRepo, notesRef, commitID, &note); err != nil {
 		if git.IsErrNotExist(err) {
-			ctx.APIErrorNotFound("commit doesn't exist: " + identifier)
+			ctx.APIErrorNotFound("commit doesn't have a note: " + commitID)
 			return
 		}
 		ctx.APIErrorInternal(err)
@@ -99,6 +275,6 @@ func getNote(ctx *context.APIContext, identifier string) {
 		ctx.APIErrorInternal(err)
 		return
 	}
-	apiNote := api.Note{Message: string(note.Message), Commit: cmt}
+	apiNote := api.Note{Ref: notesRef, Message: string(note.Message), Commit: cmt}
 	ctx.JSON(http.StatusOK, apiNote)
 }
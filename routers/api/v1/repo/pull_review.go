@@ -436,6 +436,13 @@ func CreatePullReview(ctx *context.APIContext) {
 		return
 	}
 
+	for _, c := range opts.Comments {
+		if err := validatePullReviewCommentLines(c); err != nil {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+
 	// determine review type
 	reviewType, isWrong := preparePullReviewType(ctx, pr, opts.Event, opts.Body, len(opts.Comments) > 0)
 	if isWrong {
@@ -467,15 +474,16 @@ func CreatePullReview(ctx *context.APIContext) {
 
 	// create review comments
 	for _, c := range opts.Comments {
-		line := c.NewLineNum
+		startLine, line := c.NewStartLineNum, c.NewLineNum
 		if c.OldLineNum > 0 {
-			line = c.OldLineNum * -1
+			startLine, line = c.OldStartLineNum*-1, c.OldLineNum*-1
 		}
 
 		if _, err := pull_service.CreateCodeComment(ctx,
 			ctx.Doer,
 			ctx.Repo.GitRepo,
 			pr.Issue,
+			startLine,
 			line,
 			c.Body,
 			c.Path,
@@ -602,6 +610,20 @@ func SubmitPullReview(ctx *context.APIContext) {
 }
 
 // preparePullReviewType return ReviewType and false or nil and true if an error happen
+// validatePullReviewCommentLines checks the first line of a multi-line review comment is before its line on the same side of the diff
+func validatePullReviewCommentLines(c api.CreatePullReviewComment) error {
+	if c.OldLineNum < 0 || c.NewLineNum < 0 || c.OldStartLineNum < 0 || c.NewStartLineNum < 0 {
+		return fmt.Errorf("lines of the comment on %q must not be negative", c.Path)
+	}
+	if c.OldStartLineNum > 0 && (c.OldLineNum == 0 || c.OldStartLineNum >= c.OldLineNum || c.NewStartLineNum > 0) {
+		return fmt.Errorf("old_start_position of the comment on %q must be before its old_position", c.Path)
+	}
+	if c.NewStartLineNum > 0 && (c.NewLineNum == 0 || c.OldLineNum > 0 || c.NewStartLineNum >= c.NewLineNum) {
+		return fmt.Errorf("new_start_position of the comment on %q must be before its new_position", c.Path)
+	}
+	return nil
+}
+
 func preparePullReviewType(ctx *context.APIContext, pr *issues_model.PullRequest, event api.ReviewStateType, body string, hasComments bool) (issues_model.ReviewType, bool) {
 	if err := pr.LoadIssue(ctx); err != nil {
 		ctx.APIErrorInternal(err)
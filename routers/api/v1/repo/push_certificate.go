@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListPushCertificates lists the push certificates received by a repository
+func ListPushCertificates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/push_certificates repository repoListPushCertificates
+	// ---
+	// summary: List the certificates of the signed pushes to a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: pusher
+	//   in: query
+	//   description: only list the certificates of the pushes made by this user
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushCertificateList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := git_model.FindPushCertificatesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+	}
+	if pusherName := ctx.FormString("pusher"); pusherName != "" {
+		pusher, err := user_model.GetUserByName(ctx, pusherName)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIErrorNotFound(err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		opts.PusherID = pusher.ID
+	}
+
+	certs, count, err := db.FindAndCount[git_model.PushCertificate](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := git_model.PushCertificateList(certs).LoadPushers(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiCerts := make([]*api.PushCertificate, 0, len(certs))
+	for _, cert := range certs {
+		apiCerts = append(apiCerts, convert.ToPushCertificate(ctx, cert, ctx.Doer))
+	}
+	ctx.SetLinkHeader(int(count), opts.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiCerts)
+}
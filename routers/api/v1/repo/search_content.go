@@ -0,0 +1,142 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// SearchContent searches the wiki pages, the releases or the commit messages of the accessible repositories
+func SearchContent(ctx *context.APIContext) {
+	// swagger:operation GET /repos/search/{type} repository repoSearchContent
+	// ---
+	// summary: Search for wiki pages, releases or commit messages
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: type
+	//   in: path
+	//   description: kind of the searched contents
+	//   type: string
+	//   enum: [wiki, releases, commits]
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ContentSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Indexer.ContentIndexerEnabled {
+		ctx.APIErrorNotFound("content indexer is disabled")
+		return
+	}
+	kind, ok := common.ContentSearchKind(ctx.PathParam("type"))
+	if !ok {
+		ctx.APIErrorNotFound()
+		return
+	}
+	keyword := ctx.FormTrim("q")
+	if keyword == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, errors.New("the keyword is required"))
+		return
+	}
+
+	var repoIDs []int64
+	if ctx.Doer == nil || !ctx.Doer.IsAdmin || ctx.PublicOnly {
+		doer := ctx.Doer
+		if ctx.PublicOnly {
+			doer = nil
+		}
+		var err error
+		repoIDs, err = repo_model.SearchRepositoryIDsByCondition(ctx, repo_model.AccessibleRepositoryCondition(doer, common.ContentSearchUnitType(kind)))
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if len(repoIDs) == 0 {
+			ctx.SetTotalCountHeader(0)
+			ctx.JSON(http.StatusOK, []*api.ContentSearchResult{})
+			return
+		}
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	total, results, err := content_indexer.Search(ctx, &content_indexer.SearchOptions{
+		Keyword:    keyword,
+		SearchMode: indexer.SearchModeWords,
+		Kind:       kind,
+		RepoIDs:    repoIDs,
+		Paginator:  &listOptions,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	resultRepoIDs := make([]int64, 0, len(results))
+	for _, result := range results {
+		resultRepoIDs = append(resultRepoIDs, result.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, resultRepoIDs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiResults := make([]*api.ContentSearchResult, 0, len(results))
+	for _, result := range results {
+		repo, ok := repos[result.RepoID]
+		if !ok {
+			continue
+		}
+		link := common.ContentSearchResultLink(repo, result)
+		if link == "" {
+			continue
+		}
+		apiResults = append(apiResults, &api.ContentSearchResult{
+			Kind: string(result.Kind),
+			Repository: &api.RepositoryMeta{
+				ID:       repo.ID,
+				Name:     repo.Name,
+				Owner:    repo.OwnerName,
+				FullName: repo.FullName(),
+			},
+			Ref:     result.Ref,
+			Title:   result.Title,
+			Snippet: result.Snippet,
+			HTMLURL: strings.TrimSuffix(setting.AppURL, "/") + strings.TrimPrefix(link, setting.AppSubURL),
+			Updated: result.UpdatedUnix.AsTime(),
+		})
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiResults)
+}
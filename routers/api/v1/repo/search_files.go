@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/git"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// SearchFiles searches the paths of the files of the accessible repositories
+func SearchFiles(ctx *context.APIContext) {
+	// swagger:operation GET /repos/search/files repository repoSearchFiles
+	// ---
+	// summary: Search for files by their paths
+	// description: The characters of the keyword must appear in the same order in the paths, the best matches are returned first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: q
+	//   in: query
+	//   description: keyword
+	//   type: string
+	//   required: true
+	// - name: org
+	//   in: query
+	//   description: name of the organization whose repositories are searched
+	//   type: string
+	// - name: language
+	//   in: query
+	//   description: language of the files
+	//   type: string
+	// - name: extension
+	//   in: query
+	//   description: comma-separated extensions of the files, like "go,yaml"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/FileSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Indexer.RepoIndexerEnabled || unit.TypeCode.UnitGlobalDisabled() {
+		ctx.APIErrorNotFound("repository indexer is disabled")
+		return
+	}
+	keyword := ctx.FormTrim("q")
+	if keyword == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, errors.New("the keyword is required"))
+		return
+	}
+
+	doer := ctx.Doer
+	if ctx.PublicOnly {
+		doer = nil
+	}
+	repoIDs, searchable, err := common.FileSearchRepoIDs(ctx, doer, ctx.FormTrim("org"))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !searchable {
+		ctx.SetTotalCountHeader(0)
+		ctx.JSON(http.StatusOK, []*api.FileSearchResult{})
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	total, results, _, truncated, err := code_indexer.SearchPaths(ctx, &code_indexer.SearchOptions{
+		RepoIDs:    repoIDs,
+		Keyword:    keyword,
+		Language:   ctx.FormTrim("language"),
+		Extensions: common.ParseFileSearchExtensions(ctx.FormTrim("extension")),
+		Paginator:  &listOptions,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	resultRepoIDs := make([]int64, 0, len(results))
+	for _, result := range results {
+		resultRepoIDs = append(resultRepoIDs, result.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, resultRepoIDs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiResults := make([]*api.FileSearchResult, 0, len(results))
+	for _, result := range results {
+		repo, ok := repos[result.RepoID]
+		if !ok {
+			continue
+		}
+		refs := make([]string, 0, len(result.Refs))
+		for _, ref := range result.Refs {
+			if ref == code_indexer.DefaultBranchRef {
+				ref = git.RefNameFromBranch(repo.DefaultBranch).String()
+			}
+			refs = append(refs, ref)
+		}
+		apiResults = append(apiResults, &api.FileSearchResult{
+			Repository: &api.RepositoryMeta{
+				ID:       repo.ID,
+				Name:     repo.Name,
+				Owner:    repo.OwnerName,
+				FullName: repo.FullName(),
+			},
+			Path:     result.Filename,
+			Language: result.Language,
+			CommitID: result.CommitID,
+			Refs:     refs,
+			HTMLURL:  strings.TrimSuffix(setting.AppURL, "/") + strings.TrimPrefix(common.FileSearchResultLink(repo, result), setting.AppSubURL),
+		})
+	}
+
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(int64(total))
+	// the total is a lower bound if too many files match
+	ctx.RespHeader().Set("X-Results-Truncated", strconv.FormatBool(truncated))
+	ctx.AppendAccessControlExposeHeaders("X-Results-Truncated")
+	ctx.JSON(http.StatusOK, apiResults)
+}
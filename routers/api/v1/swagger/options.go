@@ -183,6 +183,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateTagOption api.CreateTagOption
 
+	// in:body
+	CreateNoteOption api.CreateNoteOption
+
 	// in:body
 	CreateTagProtectionOption api.CreateTagProtectionOption
 
@@ -210,6 +210,23 @@ type swaggerResponseSearchResults struct {
 	Body api.SearchResults `json:"body"`
 }
 
+// ContentSearchResultList
+// swagger:response ContentSearchResultList
+type swaggerResponseContentSearchResultList struct {
+	// in:body
+	Body []api.ContentSearchResult `json:"body"`
+}
+
+// FileSearchResultList
+// swagger:response FileSearchResultList
+type swaggerResponseFileSearchResultList struct {
+	// True if too many files match, then the total count is a lower bound
+	Truncated bool `json:"X-Results-Truncated"`
+
+	// in:body
+	Body []api.FileSearchResult `json:"body"`
+}
+
 // AttachmentList
 // swagger:response AttachmentList
 type swaggerResponseAttachmentList struct {
@@ -296,6 +313,13 @@ type swaggerNote struct {
 	Body api.Note `json:"body"`
 }
 
+// NotesRefList
+// swagger:response NotesRefList
+type swaggerNotesRefList struct {
+	// in: body
+	Body []api.NotesRef `json:"body"`
+}
+
 // EmptyRepository
 // swagger:response EmptyRepository
 type swaggerEmptyRepository struct {
@@ -414,6 +438,13 @@ type swaggerPushMirrorList struct {
 	Body []api.PushMirror `json:"body"`
 }
 
+// PushCertificateList
+// swagger:response PushCertificateList
+type swaggerPushCertificateList struct {
+	// in:body
+	Body []api.PushCertificate `json:"body"`
+}
+
 // RepoCollaboratorPermission
 // swagger:response RepoCollaboratorPermission
 type swaggerRepoCollaboratorPermission struct {
@@ -4,6 +4,11 @@
 package common
 
 import (
+	"regexp"
+	"slices"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/indexer"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
@@ -11,9 +16,11 @@ import (
 )
 
 func PrepareCodeSearch(ctx *context.Context) (ret struct {
-	Keyword    string
-	Language   string
-	SearchMode indexer.SearchModeType
+	Keyword      string
+	Language     string
+	SearchMode   indexer.SearchModeType
+	PathPatterns []string
+	RepoNames    []string
 },
 ) {
 	ret.Language = ctx.FormTrim("l")
@@ -29,5 +36,43 @@ func PrepareCodeSearch(ctx *context.Context) (ret struct {
 		ctx.Data["SearchModes"] = indexer.GitGrepSupportedSearchModes()
 	}
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+
+	query := code_indexer.ParseSearchQuery(ret.Keyword)
+	ret.Keyword, ret.PathPatterns, ret.RepoNames = query.Keyword, query.PathPatterns, query.RepoNames
+
+	// the indexers confirm the matches with Go regexps, git grep uses Perl regexps and reports its own errors
+	if ret.SearchMode == indexer.SearchModeRegexp && setting.Indexer.RepoIndexerEnabled {
+		if _, err := regexp.Compile(ret.Keyword); err != nil {
+			ctx.Data["CodeSearchError"] = ctx.Tr("search.invalid_regexp", err.Error())
+			ret.Keyword = ""
+		}
+	}
 	return ret
 }
+
+// FilterCodeSearchRepoIDs limits the repositories of a code search to the ones named by the "repo:" qualifiers,
+// a name without owner belongs to the default owner. The names which are not in the searchable repositories are ignored.
+// If repoIDs is nil, all the repositories are searchable. It returns false if none of the named repositories is searchable.
+func FilterCodeSearchRepoIDs(ctx *context.Context, repoIDs []int64, repoNames []string, defaultOwner string) ([]int64, bool, error) {
+	if len(repoNames) == 0 {
+		return repoIDs, true, nil
+	}
+	filtered := make([]int64, 0, len(repoNames))
+	for _, name := range repoNames {
+		ownerName, repoName, ok := strings.Cut(name, "/")
+		if !ok {
+			ownerName, repoName = defaultOwner, name
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				continue
+			}
+			return nil, false, err
+		}
+		if repoIDs == nil || slices.Contains(repoIDs, repo.ID) {
+			filtered = append(filtered, repo.ID)
+		}
+	}
+	return filtered, len(filtered) > 0, nil
+}
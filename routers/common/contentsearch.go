@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"net/url"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/indexer"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	wiki_service "code.gitea.io/gitea/services/wiki"
+)
+
+// contentSearchTypes maps the search types of the urls to the kinds of the indexed contents
+var contentSearchTypes = map[string]content_indexer.Kind{
+	"wiki":     content_indexer.KindWiki,
+	"releases": content_indexer.KindRelease,
+	"commits":  content_indexer.KindCommit,
+}
+
+// ContentSearchKind returns the kind of the contents searched by the search type of a url
+func ContentSearchKind(searchType string) (content_indexer.Kind, bool) {
+	kind, ok := contentSearchTypes[searchType]
+	return kind, ok
+}
+
+// ContentSearchUnitType returns the repository unit which must be readable to search the contents of the kind
+func ContentSearchUnitType(kind content_indexer.Kind) unit.Type {
+	switch kind {
+	case content_indexer.KindWiki:
+		return unit.TypeWiki
+	case content_indexer.KindRelease:
+		return unit.TypeReleases
+	}
+	return unit.TypeCode
+}
+
+// ContentSearchResult is a searched content with the repository it belongs to
+type ContentSearchResult struct {
+	*content_indexer.Result
+	Repo *repo_model.Repository
+	Link string
+}
+
+// ContentSearchResultLink returns the link of the web page of a searched content,
+// it returns an empty string if the content doesn't have a valid reference
+func ContentSearchResultLink(repo *repo_model.Repository, result *content_indexer.Result) string {
+	switch result.Kind {
+	case content_indexer.KindWiki:
+		webPath, err := wiki_service.GitPathToWebPath(result.Ref)
+		if err != nil {
+			return ""
+		}
+		return repo.Link() + "/wiki/" + wiki_service.WebPathToURLPath(webPath)
+	case content_indexer.KindRelease:
+		return repo.Link() + "/releases/tag/" + util.PathEscapeSegments(result.Ref)
+	case content_indexer.KindCommit:
+		return repo.Link() + "/commit/" + url.PathEscape(result.Ref)
+	}
+	return ""
+}
+
+// PrepareContentSearch parses the search type of the url and the keyword of a content search,
+// it returns false if the search type is unknown.
+func PrepareContentSearch(ctx *context.Context) (ret struct {
+	Kind       content_indexer.Kind
+	Keyword    string
+	SearchMode indexer.SearchModeType
+}, ok bool,
+) {
+	ret.Kind, ok = ContentSearchKind(ctx.PathParam("type"))
+	if !ok {
+		return ret, false
+	}
+	ret.Keyword = ctx.FormTrim("q")
+	ret.SearchMode = indexer.SearchModeType(ctx.FormTrim("search_mode"))
+
+	ctx.Data["ContentSearchType"] = ctx.PathParam("type")
+	ctx.Data["Keyword"] = ret.Keyword
+	ctx.Data["SelectedSearchMode"] = string(ret.SearchMode)
+	ctx.Data["SearchModes"] = content_indexer.SupportedSearchModes()
+	return ret, true
+}
+
+// SearchContents searches the contents and fills the results and the pagination of the page,
+// the repositories which don't exist anymore are removed from the results
+func SearchContents(ctx *context.Context, opts *content_indexer.SearchOptions) {
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	opts.Paginator = &db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.RepoSearchPagingNum,
+	}
+
+	total, results, err := content_indexer.Search(ctx, opts)
+	if err != nil {
+		if content_indexer.IsAvailable(ctx) {
+			ctx.ServerError("SearchContents", err)
+			return
+		}
+		ctx.Data["ContentIndexerUnavailable"] = true
+		return
+	}
+
+	repoIDs := make([]int64, 0, len(results))
+	for _, result := range results {
+		if !slices.Contains(repoIDs, result.RepoID) {
+			repoIDs = append(repoIDs, result.RepoID)
+		}
+	}
+	repoMaps, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		ctx.ServerError("GetRepositoriesMapByIDs", err)
+		return
+	}
+
+	searchResults := make([]*ContentSearchResult, 0, len(results))
+	for _, result := range results {
+		repo, ok := repoMaps[result.RepoID]
+		if !ok {
+			continue
+		}
+		link := ContentSearchResultLink(repo, result)
+		if link == "" {
+			continue
+		}
+		searchResults = append(searchResults, &ContentSearchResult{Result: result, Repo: repo, Link: link})
+	}
+	ctx.Data["SearchResults"] = searchResults
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ParseFileSearchExtensions splits the comma-separated extensions of a file path search, like "go,.yaml"
+func ParseFileSearchExtensions(s string) []string {
+	var extensions []string
+	for ext := range strings.SplitSeq(s, ",") {
+		if ext = strings.TrimPrefix(strings.TrimSpace(ext), "."); ext != "" {
+			extensions = append(extensions, ext)
+		}
+	}
+	return extensions
+}
+
+// FileSearchRepoIDs returns the repositories whose files could be searched by the doer, they are limited to the ones
+// of the organization if orgName isn't empty. The repository IDs are nil if all the repositories are searchable,
+// it returns false if none of them is searchable.
+func FileSearchRepoIDs(ctx context.Context, doer *user_model.User, orgName string) ([]int64, bool, error) {
+	cond := builder.NewCond()
+	if orgName != "" {
+		org, err := user_model.GetUserByName(ctx, orgName)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		if !org.IsOrganization() {
+			return nil, false, nil
+		}
+		cond = builder.Eq{"`repository`.owner_id": org.ID}
+	}
+
+	if doer == nil || !doer.IsAdmin {
+		cond = cond.And(repo_model.AccessibleRepositoryCondition(doer, unit.TypeCode))
+	} else if orgName == "" {
+		return nil, true, nil
+	}
+	repoIDs, err := repo_model.SearchRepositoryIDsByCondition(ctx, cond)
+	if err != nil {
+		return nil, false, err
+	}
+	return repoIDs, len(repoIDs) > 0, nil
+}
+
+// FileSearchResultLink returns the link of the web page of a file found by a file path search
+func FileSearchResultLink(repo *repo_model.Repository, result *code_indexer.Result) string {
+	return repo.Link() + "/src/commit/" + url.PathEscape(result.CommitID) + "/" + util.PathEscapeSegments(result.Filename)
+}
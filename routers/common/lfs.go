@@ -20,6 +20,11 @@ func AddOwnerRepoGitLFSRoutes(m *web.Router, middlewares ...any) {
 		m.Get("/objects/{oid}/{filename}", lfs.DownloadHandler)
 		m.Get("/objects/{oid}", lfs.DownloadHandler)
 		m.Post("/verify", lfs.CheckAcceptMediaType, lfs.VerifyHandler)
+		m.Group("/uploads/{uuid}", func() {
+			m.Put("/{part}", lfs.UploadPartHandler)
+			m.Post("/commit", lfs.CheckAcceptMediaType, lfs.CommitUploadHandler)
+			m.Delete("", lfs.AbortUploadHandler)
+		})
 		m.Group("/locks", func() {
 			m.Get("/", lfs.GetListLockHandler)
 			m.Post("/", lfs.PostLockHandler)
@@ -41,6 +41,7 @@ import (
 	"code.gitea.io/gitea/services/cron"
 	feed_service "code.gitea.io/gitea/services/feed"
 	indexer_service "code.gitea.io/gitea/services/indexer"
+	lfs_service "code.gitea.io/gitea/services/lfs"
 	"code.gitea.io/gitea/services/mailer"
 	mailer_incoming "code.gitea.io/gitea/services/mailer/incoming"
 	markup_service "code.gitea.io/gitea/services/markup"
@@ -129,6 +130,7 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(feed_service.Init)
 	mustInit(uinotification.Init)
 	mustInitCtx(ctx, archiver.Init)
+	mustInit(lfs_service.Init)
 
 	external.RegisterRenderers()
 	markup.Init(markup_service.FormalRenderHelperFuncs())
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// loadForeignLFSLocks loads the locks of the repository held by other users than the pusher once for all the refs,
+// it returns false if an error occurs, and it writes the error response
+func (ctx *preReceiveContext) loadForeignLFSLocks() bool {
+	if ctx.loadedForeignLFSLocks {
+		return true
+	}
+	locks, err := git_model.FindLFSLocksOfOtherOwners(ctx, ctx.Repo.Repository.ID, ctx.opts.UserID)
+	if err == nil {
+		err = locks.LoadOwner(ctx)
+	}
+	if err != nil {
+		log.Error("Unable to get LFS locks of %-v: %v", ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to get LFS locks: %v", err),
+		})
+		return false
+	}
+	ctx.foreignLFSLocks = make(map[string]*git_model.LFSLock, len(locks))
+	for _, lock := range locks {
+		// the paths of the locks are compared case-insensitively like GetLFSLock does
+		ctx.foreignLFSLocks[strings.ToLower(lock.Path)] = lock
+	}
+	ctx.loadedForeignLFSLocks = true
+	return true
+}
+
+// checkLFSLocks rejects the push if a new commit of a branch changes a file locked by another user,
+// it returns false if the push is rejected or an error occurs, and it writes the response
+func (ctx *preReceiveContext) checkLFSLocks(newCommitID string) bool {
+	repo := ctx.Repo.Repository
+	if !setting.LFS.StartServer || !repo.EnforceLFSLocks {
+		return true
+	}
+	if !ctx.loadForeignLFSLocks() {
+		return false
+	}
+	if len(ctx.foreignLFSLocks) == 0 {
+		return true
+	}
+
+	// only the commits new to the repository are checked, so merging a branch which changed a locked file is allowed.
+	// The merge commits only list the files which differ from all their parents, like a conflict resolution.
+	output, _, err := gitrepo.RunCmdString(ctx,
+		repo,
+		gitcmd.NewCommand("log", "-c", "--name-only", "--no-renames", "-z", "--format=").
+			AddDynamicArguments(newCommitID).
+			AddArguments("--not", "--all").
+			WithEnv(ctx.env),
+	)
+	if err != nil {
+		log.Error("Unable to list the files changed by %s in %-v: %v", newCommitID, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to list the changed files: %v", err),
+		})
+		return false
+	}
+
+	for file := range strings.SplitSeq(output, "\x00") {
+		if file == "" {
+			continue
+		}
+		if lock, ok := ctx.foreignLFSLocks[strings.ToLower(file)]; ok {
+			log.Warn("Forbidden: %s in %-v is locked by %s", file, repo, lock.Owner.Name)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("file %s is locked by %s, it can't be changed until the lock is released", lock.Path, lock.Owner.Name),
+			})
+			return false
+		}
+	}
+	return true
+}
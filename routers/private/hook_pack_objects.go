@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	gitea_context "code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/repository/packcache"
+)
+
+// HookPackObjects generates the pack for git upload-pack, the pack may be served from the pack cache
+func HookPackObjects(ctx *gitea_context.PrivateContext) {
+	opts := web.GetForm(ctx).(*private.HookPackObjectsOptions)
+	repoID := ctx.PathParamInt64("repoid")
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to get repository %d: %v", repoID, err),
+		})
+		return
+	}
+	var storageRepo gitrepo.Repository = repo
+	if opts.IsWiki {
+		storageRepo = repo.WikiStorageRepo()
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	if err := packcache.ServePack(ctx, storageRepo, opts.Args, opts.Input, ctx.Resp); err != nil {
+		if ctx.Resp.WrittenStatus() != 0 {
+			// the pack has been partially sent, the hook will fail on the broken pack
+			log.Error("Unable to serve pack for %s: %v", repo.FullName(), err)
+			return
+		}
+		status := http.StatusInternalServerError
+		if errors.Is(err, util.ErrInvalidArgument) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, private.Response{
+			Err: fmt.Sprintf("Unable to serve pack for %s: %v", repo.FullName(), err),
+		})
+	}
+}
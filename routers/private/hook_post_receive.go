@@ -162,6 +162,21 @@ func HookPostReceive(ctx *gitea_context.PrivateContext) {
 		}
 	}
 
+	// store the push certificate of a signed push for audit, the post-receive hook is called once per batch of refs
+	// but the certificate is only stored once
+	if opts.PushCertID != "" {
+		if repo == nil {
+			repo = loadRepository(ctx, ownerName, repoName)
+			if ctx.Written() {
+				return
+			}
+			wasEmpty = repo.IsEmpty
+		}
+		if err := storePushCertificate(ctx, repo, opts); err != nil {
+			log.Error("Failed to store push certificate %s of %s/%s: %v", opts.PushCertID, ownerName, repoName, err)
+		}
+	}
+
 	// handle pull request merging, a pull request action should push at least 1 commit
 	if opts.PushTrigger == repo_module.PushTriggerPRMergeToBase {
 		handlePullRequestMerging(ctx, opts, ownerName, repoName, updates)
@@ -46,6 +46,13 @@ type preReceiveContext struct {
 	protectedTags    []*git_model.ProtectedTag
 	gotProtectedTags bool
 
+	pushCert             *git.PushCertificate
+	pushCertVerification *asymkey_model.CommitVerification
+	loadedPushCert       bool
+
+	foreignLFSLocks       map[string]*git_model.LFSLock // the locks held by other users than the pusher, keyed by the lower case paths
+	loadedForeignLFSLocks bool
+
 	env []string
 
 	opts *private.HookOptions
@@ -115,6 +122,13 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		opts:           opts,
 	}
 
+	if ctx.Repo.Repository.IsBeingConverted() {
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: "The repository is being converted to another object format, you could retry after it finished",
+		})
+		return
+	}
+
 	// Iterate across the provided old commit IDs
 	for i := range opts.OldCommitIDs {
 		oldCommitID := opts.OldCommitIDs[i]
@@ -159,6 +173,10 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
+	if newCommitID != objectFormat.EmptyObjectID().String() && !ctx.checkLFSLocks(newCommitID) {
+		return
+	}
+
 	protectBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, branchName)
 	if err != nil {
 		log.Error("Unable to get protected branch: %s in %-v Error: %v", branchName, repo, err)
@@ -236,9 +254,23 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		}
 	}
 
+	// 4. Enforce require signed pushes - the merges of pull requests are made by Gitea and cannot be signed by the pusher
+	if protectBranch.RequireSignedPushes && ctx.opts.PullRequestID == 0 {
+		if !ctx.loadPushCertificate() {
+			return
+		}
+		if reason := ctx.unsignedPushReason(refFullName, oldCommitID, newCommitID); reason != "" {
+			log.Warn("Forbidden: Branch: %s in %-v is protected from unsigned pushes: %s", branchName, repo, reason)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("branch %s is protected from unsigned pushes: %s", branchName, reason),
+			})
+			return
+		}
+	}
+
 	// Now there are several tests which can be overridden:
 	//
-	// 4. Check protected file patterns - this is overridable from the UI
+	// 5. Check protected file patterns - this is overridable from the UI
 	changedProtectedfiles := false
 	protectedFilePath := ""
 
@@ -259,7 +291,7 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		}
 	}
 
-	// 5. Check if the doer is allowed to push (and force-push if the incoming push is a force-push)
+	// 6. Check if the doer is allowed to push (and force-push if the incoming push is a force-push)
 	var canPush bool
 	if ctx.opts.DeployKeyID != 0 {
 		// This flag is only ever true if protectBranch.CanForcePush is true
@@ -284,11 +316,11 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		}
 	}
 
-	// 6. If we're not allowed to push directly
+	// 7. If we're not allowed to push directly
 	if !canPush {
 		// Is this is a merge from the UI/API?
 		if ctx.opts.PullRequestID == 0 {
-			// 6a. If we're not merging from the UI/API then there are two ways we got here:
+			// 7a. If we're not merging from the UI/API then there are two ways we got here:
 			//
 			// We are changing a protected file and we're not allowed to do that
 			if changedProtectedfiles {
@@ -330,7 +362,7 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 			})
 			return
 		}
-		// 6b. Merge (from UI or API)
+		// 7b. Merge (from UI or API)
 
 		// Get the PR, user and permissions for the user in the repository
 		pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	asymkey_service "code.gitea.io/gitea/services/asymkey"
+)
+
+// This file contains the verification of the push certificates sent by "git push --signed"
+
+// pushCertNonceOK is the nonce status set by git receive-pack if the nonce is the one it issued
+const pushCertNonceOK = "OK"
+
+func readPushCertificate(ctx context.Context, repo *repo_model.Repository, certID string, env []string) ([]byte, error) {
+	cmd := gitcmd.NewCommand("cat-file", "blob").AddDynamicArguments(certID)
+	if env != nil {
+		cmd = cmd.WithEnv(env)
+	}
+	data, _, err := gitrepo.RunCmdBytes(ctx, repo, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// loadPushCertificate reads and verifies the push certificate once for all the refs of the push,
+// it returns false if an error occurs, and it writes the error response
+func (ctx *preReceiveContext) loadPushCertificate() bool {
+	if ctx.loadedPushCert {
+		return true
+	}
+	if ctx.opts.PushCertID == "" {
+		ctx.loadedPushCert = true
+		return true
+	}
+	if !ctx.loadPusherAndPermission() {
+		return false
+	}
+
+	data, err := readPushCertificate(ctx, ctx.Repo.Repository, ctx.opts.PushCertID, ctx.env)
+	if err != nil {
+		log.Error("Unable to read push certificate %s in %-v: %v", ctx.opts.PushCertID, ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to read push certificate %s: %v", ctx.opts.PushCertID, err),
+		})
+		return false
+	}
+	// a malformed certificate is handled like an unsigned push
+	if ctx.pushCert, err = git.ParsePushCertificate(data); err != nil {
+		log.Warn("Invalid push certificate %s in %-v: %v", ctx.opts.PushCertID, ctx.Repo.Repository, err)
+	} else {
+		ctx.pushCertVerification = asymkey_service.VerifyPushCertificate(ctx, ctx.user, ctx.pushCert)
+	}
+	ctx.loadedPushCert = true
+	return true
+}
+
+// unsignedPushReason returns why the push certificate doesn't prove that the pusher requested the ref update,
+// it is empty if the update is signed
+func (ctx *preReceiveContext) unsignedPushReason(refFullName git.RefName, oldCommitID, newCommitID string) string {
+	switch {
+	case ctx.pushCert == nil:
+		return "the push is not signed, use git push --signed"
+	case ctx.opts.PushCertNonceStatus != pushCertNonceOK:
+		return fmt.Sprintf("the nonce of the push certificate is not valid (%s)", ctx.opts.PushCertNonceStatus)
+	case !ctx.pushCertVerification.Verified:
+		return fmt.Sprintf("the push certificate is not signed by a verified key of %s", ctx.user.Name)
+	case !ctx.pushCert.HasUpdate(refFullName.String(), oldCommitID, newCommitID):
+		return fmt.Sprintf("the push certificate doesn't contain the update of %s", refFullName)
+	}
+	return ""
+}
+
+// storePushCertificate verifies and stores the push certificate of a signed push for audit
+func storePushCertificate(ctx context.Context, repo *repo_model.Repository, opts *private.HookOptions) error {
+	data, err := readPushCertificate(ctx, repo, opts.PushCertID, nil)
+	if err != nil {
+		return fmt.Errorf("unable to read push certificate %s: %w", opts.PushCertID, err)
+	}
+	pusher, err := user_model.GetPossibleUserByID(ctx, opts.UserID)
+	if err != nil {
+		return fmt.Errorf("unable to get pusher %d: %w", opts.UserID, err)
+	}
+
+	pushCert := &git_model.PushCertificate{
+		RepoID:      repo.ID,
+		BlobID:      opts.PushCertID,
+		PusherID:    pusher.ID,
+		NonceStatus: opts.PushCertNonceStatus,
+		Certificate: string(data),
+	}
+	cert, err := git.ParsePushCertificate(data)
+	if err != nil {
+		pushCert.Reason = err.Error()
+		return git_model.InsertPushCertificate(ctx, pushCert)
+	}
+	pushCert.Signer, pushCert.Nonce, pushCert.Updates = cert.Pusher, cert.Nonce, cert.Updates
+
+	verification := asymkey_service.VerifyPushCertificate(ctx, pusher, cert)
+	pushCert.Verified, pushCert.Reason = verification.Verified, verification.Reason
+	pushCert.SigningKey = signingKeyOfVerification(verification)
+	return git_model.InsertPushCertificate(ctx, pushCert)
+}
+
+func signingKeyOfVerification(verification *asymkey_model.CommitVerification) string {
+	if verification.SigningSSHKey != nil {
+		return verification.SigningSSHKey.Fingerprint
+	}
+	if verification.SigningKey != nil {
+		return verification.SigningKey.KeyID
+	}
+	return ""
+}
@@ -23,17 +23,34 @@ import (
 	wiki_service "code.gitea.io/gitea/services/wiki"
 )
 
+// servCertificateKeyName is the key name of the users authenticated by an SSH certificate of a trusted user CA
+const servCertificateKeyName = "SSH certificate"
+
+// getServPublicKey returns the public key represented by the keyID, or a key without ID of the user authenticated by
+// an SSH certificate of a trusted user CA if the keyID is 0, such a user doesn't have a public key
+func getServPublicKey(ctx *context.PrivateContext, keyID int64) (*asymkey_model.PublicKey, error) {
+	if userID := ctx.FormInt64("user_id"); keyID == 0 && userID > 0 {
+		return &asymkey_model.PublicKey{
+			OwnerID: userID,
+			Name:    servCertificateKeyName,
+			Type:    asymkey_model.KeyTypeUser,
+		}, nil
+	}
+	return asymkey_model.GetPublicKeyByID(ctx, keyID)
+}
+
 // ServNoCommand returns information about the provided keyid
 func ServNoCommand(ctx *context.PrivateContext) {
 	keyID := ctx.PathParamInt64("keyid")
-	if keyID <= 0 {
+	if keyID < 0 || (keyID == 0 && ctx.FormInt64("user_id") <= 0) {
 		ctx.JSON(http.StatusBadRequest, private.Response{
 			UserMsg: fmt.Sprintf("Bad key id: %d", keyID),
 		})
+		return
 	}
 	results := private.KeyAndOwner{}
 
-	key, err := asymkey_model.GetPublicKeyByID(ctx, keyID)
+	key, err := getServPublicKey(ctx, keyID)
 	if err != nil {
 		if asymkey_model.IsErrKeyNotExist(err) {
 			ctx.JSON(http.StatusUnauthorized, private.Response{
@@ -208,6 +225,13 @@ func ServCommand(ctx *context.PrivateContext) {
 			return
 		}
 
+		if mode > perm.AccessModeRead && repo.IsBeingConverted() {
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Repository %s/%s is being converted to another object format, you could retry after it finished", results.OwnerName, results.RepoName),
+			})
+			return
+		}
+
 		// We can shortcut at this point if the repo is a mirror
 		if mode > perm.AccessModeRead && repo.IsMirror {
 			ctx.JSON(http.StatusForbidden, private.Response{
@@ -218,7 +242,7 @@ func ServCommand(ctx *context.PrivateContext) {
 	}
 
 	// Get the Public Key represented by the keyID
-	key, err := asymkey_model.GetPublicKeyByID(ctx, keyID)
+	key, err := getServPublicKey(ctx, keyID)
 	if err != nil {
 		if asymkey_model.IsErrKeyNotExist(err) {
 			ctx.JSON(http.StatusNotFound, private.Response{
@@ -418,6 +442,19 @@ func ServCommand(ctx *context.PrivateContext) {
 			return
 		}
 	}
+	if verb == git.CmdVerbUploadPack && !results.IsWiki {
+		policy, err := repo_model.GetRepoClonePolicy(ctx, repo.ID)
+		if err != nil {
+			log.Error("Failed to get the clone policy of %-v Error: %v", repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Failed to get the clone policy of %s/%s Error: %v", ownerName, repoName, err),
+			})
+			return
+		}
+		// the SSH users are always signed in
+		results.UploadPackPolicy = policy.UploadPackPolicy(false)
+	}
+
 	log.Debug("Serv Results:\nIsWiki: %t\nDeployKeyID: %d\nKeyID: %d\tKeyName: %s\nUserName: %s\nUserID: %d\nOwnerName: %s\nRepoName: %s\nRepoID: %d",
 		results.IsWiki,
 		results.DeployKeyID,
@@ -432,3 +469,30 @@ func ServCommand(ctx *context.PrivateContext) {
 	ctx.JSON(http.StatusOK, results)
 	// We will update the keys in a different call.
 }
+
+// ServCloneStat counts a clone of a repository by SSH
+func ServCloneStat(ctx *context.PrivateContext) {
+	kind := repo_model.CloneKind(ctx.PathParam("kind"))
+	if !kind.IsValid() {
+		ctx.JSON(http.StatusBadRequest, private.Response{
+			Err: fmt.Sprintf("Unknown clone kind: %s", kind),
+		})
+		return
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, ctx.PathParamInt64("repoid"))
+	if err != nil {
+		log.Error("Unable to get repository: %d Error: %v", ctx.PathParamInt64("repoid"), err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+	if err := repo_service.CountClone(ctx, repo, kind, ctx.FormString("want")); err != nil {
+		log.Error("Failed to count the clone of %-v Error: %v", repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+	ctx.PlainText(http.StatusOK, "success")
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+	"net/url"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	indexer_service "code.gitea.io/gitea/services/indexer"
+)
+
+const (
+	tplIndexers       templates.TplName = "admin/indexers"
+	tplIndexersStatus templates.TplName = "admin/indexers_status"
+)
+
+// IndexerRepoStatus is the indexing state of a repository shown in the indexers page
+type IndexerRepoStatus struct {
+	Repo          *repo_model.Repository
+	CodeStatus    *repo_model.RepoIndexerStatus
+	ContentStatus *repo_model.RepoIndexerStatus
+}
+
+// Indexers shows the states of the indexers and the repositories indexed by them
+func Indexers(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.monitor.indexers")
+	ctx.Data["PageIsAdminMonitorIndexers"] = true
+	ctx.Data["Indexers"] = indexer_service.GetIndexerStatuses(ctx)
+
+	page := max(ctx.FormInt("page"), 1)
+	keyword := ctx.FormTrim("q")
+	repos, count, err := repo_model.SearchRepository(ctx, repo_model.SearchRepoOptions{
+		ListOptions: db.ListOptions{
+			PageSize: setting.UI.Admin.RepoPagingNum,
+			Page:     page,
+		},
+		Private: true,
+		Keyword: keyword,
+		OrderBy: db.SearchOrderByID,
+	})
+	if err != nil {
+		ctx.ServerError("SearchRepository", err)
+		return
+	}
+
+	repoIDs := make([]int64, 0, len(repos))
+	for _, repo := range repos {
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	codeStatuses, err := repo_model.GetIndexerStatusesByRepoIDs(ctx, repo_model.RepoIndexerTypeCode, repoIDs)
+	if err != nil {
+		ctx.ServerError("GetIndexerStatusesByRepoIDs", err)
+		return
+	}
+	contentStatuses, err := repo_model.GetIndexerStatusesByRepoIDs(ctx, repo_model.RepoIndexerTypeContent, repoIDs)
+	if err != nil {
+		ctx.ServerError("GetIndexerStatusesByRepoIDs", err)
+		return
+	}
+
+	repoStatuses := make([]*IndexerRepoStatus, 0, len(repos))
+	for _, repo := range repos {
+		repoStatuses = append(repoStatuses, &IndexerRepoStatus{
+			Repo:          repo,
+			CodeStatus:    codeStatuses[repo.ID],
+			ContentStatus: contentStatuses[repo.ID],
+		})
+	}
+	ctx.Data["RepoStatuses"] = repoStatuses
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["Total"] = count
+
+	pager := context.NewPagination(int(count), setting.UI.Admin.RepoPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tplIndexers)
+}
+
+// IndexersStatus renders the states of the indexers, it's polled by the indexers page
+func IndexersStatus(ctx *context.Context) {
+	ctx.Data["Indexers"] = indexer_service.GetIndexerStatuses(ctx)
+	ctx.HTML(http.StatusOK, tplIndexersStatus)
+}
+
+// IndexersReindex reindexes a repository, or all the repositories if no repository is given, in an indexer
+func IndexersReindex(ctx *context.Context) {
+	name := ctx.FormString("indexer")
+	redirectTo := setting.AppSubURL + "/-/admin/monitor/indexers?page=" + url.QueryEscape(ctx.FormString("page")) + "&q=" + url.QueryEscape(ctx.FormString("q"))
+
+	repoID := ctx.FormInt64("repo_id")
+	if repoID == 0 {
+		if err := indexer_service.ReindexAll(ctx, name); err != nil {
+			ctx.Flash.Error(ctx.Tr("admin.monitor.indexers.reindex_failed", name, err.Error()))
+		} else {
+			ctx.Flash.Success(ctx.Tr("admin.monitor.indexers.reindex_all_started", name))
+		}
+		ctx.Redirect(redirectTo)
+		return
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.NotFound(err)
+			return
+		}
+		ctx.ServerError("GetRepositoryByID", err)
+		return
+	}
+	if err := indexer_service.ReindexRepo(ctx, name, repo); err != nil {
+		ctx.Flash.Error(ctx.Tr("admin.monitor.indexers.reindex_failed", name, err.Error()))
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.monitor.indexers.reindex_repo_started", name, repo.FullName()))
+	}
+	ctx.Redirect(redirectTo)
+}
@@ -23,6 +23,7 @@ import (
 const (
 	tplRepos          templates.TplName = "admin/repo/list"
 	tplUnadoptedRepos templates.TplName = "admin/repo/unadopted"
+	tplMaintenance    templates.TplName = "admin/repo/maintenance"
 )
 
 // Repos show all the repositories
@@ -102,6 +103,29 @@ func UnadoptedRepos(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplUnadoptedRepos)
 }
 
+// MaintenanceRepos lists the git maintenance states of the repositories
+func MaintenanceRepos(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.repos.maintenance")
+	ctx.Data["PageIsAdminRepositories"] = true
+
+	page := max(ctx.FormInt("page"), 1)
+	states, count, err := repo_model.FindRepoMaintenances(ctx, db.ListOptions{
+		PageSize: setting.UI.Admin.RepoPagingNum,
+		Page:     page,
+	})
+	if err != nil {
+		ctx.ServerError("FindRepoMaintenances", err)
+		return
+	}
+	ctx.Data["States"] = states
+	ctx.Data["Total"] = count
+
+	pager := context.NewPagination(int(count), setting.UI.Admin.RepoPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tplMaintenance)
+}
+
 // AdoptOrDeleteRepository adopts or deletes a repository
 func AdoptOrDeleteRepository(ctx *context.Context) {
 	dir := ctx.FormString("id")
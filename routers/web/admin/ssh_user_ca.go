@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const tplSSHUserCAs templates.TplName = "admin/ssh_user_ca"
+
+func loadTrustedUserCAKeys(ctx *context.Context) bool {
+	ctx.Data["Title"] = ctx.Tr("admin.ssh_user_cas")
+	ctx.Data["PageIsAdminSSHUserCAs"] = true
+
+	cas, err := db.Find[asymkey_model.TrustedUserCAKey](ctx, asymkey_model.FindTrustedUserCAKeysOptions{})
+	if err != nil {
+		ctx.ServerError("FindTrustedUserCAKeys", err)
+		return false
+	}
+	ctx.Data["TrustedUserCAKeys"] = cas
+	return true
+}
+
+// SSHUserCAs shows the trusted SSH user certificate authorities
+func SSHUserCAs(ctx *context.Context) {
+	if !loadTrustedUserCAKeys(ctx) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSSHUserCAs)
+}
+
+// SSHUserCAsPost registers a trusted SSH user certificate authority
+func SSHUserCAsPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdminAddTrustedUserCAForm)
+	if !loadTrustedUserCAKeys(ctx) {
+		return
+	}
+	if ctx.HasError() {
+		ctx.Data["HasAddError"] = true
+		ctx.HTML(http.StatusOK, tplSSHUserCAs)
+		return
+	}
+
+	ca := &asymkey_model.TrustedUserCAKey{
+		Name:        form.Name,
+		Content:     form.Content,
+		MaxValidity: form.MaxValidity,
+	}
+	if form.PrincipalMapping == asymkey_model.PrincipalMappingEmail.String() {
+		ca.PrincipalMapping = asymkey_model.PrincipalMappingEmail
+	}
+	if err := asymkey_model.AddTrustedUserCAKey(ctx, ca); err != nil {
+		ctx.Data["HasAddError"] = true
+		switch {
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.Data["Err_Name"] = true
+			ctx.RenderWithErr(ctx.Tr("admin.ssh_user_cas.already_exists"), tplSSHUserCAs, form)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Data["Err_Content"] = true
+			ctx.RenderWithErr(ctx.Tr("admin.ssh_user_cas.invalid_key", err.Error()), tplSSHUserCAs, form)
+		default:
+			ctx.ServerError("AddTrustedUserCAKey", err)
+		}
+		return
+	}
+	log.Info("Trusted SSH user certificate authority %s (%s) added by %s", ca.Name, ca.Fingerprint, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.ssh_user_cas.add_success", ca.Name))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/ssh_user_cas")
+}
+
+// DeleteSSHUserCA removes a trusted SSH user certificate authority
+func DeleteSSHUserCA(ctx *context.Context) {
+	if err := asymkey_model.DeleteTrustedUserCAKey(ctx, ctx.FormInt64("id")); err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("DeleteTrustedUserCAKey", err)
+		return
+	}
+	log.Info("Trusted SSH user certificate authority %d deleted by %s", ctx.FormInt64("id"), ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.ssh_user_cas.deletion_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/ssh_user_cas")
+}
@@ -12,6 +12,7 @@ import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
@@ -31,6 +32,7 @@ func Code(ctx *context.Context) {
 	ctx.Data["UsersPageIsDisabled"] = setting.Service.Explore.DisableUsersPage
 	ctx.Data["OrganizationsPageIsDisabled"] = setting.Service.Explore.DisableOrganizationsPage
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsContentIndexerEnabled"] = setting.Indexer.ContentIndexerEnabled
 	ctx.Data["Title"] = ctx.Tr("explore_title")
 	ctx.Data["PageIsExplore"] = true
 	ctx.Data["PageIsExploreCode"] = true
@@ -69,14 +71,25 @@ func Code(ctx *context.Context) {
 		total                 int
 		searchResults         []*code_indexer.Result
 		searchResultLanguages []*code_indexer.SearchResultLanguages
+		searchTruncated       bool
 	)
 
-	if (len(repoIDs) > 0) || isAdmin {
-		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
-			RepoIDs:    repoIDs,
-			Keyword:    prepareSearch.Keyword,
-			SearchMode: prepareSearch.SearchMode,
-			Language:   prepareSearch.Language,
+	searchable := len(repoIDs) > 0 || isAdmin
+	if searchable && len(prepareSearch.RepoNames) > 0 {
+		repoIDs, searchable, err = common.FilterCodeSearchRepoIDs(ctx, util.Iif(isAdmin, nil, repoIDs), prepareSearch.RepoNames, "")
+		if err != nil {
+			ctx.ServerError("FilterCodeSearchRepoIDs", err)
+			return
+		}
+	}
+
+	if searchable {
+		total, searchResults, searchResultLanguages, searchTruncated, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+			RepoIDs:      repoIDs,
+			Keyword:      prepareSearch.Keyword,
+			SearchMode:   prepareSearch.SearchMode,
+			Language:     prepareSearch.Language,
+			PathPatterns: prepareSearch.PathPatterns,
 			Paginator: &db.ListOptions{
 				Page:     page,
 				PageSize: setting.UI.RepoSearchPagingNum,
@@ -122,6 +135,7 @@ func Code(ctx *context.Context) {
 
 	ctx.Data["SearchResults"] = searchResults
 	ctx.Data["SearchResultLanguages"] = searchResultLanguages
+	ctx.Data["SearchResultsTruncated"] = searchTruncated
 
 	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
 	pager.AddParamFromRequest(ctx.Req)
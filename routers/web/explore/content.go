@@ -0,0 +1,78 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// tplExploreContent explore wiki pages, releases and commits page template
+const tplExploreContent templates.TplName = "explore/content"
+
+// Content render explore wiki pages, releases or commits page
+func Content(ctx *context.Context) {
+	if !setting.Indexer.ContentIndexerEnabled {
+		ctx.Redirect(setting.AppSubURL + "/explore")
+		return
+	}
+
+	prepareSearch, ok := common.PrepareContentSearch(ctx)
+	if !ok {
+		ctx.NotFound(nil)
+		return
+	}
+	unitType := common.ContentSearchUnitType(prepareSearch.Kind)
+	if unitType.UnitGlobalDisabled() {
+		ctx.NotFound(nil)
+		return
+	}
+
+	ctx.Data["UsersPageIsDisabled"] = setting.Service.Explore.DisableUsersPage
+	ctx.Data["OrganizationsPageIsDisabled"] = setting.Service.Explore.DisableOrganizationsPage
+	ctx.Data["CodePageIsDisabled"] = setting.Service.Explore.DisableCodePage
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsContentIndexerEnabled"] = setting.Indexer.ContentIndexerEnabled
+	ctx.Data["Title"] = ctx.Tr("explore_title")
+	ctx.Data["PageIsExplore"] = true
+
+	if prepareSearch.Keyword == "" {
+		ctx.HTML(http.StatusOK, tplExploreContent)
+		return
+	}
+
+	var repoIDs []int64
+	// guest user or non-admin user
+	if ctx.Doer == nil || !ctx.Doer.IsAdmin {
+		var err error
+		repoIDs, err = repo_model.SearchRepositoryIDsByCondition(ctx, repo_model.AccessibleRepositoryCondition(ctx.Doer, unitType))
+		if err != nil {
+			ctx.ServerError("SearchRepositoryIDsByCondition", err)
+			return
+		}
+		if len(repoIDs) == 0 {
+			ctx.HTML(http.StatusOK, tplExploreContent)
+			return
+		}
+	}
+
+	common.SearchContents(ctx, &content_indexer.SearchOptions{
+		Keyword:    prepareSearch.Keyword,
+		SearchMode: prepareSearch.SearchMode,
+		Kind:       prepareSearch.Kind,
+		RepoIDs:    repoIDs,
+	})
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["ShowRepoOnResults"] = true
+
+	ctx.HTML(http.StatusOK, tplExploreContent)
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"net/http"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// tplExploreFiles explore file paths page template
+const tplExploreFiles templates.TplName = "explore/files"
+
+// fileSearchResult is a file found by a path search with the repository it belongs to
+type fileSearchResult struct {
+	*code_indexer.Result
+	Repo *repo_model.Repository
+	Link string
+}
+
+// Files render explore file paths page, the paths of the files of all the readable repositories are searched fuzzily
+func Files(ctx *context.Context) {
+	if !setting.Indexer.RepoIndexerEnabled || setting.Service.Explore.DisableCodePage {
+		ctx.Redirect(setting.AppSubURL + "/explore")
+		return
+	}
+
+	ctx.Data["UsersPageIsDisabled"] = setting.Service.Explore.DisableUsersPage
+	ctx.Data["OrganizationsPageIsDisabled"] = setting.Service.Explore.DisableOrganizationsPage
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsContentIndexerEnabled"] = setting.Indexer.ContentIndexerEnabled
+	ctx.Data["Title"] = ctx.Tr("explore_title")
+	ctx.Data["PageIsExplore"] = true
+	ctx.Data["PageIsExploreFiles"] = true
+
+	keyword := ctx.FormTrim("q")
+	language := ctx.FormTrim("l")
+	orgName := ctx.FormTrim("org")
+	extensions := ctx.FormTrim("ext")
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["Language"] = language
+	ctx.Data["OrgName"] = orgName
+	ctx.Data["Extensions"] = extensions
+	if keyword == "" {
+		ctx.HTML(http.StatusOK, tplExploreFiles)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	repoIDs, searchable, err := common.FileSearchRepoIDs(ctx, ctx.Doer, orgName)
+	if err != nil {
+		ctx.ServerError("FileSearchRepoIDs", err)
+		return
+	}
+
+	var (
+		total                 int
+		searchResults         []*fileSearchResult
+		searchResultLanguages []*code_indexer.SearchResultLanguages
+		searchTruncated       bool
+	)
+	if searchable {
+		var results []*code_indexer.Result
+		total, results, searchResultLanguages, searchTruncated, err = code_indexer.SearchPaths(ctx, &code_indexer.SearchOptions{
+			RepoIDs:    repoIDs,
+			Keyword:    keyword,
+			Language:   language,
+			Extensions: common.ParseFileSearchExtensions(extensions),
+			Paginator: &db.ListOptions{
+				Page:     page,
+				PageSize: setting.UI.RepoSearchPagingNum,
+			},
+		})
+		if err != nil {
+			if code_indexer.IsAvailable(ctx) {
+				ctx.ServerError("SearchPaths", err)
+				return
+			}
+			ctx.Data["CodeIndexerUnavailable"] = true
+		} else {
+			ctx.Data["CodeIndexerUnavailable"] = !code_indexer.IsAvailable(ctx)
+		}
+
+		loadRepoIDs := make([]int64, 0, len(results))
+		for _, result := range results {
+			if !slices.Contains(loadRepoIDs, result.RepoID) {
+				loadRepoIDs = append(loadRepoIDs, result.RepoID)
+			}
+		}
+		repoMaps, err := repo_model.GetRepositoriesMapByIDs(ctx, loadRepoIDs)
+		if err != nil {
+			ctx.ServerError("GetRepositoriesMapByIDs", err)
+			return
+		}
+
+		// the repositories which don't exist anymore are removed from the results
+		searchResults = make([]*fileSearchResult, 0, len(results))
+		for _, result := range results {
+			if repo, ok := repoMaps[result.RepoID]; ok {
+				searchResults = append(searchResults, &fileSearchResult{Result: result, Repo: repo, Link: common.FileSearchResultLink(repo, result)})
+			}
+		}
+	}
+
+	ctx.Data["SearchResults"] = searchResults
+	ctx.Data["SearchResultLanguages"] = searchResultLanguages
+	ctx.Data["SearchResultsTruncated"] = searchTruncated
+
+	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplExploreFiles)
+}
@@ -335,6 +335,8 @@ func NewTeamPost(ctx *context.Context) {
 		AccessMode:              teamPermission,
 		IncludesAllRepositories: includesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
+		ReviewAssignPolicy:      org_model.ParseReviewAssignPolicy(form.ReviewAssignPolicy),
+		ReviewAssignCount:       form.ReviewAssignCount,
 	}
 
 	units := make([]*org_model.TeamUnit, 0, len(unitPerms))
@@ -519,6 +521,8 @@ func EditTeamPost(ctx *context.Context) {
 	}
 
 	t.Description = form.Description
+	t.ReviewAssignPolicy = org_model.ParseReviewAssignPolicy(form.ReviewAssignPolicy)
+	t.ReviewAssignCount = form.ReviewAssignCount
 	units := make([]*org_model.TeamUnit, 0, len(unitPerms))
 	for tp, perm := range unitPerms {
 		units = append(units, &org_model.TeamUnit{
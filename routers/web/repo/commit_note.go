@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"html/template"
+	"path"
+
+	"code.gitea.io/gitea/models/renderhelper"
+	unit_model "code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	mirror_service "code.gitea.io/gitea/services/mirror"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// commitNoteView is a git note rendered on the commit page
+type commitNoteView struct {
+	*repo_service.CommitNote
+	Author   *user_model.User
+	Content  string
+	Rendered string
+}
+
+// prepareCommitNotes renders the notes of the commit in all notes namespaces, it returns false if an error has been written
+func prepareCommitNotes(ctx *context.Context, commitID string) bool {
+	notes, err := repo_service.GetCommitNotes(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, commitID)
+	if err != nil {
+		log.Error("GetCommitNotes: %v", err)
+	}
+
+	views := make([]*commitNoteView, 0, len(notes))
+	for _, note := range notes {
+		content := string(charset.ToUTF8WithFallback(note.Message, charset.ConvertOpts{}))
+		rctx := renderhelper.NewRenderContextRepoComment(ctx, ctx.Repo.Repository, renderhelper.RepoCommentOptions{CurrentRefPath: path.Join("commit", util.PathEscapeSegments(commitID))})
+		rendered, err := markup.PostProcessCommitMessage(rctx, template.HTMLEscapeString(content))
+		if err != nil {
+			ctx.ServerError("PostProcessCommitMessage", err)
+			return false
+		}
+		views = append(views, &commitNoteView{
+			CommitNote: note,
+			Author:     user_model.ValidateCommitWithEmail(ctx, note.Commit),
+			Content:    content,
+			Rendered:   rendered,
+		})
+	}
+	ctx.Data["Notes"] = views
+	ctx.Data["CanEditNotes"] = ctx.Repo.CanWrite(unit_model.TypeCode) && !ctx.Repo.Repository.IsArchived
+	return true
+}
+
+// resolveNoteRefAndCommit returns the notes ref and the commit ID of a note request, it returns false if an error has been written
+func resolveNoteRefAndCommit(ctx *context.Context, namespace string) (notesRef, commitID string, ok bool) {
+	notesRef, ok = git.NotesRefName(namespace)
+	if !ok {
+		ctx.JSONError(ctx.Tr("repo.commit.notes.invalid_ref", namespace))
+		return "", "", false
+	}
+	id, err := ctx.Repo.GitRepo.ConvertToGitID(ctx.PathParam("sha"))
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("ConvertToGitID", err)
+		}
+		return "", "", false
+	}
+	return notesRef, id.String(), true
+}
+
+// SetCommitNotePost creates or updates the note of a commit
+func SetCommitNotePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CommitNoteForm)
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	notesRef, commitID, ok := resolveNoteRefAndCommit(ctx, form.Ref)
+	if !ok {
+		return
+	}
+	if err := repo_service.SetCommitNote(ctx, ctx.Doer, ctx.Repo.Repository, notesRef, commitID, form.Message); err != nil {
+		ctx.ServerError("SetCommitNote", err)
+		return
+	}
+	mirror_service.SyncPushMirrorWithSyncOnCommit(ctx, ctx.Repo.Repository.ID)
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/commit/" + commitID)
+}
+
+// DeleteCommitNotePost deletes the note of a commit
+func DeleteCommitNotePost(ctx *context.Context) {
+	notesRef, commitID, ok := resolveNoteRefAndCommit(ctx, ctx.FormString("ref"))
+	if !ok {
+		return
+	}
+	if err := repo_service.DeleteCommitNote(ctx, ctx.Doer, ctx.Repo.Repository, notesRef, commitID); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.JSONError(ctx.Tr("repo.commit.notes.not_exist"))
+		} else {
+			ctx.ServerError("DeleteCommitNote", err)
+		}
+		return
+	}
+	mirror_service.SyncPushMirrorWithSyncOnCommit(ctx, ctx.Repo.Repository.ID)
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/commit/" + commitID)
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"strings"
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectToTranslatedCommit(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	oldID := "abcdef0123456789abcdef0123456789abcdef01"
+	newID := strings.Repeat("0123456789abcdef", 4)
+	assert.NoError(t, git_model.InsertObjectIDTranslations(t.Context(), 1, map[string]string{oldID: newID}))
+
+	ctx, resp := contexttest.MockContext(t, "/user2/repo1/commit/abcdef0?style=split")
+	contexttest.LoadRepo(t, ctx, 1)
+	assert.True(t, ctx.RedirectToTranslatedCommit("abcdef0"))
+	assert.Equal(t, "/user2/repo1/commit/"+newID+"?style=split", resp.Header().Get("Location"))
+
+	// a repository name looking like the commit ID is kept
+	ctx, resp = contexttest.MockContext(t, "/user2/abcdef0/src/commit/abcdef0/README.md")
+	contexttest.LoadRepo(t, ctx, 1)
+	ctx.Repo.RepoLink = "/user2/abcdef0"
+	assert.True(t, ctx.RedirectToTranslatedCommit("abcdef0"))
+	assert.Equal(t, "/user2/abcdef0/src/commit/"+newID+"/README.md", resp.Header().Get("Location"))
+
+	ctx, _ = contexttest.MockContext(t, "/user2/repo1/commit/1234567")
+	contexttest.LoadRepo(t, ctx, 1)
+	assert.False(t, ctx.RedirectToTranslatedCommit("1234567"))
+}
@@ -5,8 +5,11 @@
 package repo
 
 import (
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path"
@@ -18,6 +21,7 @@ import (
 	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -29,10 +33,13 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/services/repository/clonebundle"
 
 	"github.com/go-chi/cors"
 )
@@ -425,7 +432,7 @@ func serviceRPC(ctx *context.Context, service string) {
 
 	ctx.Resp.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-result", service))
 
-	reqBody := ctx.Req.Body
+	var reqBody io.Reader = ctx.Req.Body
 
 	// Handle GZIP.
 	if ctx.Req.Header.Get("Content-Encoding") == "gzip" {
@@ -437,24 +444,75 @@ func serviceRPC(ctx *context.Context, service string) {
 		}
 	}
 
+	// git upload-pack doesn't advertise the pre-generated bundles, the bundle-uri command is answered by Gitea
+	if service == ServiceTypeUploadPack && setting.CloneBundle.Enabled && !h.isWiki {
+		bufReader := bufio.NewReader(reqBody)
+		if cmd, _ := bufReader.Peek(len(bundleURICommand)); bytes.Equal(cmd, bundleURICommand) {
+			h.writeBundleList(ctx)
+			return
+		}
+		reqBody = bufReader
+	}
+
 	// set this for allow pre-receive and post-receive execute
 	h.environ = append(h.environ, "SSH_ORIGINAL_COMMAND="+service)
 
 	if protocol := ctx.Req.Header.Get("Git-Protocol"); protocol != "" && safeGitProtocolHeader.MatchString(protocol) {
 		h.environ = append(h.environ, "GIT_PROTOCOL="+protocol)
 	}
+	var policyErr error
+	if service == ServiceTypeUploadPack {
+		policy, err := h.uploadPackPolicy(ctx)
+		if err != nil {
+			ctx.ServerError("GetRepoClonePolicy", err)
+			return
+		}
+		h.environ = append(h.environ, repo_module.UploadPackEnvironment(h.isWiki, policy)...)
+		if policy != nil {
+			reqBody = git.NewUploadPackRequestReader(reqBody, func(req *git.UploadPackRequest) error {
+				policyErr = policy.CheckRequest(req, func(wants []string) (bool, error) {
+					return gitrepo.UploadPackWantsHistory(ctx, h.getStorageRepo(), wants)
+				})
+				return policyErr
+			}, func(req *git.UploadPackRequest) {
+				if !req.IsClone() {
+					return
+				}
+				if err := repo_service.CountClone(ctx, h.repo, repo_model.CloneKindOfRequest(req), req.Wants[0]); err != nil {
+					log.Error("CountClone: %v", err)
+				}
+			})
+		}
+	}
 
 	if err := gitrepo.RunCmdWithStderr(ctx, h.getStorageRepo(), cmd.AddArguments(".").
 		WithEnv(append(os.Environ(), h.environ...)).
 		WithStdinCopy(reqBody).
 		WithStdoutCopy(ctx.Resp),
 	); err != nil {
+		if policyErr != nil {
+			// git upload-pack hasn't got the whole request, so it has sent nothing
+			_, _ = ctx.Resp.Write(git.UploadPackErrorPacket(policyErr.Error()))
+			return
+		}
 		if !gitcmd.IsErrorCanceledOrKilled(err) {
 			log.Error("Fail to serve RPC(%s) in %s: %v", service, h.getStorageRepo().RelativePath(), err)
 		}
 	}
 }
 
+// uploadPackPolicy returns the policy of the clones and fetches of the repository, the wiki follows the instance settings
+func (h *serviceHandler) uploadPackPolicy(ctx *context.Context) (*git.UploadPackPolicy, error) {
+	if h.isWiki {
+		return nil, nil
+	}
+	p, err := repo_model.GetRepoClonePolicy(ctx, h.repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	return p.UploadPackPolicy(!ctx.IsSigned), nil
+}
+
 const (
 	ServiceTypeUploadPack    = "upload-pack"
 	ServiceTypeReceivePack   = "receive-pack"
@@ -507,9 +565,19 @@ func GetInfoRefs(ctx *context.Context) {
 		return
 	}
 
-	if protocol := ctx.Req.Header.Get("Git-Protocol"); protocol != "" && safeGitProtocolHeader.MatchString(protocol) {
+	protocol := ctx.Req.Header.Get("Git-Protocol")
+	if protocol != "" && safeGitProtocolHeader.MatchString(protocol) {
 		h.environ = append(h.environ, "GIT_PROTOCOL="+protocol)
 	}
+	if h.serviceType == ServiceTypeUploadPack {
+		// the advertised capabilities depend on the policy
+		policy, err := h.uploadPackPolicy(ctx)
+		if err != nil {
+			ctx.ServerError("GetRepoClonePolicy", err)
+			return
+		}
+		h.environ = append(h.environ, repo_module.UploadPackEnvironment(h.isWiki, policy)...)
+	}
 	h.environ = append(os.Environ(), h.environ...)
 
 	cmd = cmd.AddArguments("--stateless-rpc", "--advertise-refs", ".").WithEnv(h.environ)
@@ -519,6 +587,14 @@ func GetInfoRefs(ctx *context.Context) {
 		return
 	}
 
+	// the protocol v2 capability advertisement ends with a flush-pkt, add the bundle-uri capability before it
+	if h.serviceType == ServiceTypeUploadPack && strings.Contains(protocol, "version=2") && bytes.HasSuffix(refs, []byte("0000")) {
+		if len(h.bundleList(ctx)) > 0 {
+			refs = append(refs[:len(refs)-4:len(refs)-4], packetWrite("bundle-uri\n")...)
+			refs = append(refs, "0000"...)
+		}
+	}
+
 	ctx.Resp.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-advertisement", h.serviceType))
 	ctx.Resp.WriteHeader(http.StatusOK)
 	_, _ = ctx.Resp.Write(packetWrite("# service=git-" + h.serviceType + "\n"))
@@ -526,6 +602,71 @@ func GetInfoRefs(ctx *context.Context) {
 	_, _ = ctx.Resp.Write(refs)
 }
 
+var bundleURICommand = packetWrite("command=bundle-uri\n")
+
+// bundleList returns the bundle list of the pre-generated clone bundles for the bundle-uri command
+func (h *serviceHandler) bundleList(ctx *context.Context) []string {
+	if h.isWiki || !setting.CloneBundle.Enabled {
+		return nil
+	}
+	list, err := clonebundle.BundleList(ctx, h.repo)
+	if err != nil {
+		log.Error("Unable to get the clone bundles of %s: %v", h.repo.FullName(), err)
+		return nil
+	}
+	return list
+}
+
+func (h *serviceHandler) writeBundleList(ctx *context.Context) {
+	var buf bytes.Buffer
+	for _, line := range h.bundleList(ctx) {
+		buf.Write(packetWrite(line + "\n"))
+	}
+	buf.WriteString("0000")
+	_, _ = ctx.Resp.Write(buf.Bytes())
+}
+
+// GetCloneBundle serves the pre-generated bundles advertised by the bundle-uri capability
+func GetCloneBundle(ctx *context.Context) {
+	h := httpBase(ctx)
+	if h == nil {
+		return
+	}
+	if h.isWiki || !setting.CloneBundle.Enabled {
+		ctx.HTTPError(http.StatusNotFound)
+		return
+	}
+
+	bundle, err := repo_model.GetCloneBundle(ctx, h.repo.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.HTTPError(http.StatusNotFound)
+		} else {
+			ctx.ServerError("GetCloneBundle", err)
+		}
+		return
+	}
+	filename := fmt.Sprintf("%s-%d.bundle", h.repo.Name, bundle.ID)
+
+	if setting.CloneBundle.Storage.ServeDirect() {
+		// If we have a signed url (S3, object storage), redirect to this directly.
+		u, err := storage.CloneBundles.URL(bundle.RelativePath(), filename, ctx.Req.Method, nil)
+		if u != nil && err == nil {
+			ctx.Redirect(u.String())
+			return
+		}
+	}
+
+	fr, err := storage.CloneBundles.Open(bundle.RelativePath())
+	if err != nil {
+		ctx.ServerError("Open", err)
+		return
+	}
+	defer fr.Close()
+
+	common.ServeContentByReadSeeker(ctx.Base, filename, new(bundle.CreatedUnix.AsTime()), fr)
+}
+
 // GetTextFile implements Git dumb HTTP
 func GetTextFile(p string) func(*context.Context) {
 	return func(ctx *context.Context) {
@@ -5,6 +5,7 @@ package repo
 
 import (
 	"bytes"
+	"errors"
 	"maps"
 	"net/http"
 	"slices"
@@ -516,6 +517,7 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 
 	var keywordMatchedIssueIDs []int64
 	var issueStats *issues_model.IssueStats
+	var queryIsClosed optional.Option[bool] // the state given by the search query
 	statsOpts := &issues_model.IssuesOptions{
 		RepoIDs:           []int64{repo.ID},
 		LabelIDs:          preparedLabelFilter.SelectedLabelIDs,
@@ -529,9 +531,23 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 		IsPull:            isPullOption,
 		IssueIDs:          nil,
 	}
+	var searchOpts *issue_indexer.SearchOptions
 	if keyword != "" {
-		keywordMatchedIssueIDs, _, err = issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, statsOpts))
-		if err != nil {
+		searchOpts = issue_indexer.ToSearchOptions("", statsOpts)
+		query, err := issue_indexer.ParseQuery(keyword)
+		if err == nil {
+			err = query.Apply(ctx, searchOpts, ctx.Doer)
+		}
+		if err == nil {
+			keywordMatchedIssueIDs, _, err = issue_indexer.SearchIssues(ctx, searchOpts)
+			if query.SortType() != "" {
+				sortType = query.SortType()
+			}
+			queryIsClosed = searchOpts.IsClosed
+		}
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Data["SearchQueryError"] = err.Error()
+		} else if err != nil {
 			if issue_indexer.IsAvailable(ctx) {
 				ctx.ServerError("issueIDsFromSearch", err)
 				return
@@ -559,6 +575,9 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 	}
 
 	isShowClosed := common.ParseIssueFilterStateIsClosed(ctx.FormString("state"))
+	if queryIsClosed.Has() {
+		isShowClosed = queryIsClosed
+	}
 
 	// if there are closed issues and no open issues, default to showing all issues
 	if ctx.FormString("state") == "" && issueStats.OpenCount == 0 && issueStats.ClosedCount != 0 {
@@ -617,6 +636,14 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 			ctx.ServerError("GetIssuesByIDs", err)
 			return
 		}
+		if searchOpts != nil {
+			// the snippets are only a hint of why the issues match, so the issues are still listed without them
+			highlights, err := issue_indexer.HighlightIssues(ctx, searchOpts, issueIDs)
+			if err != nil {
+				log.Warn("HighlightIssues: %v", err)
+			}
+			ctx.Data["IssueHighlights"] = highlights
+		}
 	}
 
 	approvalCounts, err := issues.GetApprovalCounts(ctx)
@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+)
+
+const (
+	tplIssueSimilar templates.TplName = "repo/issue/similar"
+
+	similarIssuesLimit = 5
+)
+
+// SimilarIssues renders the issues which are semantically similar to the title of a new issue
+func SimilarIssues(ctx *context.Context) {
+	title := ctx.FormTrim("title")
+	if !issue_indexer.IsSemanticSearchEnabled() || title == "" {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	// the pull requests are also embedded, more issues are searched to show enough issues after they are filtered
+	ids, err := issue_indexer.SimilarIssues(ctx, ctx.Repo.Repository.ID, title, similarIssuesLimit*2)
+	if err != nil {
+		ctx.ServerError("SimilarIssues", err)
+		return
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	if err != nil {
+		ctx.ServerError("GetIssuesByIDs", err)
+		return
+	}
+	similar := make([]*issues_model.Issue, 0, similarIssuesLimit)
+	for _, issue := range issues {
+		if issue.IsPull {
+			continue
+		}
+		issue.Repo = ctx.Repo.Repository
+		similar = append(similar, issue)
+		if len(similar) == similarIssuesLimit {
+			break
+		}
+	}
+	ctx.Data["SimilarIssues"] = similar
+	ctx.HTML(http.StatusOK, tplIssueSimilar)
+}
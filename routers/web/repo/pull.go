@@ -56,9 +56,10 @@ import (
 )
 
 const (
-	tplCompareDiff templates.TplName = "repo/diff/compare"
-	tplPullCommits templates.TplName = "repo/pulls/commits"
-	tplPullFiles   templates.TplName = "repo/pulls/files"
+	tplCompareDiff   templates.TplName = "repo/diff/compare"
+	tplPullCommits   templates.TplName = "repo/pulls/commits"
+	tplPullFiles     templates.TplName = "repo/pulls/files"
+	tplPullRangeDiff templates.TplName = "repo/pulls/range_diff"
 
 	pullRequestTemplateKey = "PullRequestTemplate"
 )
@@ -605,6 +606,11 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		ctx.Data["WorkInProgressPrefix"] = pull.GetWorkInProgressPrefix(ctx)
 	}
 
+	if unchecked := issue_template.UncheckedChecklistItems(issue.Content, pull.RequiredChecklist); len(unchecked) > 0 {
+		ctx.Data["IsPullRequiredChecklistUnchecked"] = true
+		ctx.Data["UncheckedRequiredChecklist"] = unchecked
+	}
+
 	if pull.IsFilesConflicted() {
 		ctx.Data["IsPullFilesConflicted"] = true
 		ctx.Data["ConflictedFiles"] = pull.ConflictedFiles
@@ -649,11 +655,12 @@ func GetPullCommits(ctx *context.Context) {
 
 	// Get the needed locale
 	resp.Locale = map[string]any{
-		"lang":                                ctx.Locale.Language(),
-		"show_all_commits":                    ctx.Tr("repo.pulls.show_all_commits"),
-		"stats_num_commits":                   ctx.TrN(len(commits), "repo.activity.git_stats_commit_1", "repo.activity.git_stats_commit_n", len(commits)),
-		"show_changes_since_your_last_review": ctx.Tr("repo.pulls.show_changes_since_your_last_review"),
-		"select_commit_hold_shift_for_range":  ctx.Tr("repo.pulls.select_commit_hold_shift_for_range"),
+		"lang":                                   ctx.Locale.Language(),
+		"show_all_commits":                       ctx.Tr("repo.pulls.show_all_commits"),
+		"stats_num_commits":                      ctx.TrN(len(commits), "repo.activity.git_stats_commit_1", "repo.activity.git_stats_commit_n", len(commits)),
+		"show_changes_since_your_last_review":    ctx.Tr("repo.pulls.show_changes_since_your_last_review"),
+		"show_range_diff_since_your_last_review": ctx.Tr("repo.pulls.show_range_diff_since_your_last_review"),
+		"select_commit_hold_shift_for_range":     ctx.Tr("repo.pulls.select_commit_hold_shift_for_range"),
 	}
 
 	resp.Commits = commits
@@ -995,6 +1002,63 @@ func ViewPullFilesForAllCommitsOfPr(ctx *context.Context) {
 	viewPullFiles(ctx, "", "")
 }
 
+// viewPullRangeDiff render the changes of the commit series between two pushes, ignoring changes of the base branch
+func viewPullRangeDiff(ctx *context.Context, beforeCommitID, afterCommitID string) {
+	ctx.Data["PageIsPullList"] = true
+
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	if preparePullViewPullInfo(ctx, issue); ctx.Written() {
+		return
+	}
+
+	if beforeCommitID == "" {
+		// compare with the commit of the doer's last review
+		_, lastReviewCommitID, err := pull_service.GetPullCommits(ctx, ctx.Repo.GitRepo, ctx.Doer, issue)
+		if err != nil {
+			ctx.ServerError("GetPullCommits", err)
+			return
+		}
+		if lastReviewCommitID == "" {
+			ctx.NotFound(nil)
+			return
+		}
+		beforeCommitID = lastReviewCommitID
+	}
+
+	for _, commitID := range []string{beforeCommitID, afterCommitID} {
+		if commitID == "" {
+			continue
+		}
+		if _, err := ctx.Repo.GitRepo.GetCommit(commitID); err != nil {
+			ctx.NotFoundOrServerError("GetCommit", git.IsErrNotExist, err)
+			return
+		}
+	}
+
+	rangeDiff, err := pull_service.GetPullRangeDiff(ctx, ctx.Repo.GitRepo, issue.PullRequest, beforeCommitID, afterCommitID)
+	if err != nil {
+		ctx.ServerError("GetPullRangeDiff", err)
+		return
+	}
+	ctx.Data["RangeDiff"] = rangeDiff
+
+	getBranchData(ctx, issue)
+	ctx.HTML(http.StatusOK, tplPullRangeDiff)
+}
+
+// ViewPullRangeDiffSinceLastReview render the changes since the doer's last review
+func ViewPullRangeDiffSinceLastReview(ctx *context.Context) {
+	viewPullRangeDiff(ctx, "", "")
+}
+
+// ViewPullRangeDiffForRange render the changes between two pushes
+func ViewPullRangeDiffForRange(ctx *context.Context) {
+	viewPullRangeDiff(ctx, ctx.PathParam("shaFrom"), ctx.PathParam("shaTo"))
+}
+
 // UpdatePullRequest merge PR's baseBranch into headBranch
 func UpdatePullRequest(ctx *context.Context) {
 	issue, ok := getPullInfo(ctx)
@@ -1125,6 +1189,8 @@ func MergePullRequest(ctx *context.Context) {
 			ctx.JSONError(err.Error()) // has no translation ...
 		case errors.Is(err, pull_service.ErrDependenciesLeft):
 			ctx.JSONError(ctx.Tr("repo.issues.dependency.pr_close_blocked"))
+		case errors.Is(err, pull_service.ErrRequiredChecklistUnchecked):
+			ctx.JSONError(ctx.Tr("repo.pulls.no_merge_required_checklist"))
 		default:
 			ctx.ServerError("WebCheck", err)
 		}
@@ -1403,9 +1469,15 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	}
 
 	content := form.Content
+	var requiredChecklist []string
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
 		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
+			if missing := issue_template.MissingRequiredFields(template, ctx.Req.Form); len(missing) > 0 {
+				ctx.JSONError(ctx.Tr("repo.pulls.new.template_field_required", strings.Join(missing, ", ")))
+				return
+			}
 			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+			requiredChecklist = issue_template.RequiredChecklist(template)
 		}
 	}
 
@@ -1429,6 +1501,7 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 		MergeBase:           ci.MergeBase,
 		Type:                issues_model.PullRequestGitea,
 		AllowMaintainerEdit: form.AllowMaintainerEdit,
+		RequiredChecklist:   requiredChecklist,
 	}
 	// FIXME: check error in the case two people send pull request at almost same time, give nice error prompt
 	// instead of 500.
@@ -0,0 +1,145 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+const tplPullConflicts templates.TplName = "repo/pulls/conflicts"
+
+// getPullInfoForConflicts loads the pull request and checks whether the doer is allowed to update its head branch
+func getPullInfoForConflicts(ctx *context.Context) (*issues_model.Issue, bool) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return nil, false
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		ctx.NotFound(nil)
+		return nil, false
+	}
+	if err := issue.PullRequest.LoadBaseRepo(ctx); err != nil {
+		ctx.ServerError("LoadBaseRepo", err)
+		return nil, false
+	}
+	if issue.PullRequest.HeadRepo == nil {
+		ctx.NotFound(nil)
+		return nil, false
+	}
+
+	allowedUpdateByMerge, _, err := pull_service.IsUserAllowedToUpdate(ctx, issue.PullRequest, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("IsUserAllowedToUpdate", err)
+		return nil, false
+	}
+	if !allowedUpdateByMerge {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.update_not_allowed"))
+		ctx.Redirect(issue.Link())
+		return nil, false
+	}
+	return issue, true
+}
+
+// ViewPullConflicts shows the editor to resolve the conflicts between the head and the base branch of a pull request
+func ViewPullConflicts(ctx *context.Context) {
+	issue, ok := getPullInfoForConflicts(ctx)
+	if !ok {
+		return
+	}
+	pull := issue.PullRequest
+
+	headCommitID, err := ctx.Repo.GitRepo.GetRefCommitID(pull.GetGitHeadRefName())
+	if err != nil {
+		ctx.ServerError("GetRefCommitID", err)
+		return
+	}
+
+	files, err := pull_service.GetConflictFiles(ctx, pull, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetConflictFiles", err)
+		return
+	}
+	if len(files) == 0 {
+		ctx.Flash.Info(ctx.Tr("repo.pulls.conflicts.no_conflicts"))
+		ctx.Redirect(issue.Link())
+		return
+	}
+
+	canResolve := true
+	for _, file := range files {
+		canResolve = canResolve && file.IsResolvable
+	}
+
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["ConflictFiles"] = files
+	ctx.Data["CanResolveConflicts"] = canResolve
+	ctx.Data["HeadCommitID"] = headCommitID
+	ctx.Data["DefaultMessage"] = fmt.Sprintf("Merge branch '%s' into %s", pull.BaseBranch, pull.HeadBranch)
+	ctx.HTML(http.StatusOK, tplPullConflicts)
+}
+
+// ResolvePullConflicts commits the resolved conflicts as a merge of the base branch into the head branch of a pull request
+func ResolvePullConflicts(ctx *context.Context) {
+	issue, ok := getPullInfoForConflicts(ctx)
+	if !ok {
+		return
+	}
+	pull := issue.PullRequest
+	conflictsLink := issue.Link() + "/conflicts"
+
+	// the mode and the hunk choices of a file are named after the index of the file in all conflicting files,
+	// which is submitted with its path because the files which can't be resolved have no inputs
+	paths, indexes, contents := ctx.FormStrings("path"), ctx.FormStrings("index"), ctx.FormStrings("content")
+	if len(paths) == 0 || len(paths) != len(contents) || len(paths) != len(indexes) {
+		ctx.HTTPError(http.StatusBadRequest, "paths and contents mismatch")
+		return
+	}
+	resolutions := make(map[string]*pull_service.ConflictResolution, len(paths))
+	for i, path := range paths {
+		resolution := &pull_service.ConflictResolution{Content: contents[i]}
+		if ctx.FormString("mode-"+indexes[i]) == "hunks" {
+			// the choices of the conflicts which are not chosen are not submitted, so they end at the first missing one
+			for j := 0; ctx.Req.Form.Has(fmt.Sprintf("hunk-%s-%d", indexes[i], j)); j++ {
+				resolution.Choices = append(resolution.Choices, pull_service.ConflictChoice(ctx.FormString(fmt.Sprintf("hunk-%s-%d", indexes[i], j))))
+			}
+			if len(resolution.Choices) == 0 {
+				ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.not_resolved", path))
+				ctx.Redirect(conflictsLink)
+				return
+			}
+		}
+		resolutions[path] = resolution
+	}
+
+	message := ctx.FormTrim("message")
+	if message == "" {
+		message = fmt.Sprintf("Merge branch '%s' into %s", pull.BaseBranch, pull.HeadBranch)
+	}
+
+	// The update process should not be cancelled by the user
+	// so we set the context to be a background context
+	err := pull_service.ResolveConflicts(graceful.GetManager().ShutdownContext(), pull, ctx.Doer, ctx.FormString("head_commit_id"), resolutions, message)
+	if err != nil {
+		switch {
+		case pull_service.IsErrConflictNotResolved(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.not_resolved", err.(pull_service.ErrConflictNotResolved).Path))
+		case pull_service.IsErrSHADoesNotMatch(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.head_out_of_date"))
+		default:
+			ctx.Flash.Error(err.Error())
+		}
+		ctx.Redirect(conflictsLink)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.conflicts.resolved"))
+	ctx.Redirect(issue.Link())
+}
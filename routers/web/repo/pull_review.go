@@ -77,9 +77,10 @@ func CreateCodeComment(ctx *context.Context) {
 		return
 	}
 
-	signedLine := form.Line
+	signedLine, signedStartLine := form.Line, form.StartLine
 	if form.Side == "previous" {
 		signedLine *= -1
+		signedStartLine *= -1
 	}
 
 	var attachments []string
@@ -91,6 +92,7 @@ func CreateCodeComment(ctx *context.Context) {
 		ctx.Doer,
 		ctx.Repo.GitRepo,
 		issue,
+		signedStartLine,
 		signedLine,
 		form.Content,
 		form.TreePath,
@@ -10,17 +10,22 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/indexer/code/gitgrep"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
 
-const tplSearch templates.TplName = "repo/search"
+const (
+	tplSearch        templates.TplName = "repo/search"
+	tplSearchContent templates.TplName = "repo/search_content"
+)
 
 // Search render repository search page
 func Search(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
+	ctx.Data["IsContentIndexerEnabled"] = setting.Indexer.ContentIndexerEnabled
 	prepareSearch := common.PrepareCodeSearch(ctx)
 	if prepareSearch.Keyword == "" {
 		ctx.HTML(http.StatusOK, tplSearch)
@@ -35,13 +40,15 @@ func Search(ctx *context.Context) {
 	var total int
 	var searchResults []*code_indexer.Result
 	var searchResultLanguages []*code_indexer.SearchResultLanguages
+	var searchTruncated bool
 	if setting.Indexer.RepoIndexerEnabled {
 		var err error
-		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
-			RepoIDs:    []int64{ctx.Repo.Repository.ID},
-			Keyword:    prepareSearch.Keyword,
-			SearchMode: prepareSearch.SearchMode,
-			Language:   prepareSearch.Language,
+		total, searchResults, searchResultLanguages, searchTruncated, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+			RepoIDs:      []int64{ctx.Repo.Repository.ID},
+			Keyword:      prepareSearch.Keyword,
+			SearchMode:   prepareSearch.SearchMode,
+			Language:     prepareSearch.Language,
+			PathPatterns: prepareSearch.PathPatterns,
 			Paginator: &db.ListOptions{
 				Page:     page,
 				PageSize: setting.UI.RepoSearchPagingNum,
@@ -60,7 +67,9 @@ func Search(ctx *context.Context) {
 		var err error
 		// ref should be default branch or the first existing branch
 		searchRef := git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch)
-		searchResults, total, err = gitgrep.PerformSearch(ctx, page, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, searchRef, prepareSearch.Keyword, prepareSearch.SearchMode)
+		// git grep doesn't know the symbols, their names are searched as the keyword
+		_, keyword := code_indexer.ParseSymbolQuery(prepareSearch.Keyword)
+		searchResults, total, err = gitgrep.PerformSearch(ctx, page, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, searchRef, keyword, prepareSearch.SearchMode, prepareSearch.PathPatterns)
 		if err != nil {
 			ctx.ServerError("gitgrep.PerformSearch", err)
 			return
@@ -70,6 +79,7 @@ func Search(ctx *context.Context) {
 	ctx.Data["Repo"] = ctx.Repo.Repository
 	ctx.Data["SearchResults"] = searchResults
 	ctx.Data["SearchResultLanguages"] = searchResultLanguages
+	ctx.Data["SearchResultsTruncated"] = searchTruncated
 
 	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
 	pager.AddParamFromRequest(ctx.Req)
@@ -77,3 +87,34 @@ func Search(ctx *context.Context) {
 
 	ctx.HTML(http.StatusOK, tplSearch)
 }
+
+// SearchContent render repository wiki pages, releases or commits search page
+func SearchContent(ctx *context.Context) {
+	if !setting.Indexer.ContentIndexerEnabled {
+		ctx.NotFound(nil)
+		return
+	}
+	prepareSearch, ok := common.PrepareContentSearch(ctx)
+	if !ok || !ctx.Repo.CanRead(common.ContentSearchUnitType(prepareSearch.Kind)) {
+		ctx.NotFound(nil)
+		return
+	}
+	ctx.Data["IsContentIndexerEnabled"] = true
+
+	if prepareSearch.Keyword == "" {
+		ctx.HTML(http.StatusOK, tplSearchContent)
+		return
+	}
+
+	common.SearchContents(ctx, &content_indexer.SearchOptions{
+		Keyword:    prepareSearch.Keyword,
+		SearchMode: prepareSearch.SearchMode,
+		Kind:       prepareSearch.Kind,
+		RepoIDs:    []int64{ctx.Repo.Repository.ID},
+	})
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplSearchContent)
+}
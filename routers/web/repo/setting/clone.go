@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplRepoSettingsClone templates.TplName = "repo/settings/clone"
+
+// cloneStatDays is the number of the days shown in the clone stats
+const cloneStatDays = 30
+
+// ClonePolicy shows the clone policy and the clone stats of the repository
+func ClonePolicy(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.clone")
+	ctx.Data["PageIsSettingsClone"] = true
+
+	policy, err := repo_model.GetRepoClonePolicy(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetRepoClonePolicy", err)
+		return
+	}
+	ctx.Data["ClonePolicy"] = policy
+	ctx.Data["InstancePartialCloneDisabled"] = setting.Git.DisablePartialClone
+	ctx.Data["InstanceAnonymousMaxDepth"] = setting.Git.AnonymousMaxShallowDepth
+
+	since := timeutil.TimeStamp(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-cloneStatDays).Unix())
+	stats, err := repo_model.GetRepoCloneStats(ctx, ctx.Repo.Repository.ID, since)
+	if err != nil {
+		ctx.ServerError("GetRepoCloneStats", err)
+		return
+	}
+	total := &repo_model.RepoCloneStat{}
+	for _, stat := range stats {
+		total.FullCount += stat.FullCount
+		total.PartialCount += stat.PartialCount
+		total.ShallowCount += stat.ShallowCount
+	}
+	ctx.Data["CloneStats"] = stats
+	ctx.Data["CloneStatsTotal"] = total
+	ctx.Data["CloneStatDays"] = cloneStatDays
+
+	ctx.HTML(http.StatusOK, tplRepoSettingsClone)
+}
+
+// ClonePolicyPost updates the clone policy of the repository
+func ClonePolicyPost(ctx *context.Context) {
+	partialClone := repo_model.PartialClonePolicy(ctx.FormInt("partial_clone"))
+	anonymousMaxDepth := ctx.FormInt("anonymous_max_depth")
+	if partialClone < repo_model.PartialCloneDefault || partialClone > repo_model.PartialCloneRequired || anonymousMaxDepth < -1 {
+		ctx.Flash.Error(ctx.Tr("repo.settings.clone.invalid"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/clone")
+		return
+	}
+
+	if err := repo_model.SaveRepoClonePolicy(ctx, &repo_model.RepoClonePolicy{
+		RepoID:            ctx.Repo.Repository.ID,
+		PartialClone:      partialClone,
+		AnonymousMaxDepth: anonymousMaxDepth,
+	}); err != nil {
+		ctx.ServerError("SaveRepoClonePolicy", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/clone")
+}
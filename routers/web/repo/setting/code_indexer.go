@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplRepoSettingsCodeIndexer templates.TplName = "repo/settings/code_indexer"
+
+// CodeIndexerRefs shows the branches and the tags of the repository indexed by the code indexer
+func CodeIndexerRefs(ctx *context.Context) {
+	if !setting.Indexer.RepoIndexerEnabled {
+		ctx.NotFound(nil)
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("repo.settings.code_indexer")
+	ctx.Data["PageIsSettingsCodeIndexer"] = true
+
+	indexerRefs, err := repo_model.GetRepoCodeIndexerRefs(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetRepoCodeIndexerRefs", err)
+		return
+	}
+	ctx.Data["CodeIndexerRefs"] = indexerRefs
+
+	statuses, err := repo_model.GetIndexerRefStatuses(ctx, ctx.Repo.Repository.ID, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		ctx.ServerError("GetIndexerRefStatuses", err)
+		return
+	}
+	ctx.Data["IndexedRefs"] = statuses
+
+	ctx.HTML(http.StatusOK, tplRepoSettingsCodeIndexer)
+}
+
+// CodeIndexerRefsPost updates the branches and the tags of the repository indexed by the code indexer
+func CodeIndexerRefsPost(ctx *context.Context) {
+	if !setting.Indexer.RepoIndexerEnabled {
+		ctx.NotFound(nil)
+		return
+	}
+	indexerRefs := &repo_model.RepoCodeIndexerRefs{
+		RepoID:         ctx.Repo.Repository.ID,
+		BranchPatterns: ctx.FormTrim("branch_patterns"),
+		TagPatterns:    ctx.FormTrim("tag_patterns"),
+	}
+	for _, patterns := range []string{indexerRefs.BranchPatterns, indexerRefs.TagPatterns} {
+		if err := repo_model.ValidateRefPatterns(patterns); err != nil {
+			ctx.Flash.Error(ctx.Tr("repo.settings.code_indexer.invalid_patterns", err.Error()))
+			ctx.Redirect(ctx.Repo.RepoLink + "/settings/code_indexer")
+			return
+		}
+	}
+
+	if err := repo_model.SaveRepoCodeIndexerRefs(ctx, indexerRefs); err != nil {
+		ctx.ServerError("SaveRepoCodeIndexerRefs", err)
+		return
+	}
+	code_indexer.UpdateRepoIndexer(ctx.Repo.Repository)
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/code_indexer")
+}
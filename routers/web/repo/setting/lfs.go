@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
@@ -98,6 +99,13 @@ func LFSLocks(ctx *context.Context) {
 
 	ctx.Data["LFSLocks"] = lfsLocks
 
+	lockBreaks, err := git_model.FindLFSLockBreaks(ctx, ctx.Repo.Repository.ID, 1, setting.UI.ExplorePagingNum)
+	if err != nil {
+		ctx.ServerError("LFSLocks", err)
+		return
+	}
+	ctx.Data["LFSLockBreaks"] = lockBreaks
+
 	if len(lfsLocks) == 0 {
 		ctx.Data["Page"] = pager
 		ctx.HTML(http.StatusOK, tplSettingsLFSLocks)
@@ -218,7 +226,7 @@ func LFSUnlock(ctx *context.Context) {
 		ctx.NotFound(nil)
 		return
 	}
-	_, err := git_model.DeleteLFSLockByID(ctx, ctx.PathParamInt64("lid"), ctx.Repo.Repository, ctx.Doer, true)
+	_, err := git_model.BreakLFSLock(ctx, ctx.PathParamInt64("lid"), ctx.Repo.Repository, ctx.Doer, strings.TrimSpace(ctx.FormString("reason")))
 	if err != nil {
 		ctx.ServerError("LFSUnlock", err)
 		return
@@ -226,6 +234,23 @@ func LFSUnlock(ctx *context.Context) {
 	ctx.Redirect(ctx.Repo.RepoLink + "/settings/lfs/locks")
 }
 
+// LFSLocksEnforce changes whether the locks are enforced when pushing
+func LFSLocksEnforce(ctx *context.Context) {
+	if !setting.LFS.StartServer {
+		ctx.NotFound(nil)
+		return
+	}
+	repo := ctx.Repo.Repository
+	repo.EnforceLFSLocks = ctx.FormBool("enforce")
+	if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "enforce_lfs_locks"); err != nil {
+		ctx.ServerError("LFSLocksEnforce", err)
+		return
+	}
+	log.Trace("Repository LFS lock enforcement changed to %t: %s/%s", repo.EnforceLFSLocks, ctx.Repo.Owner.Name, repo.Name)
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/lfs/locks")
+}
+
 // LFSFileGet serves a single LFS file
 func LFSFileGet(ctx *context.Context) {
 	if !setting.LFS.StartServer {
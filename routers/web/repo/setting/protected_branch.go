@@ -256,9 +256,11 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.DismissStaleApprovals = f.DismissStaleApprovals
 	protectBranch.IgnoreStaleApprovals = f.IgnoreStaleApprovals
 	protectBranch.RequireSignedCommits = f.RequireSignedCommits
+	protectBranch.RequireSignedPushes = f.RequireSignedPushes
 	protectBranch.ProtectedFilePatterns = f.ProtectedFilePatterns
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
+	protectBranch.BlockOnUnresolvedConversations = f.BlockOnUnresolvedConversations
 	protectBranch.BlockAdminMergeOverride = f.BlockAdminMergeOverride
 
 	if err = pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	admin_model "code.gitea.io/gitea/models/admin"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -35,6 +36,7 @@ import (
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/services/task"
 	wiki_service "code.gitea.io/gitea/services/wiki"
 
 	"xorm.io/xorm/convert"
@@ -61,6 +63,18 @@ func SettingsCtxData(ctx *context.Context) {
 	ctx.Data["DefaultMirrorInterval"] = setting.Mirror.DefaultInterval
 	ctx.Data["MinimumMirrorInterval"] = setting.Mirror.MinInterval
 	ctx.Data["CanConvertFork"] = ctx.Repo.Repository.IsFork && ctx.Doer.CanCreateRepoIn(ctx.Repo.Repository.Owner)
+	if ctx.Repo.IsOwner() && ctx.Repo.Repository.ObjectFormatName != git.Sha256ObjectFormat.Name() {
+		ctx.Data["ShowConvertObjectFormat"] = true
+		if err := repo_service.CheckObjectFormatConversion(ctx.Repo.Repository, git.Sha256ObjectFormat); err != nil {
+			ctx.Data["ConvertObjectFormatError"] = err.Error()
+		}
+		convertTask, err := admin_model.GetLatestRepoTask(ctx, ctx.Repo.Repository.ID, structs.TaskTypeConvertObjectFormat)
+		if err != nil && !admin_model.IsErrTaskDoesNotExist(err) {
+			ctx.ServerError("GetLatestRepoTask", err)
+			return
+		}
+		ctx.Data["ConvertObjectFormatTask"] = convertTask
+	}
 
 	signing, _ := gitrepo.GetSigningKey(ctx)
 	ctx.Data["SigningKeyAvailable"] = signing != nil
@@ -140,6 +154,8 @@ func SettingsPost(ctx *context.Context) {
 		handleSettingsPostAdminIndex(ctx)
 	case "convert":
 		handleSettingsPostConvert(ctx)
+	case "convert_object_format":
+		handleSettingsPostConvertObjectFormat(ctx)
 	case "convert_fork":
 		handleSettingsPostConvertFork(ctx)
 	case "transfer":
@@ -384,6 +400,10 @@ func handleSettingsPostPushMirrorUpdate(ctx *context.Context) {
 		ctx.ServerError("UpdatePushMirrorInterval", err)
 		return
 	}
+	if err := mirror_service.UpdatePushMirrorRemote(ctx, m); err != nil {
+		ctx.ServerError("UpdatePushMirrorRemote", err)
+		return
+	}
 	// Background why we are adding it to Queue
 	// If we observed its implementation in the context of `push-mirror-sync` where it
 	// is evident that pushing to the queue is necessary for updates.
@@ -795,6 +815,33 @@ func handleSettingsPostConvertFork(ctx *context.Context) {
 	ctx.Redirect(repo.Link())
 }
 
+func handleSettingsPostConvertObjectFormat(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.RepoSettingForm)
+	repo := ctx.Repo.Repository
+	if !ctx.Repo.IsOwner() {
+		ctx.HTTPError(http.StatusNotFound)
+		return
+	}
+	if repo.Name != form.RepoName {
+		ctx.RenderWithErr(ctx.Tr("form.enterred_invalid_repo_name"), tplSettingsOptions, nil)
+		return
+	}
+
+	if err := task.ConvertRepositoryObjectFormat(ctx, ctx.Doer, repo, git.Sha256ObjectFormat); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.convert_object_format_failed", err.Error()))
+			ctx.Redirect(repo.Link() + "/settings")
+			return
+		}
+		ctx.ServerError("ConvertRepositoryObjectFormat", err)
+		return
+	}
+
+	log.Trace("Repository conversion to the %s object format queued: %s", git.Sha256ObjectFormat.Name(), repo.FullName())
+	ctx.Flash.Success(ctx.Tr("repo.settings.convert_object_format_queued"))
+	ctx.Redirect(repo.Link() + "/settings")
+}
+
 func handleSettingsPostTransfer(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.RepoSettingForm)
 	repo := ctx.Repo.Repository
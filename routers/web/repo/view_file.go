@@ -16,10 +16,12 @@ import (
 	"code.gitea.io/gitea/models/renderhelper"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/analyze"
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/attribute"
 	"code.gitea.io/gitea/modules/highlight"
+	"code.gitea.io/gitea/modules/indexer/code/symbols"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
@@ -121,6 +123,13 @@ func handleFileViewRenderSource(ctx *context.Context, attrs *attribute.Attribute
 	language := attrs.GetLanguage().Value()
 	fileContent, lexerName, err := highlight.RenderFullFile(filename, language, buf)
 	ctx.Data["LexerName"] = lexerName
+	if setting.Indexer.RepoIndexerEnabled {
+		// the symbols of the file link to their definitions, the references are searched by the code indexer
+		if codeLanguage := analyze.GetCodeLanguage(filename, buf); symbols.IsSupported(codeLanguage) {
+			ctx.Data["CanSearchSymbols"] = true
+			ctx.Data["FileSymbols"], _ = symbols.Extract(codeLanguage, buf)
+		}
+	}
 	if err != nil {
 		log.Error("highlight.RenderFullFile failed, fallback to plain text: %v", err)
 		fileContent = highlight.RenderPlainText(buf)
@@ -62,14 +62,25 @@ func CodeSearch(ctx *context.Context) {
 		total                 int
 		searchResults         []*code_indexer.Result
 		searchResultLanguages []*code_indexer.SearchResultLanguages
+		searchTruncated       bool
 	)
 
-	if len(repoIDs) > 0 {
-		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
-			RepoIDs:    repoIDs,
-			Keyword:    prepareSearch.Keyword,
-			SearchMode: prepareSearch.SearchMode,
-			Language:   prepareSearch.Language,
+	searchable := len(repoIDs) > 0
+	if searchable && len(prepareSearch.RepoNames) > 0 {
+		repoIDs, searchable, err = common.FilterCodeSearchRepoIDs(ctx, repoIDs, prepareSearch.RepoNames, ctx.ContextUser.Name)
+		if err != nil {
+			ctx.ServerError("FilterCodeSearchRepoIDs", err)
+			return
+		}
+	}
+
+	if searchable {
+		total, searchResults, searchResultLanguages, searchTruncated, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+			RepoIDs:      repoIDs,
+			Keyword:      prepareSearch.Keyword,
+			SearchMode:   prepareSearch.SearchMode,
+			Language:     prepareSearch.Language,
+			PathPatterns: prepareSearch.PathPatterns,
 			Paginator: &db.ListOptions{
 				Page:     page,
 				PageSize: setting.UI.RepoSearchPagingNum,
@@ -102,6 +113,7 @@ func CodeSearch(ctx *context.Context) {
 	}
 	ctx.Data["SearchResults"] = searchResults
 	ctx.Data["SearchResultLanguages"] = searchResultLanguages
+	ctx.Data["SearchResultsTruncated"] = searchTruncated
 
 	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
 	pager.AddParamFromRequest(ctx.Req)
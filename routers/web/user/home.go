@@ -6,6 +6,7 @@ package user
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -536,12 +537,26 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// Slice of Issues that will be displayed on the overview page
 	// USING FINAL STATE OF opts FOR A QUERY.
 	var issues issues_model.IssueList
-	{
-		issueIDs, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts).Copy(
-			func(o *issue_indexer.SearchOptions) {
-				o.SearchMode = indexer.SearchModeType(searchMode)
-			},
-		))
+	searchOpts := issue_indexer.ToSearchOptions("", opts).Copy(
+		func(o *issue_indexer.SearchOptions) {
+			o.SearchMode = indexer.SearchModeType(searchMode)
+		},
+	)
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err == nil {
+		err = query.Apply(ctx, searchOpts, ctx.Doer)
+	}
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.Data["SearchQueryError"] = err.Error()
+	} else if err != nil {
+		ctx.ServerError("ParseQuery", err)
+		return
+	} else {
+		if query.SortType() != "" {
+			sortType = query.SortType()
+		}
+		isShowClosed = searchOpts.IsClosed.Value()
+		issueIDs, _, err := issue_indexer.SearchIssues(ctx, searchOpts)
 		if err != nil {
 			ctx.ServerError("issueIDsFromSearch", err)
 			return
@@ -551,6 +566,11 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 			ctx.ServerError("GetIssuesByIDs", err)
 			return
 		}
+		highlights, err := issue_indexer.HighlightIssues(ctx, searchOpts, issueIDs)
+		if err != nil {
+			log.Warn("HighlightIssues: %v", err)
+		}
+		ctx.Data["IssueHighlights"] = highlights
 	}
 
 	commitStatuses, lastStatus, err := pull_service.GetIssuesAllCommitStatus(ctx, issues)
@@ -567,14 +587,13 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// -------------------------------
 	// Fill stats to post to ctx.Data.
 	// -------------------------------
-	issueStats, err := getUserIssueStats(ctx, ctxUser, filterMode, issue_indexer.ToSearchOptions(keyword, opts).Copy(
-		func(o *issue_indexer.SearchOptions) {
-			o.SearchMode = indexer.SearchModeType(searchMode)
-		},
-	))
-	if err != nil {
-		ctx.ServerError("getUserIssueStats", err)
-		return
+	issueStats := &issues_model.IssueStats{}
+	if ctx.Data["SearchQueryError"] == nil {
+		issueStats, err = getUserIssueStats(ctx, ctxUser, filterMode, searchOpts)
+		if err != nil {
+			ctx.ServerError("getUserIssueStats", err)
+			return
+		}
 	}
 
 	// Will be posted to ctx.Data.
@@ -634,6 +653,18 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 		ctx.Data["State"] = "open"
 	}
 
+	prepareSavedSearches(ctx, ctxUser, isPullList, &savedSearchFilters{
+		Keyword:          keyword,
+		ViewType:         viewType,
+		IsShowClosed:     isShowClosed,
+		PosterUsername:   posterUsername,
+		AssigneeUsername: assigneeUsername,
+		LabelIDs:         opts.LabelIDs,
+	})
+	if ctx.Written() {
+		return
+	}
+
 	pager := context.NewPagination(shownIssues, setting.UI.IssuePagingNum, page, 5)
 	pager.AddParamFromRequest(ctx.Req)
 	ctx.Data["Page"] = pager
@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+)
+
+// savedSearchFilters are the filters of the dashboard which are converted to the qualifiers of a saved search
+type savedSearchFilters struct {
+	Keyword          string
+	ViewType         string
+	IsShowClosed     bool
+	PosterUsername   string
+	AssigneeUsername string
+	LabelIDs         []int64
+}
+
+// the qualifiers of the view types of the dashboard
+var savedSearchViewTypeQualifiers = map[string]string{
+	"assigned":         "assignee:@me",
+	"created_by":       "author:@me",
+	"mentioned":        "mentions:@me",
+	"review_requested": "review-requested:@me",
+	"reviewed_by":      "reviewed-by:@me",
+}
+
+// prepareSavedSearches lists the saved searches of the doer for the dashboard and prepares the link to save the current search
+func prepareSavedSearches(ctx *context.Context, ctxUser *user_model.User, isPull bool, filters *savedSearchFilters) {
+	searches, err := issues_model.GetSavedSearchesByUser(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchesByUser", err)
+		return
+	}
+	shown := make([]*issues_model.SavedSearch, 0, len(searches))
+	for _, search := range searches {
+		if search.IsPull != isPull {
+			continue
+		}
+		if err := search.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+		shown = append(shown, search)
+	}
+	ctx.Data["SavedSearches"] = shown
+
+	query, err := savedSearchQuery(ctx, filters)
+	if err != nil {
+		ctx.ServerError("savedSearchQuery", err)
+		return
+	}
+	link := setting.AppSubURL + "/user/settings/saved_searches?type=" + util.Iif(isPull, "pulls", "issues") + "&q=" + url.QueryEscape(query)
+	if ctxUser.IsOrganization() {
+		link += "&org=" + strconv.FormatInt(ctxUser.ID, 10)
+	}
+	ctx.Data["SaveSearchLink"] = link
+}
+
+// savedSearchQuery converts the filters of the dashboard to a search query with qualifiers
+func savedSearchQuery(ctx *context.Context, filters *savedSearchFilters) (string, error) {
+	var qualifiers []string
+	hasState := false
+	if query, err := issue_indexer.ParseQuery(filters.Keyword); err == nil {
+		for _, f := range query.Filters {
+			hasState = hasState || (f.Key == "is" && (f.Value == "open" || f.Value == "closed"))
+		}
+	}
+	if !hasState {
+		qualifiers = append(qualifiers, util.Iif(filters.IsShowClosed, "is:closed", "is:open"))
+	}
+	if q, ok := savedSearchViewTypeQualifiers[filters.ViewType]; ok {
+		qualifiers = append(qualifiers, q)
+	}
+	if filters.PosterUsername != "" {
+		qualifiers = append(qualifiers, "author:"+filters.PosterUsername)
+	}
+	if filters.AssigneeUsername != "" {
+		qualifiers = append(qualifiers, "assignee:"+filters.AssigneeUsername)
+	}
+	// the negative label IDs are the excluded labels
+	labelIDs := make([]int64, 0, len(filters.LabelIDs))
+	for _, id := range filters.LabelIDs {
+		labelIDs = append(labelIDs, max(id, -id))
+	}
+	labels, err := issues_model.GetLabelsByIDs(ctx, labelIDs, "id", "name")
+	if err != nil {
+		return "", err
+	}
+	for _, label := range labels {
+		name := label.Name
+		if strings.ContainsAny(name, " \t") {
+			name = strconv.Quote(name)
+		}
+		qualifiers = append(qualifiers, util.Iif(slices.Contains(filters.LabelIDs, -label.ID), "-label:", "label:")+name)
+	}
+	if filters.Keyword != "" {
+		qualifiers = append(qualifiers, filters.Keyword)
+	}
+	return strings.Join(qualifiers, " "), nil
+}
@@ -51,6 +51,13 @@ func Profile(ctx *context.Context) {
 
 	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
 
+	reviewAvailability, err := user_model.GetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyReviewAvailability)
+	if err != nil {
+		ctx.ServerError("GetUserSetting", err)
+		return
+	}
+	ctx.Data["ReviewAvailability"] = reviewAvailability
+
 	ctx.HTML(http.StatusOK, tplSettingsProfile)
 }
 
@@ -63,6 +70,12 @@ func ProfilePost(ctx *context.Context) {
 	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
 
 	if ctx.HasError() {
+		reviewAvailability, err := user_model.GetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyReviewAvailability)
+		if err != nil {
+			ctx.ServerError("GetUserSetting", err)
+			return
+		}
+		ctx.Data["ReviewAvailability"] = reviewAvailability
 		ctx.HTML(http.StatusOK, tplSettingsProfile)
 		return
 	}
@@ -187,6 +200,30 @@ func AvatarPost(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + "/user/settings")
 }
 
+// ReviewAvailabilityPost sets whether the user can be assigned to review requests of teams
+func ReviewAvailabilityPost(ctx *context.Context) {
+	availability := ctx.FormString("availability")
+	switch availability {
+	case user_model.SettingReviewAvailabilityActive:
+		if err := user_model.DeleteUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyReviewAvailability); err != nil {
+			ctx.ServerError("DeleteUserSetting", err)
+			return
+		}
+	case user_model.SettingReviewAvailabilityBusy, user_model.SettingReviewAvailabilityAway:
+		if err := user_model.SetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyReviewAvailability, availability); err != nil {
+			ctx.ServerError("SetUserSetting", err)
+			return
+		}
+	default:
+		ctx.Flash.Error(ctx.Tr("invalid_data", availability))
+		ctx.Redirect(setting.AppSubURL + "/user/settings")
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.review_availability_success"))
+	ctx.Redirect(setting.AppSubURL + "/user/settings")
+}
+
 // DeleteAvatar render delete avatar page
 func DeleteAvatar(ctx *context.Context) {
 	if err := user_service.DeleteAvatar(ctx, ctx.Doer); err != nil {
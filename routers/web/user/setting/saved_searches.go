@@ -0,0 +1,191 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	savedsearch_service "code.gitea.io/gitea/services/savedsearch"
+)
+
+const (
+	tplSettingsSavedSearches templates.TplName = "user/settings/saved_searches"
+)
+
+// SavedSearches renders the saved searches of the user and the ones shared with the user
+func SavedSearches(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.saved_searches")
+	ctx.Data["PageIsSettingsSavedSearches"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	searches, err := issues_model.GetSavedSearchesByUser(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchesByUser", err)
+		return
+	}
+	for _, search := range searches {
+		if err := search.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+	subscriptions, err := issues_model.GetSavedSearchSubscriptionsByUser(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchSubscriptionsByUser", err)
+		return
+	}
+
+	orgs, err := organization.GetUserOrgsList(ctx, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserOrgsList", err)
+		return
+	}
+	orgNames := make(map[int64]string, len(orgs))
+	for _, org := range orgs {
+		orgNames[org.ID] = org.Name
+	}
+	teams, _, err := organization.SearchTeam(ctx, &organization.SearchTeamOptions{UserID: ctx.Doer.ID})
+	if err != nil {
+		ctx.ServerError("SearchTeam", err)
+		return
+	}
+
+	ctx.Data["SavedSearchList"] = searches
+	ctx.Data["Subscriptions"] = subscriptions
+	ctx.Data["Orgs"] = orgs
+	ctx.Data["OrgNames"] = orgNames
+	ctx.Data["Teams"] = teams
+
+	// the search saved from the dashboard is prefilled
+	ctx.Data["name"] = ctx.FormString("name")
+	ctx.Data["q"] = ctx.FormString("q")
+	ctx.Data["type"] = util.Iif(ctx.FormString("type") == "pulls", "pulls", "issues")
+	ctx.Data["org"] = ctx.FormInt64("org")
+
+	ctx.HTML(http.StatusOK, tplSettingsSavedSearches)
+}
+
+// SavedSearchesPost saves a search
+func SavedSearchesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.NewSavedSearchForm)
+	redirectTo := setting.AppSubURL + "/user/settings/saved_searches"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(redirectTo)
+		return
+	}
+
+	err := savedsearch_service.CreateSavedSearch(ctx, &issues_model.SavedSearch{
+		OwnerID: ctx.Doer.ID,
+		Name:    form.Name,
+		Query:   form.Query,
+		IsPull:  form.Type == "pulls",
+		OrgID:   form.OrgID,
+		TeamID:  form.TeamID,
+	})
+	switch {
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.Flash.Error(ctx.Tr("settings.saved_searches.name_exists", form.Name))
+	case errors.Is(err, util.ErrInvalidArgument), errors.Is(err, util.ErrNotExist):
+		ctx.Flash.Error(ctx.Tr("settings.saved_searches.invalid", err.Error()))
+	case err != nil:
+		ctx.ServerError("CreateSavedSearch", err)
+		return
+	default:
+		ctx.Flash.Success(ctx.Tr("settings.saved_searches.created", form.Name))
+	}
+	ctx.Redirect(redirectTo)
+}
+
+// getSavedSearch returns the saved search of the path which could be used by the doer
+func getSavedSearch(ctx *context.Context) *issues_model.SavedSearch {
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if issues_model.IsErrSavedSearchNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetSavedSearchByID", err)
+		}
+		return nil
+	}
+	if ok, err := search.CanBeUsedBy(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("CanBeUsedBy", err)
+		return nil
+	} else if !ok {
+		ctx.NotFound(nil)
+		return nil
+	}
+	return search
+}
+
+// SavedSearchDelete deletes a saved search owned by the user
+func SavedSearchDelete(ctx *context.Context) {
+	search := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if search.OwnerID != ctx.Doer.ID {
+		ctx.NotFound(nil)
+		return
+	}
+	if err := issues_model.DeleteSavedSearch(ctx, search.ID); err != nil {
+		ctx.ServerError("DeleteSavedSearch", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("settings.saved_searches.deleted", search.Name))
+	ctx.Redirect(setting.AppSubURL + "/user/settings/saved_searches")
+}
+
+// SavedSearchSubscribe subscribes the user to a saved search or updates the subscription
+func SavedSearchSubscribe(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchSubscriptionForm)
+	search := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	redirectTo := setting.AppSubURL + "/user/settings/saved_searches"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(redirectTo)
+		return
+	}
+
+	frequency := util.Iif(form.Frequency == "daily", issues_model.SavedSearchFrequencyDaily, issues_model.SavedSearchFrequencyImmediate)
+	method := util.Iif(form.Method == "email", issues_model.SavedSearchNotifyMethodEmail, issues_model.SavedSearchNotifyMethodNotification)
+	if err := savedsearch_service.Subscribe(ctx, search, ctx.Doer, frequency, method); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("settings.saved_searches.invalid", err.Error()))
+			ctx.Redirect(redirectTo)
+			return
+		}
+		ctx.ServerError("Subscribe", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("settings.saved_searches.subscribed", search.Name))
+	ctx.Redirect(redirectTo)
+}
+
+// SavedSearchUnsubscribe unsubscribes the user from a saved search
+func SavedSearchUnsubscribe(ctx *context.Context) {
+	search := getSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.UnsubscribeSavedSearch(ctx, search.ID, ctx.Doer.ID); err != nil {
+		ctx.ServerError("UnsubscribeSavedSearch", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("settings.saved_searches.unsubscribed", search.Name))
+	ctx.Redirect(setting.AppSubURL + "/user/settings/saved_searches")
+}
@@ -499,6 +499,13 @@ func registerWebRoutes(m *web.Router) {
 				return
 			}
 		}, explore.Code)
+		m.Get("/files", func(ctx *context.Context) {
+			if unit.TypeCode.UnitGlobalDisabled() {
+				ctx.NotFound(nil)
+				return
+			}
+		}, explore.Files)
+		m.Get("/{type:wiki|releases|commits}", explore.Content)
 		m.Get("/topics/search", explore.TopicSearch)
 	}, optExploreSignIn)
 
@@ -576,6 +583,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/change_password", web.Bind(forms.MustChangePasswordForm{}), auth.MustChangePasswordPost)
 		m.Post("/avatar", web.Bind(forms.AvatarForm{}), user_setting.AvatarPost)
 		m.Post("/avatar/delete", user_setting.DeleteAvatar)
+		m.Post("/review_availability", user_setting.ReviewAvailabilityPost)
 		m.Group("/account", func() {
 			m.Combo("").Get(user_setting.Account).Post(web.Bind(forms.ChangePasswordForm{}), user_setting.AccountPost)
 			m.Post("/email", web.Bind(forms.AddEmailForm{}), user_setting.EmailPost)
@@ -680,6 +688,16 @@ func registerWebRoutes(m *web.Router) {
 			m.Get("", user_setting.BlockedUsers)
 			m.Post("", web.Bind(forms.BlockUserForm{}), user_setting.BlockedUsersPost)
 		})
+
+		m.Group("/saved_searches", func() {
+			m.Get("", user_setting.SavedSearches)
+			m.Post("", web.Bind(forms.NewSavedSearchForm{}), user_setting.SavedSearchesPost)
+			m.Group("/{id}", func() {
+				m.Post("/delete", user_setting.SavedSearchDelete)
+				m.Post("/subscribe", web.Bind(forms.SavedSearchSubscriptionForm{}), user_setting.SavedSearchSubscribe)
+				m.Post("/unsubscribe", user_setting.SavedSearchUnsubscribe)
+			})
+		})
 	}, reqSignIn, ctxDataSet("PageIsUserSettings", true, "EnablePackages", setting.Packages.Enabled, "EnableNotifyMail", setting.Service.EnableNotifyMail))
 
 	m.Group("/user", func() {
@@ -734,6 +752,11 @@ func registerWebRoutes(m *web.Router) {
 				m.Post("/set", admin.QueueSet)
 				m.Post("/remove-all-items", admin.QueueRemoveAllItems)
 			})
+			m.Group("/indexers", func() {
+				m.Get("", admin.Indexers)
+				m.Get("/status", admin.IndexersStatus)
+				m.Post("/reindex", admin.IndexersReindex)
+			})
 			m.Get("/diagnosis", admin.MonitorDiagnosis)
 		})
 
@@ -753,6 +776,11 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/delete", admin.DeleteEmail)
 		})
 
+		m.Group("/ssh_user_cas", func() {
+			m.Combo("").Get(admin.SSHUserCAs).Post(web.Bind(forms.AdminAddTrustedUserCAForm{}), admin.SSHUserCAsPost)
+			m.Post("/delete", admin.DeleteSSHUserCA)
+		})
+
 		m.Group("/orgs", func() {
 			m.Get("", admin.Organizations)
 		})
@@ -760,6 +788,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Group("/repos", func() {
 			m.Get("", admin.Repos)
 			m.Combo("/unadopted").Get(admin.UnadoptedRepos).Post(admin.AdoptOrDeleteRepository)
+			m.Get("/maintenance", admin.MaintenanceRepos)
 			m.Post("/delete", admin.DeleteRepo)
 		})
 
@@ -1083,6 +1112,8 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/avatar/delete", repo_setting.SettingsDeleteAvatar)
 
 		m.Combo("/public_access").Get(repo_setting.PublicAccess).Post(repo_setting.PublicAccessPost)
+		m.Combo("/clone").Get(repo_setting.ClonePolicy).Post(repo_setting.ClonePolicyPost)
+		m.Combo("/code_indexer").Get(repo_setting.CodeIndexerRefs).Post(repo_setting.CodeIndexerRefsPost)
 
 		m.Group("/collaboration", func() {
 			m.Combo("").Get(repo_setting.Collaboration).Post(repo_setting.CollaborationPost)
@@ -1149,6 +1180,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("/", repo_setting.LFSLocks)
 				m.Post("/", repo_setting.LFSLockFile)
 				m.Post("/{lid}/unlock", repo_setting.LFSUnlock)
+				m.Post("/enforce", repo_setting.LFSLocksEnforce)
 			})
 		})
 		m.Group("/actions/general", func() {
@@ -1244,6 +1276,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("/choose", repo.NewIssueChooseTemplate)
 			})
 			m.Get("/search", repo.SearchRepoIssuesJSON)
+			m.Get("/similar", repo.SimilarIssues)
 		}, reqUnitIssuesReader)
 
 		addIssuesPullsUpdateRoutes := func() {
@@ -1373,6 +1406,11 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/merge-upstream", repo.MergeUpstream)
 		}, context.RepoMustNotBeArchived(), reqRepoCodeWriter, repo.MustBeNotEmpty)
 
+		m.Group("/commit/{sha:([a-f0-9]{7,64})}/notes", func() {
+			m.Post("", web.Bind(forms.CommitNoteForm{}), repo.SetCommitNotePost)
+			m.Post("/delete", repo.DeleteCommitNotePost)
+		}, context.RepoMustNotBeArchived(), reqRepoCodeWriter, repo.MustBeNotEmpty)
+
 		m.Combo("/fork").Get(repo.Fork).Post(web.Bind(forms.CreateRepoForm{}), repo.ForkPost)
 	}, reqSignIn, context.RepoAssignment, reqUnitCodeReader)
 	// end "/{username}/{reponame}": repo code
@@ -1542,9 +1580,14 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("/list", repo.GetPullCommits)
 				m.Get("/{sha:[a-f0-9]{7,64}}", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForSingleCommit)
 			})
+			m.Group("/range-diff", func() {
+				m.Get("", reqSignIn, repo.ViewPullRangeDiffSinceLastReview)
+				m.Get("/{shaFrom:[a-f0-9]{7,64}}..{shaTo:[a-f0-9]{7,64}}", repo.ViewPullRangeDiffForRange)
+			})
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
+			m.Combo("/conflicts", reqSignIn, context.RepoMustNotBeArchived()).Get(repo.ViewPullConflicts).Post(repo.ResolvePullConflicts)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), repo.CleanUpPullRequest)
 			m.Group("/files", func() {
@@ -1646,6 +1689,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Get("/stars", starsEnabled, repo.Stars)
 		m.Get("/watchers", repo.Watchers)
 		m.Get("/search", reqUnitCodeReader, repo.Search)
+		m.Get("/search/{type:wiki|releases|commits}", repo.SearchContent)
 		m.Post("/action/{action:star|unstar}", reqSignIn, starsEnabled, repo.ActionStar)
 		m.Post("/action/{action:watch|unwatch}", reqSignIn, repo.ActionWatch)
 		m.Post("/action/{action:accept_transfer|reject_transfer}", reqSignIn, repo.ActionTransfer)
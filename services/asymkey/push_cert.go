@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// VerifyPushCertificate checks whether a push certificate is signed by one of the GPG or SSH keys of the pusher.
+// Unlike commit signatures, the signer must be the user who pushed, the emails of the key don't matter.
+func VerifyPushCertificate(ctx context.Context, pusher *user_model.User, cert *git.PushCertificate) *asymkey_model.CommitVerification {
+	if cert.IsSSHSigned() {
+		return verifySSHPushCertificate(ctx, pusher, cert)
+	}
+	return verifyGPGPushCertificate(ctx, pusher, cert)
+}
+
+func verifyGPGPushCertificate(ctx context.Context, pusher *user_model.User, cert *git.PushCertificate) *asymkey_model.CommitVerification {
+	sig, err := asymkey_model.ExtractSignature(cert.Signature)
+	if err != nil {
+		log.Error("SignatureRead err: %v", err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: pusher,
+			Verified:       false,
+			Reason:         "gpg.error.extract_sign",
+		}
+	}
+
+	keys, err := db.Find[asymkey_model.GPGKey](ctx, asymkey_model.FindGPGKeyOptions{
+		OwnerID: pusher.ID,
+	})
+	if err == nil {
+		err = asymkey_model.GPGKeyList(keys).LoadSubKeys(ctx)
+	}
+	if err != nil {
+		log.Error("ListGPGKeys: %v", err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: pusher,
+			Verified:       false,
+			Reason:         "gpg.error.failed_retrieval_gpg_keys",
+		}
+	}
+
+	for _, k := range keys {
+		// only the keys which have been verified or which belong to an activated email are trusted
+		if !k.Verified && !hasActivatedEmail(k) {
+			continue
+		}
+		if verification := asymkey_model.HashAndVerifyWithSubKeysCommitVerification(sig, cert.Payload, k, pusher, pusher, ""); verification != nil {
+			return verification
+		}
+	}
+
+	return &asymkey_model.CommitVerification{
+		CommittingUser: pusher,
+		Verified:       false,
+		Reason:         asymkey_model.NoKeyFound,
+		SigningKey: &asymkey_model.GPGKey{
+			KeyID: asymkey_model.TryGetKeyIDFromSignature(sig),
+		},
+	}
+}
+
+func hasActivatedEmail(k *asymkey_model.GPGKey) bool {
+	for _, e := range k.Emails {
+		if e.IsActivated {
+			return true
+		}
+	}
+	return false
+}
+
+func verifySSHPushCertificate(ctx context.Context, pusher *user_model.User, cert *git.PushCertificate) *asymkey_model.CommitVerification {
+	keys, err := db.Find[asymkey_model.PublicKey](ctx, asymkey_model.FindPublicKeyOptions{
+		OwnerID:    pusher.ID,
+		NotKeytype: asymkey_model.KeyTypePrincipal,
+	})
+	if err != nil {
+		log.Error("ListPublicKeys: %v", err)
+		return &asymkey_model.CommitVerification{
+			CommittingUser: pusher,
+			Verified:       false,
+			Reason:         "gpg.error.failed_retrieval_gpg_keys",
+		}
+	}
+
+	for _, k := range keys {
+		if !k.Verified {
+			continue
+		}
+		if verification := verifySSHCommitVerification(cert.Signature, cert.Payload, k, pusher, pusher, ""); verification != nil {
+			return verification
+		}
+	}
+
+	return &asymkey_model.CommitVerification{
+		CommittingUser: pusher,
+		Verified:       false,
+		Reason:         asymkey_model.NoKeyFound,
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strings"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
@@ -116,6 +117,46 @@ func (r *Repository) GetObjectFormat() git.ObjectFormat {
 	return git.ObjectFormatFromName(r.Repository.ObjectFormatName)
 }
 
+// RedirectToTranslatedCommit redirects a link with the ID of a commit from before the repository was converted
+// to another object format to the same link with the new ID of the commit, it returns false if there is no translation
+func (ctx *Context) RedirectToTranslatedCommit(commitID string) bool {
+	if !git.IsStringLikelyCommitID(nil, commitID, 7) {
+		return false
+	}
+	newID, err := git_model.GetTranslatedObjectID(ctx, ctx.Repo.Repository.ID, commitID)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Error("GetTranslatedObjectID: %v", err)
+		}
+		return false
+	}
+	// only the path segment of the commit below the repository is replaced, the owner or the repository name may look like the commit ID
+	subPath, ok := cutPrefixFold(setting.AppSubURL+ctx.Req.URL.EscapedPath(), ctx.Repo.RepoLink+"/")
+	if !ok {
+		return false
+	}
+	segments := strings.Split(subPath, "/")
+	idx := slices.Index(segments, commitID)
+	if idx == -1 {
+		return false
+	}
+	segments[idx] = newID
+	link := ctx.Repo.RepoLink + "/" + strings.Join(segments, "/")
+	if ctx.Req.URL.RawQuery != "" {
+		link += "?" + ctx.Req.URL.RawQuery
+	}
+	ctx.Redirect(link)
+	return true
+}
+
+// cutPrefixFold is strings.CutPrefix with a case-insensitive prefix, the owner and repository names in the links are case-insensitive
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
 // RepoMustNotBeArchived checks if a repo is archived
 func RepoMustNotBeArchived() func(ctx *Context) {
 	return func(ctx *Context) {
@@ -956,7 +997,9 @@ func RepoRefByType(detectRefType git.RefType) func(*Context) {
 
 				ctx.Repo.Commit, err = ctx.Repo.GitRepo.GetCommit(refShortName)
 				if err != nil {
-					ctx.NotFound(err)
+					if !ctx.RedirectToTranslatedCommit(refShortName) {
+						ctx.NotFound(err)
+					}
 					return
 				}
 				// If short commit ID add canonical link header
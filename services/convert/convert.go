@@ -165,39 +165,41 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 	}
 
 	return &api.BranchProtection{
-		BranchName:                    branchName,
-		RuleName:                      bp.RuleName,
-		Priority:                      bp.Priority,
-		EnablePush:                    bp.CanPush,
-		EnablePushWhitelist:           bp.EnableWhitelist,
-		PushWhitelistUsernames:        pushWhitelistUsernames,
-		PushWhitelistTeams:            pushWhitelistTeams,
-		PushWhitelistDeployKeys:       bp.WhitelistDeployKeys,
-		EnableForcePush:               bp.CanForcePush,
-		EnableForcePushAllowlist:      bp.EnableForcePushAllowlist,
-		ForcePushAllowlistUsernames:   forcePushAllowlistUsernames,
-		ForcePushAllowlistTeams:       forcePushAllowlistTeams,
-		ForcePushAllowlistDeployKeys:  bp.ForcePushAllowlistDeployKeys,
-		EnableMergeWhitelist:          bp.EnableMergeWhitelist,
-		MergeWhitelistUsernames:       mergeWhitelistUsernames,
-		MergeWhitelistTeams:           mergeWhitelistTeams,
-		EnableStatusCheck:             bp.EnableStatusCheck,
-		StatusCheckContexts:           bp.StatusCheckContexts,
-		RequiredApprovals:             bp.RequiredApprovals,
-		EnableApprovalsWhitelist:      bp.EnableApprovalsWhitelist,
-		ApprovalsWhitelistUsernames:   approvalsWhitelistUsernames,
-		ApprovalsWhitelistTeams:       approvalsWhitelistTeams,
-		BlockOnRejectedReviews:        bp.BlockOnRejectedReviews,
-		BlockOnOfficialReviewRequests: bp.BlockOnOfficialReviewRequests,
-		BlockOnOutdatedBranch:         bp.BlockOnOutdatedBranch,
-		DismissStaleApprovals:         bp.DismissStaleApprovals,
-		IgnoreStaleApprovals:          bp.IgnoreStaleApprovals,
-		RequireSignedCommits:          bp.RequireSignedCommits,
-		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
-		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
-		BlockAdminMergeOverride:       bp.BlockAdminMergeOverride,
-		Created:                       bp.CreatedUnix.AsTime(),
-		Updated:                       bp.UpdatedUnix.AsTime(),
+		BranchName:                     branchName,
+		RuleName:                       bp.RuleName,
+		Priority:                       bp.Priority,
+		EnablePush:                     bp.CanPush,
+		EnablePushWhitelist:            bp.EnableWhitelist,
+		PushWhitelistUsernames:         pushWhitelistUsernames,
+		PushWhitelistTeams:             pushWhitelistTeams,
+		PushWhitelistDeployKeys:        bp.WhitelistDeployKeys,
+		EnableForcePush:                bp.CanForcePush,
+		EnableForcePushAllowlist:       bp.EnableForcePushAllowlist,
+		ForcePushAllowlistUsernames:    forcePushAllowlistUsernames,
+		ForcePushAllowlistTeams:        forcePushAllowlistTeams,
+		ForcePushAllowlistDeployKeys:   bp.ForcePushAllowlistDeployKeys,
+		EnableMergeWhitelist:           bp.EnableMergeWhitelist,
+		MergeWhitelistUsernames:        mergeWhitelistUsernames,
+		MergeWhitelistTeams:            mergeWhitelistTeams,
+		EnableStatusCheck:              bp.EnableStatusCheck,
+		StatusCheckContexts:            bp.StatusCheckContexts,
+		RequiredApprovals:              bp.RequiredApprovals,
+		EnableApprovalsWhitelist:       bp.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:    approvalsWhitelistUsernames,
+		ApprovalsWhitelistTeams:        approvalsWhitelistTeams,
+		BlockOnRejectedReviews:         bp.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests:  bp.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:          bp.BlockOnOutdatedBranch,
+		BlockOnUnresolvedConversations: bp.BlockOnUnresolvedConversations,
+		DismissStaleApprovals:          bp.DismissStaleApprovals,
+		IgnoreStaleApprovals:           bp.IgnoreStaleApprovals,
+		RequireSignedCommits:           bp.RequireSignedCommits,
+		RequireSignedPushes:            bp.RequireSignedPushes,
+		ProtectedFilePatterns:          bp.ProtectedFilePatterns,
+		UnprotectedFilePatterns:        bp.UnprotectedFilePatterns,
+		BlockAdminMergeOverride:        bp.BlockAdminMergeOverride,
+		Created:                        bp.CreatedUnix.AsTime(),
+		Updated:                        bp.UpdatedUnix.AsTime(),
 	}
 }
 
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 
 	issues_model "code.gitea.io/gitea/models/issues"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	"code.gitea.io/gitea/modules/label"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -145,6 +147,28 @@ func ToAPIIssueList(ctx context.Context, doer *user_model.User, il issues_model.
 	return result
 }
 
+// ToAPIIssueSearchHighlight converts the highlight of an issue found by a search to API format
+func ToAPIIssueSearchHighlight(ctx context.Context, issue *issues_model.Issue, highlight *issue_indexer.SearchHighlight) *api.IssueSearchHighlight {
+	apiHighlight := &api.IssueSearchHighlight{
+		CommentID: highlight.CommentID,
+		Fragment:  highlight.Text(),
+		Matches:   make([]*api.IssueSearchHighlightMatch, 0, len(highlight.Parts)/2),
+		HTMLURL:   issue.HTMLURL(ctx),
+	}
+	if highlight.CommentID > 0 {
+		apiHighlight.HTMLURL += "#" + issues_model.CommentHashTag(highlight.CommentID)
+	}
+	offset := 0
+	for i, part := range highlight.Parts {
+		size := utf8.RuneCountInString(part)
+		if i%2 == 1 {
+			apiHighlight.Matches = append(apiHighlight.Matches, &api.IssueSearchHighlightMatch{Start: offset, End: offset + size})
+		}
+		offset += size
+	}
+	return apiHighlight
+}
+
 // ToTrackedTime converts TrackedTime to API format
 func ToTrackedTime(ctx context.Context, doer *user_model.User, t *issues_model.TrackedTime) (apiT *api.TrackedTime) {
 	apiT = &api.TrackedTime{
@@ -119,8 +119,14 @@ func ToPullReviewComment(ctx context.Context, comment *issues_model.Comment, doe
 
 	if comment.Line < 0 {
 		apiComment.OldLineNum = comment.UnsignedLine()
+		if comment.IsMultiLine() {
+			apiComment.OldStartLineNum = comment.UnsignedStartLine()
+		}
 	} else {
 		apiComment.LineNum = comment.UnsignedLine()
+		if comment.IsMultiLine() {
+			apiComment.StartLineNum = comment.UnsignedStartLine()
+		}
 	}
 
 	return apiComment
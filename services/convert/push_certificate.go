@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	git_model "code.gitea.io/gitea/models/git"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToPushCertificate converts a git_model.PushCertificate to an api.PushCertificate, the pusher must be loaded
+func ToPushCertificate(ctx context.Context, cert *git_model.PushCertificate, doer *user_model.User) *api.PushCertificate {
+	updates := make([]*api.PushCertificateUpdate, 0, len(cert.Updates))
+	for _, update := range cert.Updates {
+		updates = append(updates, &api.PushCertificateUpdate{
+			Ref:    update.RefName,
+			Before: update.OldCommitID,
+			After:  update.NewCommitID,
+		})
+	}
+	return &api.PushCertificate{
+		ID:          cert.ID,
+		Pusher:      ToUser(ctx, cert.Pusher, doer),
+		Signer:      cert.Signer,
+		Nonce:       cert.Nonce,
+		NonceStatus: cert.NonceStatus,
+		Verified:    cert.Verified,
+		Reason:      cert.Reason,
+		SigningKey:  cert.SigningKey,
+		Updates:     updates,
+		Certificate: cert.Certificate,
+		Created:     cert.CreatedUnix.AsTime(),
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/gitea/models"
 	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/git/gitcmd"
@@ -19,6 +20,9 @@ import (
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
+	"code.gitea.io/gitea/services/repository/clonebundle"
+	"code.gitea.io/gitea/services/repository/packcache"
+	savedsearch_service "code.gitea.io/gitea/services/savedsearch"
 )
 
 func registerUpdateMirrorTask() {
@@ -166,6 +170,43 @@ func registerSyncRepoLicenses() {
 	})
 }
 
+func registerPackCacheCleanup() {
+	RegisterTaskFatal("pack_cache_cleanup", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return packcache.Cleanup(ctx)
+	})
+}
+
+func registerGenerateCloneBundles() {
+	RegisterTaskFatal("generate_clone_bundles", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return clonebundle.GenerateBundles(ctx)
+	})
+}
+
+func registerNotifySavedSearchSubscribers() {
+	RegisterTaskFatal("saved_search_notifications", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return savedsearch_service.NotifySubscribers(ctx, issues_model.SavedSearchFrequencyImmediate)
+	})
+	RegisterTaskFatal("saved_search_digests", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return savedsearch_service.NotifySubscribers(ctx, issues_model.SavedSearchFrequencyDaily)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -183,4 +224,11 @@ func initBasicTasks() {
 		registerCleanupPackages()
 	}
 	registerSyncRepoLicenses()
+	if setting.PackCache.Enabled {
+		registerPackCacheCleanup()
+	}
+	if setting.CloneBundle.Enabled {
+		registerGenerateCloneBundles()
+	}
+	registerNotifySavedSearchSubscribers()
 }
@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/updatechecker"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	lfs_service "code.gitea.io/gitea/services/lfs"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 	user_service "code.gitea.io/gitea/services/user"
@@ -65,6 +66,38 @@ func registerGarbageCollectRepositories() {
 	})
 }
 
+func registerGitMaintenanceRepositories() {
+	type RepoMaintenanceConfig struct {
+		BaseConfig
+		Timeout               time.Duration
+		PushThreshold         int64
+		LooseObjectsThreshold int64
+		PackThreshold         int64
+		PruneExpire           string
+	}
+	RegisterTaskFatal("git_maintenance", &RepoMaintenanceConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 1h",
+		},
+		Timeout:               time.Duration(setting.Git.Timeout.GC) * time.Second,
+		PushThreshold:         20,
+		LooseObjectsThreshold: 1000,
+		PackThreshold:         10,
+		PruneExpire:           "2.weeks.ago",
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		cfg := config.(*RepoMaintenanceConfig)
+		return repo_service.GitMaintenanceRepos(ctx, &repo_service.MaintenanceOptions{
+			Timeout:               cfg.Timeout,
+			PushThreshold:         cfg.PushThreshold,
+			LooseObjectsThreshold: cfg.LooseObjectsThreshold,
+			PackThreshold:         cfg.PackThreshold,
+			PruneExpire:           cfg.PruneExpire,
+		})
+	})
+}
+
 func registerRewriteAllPublicKeys() {
 	RegisterTaskFatal("resync_all_sshkeys", &BaseConfig{
 		Enabled:    false,
@@ -214,6 +247,20 @@ func registerGCLFS() {
 	})
 }
 
+func registerDeleteExpiredLFSUploads() {
+	if !setting.LFS.StartServer {
+		return
+	}
+
+	RegisterTaskFatal("delete_expired_lfs_uploads", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 24h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return lfs_service.DeleteExpiredUploads(ctx, setting.LFS.MultipartUploadExpiry)
+	})
+}
+
 func registerRebuildIssueIndexer() {
 	RegisterTaskFatal("rebuild_issue_indexer", &BaseConfig{
 		Enabled:    false,
@@ -228,6 +275,7 @@ func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
 	registerGarbageCollectRepositories()
+	registerGitMaintenanceRepositories()
 	registerRewriteAllPublicKeys()
 	registerRewriteAllPrincipalKeys()
 	registerRepositoryUpdateHook()
@@ -238,5 +286,6 @@ func initExtendedTasks() {
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
 	registerGCLFS()
+	registerDeleteExpiredLFSUploads()
 	registerRebuildIssueIndexer()
 }
@@ -71,3 +71,17 @@ func (f *AdminDashboardForm) Validate(req *http.Request, errs binding.Errors) bi
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// AdminAddTrustedUserCAForm form for admin to register a trusted SSH user certificate authority
+type AdminAddTrustedUserCAForm struct {
+	Name             string `binding:"Required;MaxSize(50)"`
+	Content          string `binding:"Required"`
+	PrincipalMapping string `binding:"In(username,email)"`
+	MaxValidity      int64  `binding:"Min(0)"`
+}
+
+// Validate validates form fields
+func (f *AdminAddTrustedUserCAForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
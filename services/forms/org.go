@@ -70,6 +70,9 @@ type CreateTeamForm struct {
 	Permission       string
 	RepoAccess       string
 	CanCreateOrgRepo bool
+
+	ReviewAssignPolicy string
+	ReviewAssignCount  int `binding:"Range(0,100)"`
 }
 
 // Validate validates the fields
@@ -36,3 +36,15 @@ func (f *RenameBranchForm) Validate(req *http.Request, errs binding.Errors) bind
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// CommitNoteForm form for creating or updating a git note of a commit
+type CommitNoteForm struct {
+	Ref     string `binding:"MaxSize(255)"`
+	Message string `binding:"Required"`
+}
+
+// Validate validates the fields
+func (f *CommitNoteForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -164,34 +164,36 @@ func (f *RepoSettingForm) Validate(req *http.Request, errs binding.Errors) bindi
 
 // ProtectBranchForm form for changing protected branch settings
 type ProtectBranchForm struct {
-	RuleName                      string `binding:"Required"`
-	RuleID                        int64
-	EnablePush                    string
-	WhitelistUsers                string
-	WhitelistTeams                string
-	WhitelistDeployKeys           bool
-	EnableForcePush               string
-	ForcePushAllowlistUsers       string
-	ForcePushAllowlistTeams       string
-	ForcePushAllowlistDeployKeys  bool
-	EnableMergeWhitelist          bool
-	MergeWhitelistUsers           string
-	MergeWhitelistTeams           string
-	EnableStatusCheck             bool
-	StatusCheckContexts           string
-	RequiredApprovals             int64
-	EnableApprovalsWhitelist      bool
-	ApprovalsWhitelistUsers       string
-	ApprovalsWhitelistTeams       string
-	BlockOnRejectedReviews        bool
-	BlockOnOfficialReviewRequests bool
-	BlockOnOutdatedBranch         bool
-	DismissStaleApprovals         bool
-	IgnoreStaleApprovals          bool
-	RequireSignedCommits          bool
-	ProtectedFilePatterns         string
-	UnprotectedFilePatterns       string
-	BlockAdminMergeOverride       bool
+	RuleName                       string `binding:"Required"`
+	RuleID                         int64
+	EnablePush                     string
+	WhitelistUsers                 string
+	WhitelistTeams                 string
+	WhitelistDeployKeys            bool
+	EnableForcePush                string
+	ForcePushAllowlistUsers        string
+	ForcePushAllowlistTeams        string
+	ForcePushAllowlistDeployKeys   bool
+	EnableMergeWhitelist           bool
+	MergeWhitelistUsers            string
+	MergeWhitelistTeams            string
+	EnableStatusCheck              bool
+	StatusCheckContexts            string
+	RequiredApprovals              int64
+	EnableApprovalsWhitelist       bool
+	ApprovalsWhitelistUsers        string
+	ApprovalsWhitelistTeams        string
+	BlockOnRejectedReviews         bool
+	BlockOnOfficialReviewRequests  bool
+	BlockOnOutdatedBranch          bool
+	BlockOnUnresolvedConversations bool
+	DismissStaleApprovals          bool
+	IgnoreStaleApprovals           bool
+	RequireSignedCommits           bool
+	RequireSignedPushes            bool
+	ProtectedFilePatterns          string
+	UnprotectedFilePatterns        string
+	BlockAdminMergeOverride        bool
 }
 
 // Validate validates the fields
@@ -544,6 +546,7 @@ type CodeCommentForm struct {
 	Content        string `binding:"Required"`
 	Side           string `binding:"Required;In(previous,proposed)"`
 	Line           int64
+	StartLine      int64  `form:"start_line"`
 	TreePath       string `form:"path" binding:"Required"`
 	SingleReview   bool   `form:"single_review"`
 	Reply          int64  `form:"reply"`
@@ -436,3 +436,30 @@ func (f *BlockUserForm) Validate(req *http.Request, errs binding.Errors) binding
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// NewSavedSearchForm form for saving an issue or pull request search
+type NewSavedSearchForm struct {
+	Name   string `binding:"Required;MaxSize(255)" locale:"settings.saved_searches.name"`
+	Query  string `form:"q" binding:"MaxSize(1024)" locale:"settings.saved_searches.query"`
+	Type   string `binding:"Required;In(issues,pulls)"`
+	OrgID  int64  `form:"org"`
+	TeamID int64  `form:"team"`
+}
+
+// Validate validates the fields
+func (f *NewSavedSearchForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SavedSearchSubscriptionForm form for subscribing to a saved search
+type SavedSearchSubscriptionForm struct {
+	Frequency string `binding:"Required;In(immediate,daily)"`
+	Method    string `binding:"Required;In(notification,email)"`
+}
+
+// Validate validates the fields
+func (f *SavedSearchSubscriptionForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+)
+
+// lineMappingHunk is a changed range of lines, the old lines [OldStart, OldStart+OldCount) are replaced by
+// the new lines [NewStart, NewStart+NewCount). If OldCount is 0, the new lines are inserted after the line OldStart.
+type lineMappingHunk struct {
+	OldStart, OldCount int64
+	NewStart, NewCount int64
+}
+
+// LineMapping maps the lines of a file in an old commit to the same lines in a new commit
+type LineMapping struct {
+	hunks []lineMappingHunk
+}
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+func parseLineMapping(r io.Reader) (*LineMapping, error) {
+	mapping := &LineMapping{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := hunkHeaderRegexp.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		hunk := lineMappingHunk{OldCount: 1, NewCount: 1}
+		hunk.OldStart, _ = strconv.ParseInt(m[1], 10, 64)
+		if m[2] != "" {
+			hunk.OldCount, _ = strconv.ParseInt(m[2], 10, 64)
+		}
+		hunk.NewStart, _ = strconv.ParseInt(m[3], 10, 64)
+		if m[4] != "" {
+			hunk.NewCount, _ = strconv.ParseInt(m[4], 10, 64)
+		}
+		mapping.hunks = append(mapping.hunks, hunk)
+	}
+	return mapping, scanner.Err()
+}
+
+// MapRange maps the old lines [start, end] to the new lines, ok is false if any of the lines has been changed
+// or new lines have been inserted between them.
+func (m *LineMapping) MapRange(start, end int64) (newStart, newEnd int64, ok bool) {
+	if start <= 0 || end < start {
+		return 0, 0, false
+	}
+	var offset int64
+	for _, hunk := range m.hunks {
+		if hunk.OldCount == 0 {
+			// pure insertion after the line OldStart
+			if hunk.OldStart < start {
+				offset += hunk.NewCount
+				continue
+			}
+			if hunk.OldStart < end {
+				return 0, 0, false
+			}
+			break
+		}
+		oldEnd := hunk.OldStart + hunk.OldCount - 1
+		if oldEnd < start {
+			offset += hunk.NewCount - hunk.OldCount
+			continue
+		}
+		if hunk.OldStart <= end {
+			return 0, 0, false
+		}
+		break
+	}
+	return start + offset, end + offset, true
+}
+
+// GetLineMapping returns the mapping of the lines of a file between two commits
+func GetLineMapping(ctx context.Context, gitRepo *git.Repository, oldCommitID, newCommitID, treePath string) (*LineMapping, error) {
+	stdout, _, err := gitcmd.NewCommand("diff", "--no-color", "--no-ext-diff", "--no-renames", "-U0").
+		AddDynamicArguments(oldCommitID, newCommitID).
+		AddDashesAndList(treePath).
+		WithDir(gitRepo.Path).
+		RunStdString(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to diff %s between %s and %s: %w", treePath, oldCommitID, newCommitID, err)
+	}
+	return parseLineMapping(strings.NewReader(stdout))
+}
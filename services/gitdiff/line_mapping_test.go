@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineMapping(t *testing.T) {
+	output := `diff --git a/file b/file
+index 1234567..89abcde 100644
+--- a/file
++++ b/file
+@@ -2,0 +3,2 @@ func a() {
++inserted
++inserted
+@@ -5 +7 @@ func b() {
+-old
++new
+@@ -10,2 +11,0 @@ func c() {
+-deleted
+-deleted
+`
+	mapping, err := parseLineMapping(strings.NewReader(output))
+	require.NoError(t, err)
+
+	cases := []struct {
+		start, end       int64
+		newStart, newEnd int64
+		ok               bool
+	}{
+		{1, 2, 1, 2, true},
+		{2, 3, 0, 0, false}, // lines are inserted between them
+		{3, 4, 5, 6, true},
+		{5, 5, 0, 0, false}, // changed
+		{4, 6, 0, 0, false},
+		{6, 9, 8, 11, true},
+		{9, 10, 0, 0, false}, // deleted
+		{12, 12, 12, 12, true},
+		{0, 1, 0, 0, false},
+	}
+	for _, c := range cases {
+		newStart, newEnd, ok := mapping.MapRange(c.start, c.end)
+		assert.Equal(t, c.ok, ok, "%d-%d", c.start, c.end)
+		assert.Equal(t, c.newStart, newStart, "%d-%d", c.start, c.end)
+		assert.Equal(t, c.newEnd, newEnd, "%d-%d", c.start, c.end)
+	}
+
+	mapping, err = parseLineMapping(strings.NewReader(""))
+	require.NoError(t, err)
+	newStart, newEnd, ok := mapping.MapRange(3, 7)
+	assert.True(t, ok)
+	assert.EqualValues(t, 3, newStart)
+	assert.EqualValues(t, 7, newEnd)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// RangeDiffStatus represents how a commit of the old range relates to a commit of the new range
+type RangeDiffStatus string
+
+const (
+	RangeDiffStatusEqual    RangeDiffStatus = "=" // the commit patches are identical
+	RangeDiffStatusModified RangeDiffStatus = "!" // the commit patches differ
+	RangeDiffStatusRemoved  RangeDiffStatus = "<" // the commit only exists in the old range
+	RangeDiffStatusAdded    RangeDiffStatus = ">" // the commit only exists in the new range
+)
+
+// RangeDiffLine represents a line of the diff between two commit patches
+type RangeDiffLine struct {
+	Type    DiffLineType // DiffLineDel: only in the old patch, DiffLineAdd: only in the new patch
+	Content string       // the line of the commit patch, including its own diff marker
+}
+
+// GetHTMLDiffLineType returns the diff line type name for HTML
+func (l *RangeDiffLine) GetHTMLDiffLineType() string {
+	return (&DiffLine{Type: l.Type}).GetHTMLDiffLineType()
+}
+
+// GetLineTypeMarker returns the outer diff marker of the line
+func (l *RangeDiffLine) GetLineTypeMarker() string {
+	switch l.Type {
+	case DiffLineAdd:
+		return "+"
+	case DiffLineDel:
+		return "-"
+	}
+	return ""
+}
+
+// RangeDiffEntry represents a pair of matching commits from the old and the new range
+type RangeDiffEntry struct {
+	Status      RangeDiffStatus
+	OldIndex    int    // 1-based position in the old range, 0 if the commit was added
+	OldCommitID string // abbreviated commit ID in the old range, empty if the commit was added
+	NewIndex    int    // 1-based position in the new range, 0 if the commit was removed
+	NewCommitID string // abbreviated commit ID in the new range, empty if the commit was removed
+	Title       string
+	Lines       []*RangeDiffLine
+}
+
+// RangeDiff represents the changes between two versions of a commit series, ignoring changes of the base
+type RangeDiff struct {
+	OldMergeBase string
+	OldHead      string
+	NewMergeBase string
+	NewHead      string
+	Entries      []*RangeDiffEntry
+}
+
+// IsEmpty returns true if both commit series are the same
+func (rd *RangeDiff) IsEmpty() bool {
+	for _, entry := range rd.Entries {
+		if entry.Status != RangeDiffStatusEqual {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRangeDiff compares the commit series "merge-base(base, oldHead)..oldHead" with "merge-base(base, newHead)..newHead",
+// so a rebased pull request only shows what the author changed and not what was pulled in from the base branch.
+func GetRangeDiff(ctx context.Context, gitRepo *git.Repository, baseRef, oldHead, newHead string) (*RangeDiff, error) {
+	rd := &RangeDiff{OldHead: oldHead, NewHead: newHead}
+	var err error
+	if rd.OldMergeBase, err = getMergeBase(ctx, gitRepo, baseRef, oldHead); err != nil {
+		return nil, err
+	}
+	if rd.NewMergeBase, err = getMergeBase(ctx, gitRepo, baseRef, newHead); err != nil {
+		return nil, err
+	}
+
+	cmd := gitcmd.NewCommand("range-diff", "--no-color").
+		AddDynamicArguments(rd.OldMergeBase+".."+oldHead, rd.NewMergeBase+".."+newHead)
+	stdout, _, runErr := cmd.WithDir(gitRepo.Path).RunStdString(ctx)
+	if runErr != nil {
+		log.Warn("git range-diff: %v", runErr)
+		return nil, runErr
+	}
+
+	if rd.Entries, err = parseRangeDiff(strings.NewReader(stdout)); err != nil {
+		return nil, err
+	}
+	return rd, nil
+}
+
+func getMergeBase(ctx context.Context, gitRepo *git.Repository, baseRef, headRef string) (string, error) {
+	stdout, _, err := gitcmd.NewCommand("merge-base").AddDashesAndList(baseRef, headRef).
+		WithDir(gitRepo.Path).RunStdString(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get merge-base of %s and %s failed: %w", baseRef, headRef, err)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// rangeDiffHeaderRegex matches lines like "1:  4b22641 ! 2:  8a41415 commit title"
+var rangeDiffHeaderRegex = regexp.MustCompile(`^\s*(-|\d+):\s+(-+|[0-9a-f]+) ([=!<>]) \s*(-|\d+):\s+(-+|[0-9a-f]+) (.*)$`)
+
+func parseRangeDiff(gitOutput io.Reader) ([]*RangeDiffEntry, error) {
+	/*
+		The output of `git range-diff --no-color` is of the form:
+
+		1:  4b22641 = 1:  34c107e add b
+		2:  10f7387 ! 2:  8a41415 add c
+		    @@ c (new)
+		     +4
+		    -+5
+		    ++five
+		-:  ------- > 3:  7e5579e add d
+
+		See: <https://git-scm.com/docs/git-range-diff#_output_stability> for more details
+	*/
+	var entries []*RangeDiffEntry
+	var current *RangeDiffEntry
+
+	lines := bufio.NewScanner(gitOutput)
+	lines.Buffer(nil, 1024*1024)
+	for lines.Scan() {
+		line := lines.Text()
+		if patchLine, ok := strings.CutPrefix(line, "    "); ok && current != nil {
+			if patchLine == "" {
+				continue
+			}
+			current.Lines = append(current.Lines, parseRangeDiffLine(patchLine))
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		matches := rangeDiffHeaderRegex.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, fmt.Errorf("unexpected range-diff line: %q", line)
+		}
+		current = &RangeDiffEntry{
+			Status: RangeDiffStatus(matches[3]),
+			Title:  matches[6],
+		}
+		if matches[1] != "-" {
+			current.OldIndex, _ = strconv.Atoi(matches[1])
+			current.OldCommitID = matches[2]
+		}
+		if matches[4] != "-" {
+			current.NewIndex, _ = strconv.Atoi(matches[4])
+			current.NewCommitID = matches[5]
+		}
+		entries = append(entries, current)
+	}
+	return entries, lines.Err()
+}
+
+func parseRangeDiffLine(patchLine string) *RangeDiffLine {
+	l := &RangeDiffLine{Type: DiffLinePlain, Content: patchLine[1:]}
+	switch patchLine[0] {
+	case '+':
+		l.Type = DiffLineAdd
+	case '-':
+		l.Type = DiffLineDel
+	case '@':
+		l.Type, l.Content = DiffLineSection, patchLine
+	}
+	return l
+}
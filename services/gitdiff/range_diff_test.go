@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRangeDiff(t *testing.T) {
+	output := `1:  4b22641 = 1:  34c107e add b
+2:  10f7387 ! 2:  8a41415 add c: with colon
+    @@ c (new)
+     +4
+    -+5
+    ++five
+
+3:  6d8d0c3 < -:  ------- dropped
+-:  ------- > 3:  7e5579e add d
+`
+	entries, err := parseRangeDiff(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, &RangeDiffEntry{
+		Status:      RangeDiffStatusEqual,
+		OldIndex:    1,
+		OldCommitID: "4b22641",
+		NewIndex:    1,
+		NewCommitID: "34c107e",
+		Title:       "add b",
+	}, entries[0])
+
+	assert.Equal(t, RangeDiffStatusModified, entries[1].Status)
+	assert.Equal(t, "add c: with colon", entries[1].Title)
+	assert.Equal(t, []*RangeDiffLine{
+		{Type: DiffLineSection, Content: "@@ c (new)"},
+		{Type: DiffLinePlain, Content: "+4"},
+		{Type: DiffLineDel, Content: "+5"},
+		{Type: DiffLineAdd, Content: "+five"},
+	}, entries[1].Lines)
+
+	assert.Equal(t, RangeDiffStatusRemoved, entries[2].Status)
+	assert.Equal(t, 3, entries[2].OldIndex)
+	assert.Zero(t, entries[2].NewIndex)
+	assert.Empty(t, entries[2].NewCommitID)
+
+	assert.Equal(t, RangeDiffStatusAdded, entries[3].Status)
+	assert.Zero(t, entries[3].OldIndex)
+	assert.Empty(t, entries[3].OldCommitID)
+	assert.Equal(t, "7e5579e", entries[3].NewCommitID)
+
+	rd := &RangeDiff{Entries: entries[:1]}
+	assert.True(t, rd.IsEmpty())
+	rd.Entries = entries
+	assert.False(t, rd.IsEmpty())
+
+	_, err = parseRangeDiff(strings.NewReader("garbage\n"))
+	assert.Error(t, err)
+}
@@ -9,7 +9,9 @@ import (
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
 	"code.gitea.io/gitea/modules/log"
@@ -72,6 +74,9 @@ func (r *indexerNotifier) DeleteRepository(ctx context.Context, doer *user_model
 	if setting.Indexer.RepoIndexerEnabled {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.ContentIndexerEnabled {
+		content_indexer.UpdateRepoIndexer(repo.ID, "")
+	}
 }
 
 func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
@@ -79,6 +84,9 @@ func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_m
 	if setting.Indexer.RepoIndexerEnabled && !repo.IsEmpty {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.ContentIndexerEnabled {
+		content_indexer.UpdateRepoIndexer(repo.ID, "")
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
@@ -89,9 +97,12 @@ func (r *indexerNotifier) PushCommits(ctx context.Context, pusher *user_model.Us
 		return
 	}
 
-	if setting.Indexer.RepoIndexerEnabled && opts.RefFullName.BranchName() == repo.DefaultBranch {
+	if setting.Indexer.RepoIndexerEnabled && (opts.RefFullName.BranchName() == repo.DefaultBranch || isCodeIndexerRef(ctx, repo, opts.RefFullName)) {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.ContentIndexerEnabled && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		content_indexer.UpdateRepoIndexer(repo.ID, content_indexer.KindCommit)
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
@@ -102,23 +113,87 @@ func (r *indexerNotifier) SyncPushCommits(ctx context.Context, pusher *user_mode
 		return
 	}
 
-	if setting.Indexer.RepoIndexerEnabled && opts.RefFullName.BranchName() == repo.DefaultBranch {
+	if setting.Indexer.RepoIndexerEnabled && (opts.RefFullName.BranchName() == repo.DefaultBranch || isCodeIndexerRef(ctx, repo, opts.RefFullName)) {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.ContentIndexerEnabled && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		content_indexer.UpdateRepoIndexer(repo.ID, content_indexer.KindCommit)
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
 }
 
+// isCodeIndexerRef returns whether the branch or the tag is indexed by the code indexer besides the default branch
+func isCodeIndexerRef(ctx context.Context, repo *repo_model.Repository, refFullName git.RefName) bool {
+	indexerRefs, err := repo_model.GetRepoCodeIndexerRefs(ctx, repo.ID)
+	if err != nil {
+		log.Error("GetRepoCodeIndexerRefs(%d) failed: %v", repo.ID, err)
+		return false
+	}
+	return indexerRefs.Match(refFullName)
+}
+
+func (r *indexerNotifier) CreateRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName, refID string) {
+	if setting.Indexer.RepoIndexerEnabled && isCodeIndexerRef(ctx, repo, refFullName) {
+		code_indexer.UpdateRepoIndexer(repo)
+	}
+}
+
+func (r *indexerNotifier) DeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
+	if setting.Indexer.RepoIndexerEnabled && isCodeIndexerRef(ctx, repo, refFullName) {
+		code_indexer.UpdateRepoIndexer(repo)
+	}
+}
+
+func (r *indexerNotifier) SyncCreateRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName, refID string) {
+	r.CreateRef(ctx, doer, repo, refFullName, refID)
+}
+
+func (r *indexerNotifier) SyncDeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
+	r.DeleteRef(ctx, doer, repo, refFullName)
+}
+
 func (r *indexerNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	if setting.Indexer.RepoIndexerEnabled && !repo.IsEmpty {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.ContentIndexerEnabled && !repo.IsEmpty {
+		content_indexer.UpdateRepoIndexer(repo.ID, content_indexer.KindCommit)
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
 }
 
+func (r *indexerNotifier) NewWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
+	if setting.Indexer.ContentIndexerEnabled {
+		content_indexer.UpdateRepoIndexer(repo.ID, content_indexer.KindWiki)
+	}
+}
+
+func (r *indexerNotifier) EditWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
+	r.NewWikiPage(ctx, doer, repo, page, comment)
+}
+
+func (r *indexerNotifier) DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page string) {
+	r.NewWikiPage(ctx, doer, repo, page, "")
+}
+
+func (r *indexerNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
+	if setting.Indexer.ContentIndexerEnabled {
+		content_indexer.UpdateRepoIndexer(rel.RepoID, content_indexer.KindRelease)
+	}
+}
+
+func (r *indexerNotifier) UpdateRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	r.NewRelease(ctx, rel)
+}
+
+func (r *indexerNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	r.NewRelease(ctx, rel)
+}
+
 func (r *indexerNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
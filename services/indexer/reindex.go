@@ -0,0 +1,185 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// The names of the indexers which could be reindexed
+const (
+	NameCode     = "code"
+	NameIssues   = "issues"
+	NameContents = "contents"
+)
+
+var (
+	// ErrIndexerDisabled is returned when reindexing an indexer which is disabled
+	ErrIndexerDisabled = util.NewInvalidArgumentErrorf("indexer is disabled")
+	// ErrReindexRunning is returned when reindexing all the repositories while a previous reindexing is still running
+	ErrReindexRunning = util.NewAlreadyExistErrorf("reindexing is already running")
+)
+
+// ReindexProgress is the progress of queueing all the repositories of an indexer to be reindexed,
+// the queued repositories are indexed later by the queue of the indexer
+type ReindexProgress struct {
+	Total        int64
+	Queued       int64
+	Failed       int64
+	StartedUnix  timeutil.TimeStamp
+	FinishedUnix timeutil.TimeStamp
+}
+
+// IsRunning returns whether the reindexing is still queueing repositories
+func (p *ReindexProgress) IsRunning() bool {
+	return p.FinishedUnix == 0
+}
+
+// Percent returns the percentage of the repositories which have been queued
+func (p *ReindexProgress) Percent() int {
+	if p.Total <= 0 {
+		return 100
+	}
+	return int(min((p.Queued+p.Failed)*100/p.Total, 100))
+}
+
+// IndexerStatus is the status of an indexer with the progress of its last reindexing
+type IndexerStatus struct {
+	*indexer.Status
+	Progress *ReindexProgress // nil if the indexer hasn't been reindexed since the start
+}
+
+var reindexProgresses = struct {
+	sync.Mutex
+	m map[string]*ReindexProgress
+}{m: map[string]*ReindexProgress{}}
+
+// GetReindexProgress returns a copy of the progress of the last reindexing of the indexer, nil if there is none
+func GetReindexProgress(name string) *ReindexProgress {
+	reindexProgresses.Lock()
+	defer reindexProgresses.Unlock()
+	p, ok := reindexProgresses.m[name]
+	if !ok {
+		return nil
+	}
+	ret := *p
+	return &ret
+}
+
+// GetIndexerStatuses returns the statuses of all the indexers
+func GetIndexerStatuses(ctx context.Context) []*IndexerStatus {
+	statuses := []*indexer.Status{
+		code_indexer.Status(ctx),
+		issue_indexer.Status(ctx),
+		content_indexer.Status(ctx),
+	}
+	ret := make([]*IndexerStatus, 0, len(statuses))
+	for _, status := range statuses {
+		ret = append(ret, &IndexerStatus{Status: status, Progress: GetReindexProgress(status.Name)})
+	}
+	return ret
+}
+
+func isIndexerEnabled(name string) bool {
+	switch name {
+	case NameCode:
+		return setting.Indexer.RepoIndexerEnabled
+	case NameIssues:
+		return true
+	case NameContents:
+		return setting.Indexer.ContentIndexerEnabled
+	}
+	return false
+}
+
+// ReindexRepo drops the indexed data of the repository from the indexer and queues it to be indexed again
+func ReindexRepo(ctx context.Context, name string, repo *repo_model.Repository) error {
+	if !isIndexerEnabled(name) {
+		return ErrIndexerDisabled
+	}
+	return reindexRepo(ctx, name, repo)
+}
+
+func reindexRepo(ctx context.Context, name string, repo *repo_model.Repository) error {
+	switch name {
+	case NameCode:
+		if repo.IsEmpty || repo.IsBroken() {
+			return nil
+		}
+		return code_indexer.ReindexRepo(ctx, repo)
+	case NameIssues:
+		return issue_indexer.ReindexRepo(ctx, repo.ID)
+	case NameContents:
+		return content_indexer.ReindexRepo(ctx, repo.ID)
+	}
+	return ErrIndexerDisabled
+}
+
+// ReindexAll starts queueing all the repositories to be indexed again in the background,
+// the progress could be fetched by GetReindexProgress
+func ReindexAll(ctx context.Context, name string) error {
+	if !isIndexerEnabled(name) {
+		return ErrIndexerDisabled
+	}
+
+	total, err := db.GetEngine(ctx).Count(new(repo_model.Repository))
+	if err != nil {
+		return err
+	}
+
+	reindexProgresses.Lock()
+	if p, ok := reindexProgresses.m[name]; ok && p.IsRunning() {
+		reindexProgresses.Unlock()
+		return ErrReindexRunning
+	}
+	progress := &ReindexProgress{Total: total, StartedUnix: timeutil.TimeStampNow()}
+	reindexProgresses.m[name] = progress
+	reindexProgresses.Unlock()
+
+	go graceful.GetManager().RunWithShutdownContext(func(ctx context.Context) {
+		defer func() {
+			reindexProgresses.Lock()
+			progress.FinishedUnix = timeutil.TimeStampNow()
+			reindexProgresses.Unlock()
+		}()
+
+		log.Info("Reindexing all the repositories in the %s indexer", name)
+		err := db.Iterate(ctx, builder.NewCond(), func(ctx context.Context, repo *repo_model.Repository) error {
+			err := reindexRepo(ctx, name, repo)
+			reindexProgresses.Lock()
+			if err != nil {
+				progress.Failed++
+			} else {
+				progress.Queued++
+			}
+			reindexProgresses.Unlock()
+			if err != nil {
+				log.Error("Unable to reindex repository %d in the %s indexer: %v", repo.ID, name, err)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Error("Reindexing all the repositories in the %s indexer: %v", name, err)
+			return
+		}
+		log.Info("Done queueing all the repositories to be reindexed in the %s indexer", name)
+	})
+	return nil
+}
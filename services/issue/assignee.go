@@ -81,6 +81,13 @@ func ReviewRequest(ctx context.Context, issue *issues_model.Issue, doer *user_mo
 
 	if comment != nil {
 		notify_service.PullRequestReviewRequest(ctx, doer, issue, reviewer, isAdd, comment)
+
+		// a declined review request of a team member is handed over to another member of the team
+		if !isAdd && doer.ID == reviewer.ID {
+			if err := reassignTeamMemberReviewRequest(ctx, issue, doer, reviewer); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return comment, err
@@ -237,6 +244,18 @@ func TeamReviewRequest(ctx context.Context, issue *issues_model.Issue, doer *use
 		return nil, nil //nolint:nilnil // return nil because no comment was created or it is a removal
 	}
 
+	if reviewer.IsReviewAssignEnabled() {
+		// only the assigned members are notified instead of the whole team
+		notifiers, err := assignTeamReviewers(ctx, issue, doer, reviewer, reviewer.ReviewAssignCount, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, notifier := range notifiers {
+			notify_service.PullRequestReviewRequest(ctx, doer, issue, notifier.Reviewer, true, notifier.Comment)
+		}
+		return comment, nil
+	}
+
 	return comment, teamReviewRequestNotify(ctx, issue, doer, reviewer, isAdd, comment)
 }
 
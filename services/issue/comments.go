@@ -184,6 +184,10 @@ func LoadCommentPushCommits(ctx context.Context, c *issues_model.Comment) error
 		}
 		defer closer.Close()
 
+		// the heads before and after the push let the timeline link to the changes since the previous push,
+		// older comments don't have them and the pushed commits alone can't tell them for a non-linear history
+		c.OldCommit, c.NewCommit = data.OldCommitID, data.NewCommitID
+
 		c.Commits, err = git_service.ConvertFromGitCommit(ctx, gitRepo.GetCommitsFromIDs(data.CommitIDs), c.Issue.Repo)
 		if err != nil {
 			log.Debug("ConvertFromGitCommit: %v", err) // no need to show 500 error to end user when the commit does not exist
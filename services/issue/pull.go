@@ -154,6 +154,15 @@ func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullReque
 		if comment == nil { // comment maybe nil if review type is ReviewTypeRequest
 			continue
 		}
+		if t.IsReviewAssignEnabled() {
+			memberNotifiers, err := assignTeamReviewers(ctx, issue, issue.Poster, t, t.ReviewAssignCount, nil)
+			if err != nil {
+				log.Warn("Failed assign members of team: %s to PR review: %s#%d, error: %s", t.Name, pr.BaseRepo.Name, pr.ID, err)
+				return nil, err
+			}
+			notifiers = append(notifiers, memberNotifiers...)
+			continue
+		}
 		notifiers = append(notifiers, &ReviewRequestNotifier{
 			Comment:    comment,
 			IsAdd:      true,
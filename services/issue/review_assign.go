@@ -0,0 +1,215 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// pickTeamReviewers chooses up to count reviewers from the candidates (sorted by ID) according to the policy,
+// lastUserID is the member which got the last round-robin request and loads are the numbers of pending review requests.
+func pickTeamReviewers(policy organization.ReviewAssignPolicy, candidates []*user_model.User, count int, lastUserID int64, loads map[int64]int64) []*user_model.User {
+	if count <= 0 || len(candidates) == 0 {
+		return nil
+	}
+	ordered := slices.Clone(candidates)
+	switch policy {
+	case organization.ReviewAssignPolicyRoundRobin:
+		// continue with the first member after the one who got the last request
+		start := slices.IndexFunc(ordered, func(u *user_model.User) bool { return u.ID > lastUserID })
+		if start > 0 {
+			ordered = slices.Concat(ordered[start:], ordered[:start])
+		}
+	case organization.ReviewAssignPolicyLoadBalance:
+		slices.SortStableFunc(ordered, func(a, b *user_model.User) int {
+			return cmp.Compare(loads[a.ID], loads[b.ID])
+		})
+	default:
+		return nil
+	}
+	return ordered[:min(count, len(ordered))]
+}
+
+// getTeamReviewCandidates returns the members of the team which can be assigned to review the pull request, sorted by ID
+func getTeamReviewCandidates(ctx context.Context, issue *issues_model.Issue, team *organization.Team, excludeIDs container.Set[int64]) ([]*user_model.User, error) {
+	members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{
+		TeamID: team.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// members who have been requested or have already reviewed don't get another request
+	latestReviews, _, err := issues_model.GetReviewsByIssueID(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, review := range latestReviews {
+		if review.ReviewerTeamID == 0 {
+			excludeIDs.Add(review.ReviewerID)
+		}
+	}
+
+	candidates := make([]*user_model.User, 0, len(members))
+	for _, member := range members {
+		if member.ID == issue.PosterID || !member.IsActive || member.ProhibitLogin || excludeIDs.Contains(member.ID) {
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+
+	candidateIDs := make([]int64, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateIDs = append(candidateIDs, candidate.ID)
+	}
+	unavailableIDs, err := user_model.GetUserIDsBySettingValues(ctx, candidateIDs, user_model.SettingsKeyReviewAvailability,
+		user_model.SettingReviewAvailabilityBusy, user_model.SettingReviewAvailabilityAway)
+	if err != nil {
+		return nil, err
+	}
+	candidates = slices.DeleteFunc(candidates, func(u *user_model.User) bool {
+		return slices.Contains(unavailableIDs, u.ID)
+	})
+	slices.SortFunc(candidates, func(a, b *user_model.User) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return candidates, nil
+}
+
+// assignTeamReviewers requests the review from count members of the team according to its review assignment policy,
+// reassignedFrom is the member whose review request is handed over to another member.
+func assignTeamReviewers(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, team *organization.Team, count int, reassignedFrom *user_model.User) ([]*ReviewRequestNotifier, error) {
+	excludeIDs := make(container.Set[int64])
+	if reassignedFrom != nil {
+		excludeIDs.Add(reassignedFrom.ID)
+	}
+	candidates, err := getTeamReviewCandidates(ctx, issue, team, excludeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var loads map[int64]int64
+	if team.ReviewAssignPolicy == organization.ReviewAssignPolicyLoadBalance {
+		candidateIDs := make([]int64, 0, len(candidates))
+		for _, candidate := range candidates {
+			candidateIDs = append(candidateIDs, candidate.ID)
+		}
+		if loads, err = issues_model.CountPendingReviewRequests(ctx, candidateIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var notifiers []*ReviewRequestNotifier
+	return notifiers, db.WithTx(ctx, func(ctx context.Context) error {
+		reviewers, err := pickTeamReviewersInTurn(ctx, team, candidates, count, loads)
+		if err != nil {
+			return err
+		}
+		notifiers = make([]*ReviewRequestNotifier, 0, len(reviewers))
+		for _, reviewer := range reviewers {
+			comment, err := issues_model.AddTeamMemberReviewRequest(ctx, issue, reviewer, doer, team, reassignedFrom)
+			if err != nil {
+				return err
+			}
+			if comment == nil {
+				continue
+			}
+			notifiers = append(notifiers, &ReviewRequestNotifier{
+				Comment:  comment,
+				IsAdd:    true,
+				Reviewer: reviewer,
+			})
+		}
+		return nil
+	})
+}
+
+// maxRoundRobinAttempts is the number of times the round-robin reviewers are picked again
+// if the concurrent review requests of the team keep moving its turn
+const maxRoundRobinAttempts = 3
+
+// pickTeamReviewersInTurn picks the reviewers of the team, for the round-robin policy the turn of the team is moved to
+// the last picked member only if no concurrent request has moved it since it was read, otherwise the reviewers are picked again.
+func pickTeamReviewersInTurn(ctx context.Context, team *organization.Team, candidates []*user_model.User, count int, loads map[int64]int64) ([]*user_model.User, error) {
+	if team.ReviewAssignPolicy != organization.ReviewAssignPolicyRoundRobin {
+		return pickTeamReviewers(team.ReviewAssignPolicy, candidates, count, team.ReviewAssignLastUserID, loads), nil
+	}
+	for range maxRoundRobinAttempts {
+		lastUserID, err := organization.GetTeamReviewAssignLastUserID(ctx, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		reviewers := pickTeamReviewers(team.ReviewAssignPolicy, candidates, count, lastUserID, loads)
+		if len(reviewers) == 0 {
+			return nil, nil
+		}
+		updated, err := organization.UpdateTeamReviewAssignLastUser(ctx, team, lastUserID, reviewers[len(reviewers)-1].ID)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			return reviewers, nil
+		}
+	}
+	return nil, fmt.Errorf("the round-robin turn of team %d keeps being changed by concurrent review requests", team.ID)
+}
+
+// reassignTeamMemberReviewRequest hands the review request over to another member of the team
+// if the removed request of the reviewer was assigned on behalf of a team which is still requested to review.
+func reassignTeamMemberReviewRequest(ctx context.Context, issue *issues_model.Issue, doer, reviewer *user_model.User) error {
+	comment, err := issues_model.GetLatestReviewRequestComment(ctx, issue.ID, reviewer.ID)
+	if err != nil {
+		if issues_model.IsErrCommentNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if comment.CommentMetaData == nil || comment.CommentMetaData.ReviewTeamID == 0 {
+		return nil
+	}
+
+	team, err := organization.GetTeamByID(ctx, comment.CommentMetaData.ReviewTeamID)
+	if err != nil {
+		if organization.IsErrTeamNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !team.IsReviewAssignEnabled() {
+		return nil
+	}
+	teamReview, err := issues_model.GetTeamReviewerByIssueIDAndTeamID(ctx, issue.ID, team.ID)
+	if err != nil {
+		if issues_model.IsErrReviewNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if teamReview.Type != issues_model.ReviewTypeRequest {
+		return nil
+	}
+
+	notifiers, err := assignTeamReviewers(ctx, issue, doer, team, 1, reviewer)
+	if err != nil {
+		return err
+	}
+	for _, notifier := range notifiers {
+		notify_service.PullRequestReviewRequest(ctx, doer, issue, notifier.Reviewer, true, notifier.Comment)
+	}
+	if len(notifiers) == 0 {
+		log.Debug("No member of team %d is available to take over the review request of %s for issue %d", team.ID, reviewer.Name, issue.ID)
+	}
+	return nil
+}
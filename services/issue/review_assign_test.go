@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickTeamReviewers(t *testing.T) {
+	users := []*user_model.User{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	ids := func(users []*user_model.User) (ret []int64) {
+		for _, u := range users {
+			ret = append(ret, u.ID)
+		}
+		return ret
+	}
+
+	assert.Empty(t, pickTeamReviewers(organization.ReviewAssignPolicyNone, users, 2, 0, nil))
+	assert.Empty(t, pickTeamReviewers(organization.ReviewAssignPolicyRoundRobin, users, 0, 0, nil))
+
+	assert.Equal(t, []int64{1, 2}, ids(pickTeamReviewers(organization.ReviewAssignPolicyRoundRobin, users, 2, 0, nil)))
+	assert.Equal(t, []int64{3, 4}, ids(pickTeamReviewers(organization.ReviewAssignPolicyRoundRobin, users, 2, 2, nil)))
+	assert.Equal(t, []int64{4, 1, 2}, ids(pickTeamReviewers(organization.ReviewAssignPolicyRoundRobin, users, 3, 3, nil)))
+	assert.Equal(t, []int64{1}, ids(pickTeamReviewers(organization.ReviewAssignPolicyRoundRobin, users, 1, 4, nil)))
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids(pickTeamReviewers(organization.ReviewAssignPolicyRoundRobin, users, 10, 0, nil)))
+
+	loads := map[int64]int64{1: 3, 2: 1, 4: 1}
+	assert.Equal(t, []int64{3, 2}, ids(pickTeamReviewers(organization.ReviewAssignPolicyLoadBalance, users, 2, 0, loads)))
+	assert.Equal(t, []int64{3, 2, 4, 1}, ids(pickTeamReviewers(organization.ReviewAssignPolicyLoadBalance, users, 4, 0, loads)))
+}
+
+func TestAssignTeamReviewers(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 9}) // members: user15, user20, user29
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	require.NoError(t, issue.LoadRepo(t.Context()))
+
+	// user15 and user20 have pending review requests
+	team.ReviewAssignPolicy, team.ReviewAssignCount = organization.ReviewAssignPolicyLoadBalance, 1
+	notifiers, err := assignTeamReviewers(t.Context(), issue, doer, team, team.ReviewAssignCount, nil)
+	require.NoError(t, err)
+	require.Len(t, notifiers, 1)
+	assert.EqualValues(t, 29, notifiers[0].Reviewer.ID)
+	assert.EqualValues(t, 9, notifiers[0].Comment.CommentMetaData.ReviewTeamID)
+	assert.Equal(t, "review_team", notifiers[0].Comment.CommentMetaData.ReviewTeamName)
+
+	// user29 has been requested already and user20 is away
+	require.NoError(t, user_model.SetUserSetting(t.Context(), 20, user_model.SettingsKeyReviewAvailability, user_model.SettingReviewAvailabilityAway))
+	team.ReviewAssignPolicy, team.ReviewAssignCount = organization.ReviewAssignPolicyRoundRobin, 2
+	notifiers, err = assignTeamReviewers(t.Context(), issue, doer, team, team.ReviewAssignCount, nil)
+	require.NoError(t, err)
+	require.Len(t, notifiers, 1)
+	assert.EqualValues(t, 15, notifiers[0].Reviewer.ID)
+	unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 9, ReviewAssignLastUserID: 15})
+	require.NoError(t, user_model.DeleteUserSetting(t.Context(), 20, user_model.SettingsKeyReviewAvailability))
+
+	// a declined request is handed over to the next member
+	_, err = issues_model.AddTeamReviewRequest(t.Context(), issue, team, doer, false)
+	require.NoError(t, err)
+	_, err = db.GetEngine(t.Context()).ID(team.ID).Cols("review_assign_policy", "review_assign_count").Update(team)
+	require.NoError(t, err)
+	user15 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 15})
+	_, err = ReviewRequest(t.Context(), issue, user15, nil, user15, false)
+	require.NoError(t, err)
+
+	comment, err := issues_model.GetLatestReviewRequestComment(t.Context(), issue.ID, 20)
+	require.NoError(t, err)
+	require.NotNil(t, comment.CommentMetaData)
+	assert.EqualValues(t, 9, comment.CommentMetaData.ReviewTeamID)
+	assert.EqualValues(t, 15, comment.CommentMetaData.ReassignedFromUserID)
+	assert.Equal(t, "user15", comment.CommentMetaData.ReassignedFromUserName)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Review{IssueID: issue.ID, ReviewerID: 20, Type: issues_model.ReviewTypeRequest})
+}
@@ -10,6 +10,7 @@ import (
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	git_model "code.gitea.io/gitea/models/git"
+	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/json"
 	lfs_module "code.gitea.io/gitea/modules/lfs"
@@ -308,6 +309,10 @@ func UnLockHandler(ctx *context.Context) {
 		return
 	}
 
+	if req.Force && repository.EnforceLFSLocks && !canBreakLFSLock(ctx, repository, ctx.PathParamInt64("lid")) {
+		return
+	}
+
 	lock, err := git_model.DeleteLFSLockByID(ctx, ctx.PathParamInt64("lid"), repository, ctx.Doer, req.Force)
 	if err != nil {
 		log.Error("Unable to DeleteLFSLockByID[%d] by user %-v with force %t: Error: %v", ctx.PathParamInt64("lid"), ctx.Doer, req.Force, err)
@@ -318,3 +323,41 @@ func UnLockHandler(ctx *context.Context) {
 	}
 	ctx.JSON(http.StatusOK, api.LFSLockResponse{Lock: convert.ToLFSLock(ctx, lock)})
 }
+
+// canBreakLFSLock checks whether the doer could delete a lock owned by another user, only the administrators
+// of the repositories enforcing the locks could do it. It writes the response if the lock can't be deleted.
+func canBreakLFSLock(ctx *context.Context, repository *repo_model.Repository, lockID int64) bool {
+	lock, err := git_model.GetLFSLockByIDAndRepo(ctx, lockID, repository.ID)
+	if err != nil {
+		if git_model.IsErrLFSLockNotExist(err) {
+			ctx.JSON(http.StatusNotFound, api.LFSLockError{
+				Message: "lock not found",
+			})
+			return false
+		}
+		log.Error("Unable to GetLFSLockByIDAndRepo[%d]: Error: %v", lockID, err)
+		ctx.JSON(http.StatusInternalServerError, api.LFSLockError{
+			Message: "unable to delete lock : Internal Server Error",
+		})
+		return false
+	}
+	if lock.OwnerID == ctx.Doer.ID {
+		return true
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repository, ctx.Doer)
+	if err != nil {
+		log.Error("Unable to GetUserRepoPermission for %-v in %-v: Error: %v", ctx.Doer, repository, err)
+		ctx.JSON(http.StatusInternalServerError, api.LFSLockError{
+			Message: "unable to delete lock : Internal Server Error",
+		})
+		return false
+	}
+	if !perm.IsAdmin() {
+		ctx.JSON(http.StatusForbidden, api.LFSLockError{
+			Message: "only the repository administrators could break the locks of other users",
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,482 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package lfs
+
+import (
+	stdCtx "context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	lfs_module "code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+
+	"github.com/google/uuid"
+)
+
+// the object storages like S3 accept at most 10000 parts
+const maxUploadParts = 10000
+
+var uploadVerifyQueue *queue.WorkerPoolQueue[int64]
+
+// Init creates the queue which verifies the committed uploads
+func Init() error {
+	uploadVerifyQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "lfs_upload_verifier", func(ids ...int64) []int64 {
+		for _, id := range ids {
+			if err := verifyUpload(graceful.GetManager().ShutdownContext(), id); err != nil {
+				log.Error("Unable to verify LFS upload %d: %v", id, err)
+			}
+		}
+		return nil
+	})
+	if uploadVerifyQueue == nil {
+		return errors.New("unable to create lfs_upload_verifier queue")
+	}
+	go graceful.GetManager().RunWithCancel(uploadVerifyQueue)
+	return nil
+}
+
+// MultipartUploadLink builds a URL for the upload of an object in parts.
+func (rc *requestContext) MultipartUploadLink(upload *git_model.LFSUpload) string {
+	return rc.RepoGitURL + "/info/lfs/uploads/" + url.PathEscape(upload.UUID)
+}
+
+// directMultipartStorage returns the LFS storage if the clients could upload the parts to it directly
+func directMultipartStorage() (storage.MultipartStorage, bool) {
+	if !setting.LFS.Storage.ServeDirect() {
+		return nil, false
+	}
+	ms, ok := storage.LFS.(storage.MultipartStorage)
+	return ms, ok
+}
+
+// isMultipartTransfer returns whether the client of a batch request supports the "multipart" transfer adapter
+func isMultipartTransfer(br *lfs_module.BatchRequest) bool {
+	return slices.Contains(br.Transfers, "multipart")
+}
+
+// getOrCreateUpload returns the upload of an object in parts, an unfinished upload of the object is resumed
+func getOrCreateUpload(ctx *context.Context, repo *repo_model.Repository, p lfs_module.Pointer) (*git_model.LFSUpload, error) {
+	upload, err := git_model.GetLFSUpload(ctx, repo.ID, p.Oid)
+	if err == nil {
+		if upload.Size == p.Size {
+			return upload, nil
+		}
+		// the size of the object was wrong, the upload has to be started again
+		if err := deleteUpload(ctx, upload); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, util.ErrNotExist) {
+		return nil, err
+	}
+
+	upload = &git_model.LFSUpload{
+		UUID:     uuid.New().String(),
+		Pointer:  p,
+		RepoID:   repo.ID,
+		PartSize: max(setting.LFS.MultipartPartSize, (p.Size+maxUploadParts-1)/maxUploadParts),
+	}
+	if ctx.Doer != nil {
+		upload.CreatorID = ctx.Doer.ID
+	}
+	ms, direct := directMultipartStorage()
+	if direct && p.Size > 0 {
+		if upload.StorageUploadID, err = ms.NewMultipartUpload(upload.RelativePath()); err != nil {
+			return nil, fmt.Errorf("NewMultipartUpload: %w", err)
+		}
+	}
+	if err := git_model.CreateLFSUpload(ctx, upload); err != nil {
+		if errAbort := deleteUploadFromStorage(upload); errAbort != nil {
+			log.Error("Unable to abort the LFS upload %s: %v", upload.UUID, errAbort)
+		}
+		// the upload could have been created by a concurrent request
+		if existing, errGet := git_model.GetLFSUpload(ctx, repo.ID, p.Oid); errGet == nil && existing.Size == p.Size {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return upload, nil
+}
+
+// uploadedParts returns the sizes of the uploaded parts by their numbers
+func uploadedParts(upload *git_model.LFSUpload) (map[int]int64, error) {
+	parts := make(map[int]int64)
+	if upload.StorageUploadID != "" {
+		ms, ok := storage.LFS.(storage.MultipartStorage)
+		if !ok {
+			return nil, errors.New("the LFS storage doesn't support multipart uploads")
+		}
+		storageParts, err := ms.ListMultipartUploadParts(upload.RelativePath(), upload.StorageUploadID)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range storageParts {
+			parts[part.Number] = part.Size
+		}
+		return parts, nil
+	}
+
+	for number := 1; number <= upload.PartCount(); number++ {
+		fi, err := storage.LFS.Stat(upload.PartRelativePath(number))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		parts[number] = fi.Size()
+	}
+	return parts, nil
+}
+
+// addMultipartUpload replaces the upload action of an object response by the parts which haven't been uploaded yet
+func addMultipartUpload(ctx *context.Context, rc *requestContext, repo *repo_model.Repository, rep *lfs_module.ObjectResponse) error {
+	upload, err := getOrCreateUpload(ctx, repo, rep.Pointer)
+	if err != nil {
+		return err
+	}
+	multipart := &lfs_module.MultipartUpload{PartSize: upload.PartSize, Parts: []*lfs_module.PartLink{}}
+	if upload.IsVerifying {
+		// the upload has been committed, the client only waits for the verification by committing it again
+		rep.Multipart = multipart
+		delete(rep.Actions, "upload")
+		rep.Actions["commit"] = lfs_module.NewLink(rc.MultipartUploadLink(upload)+"/commit").
+			WithHeader("Authorization", rc.Authorization).
+			WithHeader("Accept", lfs_module.AcceptHeader)
+		return nil
+	}
+	uploaded, err := uploadedParts(upload)
+	if err != nil {
+		return err
+	}
+
+	// the presigned urls of the object storages are valid for at most 7 days
+	expires := min(setting.LFS.HTTPAuthExpiry, 7*24*time.Hour)
+	expiresAt := time.Now().Add(expires)
+	for number := 1; number <= upload.PartCount(); number++ {
+		pos, size := upload.PartRange(number)
+		if uploadedSize, ok := uploaded[number]; ok && uploadedSize == size {
+			continue
+		}
+		part := &lfs_module.PartLink{Number: number, Pos: pos, Size: size}
+		if upload.StorageUploadID != "" {
+			ms := storage.LFS.(storage.MultipartStorage)
+			u, err := ms.MultipartUploadPartURL(upload.RelativePath(), upload.StorageUploadID, number, expires)
+			if err != nil {
+				return err
+			}
+			part.Href = u.String() // Presigned url does not need the Authorization header
+			part.ExpiresAt = &expiresAt
+		} else {
+			part.Href = rc.MultipartUploadLink(upload) + "/" + strconv.Itoa(number)
+			part.Header = map[string]string{"Authorization": rc.Authorization}
+		}
+		multipart.Parts = append(multipart.Parts, part)
+	}
+
+	rep.Multipart = multipart
+	delete(rep.Actions, "upload")
+	rep.Actions["commit"] = lfs_module.NewLink(rc.MultipartUploadLink(upload)+"/commit").
+		WithHeader("Authorization", rc.Authorization).
+		WithHeader("Accept", lfs_module.AcceptHeader)
+	rep.Actions["abort"] = lfs_module.NewLink(rc.MultipartUploadLink(upload)).
+		WithHeader("Authorization", rc.Authorization)
+	return nil
+}
+
+// getAuthenticatedUpload returns the upload of the request if the doer could write to the repository
+func getAuthenticatedUpload(ctx *context.Context) *git_model.LFSUpload {
+	rc := getRequestContext(ctx)
+	repository := getAuthenticatedRepository(ctx, rc, true)
+	if repository == nil {
+		return nil
+	}
+
+	upload, err := git_model.GetLFSUploadByUUID(ctx, repository.ID, ctx.PathParam("uuid"))
+	if errors.Is(err, util.ErrNotExist) {
+		writeStatus(ctx, http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		log.Error("Unable to get LFS upload %s: %v", ctx.PathParam("uuid"), err)
+		writeStatus(ctx, http.StatusInternalServerError)
+		return nil
+	}
+	return upload
+}
+
+// UploadPartHandler receives a part of an object uploaded through Gitea
+func UploadPartHandler(ctx *context.Context) {
+	upload := getAuthenticatedUpload(ctx)
+	if upload == nil {
+		return
+	}
+	defer ctx.Req.Body.Close()
+
+	number, err := strconv.Atoi(ctx.PathParam("part"))
+	if err != nil || number < 1 || number > upload.PartCount() {
+		writeStatusMessage(ctx, http.StatusUnprocessableEntity, "Invalid part number")
+		return
+	}
+	if upload.StorageUploadID != "" {
+		writeStatusMessage(ctx, http.StatusUnprocessableEntity, "The parts must be uploaded to the object storage")
+		return
+	}
+	if upload.IsVerifying {
+		writeStatusMessage(ctx, http.StatusUnprocessableEntity, "The upload has been committed")
+		return
+	}
+
+	_, size := upload.PartRange(number)
+	written, err := storage.LFS.Save(upload.PartRelativePath(number), io.LimitReader(ctx.Req.Body, size), size)
+	if err == nil && written != size {
+		err = lfs_module.ErrSizeMismatch
+	}
+	if err != nil {
+		if errDel := storage.LFS.Delete(upload.PartRelativePath(number)); errDel != nil && !errors.Is(errDel, os.ErrNotExist) {
+			log.Error("Unable to delete part %d of LFS upload %s: %v", number, upload.UUID, errDel)
+		}
+		if errors.Is(err, lfs_module.ErrSizeMismatch) {
+			writeStatusMessage(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		log.Error("Unable to save part %d of LFS upload %s: %v", number, upload.UUID, err)
+		writeStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+	writeStatus(ctx, http.StatusOK)
+}
+
+// CommitUploadHandler checks that all the parts have been uploaded and queues the verification of the object.
+// It answers 202 until the object has been verified, the client commits the upload again to wait for it.
+func CommitUploadHandler(ctx *context.Context) {
+	var p lfs_module.Pointer
+	if err := decodeJSON(ctx.Req, &p); err != nil {
+		writeStatus(ctx, http.StatusUnprocessableEntity)
+		return
+	}
+	rc := getRequestContext(ctx)
+	repository := getAuthenticatedRepository(ctx, rc, true)
+	if repository == nil {
+		return
+	}
+
+	upload, err := git_model.GetLFSUploadByUUID(ctx, repository.ID, ctx.PathParam("uuid"))
+	if errors.Is(err, util.ErrNotExist) {
+		// the upload is deleted once its object has been verified, or if the object didn't match
+		if _, err := git_model.GetLFSMetaObjectByOid(ctx, repository.ID, p.Oid); err == nil {
+			writeStatus(ctx, http.StatusOK)
+		} else if errors.Is(err, git_model.ErrLFSObjectNotExist) {
+			writeStatus(ctx, http.StatusNotFound)
+		} else {
+			log.Error("Unable to get LFS meta object %s: %v", p.Oid, err)
+			writeStatus(ctx, http.StatusInternalServerError)
+		}
+		return
+	} else if err != nil {
+		log.Error("Unable to get LFS upload %s: %v", ctx.PathParam("uuid"), err)
+		writeStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+	if p != upload.Pointer {
+		writeStatusMessage(ctx, http.StatusUnprocessableEntity, "The object doesn't match the upload")
+		return
+	}
+
+	if err := commitUpload(ctx, upload); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			writeStatusMessage(ctx, http.StatusUnprocessableEntity, err.Error())
+		} else {
+			log.Error("Unable to commit LFS upload %s: %v", upload.UUID, err)
+			writeStatus(ctx, http.StatusInternalServerError)
+		}
+		return
+	}
+	writeStatusMessage(ctx, http.StatusAccepted, "The upload is being verified")
+}
+
+// AbortUploadHandler deletes an unfinished upload
+func AbortUploadHandler(ctx *context.Context) {
+	upload := getAuthenticatedUpload(ctx)
+	if upload == nil {
+		return
+	}
+	if upload.IsVerifying {
+		writeStatusMessage(ctx, http.StatusUnprocessableEntity, "The upload has been committed")
+		return
+	}
+	if err := deleteUpload(ctx, upload); err != nil {
+		log.Error("Unable to abort LFS upload %s: %v", upload.UUID, err)
+		writeStatus(ctx, http.StatusInternalServerError)
+		return
+	}
+	writeStatus(ctx, http.StatusOK)
+}
+
+// commitUpload queues the verification of the uploaded object once all its parts have been uploaded.
+// The object is concatenated and its hash is checked in the background, reading it could take a long time.
+func commitUpload(ctx stdCtx.Context, upload *git_model.LFSUpload) error {
+	if upload.IsVerifying {
+		return nil
+	}
+	uploaded, err := uploadedParts(upload)
+	if err != nil {
+		return err
+	}
+	for number := 1; number <= upload.PartCount(); number++ {
+		if _, size := upload.PartRange(number); uploaded[number] != size {
+			return util.NewInvalidArgumentErrorf("part %d has not been uploaded", number)
+		}
+	}
+
+	if marked, err := git_model.SetLFSUploadVerifying(ctx, upload, true); err != nil || !marked {
+		return err // the upload could have been committed by a concurrent request
+	}
+	return uploadVerifyQueue.Push(upload.ID)
+}
+
+// verifyUpload stores the object of a committed upload if its hash matches
+func verifyUpload(ctx stdCtx.Context, id int64) error {
+	upload, err := git_model.GetLFSUploadByID(ctx, id)
+	if errors.Is(err, util.ErrNotExist) {
+		return nil // the upload has expired
+	} else if err != nil {
+		return err
+	}
+
+	direct := upload.StorageUploadID != ""
+	err = storeUploadedObject(upload)
+	// the parts are kept for another try unless they are wrong or they have been concatenated by the object storage
+	if err == nil || errors.Is(err, lfs_module.ErrSizeMismatch) || errors.Is(err, lfs_module.ErrHashMismatch) || (direct && upload.StorageUploadID == "") {
+		if errDel := deleteUpload(ctx, upload); errDel != nil {
+			log.Error("Unable to delete LFS upload %s: %v", upload.UUID, errDel)
+		}
+	} else if _, errReset := git_model.SetLFSUploadVerifying(ctx, upload, false); errReset != nil {
+		log.Error("Unable to reset LFS upload %s: %v", upload.UUID, errReset)
+	}
+	if errors.Is(err, lfs_module.ErrSizeMismatch) || errors.Is(err, lfs_module.ErrHashMismatch) {
+		log.Warn("Upload does not match LFS object [%s]: %v", upload.Oid, err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	_, err = git_model.NewLFSMetaObject(ctx, upload.RepoID, upload.Pointer)
+	return err
+}
+
+// storeUploadedObject checks the hash of the uploaded object and moves it to the path of the object
+func storeUploadedObject(upload *git_model.LFSUpload) error {
+	contentStore := lfs_module.NewContentStore()
+	exists, err := contentStore.Exists(upload.Pointer)
+	if err != nil {
+		return err
+	}
+
+	if upload.StorageUploadID != "" {
+		ms := storage.LFS.(storage.MultipartStorage)
+		parts, err := ms.ListMultipartUploadParts(upload.RelativePath(), upload.StorageUploadID)
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(parts, func(a, b storage.MultipartUploadPart) int { return a.Number - b.Number })
+		if err := ms.CompleteMultipartUpload(upload.RelativePath(), upload.StorageUploadID, parts); err != nil {
+			return err
+		}
+		upload.StorageUploadID = "" // the object has been completed, there is nothing to abort
+
+		obj, err := ms.Open(upload.RelativePath())
+		if err != nil {
+			return err
+		}
+		err = lfs_module.CheckContent(upload.Pointer, obj)
+		obj.Close()
+		if err != nil || exists {
+			return err
+		}
+		return ms.CopyObject(upload.Pointer.RelativePath(), upload.RelativePath())
+	}
+
+	readers := make([]io.Reader, 0, upload.PartCount())
+	for number := 1; number <= upload.PartCount(); number++ {
+		obj, err := storage.LFS.Open(upload.PartRelativePath(number))
+		if err != nil {
+			return err
+		}
+		defer obj.Close()
+		readers = append(readers, obj)
+	}
+	content := io.MultiReader(readers...)
+	if exists {
+		// the object exists but the doer might have no access to it, the upload proves the access
+		return lfs_module.CheckContent(upload.Pointer, content)
+	}
+	return contentStore.Put(upload.Pointer, content)
+}
+
+// deleteUploadFromStorage deletes the uploaded parts and the unfinished object
+func deleteUploadFromStorage(upload *git_model.LFSUpload) error {
+	if upload.StorageUploadID != "" {
+		ms, ok := storage.LFS.(storage.MultipartStorage)
+		if !ok {
+			return errors.New("the LFS storage doesn't support multipart uploads")
+		}
+		if err := ms.AbortMultipartUpload(upload.RelativePath(), upload.StorageUploadID); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else {
+		for number := 1; number <= upload.PartCount(); number++ {
+			if err := storage.LFS.Delete(upload.PartRelativePath(number)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	if err := storage.LFS.Delete(upload.RelativePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func deleteUpload(ctx stdCtx.Context, upload *git_model.LFSUpload) error {
+	if err := deleteUploadFromStorage(upload); err != nil {
+		return err
+	}
+	return git_model.DeleteLFSUpload(ctx, upload.ID)
+}
+
+// DeleteExpiredUploads deletes the unfinished uploads older than the expiry
+func DeleteExpiredUploads(ctx stdCtx.Context, expiry time.Duration) error {
+	before := timeutil.TimeStamp(time.Now().Add(-expiry).Unix())
+	for {
+		uploads, err := git_model.FindLFSUploadsCreatedBefore(ctx, before, 100)
+		if err != nil {
+			return err
+		}
+		for _, upload := range uploads {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err := deleteUpload(ctx, upload); err != nil {
+				return fmt.Errorf("delete LFS upload %s: %w", upload.UUID, err)
+			}
+		}
+		if len(uploads) < 100 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package lfs
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+	lfs_module "code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteExpiredUploads(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	newUpload := func(uuid string, created timeutil.TimeStamp) *git_model.LFSUpload {
+		upload := &git_model.LFSUpload{
+			UUID:     uuid,
+			Pointer:  lfs_module.Pointer{Oid: strings.Repeat(uuid[:1], 64), Size: 3},
+			RepoID:   1,
+			PartSize: 5 << 20,
+		}
+		require.NoError(t, git_model.CreateLFSUpload(ctx, upload))
+		_, err := db.GetEngine(ctx).Exec("UPDATE lfs_upload SET created_unix=? WHERE id=?", created, upload.ID)
+		require.NoError(t, err)
+		_, err = storage.LFS.Save(upload.PartRelativePath(1), strings.NewReader("abc"), 3)
+		require.NoError(t, err)
+		return upload
+	}
+	expired := newUpload("aaaaaaaa-0000-0000-0000-000000000000", timeutil.TimeStamp(time.Now().Add(-2*time.Hour).Unix()))
+	recent := newUpload("bbbbbbbb-0000-0000-0000-000000000000", timeutil.TimeStampNow())
+
+	require.NoError(t, DeleteExpiredUploads(ctx, time.Hour))
+
+	unittest.AssertNotExistsBean(t, &git_model.LFSUpload{ID: expired.ID})
+	_, err := storage.LFS.Stat(expired.PartRelativePath(1))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	unittest.AssertExistsAndLoadBean(t, &git_model.LFSUpload{ID: recent.ID})
+	parts, err := uploadedParts(recent)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int64{1: 3}, parts)
+	require.NoError(t, deleteUpload(ctx, recent))
+}
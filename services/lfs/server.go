@@ -218,6 +218,8 @@ func BatchHandler(ctx *context.Context) {
 	}
 
 	contentStore := lfs_module.NewContentStore()
+	// the "multipart" transfer uploads the objects in parts and downloads them with the same links as the "basic" transfer
+	multipart := isMultipartTransfer(&br)
 
 	var responseObjects []*lfs_module.ObjectResponse
 
@@ -282,6 +284,13 @@ func BatchHandler(ctx *context.Context) {
 			}
 
 			responseObject = buildObjectResponse(rc, p, false, !exists, err)
+			if multipart && !exists && err == nil {
+				if err := addMultipartUpload(ctx, rc, repository, responseObject); err != nil {
+					log.Error("Unable to prepare the multipart upload of LFS OID[%s] for %s/%s. Error: %v", p.Oid, rc.User, rc.Repo, err)
+					writeStatus(ctx, http.StatusInternalServerError)
+					return
+				}
+			}
 		} else {
 			var err *lfs_module.ObjectError
 			if !exists || meta == nil {
@@ -297,6 +306,9 @@ func BatchHandler(ctx *context.Context) {
 	}
 
 	respobj := &lfs_module.BatchResponse{Objects: responseObjects}
+	if multipart {
+		respobj.Transfer = "multipart"
+	}
 
 	ctx.Resp.Header().Set("Content-Type", lfs_module.MediaType)
 
@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
+	sender_service "code.gitea.io/gitea/services/mailer/sender"
+)
+
+const tplSavedSearchMail templates.TplName = "user/saved_search"
+
+// MailSavedSearchMatches sends the issues which newly match a saved search to a subscriber,
+// the repositories of the issues and the organization of the saved search must be loaded
+func MailSavedSearchMatches(ctx context.Context, u *user_model.User, search *issues_model.SavedSearch, issues issues_model.IssueList, isDigest bool) error {
+	if setting.MailService == nil || !u.IsMailable() {
+		return nil
+	}
+
+	locale := translation.NewLocale(u.Language)
+	var subject string
+	if isDigest {
+		subject = locale.TrString("mail.saved_search.digest_subject", search.Name)
+	} else {
+		subject = locale.TrString("mail.saved_search.subject", len(issues), search.Name)
+	}
+
+	type mailItem struct {
+		Issue *issues_model.Issue
+		Link  string
+	}
+	items := make([]mailItem, 0, len(issues))
+	for _, issue := range issues {
+		items = append(items, mailItem{Issue: issue, Link: issue.HTMLURL(ctx)})
+	}
+
+	mailMeta := map[string]any{
+		"locale":      locale,
+		"Subject":     subject,
+		"SavedSearch": search,
+		"Items":       items,
+		"IsDigest":    isDigest,
+		"Link":        search.HTMLURL(),
+	}
+
+	var mailBody bytes.Buffer
+	if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&mailBody, string(tplSavedSearchMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplSavedSearchMail)+"/body", err)
+		return err
+	}
+
+	msg := sender_service.NewMessage(u.EmailTo(), subject, mailBody.String())
+	msg.Info = subject
+
+	SendAsync(msg)
+
+	return nil
+}
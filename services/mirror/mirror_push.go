@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
@@ -29,6 +30,38 @@ import (
 
 var stripExitStatus = regexp.MustCompile(`exit status \d+ - `)
 
+// notesRefspec pushes the git-notes refs to the push mirrors
+const notesRefspec = "+refs/notes/*:refs/notes/*"
+
+// ensureNotesRefspec adds the notes refspec to the push mirror remotes which were added without it
+func ensureNotesRefspec(ctx context.Context, storageRepo gitrepo.Repository, remoteName string) error {
+	refspecs, err := gitrepo.GitConfigGetAll(ctx, storageRepo, "remote."+remoteName+".push")
+	if err != nil {
+		return err
+	}
+	if slices.Contains(refspecs, notesRefspec) {
+		return nil
+	}
+	return gitrepo.GitConfigAdd(ctx, storageRepo, "remote."+remoteName+".push", notesRefspec)
+}
+
+// UpdatePushMirrorRemote brings the remote config of an edited push mirror up to date
+func UpdatePushMirrorRemote(ctx context.Context, m *repo_model.PushMirror) error {
+	_ = m.GetRepository(ctx)
+	if err := ensureNotesRefspec(ctx, m.Repo, m.RemoteName); err != nil {
+		return err
+	}
+
+	if repo_service.HasWiki(ctx, m.Repo) {
+		if err := ensureNotesRefspec(ctx, m.Repo.WikiStorageRepo(), m.RemoteName); err != nil {
+			// The wiki remote may not exist
+			log.Warn("Wiki Remote[%d] could not be updated: %v", m.ID, err)
+		}
+	}
+
+	return nil
+}
+
 // AddPushMirrorRemote registers the push mirror remote.
 func AddPushMirrorRemote(ctx context.Context, m *repo_model.PushMirror, addr string) error {
 	addRemoteAndConfig := func(storageRepo gitrepo.Repository, addr string) error {
@@ -38,7 +71,10 @@ func AddPushMirrorRemote(ctx context.Context, m *repo_model.PushMirror, addr str
 		if err := gitrepo.GitConfigAdd(ctx, storageRepo, "remote."+m.RemoteName+".push", "+refs/heads/*:refs/heads/*"); err != nil {
 			return err
 		}
-		return gitrepo.GitConfigAdd(ctx, storageRepo, "remote."+m.RemoteName+".push", "+refs/tags/*:refs/tags/*")
+		if err := gitrepo.GitConfigAdd(ctx, storageRepo, "remote."+m.RemoteName+".push", "+refs/tags/*:refs/tags/*"); err != nil {
+			return err
+		}
+		return gitrepo.GitConfigAdd(ctx, storageRepo, "remote."+m.RemoteName+".push", notesRefspec)
 	}
 
 	if err := addRemoteAndConfig(m.Repo, addr); err != nil {
@@ -253,7 +289,8 @@ func pushAllLFSObjects(ctx context.Context, gitRepo *git.Repository, lfsClient l
 	return err
 }
 
-func syncPushMirrorWithSyncOnCommit(ctx context.Context, repoID int64) {
+// SyncPushMirrorWithSyncOnCommit queues the push mirrors of the repository which are synced on commit
+func SyncPushMirrorWithSyncOnCommit(ctx context.Context, repoID int64) {
 	pushMirrors, err := repo_model.GetPushMirrorsSyncedOnCommit(ctx, repoID)
 	if err != nil {
 		log.Error("repo_model.GetPushMirrorsSyncedOnCommit failed: %v", err)
@@ -23,9 +23,9 @@ type mirrorNotifier struct {
 var _ notify_service.Notifier = &mirrorNotifier{}
 
 func (m *mirrorNotifier) PushCommits(ctx context.Context, _ *user_model.User, repo *repo_model.Repository, _ *repository.PushUpdateOptions, _ *repository.PushCommits) {
-	syncPushMirrorWithSyncOnCommit(ctx, repo.ID)
+	SyncPushMirrorWithSyncOnCommit(ctx, repo.ID)
 }
 
 func (m *mirrorNotifier) SyncPushCommits(ctx context.Context, _ *user_model.User, repo *repo_model.Repository, _ *repository.PushUpdateOptions, _ *repository.PushCommits) {
-	syncPushMirrorWithSyncOnCommit(ctx, repo.ID)
+	SyncPushMirrorWithSyncOnCommit(ctx, repo.ID)
 }
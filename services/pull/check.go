@@ -24,6 +24,7 @@ import (
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/globallock"
 	"code.gitea.io/gitea/modules/graceful"
+	issue_template "code.gitea.io/gitea/modules/issue/template"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
@@ -46,6 +47,8 @@ var (
 	ErrIsChecking          = errors.New("cannot merge while conflict checking is in progress")
 	ErrNotMergeableState   = errors.New("not in mergeable state")
 	ErrDependenciesLeft    = errors.New("is blocked by an open dependency")
+
+	ErrRequiredChecklistUnchecked = errors.New("required checklist items are not checked")
 )
 
 func markPullRequestStatusAsChecking(ctx context.Context, pr *issues_model.PullRequest) bool {
@@ -161,6 +164,10 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			return ErrIsWorkInProgress
 		}
 
+		if len(issue_template.UncheckedChecklistItems(pr.Issue.Content, pr.RequiredChecklist)) > 0 {
+			return ErrRequiredChecklistUnchecked
+		}
+
 		if !pr.CanAutoMerge() && !pr.IsEmpty() {
 			return ErrNotMergeableState
 		}
@@ -61,7 +61,7 @@ func CreatePushPullComment(ctx context.Context, pusher *user_model.User, pr *iss
 		Issue: pr.Issue,
 	}
 
-	var data issues_model.PushActionContent
+	data := issues_model.PushActionContent{OldCommitID: oldCommitID, NewCommitID: newCommitID}
 	data.CommitIDs, err = getCommitIDsFromRepo(ctx, pr.BaseRepo, oldCommitID, newCommitID, pr.BaseBranch)
 	if err != nil {
 		// For force-push events, a missing/unreachable old commit should not prevent
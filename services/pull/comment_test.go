@@ -11,6 +11,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/json"
+	issue_service "code.gitea.io/gitea/services/issue"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -171,3 +172,39 @@ func TestCreatePushPullCommentForcePushDeletesOldComments(t *testing.T) {
 		assert.Equal(t, 1, forcePushCount)
 	})
 }
+
+func TestCreatePushPullCommentStoresHeads(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	assert.NoError(t, pr.LoadIssue(t.Context()))
+	assert.NoError(t, pr.LoadBaseRepo(t.Context()))
+
+	pusher := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	gitRepo, err := gitrepo.OpenRepository(t.Context(), pr.BaseRepo)
+	assert.NoError(t, err)
+	defer gitRepo.Close()
+
+	headCommit, err := gitRepo.GetBranchCommit(pr.HeadBranch)
+	assert.NoError(t, err)
+	baseCommit, err := gitRepo.GetBranchCommit(pr.BaseBranch)
+	assert.NoError(t, err)
+
+	comment, err := CreatePushPullComment(t.Context(), pusher, pr, baseCommit.ID.String(), headCommit.ID.String(), false)
+	assert.NoError(t, err)
+	assert.NotNil(t, comment)
+
+	var data issues_model.PushActionContent
+	assert.NoError(t, json.Unmarshal([]byte(comment.Content), &data))
+	assert.False(t, data.IsForcePush)
+	assert.Equal(t, baseCommit.ID.String(), data.OldCommitID)
+	assert.Equal(t, headCommit.ID.String(), data.NewCommitID)
+
+	// the range is taken from the stored heads, not from the parent of the oldest pushed commit
+	comment.Issue = pr.Issue
+	assert.NoError(t, issue_service.LoadCommentPushCommits(t.Context(), comment))
+	assert.Equal(t, baseCommit.ID.String(), comment.OldCommit)
+	assert.Equal(t, headCommit.ID.String(), comment.NewCommit)
+	assert.NotEmpty(t, comment.Commits)
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ConflictFile represents a file which can't be merged automatically when the base branch is merged into the head branch
+type ConflictFile struct {
+	Path   string
+	Base   string // content in the merge base, empty if the file was added on both sides
+	Ours   string // content in the head branch of the pull request
+	Theirs string // content in the base branch of the pull request
+	Merged string // content with diff3 style conflict markers
+	// Hunks are the parts of the merged content, the conflicts can be resolved one by one if it's not empty
+	Hunks []*ConflictHunk
+
+	// IsResolvable is false for binary files, too large files and files deleted on one side,
+	// these conflicts can't be resolved with the web editor.
+	IsResolvable bool
+}
+
+// ErrConflictNotResolved represents an error if a conflicted file has no resolution or still contains conflict markers
+type ErrConflictNotResolved struct {
+	Path string
+}
+
+// IsErrConflictNotResolved checks if an error is a ErrConflictNotResolved.
+func IsErrConflictNotResolved(err error) bool {
+	_, ok := err.(ErrConflictNotResolved)
+	return ok
+}
+
+func (err ErrConflictNotResolved) Error() string {
+	return fmt.Sprintf("conflict is not resolved [path: %s]", err.Path)
+}
+
+func (err ErrConflictNotResolved) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// createTemporaryRepoForConflicts merges the pr.BaseBranch into the pr.HeadBranch in a temporary repository without committing,
+// the current HEAD of the temporary repository is the head branch and the returned list contains the conflicted files
+func createTemporaryRepoForConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) (mergeCtx *mergeContext, cancel context.CancelFunc, conflictedPaths []string, err error) {
+	if pr.Flow == issues_model.PullRequestFlowAGit {
+		// TODO: update of agit flow pull request's head branch is unsupported
+		return nil, nil, nil, errors.New("update of agit flow pull request's head branch is unsupported")
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+
+	mergeCtx, cancel, err = createTemporaryRepoForMerge(ctx, newReversePullRequest(pr), doer, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cmd := gitcmd.NewCommand("merge", "--no-ff", "--no-commit").
+		AddConfig("merge.conflictStyle", "diff3").
+		AddDynamicArguments(tmpRepoTrackingBranch)
+	if err := runMergeCommand(mergeCtx, repo_model.MergeStyleMerge, cmd); err != nil {
+		if !IsErrMergeConflicts(err) {
+			cancel()
+			return nil, nil, nil, err
+		}
+	}
+
+	conflictedPaths, err = getUnmergedPaths(mergeCtx)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	return mergeCtx, cancel, conflictedPaths, nil
+}
+
+func getUnmergedPaths(ctx *mergeContext) ([]string, error) {
+	stdout, _, err := gitcmd.NewCommand("diff", "--name-only", "--diff-filter=U", "-z").
+		WithDir(ctx.tmpBasePath).RunStdString(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list unmerged files for %v: %w", ctx.pr, err)
+	}
+	var paths []string
+	for p := range strings.SplitSeq(stdout, "\x00") {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// readUnmergedStages returns the blob IDs of the stages (1: base, 2: ours, 3: theirs) of an unmerged file
+func readUnmergedStages(ctx *mergeContext, path string) (map[string]string, error) {
+	stdout, _, err := gitcmd.NewCommand("ls-files", "-u", "-z").AddDashesAndList(path).
+		WithDir(ctx.tmpBasePath).RunStdString(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The output is of the form: <mode> SP <object> SP <stage> TAB <file>
+	stages := make(map[string]string, 3)
+	for line := range strings.SplitSeq(stdout, "\x00") {
+		info, _, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) == 3 {
+			stages[fields[2]] = fields[1]
+		}
+	}
+	return stages, nil
+}
+
+func readBlobForConflict(ctx *mergeContext, blobID string) (content string, ok bool, err error) {
+	size, _, err := gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(blobID).
+		WithDir(ctx.tmpBasePath).RunStdString(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if n, _ := util.ToInt64(strings.TrimSpace(size)); n > setting.UI.MaxDisplayFileSize {
+		return "", false, nil
+	}
+	stdout, _, err := gitcmd.NewCommand("cat-file", "blob").AddDynamicArguments(blobID).
+		WithDir(ctx.tmpBasePath).RunStdBytes(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if bytes.IndexByte(stdout, 0) != -1 {
+		return "", false, nil
+	}
+	return string(stdout), true, nil
+}
+
+// GetConflictFiles returns the files which conflict when the base branch of the pull request is merged into its head branch
+func GetConflictFiles(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) ([]*ConflictFile, error) {
+	mergeCtx, cancel, conflictedPaths, err := createTemporaryRepoForConflicts(ctx, pr, doer)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	files := make([]*ConflictFile, 0, len(conflictedPaths))
+	for _, path := range conflictedPaths {
+		file := &ConflictFile{Path: path}
+		files = append(files, file)
+
+		stages, err := readUnmergedStages(mergeCtx, path)
+		if err != nil {
+			return nil, err
+		}
+		if stages["2"] == "" || stages["3"] == "" {
+			continue // deleted on one side
+		}
+
+		file.IsResolvable = true
+		for stage, content := range map[string]*string{"1": &file.Base, "2": &file.Ours, "3": &file.Theirs} {
+			if stages[stage] == "" {
+				continue
+			}
+			var ok bool
+			if *content, ok, err = readBlobForConflict(mergeCtx, stages[stage]); err != nil {
+				return nil, err
+			}
+			file.IsResolvable = file.IsResolvable && ok
+		}
+		if !file.IsResolvable {
+			file.Base, file.Ours, file.Theirs = "", "", ""
+			continue
+		}
+
+		merged, err := os.ReadFile(filepath.Join(mergeCtx.tmpBasePath, path))
+		if err != nil {
+			log.Error("Unable to read conflicted file %q of %-v: %v", path, pr, err)
+			file.IsResolvable = false
+			continue
+		}
+		file.Merged = string(merged)
+		file.Hunks, _ = ParseConflictHunks(file.Merged)
+	}
+	return files, nil
+}
+
+// hasConflictMarkers checks if the content still contains lines of conflict markers.
+// A "=======" line is only a marker between the "<<<<<<< " and ">>>>>>> " lines,
+// otherwise it's a valid content, e.g. the underline of a Markdown heading.
+func hasConflictMarkers(content string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	inConflict := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "<<<<<<< "):
+			inConflict = true
+		case strings.HasPrefix(line, "||||||| "):
+			return true
+		case strings.HasPrefix(line, ">>>>>>> "):
+			return true
+		case line == "=======" && inConflict:
+			return true
+		}
+	}
+	return inConflict
+}
+
+// ResolveConflicts merges the base branch of the pull request into its head branch,
+// the conflicted files are replaced by the given resolutions (path -> resolution) and the merge commit is pushed to the head branch.
+func ResolveConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID string, resolutions map[string]*ConflictResolution, message string) error {
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	mergeCtx, cancel, conflictedPaths, err := createTemporaryRepoForConflicts(ctx, pr, doer)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if expectedHeadCommitID != "" {
+		headCommitID, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, "HEAD")
+		if err != nil {
+			return fmt.Errorf("unable to get sha of head branch in pr[%d]: %w", pr.ID, err)
+		}
+		if headCommitID != expectedHeadCommitID {
+			return ErrSHADoesNotMatch{
+				GivenSHA:   expectedHeadCommitID,
+				CurrentSHA: headCommitID,
+			}
+		}
+	}
+
+	for _, path := range conflictedPaths {
+		resolution, ok := resolutions[path]
+		if !ok {
+			return ErrConflictNotResolved{Path: path}
+		}
+		fullPath := filepath.Join(mergeCtx.tmpBasePath, path)
+		merged, err := os.ReadFile(fullPath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to read conflicted file %q for %v: %w", path, pr, err)
+		}
+
+		var content string
+		if len(resolution.Choices) > 0 {
+			hunks, ok := ParseConflictHunks(string(merged))
+			if !ok {
+				return ErrConflictNotResolved{Path: path}
+			}
+			if content, ok = applyConflictChoices(hunks, resolution.Choices); !ok {
+				return ErrConflictNotResolved{Path: path}
+			}
+		} else {
+			content = restoreLineEndings(resolution.Content, string(merged))
+			if hasConflictMarkers(content) {
+				return ErrConflictNotResolved{Path: path}
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(fullPath), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("unable to write resolution of %q for %v: %w", path, pr, err)
+		}
+		if err := mergeCtx.PrepareGitCmd(gitcmd.NewCommand("add").AddDashesAndList(path)).RunWithStderr(ctx); err != nil {
+			return fmt.Errorf("git add %q for %v: %w\n%s", path, pr, err, err.Stderr())
+		}
+	}
+
+	if err := commitAndSignNoAuthor(mergeCtx, message); err != nil {
+		log.Error("%-v Unable to commit conflict resolution: %v", pr, err)
+		return err
+	}
+
+	defer func() {
+		go AddTestPullRequestTask(TestPullRequestOptions{
+			RepoID: pr.BaseRepo.ID,
+			Doer:   doer,
+			Branch: pr.BaseBranch,
+		})
+	}()
+
+	_, err = pushMergeResult(ctx, mergeCtx, newReversePullRequest(pr), doer, repository.PushTriggerPRUpdateWithBase)
+	return err
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"strings"
+)
+
+// ConflictChoice is the version chosen to resolve a conflict hunk
+type ConflictChoice string
+
+const (
+	ConflictChoiceOurs   ConflictChoice = "ours"   // the version of the head branch
+	ConflictChoiceBase   ConflictChoice = "base"   // the version of the merge base
+	ConflictChoiceTheirs ConflictChoice = "theirs" // the version of the base branch
+	ConflictChoiceBoth   ConflictChoice = "both"   // the version of the head branch followed by the one of the base branch
+)
+
+// ConflictHunk is a part of a conflicted file, it's either a text merged cleanly or a conflict between the versions of the text
+type ConflictHunk struct {
+	Text string // the cleanly merged text, it's empty for a conflict
+
+	IsConflict bool
+	Index      int // the index of the conflict in the file
+	Ours       string
+	Base       string
+	Theirs     string
+}
+
+// ConflictResolution is the resolution of a conflicted file, either the chosen versions of its conflict hunks or its whole content
+type ConflictResolution struct {
+	Choices []ConflictChoice // the chosen version of each conflict hunk, the content is used if it's empty
+	Content string
+}
+
+const (
+	conflictMarkerOurs   = "<<<<<<<"
+	conflictMarkerBase   = "|||||||"
+	conflictMarkerSep    = "======="
+	conflictMarkerTheirs = ">>>>>>>"
+)
+
+// isConflictMarker checks if a line (with its line ending) is the conflict marker,
+// the markers other than the separator are followed by a label
+func isConflictMarker(line, marker string) bool {
+	line = strings.TrimRight(line, "\r\n")
+	if marker == conflictMarkerSep {
+		return line == marker
+	}
+	return line == marker || strings.HasPrefix(line, marker+" ")
+}
+
+// ParseConflictHunks splits the content of a file merged with diff3 style conflict markers into hunks,
+// it returns false if the markers are unbalanced, e.g. if the versions of the file contain conflict markers themselves.
+func ParseConflictHunks(merged string) ([]*ConflictHunk, bool) {
+	const (
+		stateClean = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+
+	var hunks []*ConflictHunk
+	var text strings.Builder
+	var conflict *ConflictHunk
+	var ours, base, theirs strings.Builder
+	state := stateClean
+	numConflicts := 0
+	for line := range strings.SplitAfterSeq(merged, "\n") {
+		switch state {
+		case stateClean:
+			if isConflictMarker(line, conflictMarkerOurs) {
+				if text.Len() > 0 {
+					hunks = append(hunks, &ConflictHunk{Text: text.String()})
+					text.Reset()
+				}
+				conflict = &ConflictHunk{IsConflict: true, Index: numConflicts}
+				numConflicts++
+				state = stateOurs
+			} else if isConflictMarker(line, conflictMarkerBase) || isConflictMarker(line, conflictMarkerTheirs) {
+				return nil, false
+			} else {
+				text.WriteString(line)
+			}
+		case stateOurs, stateBase:
+			switch {
+			case isConflictMarker(line, conflictMarkerBase) && state == stateOurs:
+				state = stateBase
+			case isConflictMarker(line, conflictMarkerSep):
+				state = stateTheirs
+			case isConflictMarker(line, conflictMarkerOurs), isConflictMarker(line, conflictMarkerBase), isConflictMarker(line, conflictMarkerTheirs):
+				return nil, false
+			case state == stateOurs:
+				ours.WriteString(line)
+			default:
+				base.WriteString(line)
+			}
+		case stateTheirs:
+			switch {
+			case isConflictMarker(line, conflictMarkerTheirs):
+				conflict.Ours, conflict.Base, conflict.Theirs = ours.String(), base.String(), theirs.String()
+				ours.Reset()
+				base.Reset()
+				theirs.Reset()
+				hunks = append(hunks, conflict)
+				state = stateClean
+			case isConflictMarker(line, conflictMarkerOurs), isConflictMarker(line, conflictMarkerBase), isConflictMarker(line, conflictMarkerSep):
+				return nil, false
+			default:
+				theirs.WriteString(line)
+			}
+		}
+	}
+	if state != stateClean || numConflicts == 0 {
+		return nil, false
+	}
+	if text.Len() > 0 {
+		hunks = append(hunks, &ConflictHunk{Text: text.String()})
+	}
+	return hunks, true
+}
+
+// applyConflictChoices returns the content of the hunks with the conflicts resolved by the chosen versions,
+// it returns false if the choices don't match the conflicts
+func applyConflictChoices(hunks []*ConflictHunk, choices []ConflictChoice) (string, bool) {
+	var sb strings.Builder
+	for _, hunk := range hunks {
+		if !hunk.IsConflict {
+			sb.WriteString(hunk.Text)
+			continue
+		}
+		if hunk.Index >= len(choices) {
+			return "", false
+		}
+		switch choices[hunk.Index] {
+		case ConflictChoiceOurs:
+			sb.WriteString(hunk.Ours)
+		case ConflictChoiceBase:
+			sb.WriteString(hunk.Base)
+		case ConflictChoiceTheirs:
+			sb.WriteString(hunk.Theirs)
+		case ConflictChoiceBoth:
+			sb.WriteString(hunk.Ours)
+			sb.WriteString(hunk.Theirs)
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), len(choices) == countConflicts(hunks)
+}
+
+func countConflicts(hunks []*ConflictHunk) int {
+	n := 0
+	for _, hunk := range hunks {
+		if hunk.IsConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// restoreLineEndings converts the line endings of the content edited in a browser, which submits CRLF line endings,
+// to the line endings of the original file
+func restoreLineEndings(content, original string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if strings.Contains(original, "\r\n") {
+		content = strings.ReplaceAll(content, "\n", "\r\n")
+	}
+	return content
+}
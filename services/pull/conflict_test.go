@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasConflictMarkers(t *testing.T) {
+	assert.False(t, hasConflictMarkers(""))
+	assert.False(t, hasConflictMarkers("a\nb\n"))
+	assert.False(t, hasConflictMarkers("a <<<<<<< b\n=========\n"))
+	assert.False(t, hasConflictMarkers("Title\n=======\nb\n"))
+	assert.True(t, hasConflictMarkers("a\n<<<<<<< HEAD\nb\n"))
+	assert.True(t, hasConflictMarkers("<<<<<<< HEAD\na\n=======\nb\n"))
+	assert.True(t, hasConflictMarkers("a\n||||||| base\nb\n"))
+	assert.True(t, hasConflictMarkers("a\n>>>>>>> tracking"))
+}
+
+func TestParseConflictHunks(t *testing.T) {
+	merged := "a\r\n<<<<<<< HEAD\r\nours\r\n||||||| base\r\nbase\r\n=======\r\ntheirs\r\n>>>>>>> tracking\r\nb\r\n=======\r\n<<<<<<< HEAD\r\n||||||| base\r\n=======\r\nadded\r\n>>>>>>> tracking\r\n"
+	hunks, ok := ParseConflictHunks(merged)
+	require.True(t, ok)
+	assert.Equal(t, []*ConflictHunk{
+		{Text: "a\r\n"},
+		{IsConflict: true, Index: 0, Ours: "ours\r\n", Base: "base\r\n", Theirs: "theirs\r\n"},
+		{Text: "b\r\n=======\r\n"},
+		{IsConflict: true, Index: 1, Theirs: "added\r\n"},
+	}, hunks)
+
+	content, ok := applyConflictChoices(hunks, []ConflictChoice{ConflictChoiceBoth, ConflictChoiceTheirs})
+	assert.True(t, ok)
+	assert.Equal(t, "a\r\nours\r\ntheirs\r\nb\r\n=======\r\nadded\r\n", content)
+	content, ok = applyConflictChoices(hunks, []ConflictChoice{ConflictChoiceBase, ConflictChoiceOurs})
+	assert.True(t, ok)
+	assert.Equal(t, "a\r\nbase\r\nb\r\n=======\r\n", content)
+	_, ok = applyConflictChoices(hunks, []ConflictChoice{ConflictChoiceOurs})
+	assert.False(t, ok)
+	_, ok = applyConflictChoices(hunks, []ConflictChoice{ConflictChoiceOurs, "unknown"})
+	assert.False(t, ok)
+	_, ok = applyConflictChoices(hunks, []ConflictChoice{ConflictChoiceOurs, ConflictChoiceOurs, ConflictChoiceOurs})
+	assert.False(t, ok)
+
+	// unbalanced markers
+	_, ok = ParseConflictHunks("a\n")
+	assert.False(t, ok)
+	_, ok = ParseConflictHunks("<<<<<<< HEAD\na\n=======\nb\n")
+	assert.False(t, ok)
+	_, ok = ParseConflictHunks("<<<<<<< HEAD\n<<<<<<< HEAD\na\n=======\nb\n>>>>>>> tracking\n")
+	assert.False(t, ok)
+	_, ok = ParseConflictHunks(">>>>>>> tracking\n")
+	assert.False(t, ok)
+}
+
+func TestRestoreLineEndings(t *testing.T) {
+	assert.Equal(t, "a\nb\n", restoreLineEndings("a\r\nb\r\n", "x\ny\n"))
+	assert.Equal(t, "a\r\nb\r\n", restoreLineEndings("a\r\nb\r\n", "x\r\ny\r\n"))
+	assert.Equal(t, "a\r\nb", restoreLineEndings("a\nb", "x\r\n"))
+}
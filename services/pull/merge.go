@@ -361,6 +361,11 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 		return "", ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
 	}
 
+	return pushMergeResult(ctx, mergeCtx, pr, doer, pushTrigger)
+}
+
+// pushMergeResult pushes the merged "base" branch of the temporary repository back to the pr.BaseBranch
+func pushMergeResult(ctx context.Context, mergeCtx *mergeContext, pr *issues_model.PullRequest, doer *user_model.User, pushTrigger repo_module.PushTrigger) (string, error) {
 	// OK we should cache our current head and origin/headbranch
 	mergeHeadSHA, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, "HEAD")
 	if err != nil {
@@ -597,6 +602,9 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "The head branch is behind the base branch")
 	}
+	if issues_model.MergeBlockedByUnresolvedConversations(ctx, pb, pr) {
+		return util.ErrorWrap(ErrNotReadyToMerge, "There are unresolved conversations")
+	}
 
 	if skipProtectedFilesCheck {
 		return nil
@@ -33,6 +33,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
 	git_service "code.gitea.io/gitea/services/git"
+	"code.gitea.io/gitea/services/gitdiff"
 	issue_service "code.gitea.io/gitea/services/issue"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
@@ -348,7 +349,7 @@ func ChangeTargetBranch(ctx context.Context, pr *issues_model.PullRequest, doer
 	})
 }
 
-func checkForInvalidation(ctx context.Context, requests issues_model.PullRequestList, repoID int64, doer *user_model.User, branch string) error {
+func checkForInvalidation(ctx context.Context, requests issues_model.PullRequestList, repoID int64, doer *user_model.User, branch, oldCommitID, newCommitID string) error {
 	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
 	if err != nil {
 		return fmt.Errorf("GetRepositoryByIDCtx: %w", err)
@@ -359,7 +360,7 @@ func checkForInvalidation(ctx context.Context, requests issues_model.PullRequest
 	}
 	go func() {
 		// FIXME: graceful: We need to tell the manager we're doing something...
-		err := InvalidateCodeComments(ctx, requests, doer, repo, gitRepo, branch)
+		err := InvalidateCodeComments(ctx, requests, doer, repo, gitRepo, branch, oldCommitID, newCommitID)
 		if err != nil {
 			log.Error("PullRequestList.InvalidateCodeComments: %v", err)
 		}
@@ -424,7 +425,7 @@ func AddTestPullRequestTask(opts TestPullRequestOptions) {
 			if err = headBranchPRs.LoadAttributes(ctx); err != nil {
 				log.Error("PullRequestList.LoadAttributes: %v", err)
 			}
-			if invalidationErr := checkForInvalidation(ctx, headBranchPRs, opts.RepoID, opts.Doer, opts.Branch); invalidationErr != nil {
+			if invalidationErr := checkForInvalidation(ctx, headBranchPRs, opts.RepoID, opts.Doer, opts.Branch, opts.OldCommitID, opts.NewCommitID); invalidationErr != nil {
 				log.Error("checkForInvalidation: %v", invalidationErr)
 			}
 			if err == nil {
@@ -1103,3 +1104,20 @@ func GetPullCommits(ctx context.Context, baseGitRepo *git.Repository, doer *user
 
 	return commits, lastReviewCommitID, nil
 }
+
+// GetPullRangeDiff returns the changes of the pull request's commit series between two pushes, ignoring changes
+// pulled in from the base branch. If newCommitID is empty, the current head of the pull request is used.
+func GetPullRangeDiff(ctx context.Context, baseGitRepo *git.Repository, pull *issues_model.PullRequest, oldCommitID, newCommitID string) (*gitdiff.RangeDiff, error) {
+	baseRef := git.RefNameFromBranch(pull.BaseBranch).String()
+	if pull.HasMerged {
+		baseRef = pull.MergeBase
+	}
+	if newCommitID == "" {
+		headCommitID, err := baseGitRepo.GetRefCommitID(pull.GetGitHeadRefName())
+		if err != nil {
+			return nil, err
+		}
+		newCommitID = headCommitID
+	}
+	return gitdiff.GetRangeDiff(ctx, baseGitRepo, baseRef, oldCommitID, newCommitID)
+}
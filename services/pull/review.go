@@ -88,8 +88,27 @@ func checkInvalidation(ctx context.Context, c *issues_model.Comment, repo *repo_
 	return nil
 }
 
-// InvalidateCodeComments will lookup the prs for code comments which got invalidated by change
-func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestList, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, branch string) error {
+// reanchorCodeComment moves the lines of the code comment on the proposed side to follow the changes between
+// the old and the new head commit. If any of the commented lines got changed the comment is going to be invalidated.
+func reanchorCodeComment(ctx context.Context, c *issues_model.Comment, mapping *gitdiff.LineMapping) error {
+	start, end, ok := mapping.MapRange(int64(c.UnsignedStartLine()), int64(c.UnsignedLine()))
+	if !ok {
+		c.Invalidated = true
+		return issues_model.UpdateCommentInvalidate(ctx, c)
+	}
+	if end == c.Line {
+		return nil
+	}
+	c.Line = end
+	if c.StartLine != 0 {
+		c.StartLine = start
+	}
+	return issues_model.UpdateCommentLines(ctx, c)
+}
+
+// InvalidateCodeComments will lookup the prs for code comments which got invalidated by change,
+// if oldCommitID is given the comments are moved along with their lines changed by the push.
+func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestList, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, branch, oldCommitID, newCommitID string) error {
 	if len(prs) == 0 {
 		return nil
 	}
@@ -104,7 +123,29 @@ func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestLis
 	if err != nil {
 		return fmt.Errorf("find code comments: %v", err)
 	}
+
+	emptyCommitID := git.ObjectFormatFromName(repo.ObjectFormatName).EmptyObjectID().String()
+	canReanchor := oldCommitID != "" && oldCommitID != emptyCommitID && newCommitID != "" && newCommitID != emptyCommitID
+	mappings := make(map[string]*gitdiff.LineMapping)
 	for _, comment := range codeComments {
+		if canReanchor && comment.Line > 0 {
+			mapping, ok := mappings[comment.TreePath]
+			if !ok {
+				mapping, err = gitdiff.GetLineMapping(ctx, gitRepo, oldCommitID, newCommitID, comment.TreePath)
+				if err != nil {
+					log.Warn("GetLineMapping: %v", err)
+				}
+				mappings[comment.TreePath] = mapping
+			}
+			if mapping != nil {
+				if err := reanchorCodeComment(ctx, comment, mapping); err != nil {
+					return err
+				}
+				if comment.Invalidated {
+					continue
+				}
+			}
+		}
 		if err := checkInvalidation(ctx, comment, repo, gitRepo, branch); err != nil {
 			return err
 		}
@@ -112,8 +153,8 @@ func InvalidateCodeComments(ctx context.Context, prs issues_model.PullRequestLis
 	return nil
 }
 
-// CreateCodeComment creates a comment on the code line
-func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, line int64, content, treePath string, pendingReview bool, replyReviewID int64, latestCommitID string, attachments []string) (*issues_model.Comment, error) {
+// CreateCodeComment creates a comment on the code line, startLine is the first line of a multi-line comment or 0
+func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, issue *issues_model.Issue, startLine, line int64, content, treePath string, pendingReview bool, replyReviewID int64, latestCommitID string, attachments []string) (*issues_model.Comment, error) {
 	var (
 		existsReview bool
 		err          error
@@ -144,6 +185,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 			issue,
 			content,
 			treePath,
+			startLine,
 			line,
 			replyReviewID,
 			attachments,
@@ -185,6 +227,7 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 		issue,
 		content,
 		treePath,
+		startLine,
 		line,
 		review.ID,
 		attachments,
@@ -206,8 +249,13 @@ func CreateCodeComment(ctx context.Context, doer *user_model.User, gitRepo *git.
 }
 
 // createCodeComment creates a plain code comment at the specified line / path
-func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content, treePath string, line, reviewID int64, attachments []string) (*issues_model.Comment, error) {
+func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content, treePath string, startLine, line, reviewID int64, attachments []string) (*issues_model.Comment, error) {
 	var commitID, patch string
+	// the start line must be on the same side before the commented line
+	lines := &issues_model.Comment{Line: line, StartLine: startLine}
+	if (startLine > 0) != (line > 0) || lines.UnsignedStartLine() >= lines.UnsignedLine() {
+		startLine = 0
+	}
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		return nil, fmt.Errorf("LoadPullRequest: %w", err)
 	}
@@ -274,9 +322,14 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 			commitID = headCommitID
 		}
 
+		// show all the commented lines of a multi-line comment
+		contextLines := setting.UI.CodeCommentLines
+		if startLine != 0 {
+			contextLines = max(contextLines, int(lines.UnsignedLine()-lines.UnsignedStartLine())+1)
+		}
 		patch, err = git.GetFileDiffCutAroundLine(
 			gitRepo, pr.MergeBase, headCommitID, treePath,
-			int64((&issues_model.Comment{Line: line}).UnsignedLine()), line < 0, setting.UI.CodeCommentLines,
+			int64((&issues_model.Comment{Line: line}).UnsignedLine()), line < 0, contextLines,
 		)
 		if err != nil {
 			return nil, err
@@ -284,7 +337,7 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 
 		// If patch is still empty (unchanged line), generate code context
 		if patch == "" && commitID != "" {
-			patch, err = gitdiff.GeneratePatchForUnchangedLine(gitRepo, commitID, treePath, line, setting.UI.CodeCommentLines)
+			patch, err = gitdiff.GeneratePatchForUnchangedLine(gitRepo, commitID, treePath, line, contextLines)
 			if err != nil {
 				// Log the error but don't fail comment creation
 				log.Debug("Unable to generate patch for unchanged line (file=%s, line=%d, commit=%s): %v", treePath, line, commitID, err)
@@ -292,18 +345,19 @@ func createCodeComment(ctx context.Context, doer *user_model.User, repo *repo_mo
 		}
 	}
 	return issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
-		Type:        issues_model.CommentTypeCode,
-		Doer:        doer,
-		Repo:        repo,
-		Issue:       issue,
-		Content:     content,
-		LineNum:     line,
-		TreePath:    treePath,
-		CommitSHA:   commitID,
-		ReviewID:    reviewID,
-		Patch:       patch,
-		Invalidated: invalidated,
-		Attachments: attachments,
+		Type:         issues_model.CommentTypeCode,
+		Doer:         doer,
+		Repo:         repo,
+		Issue:        issue,
+		Content:      content,
+		LineNum:      line,
+		StartLineNum: startLine,
+		TreePath:     treePath,
+		CommitSHA:    commitID,
+		ReviewID:     reviewID,
+		Patch:        patch,
+		Invalidated:  invalidated,
+		Attachments:  attachments,
 	})
 }
 
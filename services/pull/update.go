@@ -81,10 +81,16 @@ func Update(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.
 		return updateHeadByRebaseOnToBase(ctx, pr, doer)
 	}
 
-	// TODO: FakePR: it is somewhat hacky, but it is the only way to "merge" at the moment
-	// ideally in the future the "merge" functions should be refactored to decouple from the PullRequest
-	// now use a fake reverse PR to switch head&base repos/branches
-	reversePR := &issues_model.PullRequest{
+	_, err = doMergeAndPush(ctx, newReversePullRequest(pr), doer, repo_model.MergeStyleMerge, "", message, repository.PushTriggerPRUpdateWithBase)
+	return err
+}
+
+// newReversePullRequest returns a fake pull request whose head and base are switched,
+// merging it updates the head branch of the original pull request with its base branch.
+// TODO: FakePR: it is somewhat hacky, but it is the only way to "merge" at the moment
+// ideally in the future the "merge" functions should be refactored to decouple from the PullRequest
+func newReversePullRequest(pr *issues_model.PullRequest) *issues_model.PullRequest {
+	return &issues_model.PullRequest{
 		ID: pr.ID,
 
 		HeadRepoID: pr.BaseRepoID,
@@ -95,9 +101,6 @@ func Update(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.
 		BaseRepo:   pr.HeadRepo,
 		BaseBranch: pr.HeadBranch,
 	}
-
-	_, err = doMergeAndPush(ctx, reversePR, doer, repo_model.MergeStyleMerge, "", message, repository.PushTriggerPRUpdateWithBase)
-	return err
 }
 
 // IsUserAllowedToUpdate check if user is allowed to update PR with given permissions and branch protections
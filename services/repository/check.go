@@ -15,6 +15,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
 
@@ -82,8 +83,20 @@ func GitGcRepos(ctx context.Context, timeout time.Duration, args gitcmd.TrustedC
 	return nil
 }
 
+// getRepoGcLockKey returns the key of the lock held while the objects of a repository are repacked,
+// so 'git gc' and the git maintenance never run on the same repository at once
+func getRepoGcLockKey(repoID int64) string {
+	return fmt.Sprintf("repo_gc_%d", repoID)
+}
+
 // GitGcRepo calls 'git gc' to remove unnecessary files and optimize the local repository
 func GitGcRepo(ctx context.Context, repo *repo_model.Repository, timeout time.Duration, args gitcmd.TrustedCmdArgs) error {
+	return globallock.LockAndDo(ctx, getRepoGcLockKey(repo.ID), func(ctx context.Context) error {
+		return gitGcRepo(ctx, repo, args)
+	})
+}
+
+func gitGcRepo(ctx context.Context, repo *repo_model.Repository, args gitcmd.TrustedCmdArgs) error {
 	log.Trace("Running git gc on %-v", repo)
 	command := gitcmd.NewCommand("gc").AddArguments(args...)
 	var stdout string
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+)
+
+// CountClone counts a clone of the repository made by a git upload-pack request. The requests fetching the objects
+// missing in a partial clone look like partial clones, but they want blobs or trees instead of the commits of the refs.
+func CountClone(ctx context.Context, repo *repo_model.Repository, kind repo_model.CloneKind, firstWant string) error {
+	if kind == repo_model.CloneKindPartial {
+		objectType, _, err := gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("cat-file", "-t").AddDynamicArguments(firstWant))
+		if err != nil {
+			return err
+		}
+		if objectType = strings.TrimSpace(objectType); objectType != "commit" && objectType != "tag" {
+			return nil
+		}
+	}
+	return repo_model.IncreaseRepoCloneStat(ctx, repo.ID, kind)
+}
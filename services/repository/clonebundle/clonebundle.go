@@ -0,0 +1,305 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package clonebundle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+)
+
+// getRepositories returns the repositories the bundles should be generated for
+func getRepositories(ctx context.Context) ([]*repo_model.Repository, error) {
+	repos := make([]*repo_model.Repository, 0, len(setting.CloneBundle.Repos))
+	if setting.CloneBundle.MinRepoSize >= 0 {
+		var err error
+		if repos, err = repo_model.GetRepositoriesForCloneBundles(ctx, setting.CloneBundle.MinRepoSize); err != nil {
+			return nil, err
+		}
+	}
+	for _, fullName := range setting.CloneBundle.Repos {
+		ownerName, repoName, ok := strings.Cut(strings.TrimSpace(fullName), "/")
+		if !ok {
+			log.Warn("Invalid repository %q in [clone-bundle] REPOS", fullName)
+			continue
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				log.Warn("Repository %q in [clone-bundle] REPOS does not exist", fullName)
+				continue
+			}
+			return nil, err
+		}
+		if !slices.ContainsFunc(repos, func(r *repo_model.Repository) bool { return r.ID == repo.ID }) {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
+// GenerateBundles generates the bundles for the configured repositories
+// and deletes the bundles of the repositories which are not configured anymore
+func GenerateBundles(ctx context.Context) error {
+	repos, err := getRepositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	repoIDs := make(container.Set[int64], len(repos))
+	for _, repo := range repos {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		repoIDs.Add(repo.ID)
+		if err := GenerateRepoBundle(ctx, repo); err != nil {
+			log.Error("Unable to generate clone bundle for %s: %v", repo.FullName(), err)
+		}
+	}
+
+	bundledRepoIDs, err := repo_model.GetCloneBundleRepoIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, repoID := range bundledRepoIDs {
+		if repoIDs.Contains(repoID) {
+			continue
+		}
+		bundles, err := repo_model.GetCloneBundles(ctx, repoID)
+		if err != nil {
+			return err
+		}
+		if err := deleteBundles(ctx, bundles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteBundles(ctx context.Context, bundles []*repo_model.CloneBundle) error {
+	if len(bundles) == 0 {
+		return nil
+	}
+	if err := repo_model.DeleteCloneBundles(ctx, bundles); err != nil {
+		return err
+	}
+	for _, bundle := range bundles {
+		if err := storage.CloneBundles.Delete(bundle.RelativePath()); err != nil {
+			log.Error("Unable to delete clone bundle %s: %v", bundle.RelativePath(), err)
+		}
+	}
+	return nil
+}
+
+// getTips returns the names and the object IDs of the branches and tags
+func getTips(ctx context.Context, repo *repo_model.Repository) (refNames, objectIDs []string, _ error) {
+	stdout, _, err := gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		objectID, refName, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		refNames = append(refNames, refName)
+		objectIDs = append(objectIDs, objectID)
+	}
+	return refNames, objectIDs, nil
+}
+
+const errEmptyBundle = "fatal: Refusing to create empty bundle"
+
+// createBundle creates a bundle of the refs which excludes the objects reachable from the prerequisites
+func createBundle(ctx context.Context, repo *repo_model.Repository, refNames, prerequisites []string) (*os.File, func(), error) {
+	tmpDir, cleanup, err := setting.AppDataTempDir("clone-bundle").MkdirTempRandom("bundle")
+	if err != nil {
+		return nil, nil, err
+	}
+	bundlePath := filepath.Join(tmpDir, "repo.bundle")
+
+	// the rev-list arguments are passed by stdin, there could be a lot of branches and tags
+	stdin := &bytes.Buffer{}
+	for _, refName := range refNames {
+		stdin.WriteString(refName + "\n")
+	}
+	for _, objectID := range prerequisites {
+		stdin.WriteString("^" + objectID + "\n")
+	}
+	if err := gitrepo.RunCmdWithStderr(ctx, repo, gitcmd.NewCommand("bundle", "create", "-q").
+		AddDynamicArguments(bundlePath).
+		AddArguments("--stdin").
+		WithStdinBytes(stdin.Bytes()),
+	); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return f, func() {
+		_ = f.Close()
+		cleanup()
+	}, nil
+}
+
+// GenerateRepoBundle generates a bundle for the new objects of a repository since the last bundle.
+// A full bundle is generated if there is no bundle yet or there are too many incremental bundles,
+// and the previous bundles are deleted then.
+func GenerateRepoBundle(ctx context.Context, repo *repo_model.Repository) error {
+	return globallock.LockAndDo(ctx, getRepoBundleLockKey(repo.ID), func(ctx context.Context) error {
+		return generateRepoBundle(ctx, repo)
+	})
+}
+
+func getRepoBundleLockKey(repoID int64) string {
+	return "clone_bundle_" + strconv.FormatInt(repoID, 10)
+}
+
+// RewriteRepo runs rewrite, which rewrites the objects of a repository, with no bundle generated for the repository
+// meanwhile. The bundles of the old objects are deleted then, and a full bundle of the rewritten repository replaces
+// them if the repository had bundles.
+func RewriteRepo(ctx context.Context, repo *repo_model.Repository, rewrite func(ctx context.Context) error) error {
+	return globallock.LockAndDo(ctx, getRepoBundleLockKey(repo.ID), func(ctx context.Context) error {
+		bundles, err := repo_model.GetCloneBundles(ctx, repo.ID)
+		if err != nil {
+			return err
+		}
+		if err := rewrite(ctx); err != nil {
+			return err
+		}
+		if len(bundles) == 0 {
+			return nil
+		}
+		if err := deleteBundles(ctx, bundles); err != nil {
+			return fmt.Errorf("delete the clone bundles of the old objects: %w", err)
+		}
+		if err := generateRepoBundle(ctx, repo); err != nil {
+			// the bundle will be generated by the next run of the cron task
+			log.Error("Unable to generate clone bundle for %s: %v", repo.FullName(), err)
+		}
+		return nil
+	})
+}
+
+func generateRepoBundle(ctx context.Context, repo *repo_model.Repository) error {
+	refNames, tips, err := getTips(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if len(tips) == 0 {
+		return nil
+	}
+
+	bundles, err := repo_model.GetCloneBundles(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	prerequisites := make(container.Set[string])
+	incremental := 0
+	for _, bundle := range bundles {
+		prerequisites.AddMultiple(bundle.Tips...)
+		if bundle.IsFull {
+			incremental = 0
+		} else {
+			incremental++
+		}
+	}
+	if len(bundles) > 0 && !slices.ContainsFunc(tips, func(tip string) bool { return !prerequisites.Contains(tip) }) {
+		return nil // nothing new since the last bundle
+	}
+
+	isFull := len(bundles) == 0 || incremental >= setting.CloneBundle.MaxIncrementalBundles
+	var tmpFile *os.File
+	var cleanup func()
+	if !isFull {
+		tmpFile, cleanup, err = createBundle(ctx, repo, refNames, prerequisites.Values())
+		if err != nil {
+			if gitcmd.StderrHasPrefix(err, errEmptyBundle) {
+				return nil
+			}
+			// the objects of the previous bundles could have been removed by a force-push and a gc
+			log.Warn("Unable to create incremental clone bundle for %s, a full bundle will be created: %v", repo.FullName(), err)
+			isFull = true
+		}
+	}
+	if isFull {
+		if tmpFile, cleanup, err = createBundle(ctx, repo, refNames, nil); err != nil {
+			return err
+		}
+	}
+	defer cleanup()
+
+	fi, err := tmpFile.Stat()
+	if err != nil {
+		return err
+	}
+	bundle := &repo_model.CloneBundle{
+		RepoID: repo.ID,
+		IsFull: isFull,
+		Tips:   tips,
+		Size:   fi.Size(),
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := repo_model.AddCloneBundle(ctx, bundle); err != nil {
+			return err
+		}
+		_, err := storage.CloneBundles.Save(bundle.RelativePath(), tmpFile, fi.Size())
+		return err
+	}); err != nil {
+		return fmt.Errorf("unable to save clone bundle: %w", err)
+	}
+
+	if isFull {
+		return deleteBundles(ctx, bundles)
+	}
+	return nil
+}
+
+// BundleList returns the bundle list advertised by the bundle-uri command of the git protocol v2,
+// it is empty if there is no bundle for the repository
+func BundleList(ctx context.Context, repo *repo_model.Repository) ([]string, error) {
+	if !setting.CloneBundle.Enabled || repo.IsBeingConverted() {
+		// the bundles of a repository being converted contain the objects of the old object format
+		return nil, nil
+	}
+	bundles, err := repo_model.GetCloneBundles(ctx, repo.ID)
+	if err != nil || len(bundles) == 0 {
+		return nil, err
+	}
+
+	// the clients download the bundles in the order of the creation tokens, and stop once they have the objects
+	list := []string{
+		"bundle.version=1",
+		"bundle.mode=all",
+		"bundle.heuristic=creationToken",
+	}
+	for _, bundle := range bundles {
+		list = append(list,
+			fmt.Sprintf("bundle.%d.uri=%s.git/bundles/%d.bundle", bundle.ID, repo.HTMLURL(ctx), bundle.ID),
+			fmt.Sprintf("bundle.%d.creationToken=%d", bundle.ID, bundle.CreationToken()),
+		)
+	}
+	return list, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package clonebundle
+
+import (
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestGenerateRepoBundle(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	bundleStorage, err := storage.NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer test.MockVariableValue(&storage.CloneBundles, bundleStorage)()
+	defer test.MockVariableValue(&setting.CloneBundle.Enabled, true)()
+	defer test.MockVariableValue(&setting.CloneBundle.MaxIncrementalBundles, 1)()
+
+	verifyBundle := func(t *testing.T, bundle *repo_model.CloneBundle) {
+		fi, err := storage.CloneBundles.Stat(bundle.RelativePath())
+		require.NoError(t, err)
+		assert.Equal(t, bundle.Size, fi.Size())
+	}
+
+	// a full bundle is generated at first
+	require.NoError(t, GenerateRepoBundle(t.Context(), repo))
+	bundles, err := repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.True(t, bundles[0].IsFull)
+	assert.Contains(t, bundles[0].Tips, "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	verifyBundle(t, bundles[0])
+	full := bundles[0]
+
+	// nothing changed
+	require.NoError(t, GenerateRepoBundle(t.Context(), repo))
+	bundles, err = repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+
+	newCommit := func(t *testing.T, message string) {
+		stdout, _, err := gitrepo.RunCmdString(t.Context(), repo, gitcmd.NewCommand("commit-tree", "-p", "master", "-m").AddDynamicArguments(message).AddArguments("master^{tree}").
+			WithEnv([]string{"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com"}))
+		require.NoError(t, err)
+		_, _, err = gitrepo.RunCmdString(t.Context(), repo, gitcmd.NewCommand("update-ref", "refs/heads/bundle-test").AddDynamicArguments(strings.TrimSpace(stdout)))
+		require.NoError(t, err)
+	}
+	defer func() {
+		_, _, err := gitrepo.RunCmdString(t.Context(), repo, gitcmd.NewCommand("update-ref", "-d", "refs/heads/bundle-test"))
+		assert.NoError(t, err)
+	}()
+
+	// the new commit is added by an incremental bundle
+	newCommit(t, "first bundle test")
+	require.NoError(t, GenerateRepoBundle(t.Context(), repo))
+	bundles, err = repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+	assert.False(t, bundles[1].IsFull)
+	assert.Less(t, bundles[1].Size, full.Size)
+	verifyBundle(t, bundles[1])
+
+	list, err := BundleList(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"bundle.version=1",
+		"bundle.mode=all",
+		"bundle.heuristic=creationToken",
+		"bundle.1.uri=" + setting.AppURL + "user2/repo1.git/bundles/1.bundle",
+		"bundle.1.creationToken=1",
+		"bundle.2.uri=" + setting.AppURL + "user2/repo1.git/bundles/2.bundle",
+		"bundle.2.creationToken=2",
+	}, list)
+
+	// too many incremental bundles, a new full bundle replaces them
+	newCommit(t, "second bundle test")
+	require.NoError(t, GenerateRepoBundle(t.Context(), repo))
+	bundles, err = repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.True(t, bundles[0].IsFull)
+	verifyBundle(t, bundles[0])
+	_, err = storage.CloneBundles.Stat(full.RelativePath())
+	assert.Error(t, err)
+
+	// the bundles are deleted if the repository is not configured anymore
+	require.NoError(t, GenerateBundles(t.Context()))
+	bundles, err = repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	assert.Empty(t, bundles)
+}
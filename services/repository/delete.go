@@ -152,6 +152,7 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&git_model.CommitStatus{RepoID: repoID},
 		&git_model.Branch{RepoID: repoID},
 		&git_model.LFSLock{RepoID: repoID},
+		&git_model.LFSLockBreak{RepoID: repoID},
 		&repo_model.LanguageStat{RepoID: repoID},
 		&repo_model.RepoLicense{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
@@ -159,9 +160,14 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
 		&git_model.ProtectedTag{RepoID: repoID},
+		&git_model.PushCertificate{RepoID: repoID},
+		&git_model.ObjectIDTranslation{RepoID: repoID},
+		&repo_model.RepoClonePolicy{RepoID: repoID},
+		&repo_model.RepoCloneStat{RepoID: repoID},
 		&repo_model.PushMirror{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
+		&repo_model.RepoMaintenance{RepoID: repoID},
 		&repo_model.Redirect{RedirectRepoID: repoID},
 		&repo_model.RepoUnit{RepoID: repoID},
 		&repo_model.Star{RepoID: repoID},
@@ -262,6 +268,19 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return err
 	}
 
+	// Remove clone bundles
+	cloneBundles, err := repo_model.GetCloneBundles(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	cloneBundlePaths := make([]string, 0, len(cloneBundles))
+	for _, v := range cloneBundles {
+		cloneBundlePaths = append(cloneBundlePaths, v.RelativePath())
+	}
+	if _, err := db.DeleteByBean(ctx, &repo_model.CloneBundle{RepoID: repoID}); err != nil {
+		return err
+	}
+
 	if repo.NumForks > 0 {
 		if _, err = sess.Exec("UPDATE `repository` SET fork_id=0,is_fork=? WHERE fork_id=?", false, repo.ID); err != nil {
 			log.Error("reset 'fork_id' and 'is_fork': %v", err)
@@ -329,6 +348,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		system_model.RemoveStorageWithNotice(ctx, storage.RepoArchives, "Delete repo archive file", archive)
 	}
 
+	// Remove clone bundles
+	for _, bundle := range cloneBundlePaths {
+		system_model.RemoveStorageWithNotice(ctx, storage.CloneBundles, "Delete clone bundle file", bundle)
+	}
+
 	// Remove lfs objects
 	for _, lfsObj := range lfsPaths {
 		system_model.RemoveStorageWithNotice(ctx, storage.LFS, "Delete orphaned LFS file", lfsObj)
@@ -0,0 +1,191 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// The incremental git maintenance tasks
+const (
+	MaintenanceTaskCommitGraph     = "commit-graph"
+	MaintenanceTaskMultiPackIndex  = "multi-pack-index"
+	MaintenanceTaskGeometricRepack = "geometric-repack"
+	MaintenanceTaskPrune           = "prune"
+)
+
+// MaintenanceOptions represents the thresholds which trigger the git maintenance of a repository
+type MaintenanceOptions struct {
+	Timeout               time.Duration
+	PushThreshold         int64
+	LooseObjectsThreshold int64
+	PackThreshold         int64
+	PruneExpire           string
+}
+
+// maintenanceTasks returns the tasks to run for the pushes since the last maintenance and the objects in the repository,
+// it is empty if the repository doesn't need maintenance
+func maintenanceTasks(pushes, looseObjects, packs int64, opts *MaintenanceOptions) []string {
+	tooManyLoose := opts.LooseObjectsThreshold > 0 && looseObjects >= opts.LooseObjectsThreshold
+	tooManyPacks := opts.PackThreshold > 0 && packs >= opts.PackThreshold
+	manyPushes := opts.PushThreshold > 0 && pushes >= opts.PushThreshold
+	if !tooManyLoose && !tooManyPacks && !manyPushes {
+		return nil
+	}
+
+	tasks := []string{MaintenanceTaskCommitGraph}
+	if tooManyLoose || tooManyPacks {
+		tasks = append(tasks, MaintenanceTaskGeometricRepack)
+	} else if packs > 1 {
+		tasks = append(tasks, MaintenanceTaskMultiPackIndex)
+	}
+	if tooManyLoose && opts.PruneExpire != "" {
+		tasks = append(tasks, MaintenanceTaskPrune)
+	}
+	return tasks
+}
+
+func maintenanceTaskCommand(task string, opts *MaintenanceOptions) *gitcmd.Command {
+	switch task {
+	case MaintenanceTaskCommitGraph:
+		return gitcmd.NewCommand("commit-graph", "write", "--reachable", "--split", "--changed-paths")
+	case MaintenanceTaskMultiPackIndex:
+		return gitcmd.NewCommand("multi-pack-index", "write")
+	case MaintenanceTaskGeometricRepack:
+		// combine the packs into a geometric progression, so the large packs are rarely rewritten
+		return gitcmd.NewCommand("repack", "-d", "-l", "--geometric=2", "--write-midx")
+	case MaintenanceTaskPrune:
+		return gitcmd.NewCommand("prune").AddOptionFormat("--expire=%s", opts.PruneExpire)
+	}
+	return nil
+}
+
+// countObjects returns the number of the loose objects and the packs of a repository
+func countObjects(ctx context.Context, repo *repo_model.Repository) (looseObjects, packs int64, _ error) {
+	stdout, _, runErr := gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("count-objects", "-v"))
+	if runErr != nil {
+		return 0, 0, runErr
+	}
+	for line := range strings.SplitSeq(stdout, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		var err error
+		switch key {
+		case "count":
+			looseObjects, err = strconv.ParseInt(value, 10, 64)
+		case "packs":
+			packs, err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to parse count-objects output %q: %w", line, err)
+		}
+	}
+	return looseObjects, packs, nil
+}
+
+// GitMaintenanceRepos runs the git maintenance of the repositories which need it
+func GitMaintenanceRepos(ctx context.Context, opts *MaintenanceOptions) error {
+	log.Trace("Doing: GitMaintenanceRepos")
+
+	if err := db.Iterate(
+		ctx,
+		builder.Gt{"id": 0}.And(builder.Eq{"is_empty": false}),
+		func(ctx context.Context, repo *repo_model.Repository) error {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before maintenance of %s", repo.FullName())
+			default:
+			}
+			// we can ignore the error here because it will be logged in GitMaintenanceRepo
+			_ = GitMaintenanceRepo(ctx, repo, opts)
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	log.Trace("Finished: GitMaintenanceRepos")
+	return nil
+}
+
+// GitMaintenanceRepo runs the incremental git maintenance tasks of a repository if the pushes since the last maintenance,
+// the loose objects or the packs reach the thresholds, and records the result
+func GitMaintenanceRepo(ctx context.Context, repo *repo_model.Repository, opts *MaintenanceOptions) error {
+	return globallock.LockAndDo(ctx, getRepoGcLockKey(repo.ID), func(ctx context.Context) error {
+		return gitMaintenanceRepo(ctx, repo, opts)
+	})
+}
+
+func gitMaintenanceRepo(ctx context.Context, repo *repo_model.Repository, opts *MaintenanceOptions) error {
+	state, err := repo_model.GetRepoMaintenance(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	looseObjects, packs, err := countObjects(ctx, repo)
+	if err != nil {
+		log.Error("Unable to count objects of %-v: %v", repo, err)
+		return err
+	}
+	handledPushes := state.PushCount
+	state.LooseObjects, state.PackCount = looseObjects, packs
+	state.LastCheckedUnix = timeutil.TimeStampNow()
+
+	tasks := maintenanceTasks(handledPushes, looseObjects, packs, opts)
+	if len(tasks) == 0 {
+		return repo_model.UpdateRepoMaintenance(ctx, state, 0)
+	}
+
+	log.Trace("Running git maintenance %v on %-v", tasks, repo)
+	start := time.Now()
+	var runErr error
+	for _, task := range tasks {
+		if _, _, runErr = gitrepo.RunCmdString(ctx, repo, maintenanceTaskCommand(task, opts).WithTimeout(opts.Timeout)); runErr != nil {
+			runErr = fmt.Errorf("%s: %w", task, runErr)
+			break
+		}
+	}
+	if runErr == nil {
+		runErr = repo_module.UpdateRepoSize(ctx, repo)
+	}
+	state.Tasks = tasks
+	state.DurationMs = time.Since(start).Milliseconds()
+	state.LastMaintenanceUnix = timeutil.TimeStampNow()
+	if runErr != nil {
+		state.Status, state.Error = repo_model.MaintenanceStatusFailed, runErr.Error()
+		log.Error("Repository maintenance failed for %-v: %v", repo, runErr)
+		desc := fmt.Sprintf("Repository maintenance failed (%s): %v", repo.FullName(), runErr)
+		if err := system_model.CreateRepositoryNotice(desc); err != nil {
+			log.Error("CreateRepositoryNotice: %v", err)
+		}
+	} else {
+		state.Status, state.Error = repo_model.MaintenanceStatusSuccess, ""
+		// the objects have been repacked, count them again for the admin page
+		if state.LooseObjects, state.PackCount, err = countObjects(ctx, repo); err != nil {
+			log.Error("Unable to count objects of %-v: %v", repo, err)
+		}
+	}
+
+	if err := repo_model.UpdateRepoMaintenance(ctx, state, handledPushes); err != nil {
+		return err
+	}
+	return runErr
+}
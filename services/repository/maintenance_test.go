@@ -0,0 +1,67 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/globallock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceTasks(t *testing.T) {
+	opts := &MaintenanceOptions{PushThreshold: 20, LooseObjectsThreshold: 1000, PackThreshold: 10, PruneExpire: "2.weeks.ago"}
+
+	assert.Empty(t, maintenanceTasks(0, 0, 1, opts))
+	assert.Empty(t, maintenanceTasks(19, 999, 9, opts))
+	assert.Equal(t, []string{"commit-graph"}, maintenanceTasks(20, 0, 1, opts))
+	assert.Equal(t, []string{"commit-graph", "multi-pack-index"}, maintenanceTasks(20, 0, 3, opts))
+	assert.Equal(t, []string{"commit-graph", "geometric-repack"}, maintenanceTasks(0, 0, 10, opts))
+	assert.Equal(t, []string{"commit-graph", "geometric-repack", "prune"}, maintenanceTasks(0, 1000, 1, opts))
+
+	opts.PushThreshold = 0
+	assert.Empty(t, maintenanceTasks(100, 0, 1, opts))
+}
+
+func TestGitMaintenanceRepo(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	opts := &MaintenanceOptions{PushThreshold: 2, LooseObjectsThreshold: 1000, PackThreshold: 10}
+	require.NoError(t, GitMaintenanceRepo(t.Context(), repo, opts))
+	m := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoMaintenance{RepoID: repo.ID})
+	assert.Equal(t, repo_model.MaintenanceStatusNone, m.Status)
+	assert.NotZero(t, m.LastCheckedUnix)
+	assert.Zero(t, m.LastMaintenanceUnix)
+
+	require.NoError(t, repo_model.IncreaseRepoMaintenancePushCount(t.Context(), repo.ID, 2))
+	require.NoError(t, GitMaintenanceRepo(t.Context(), repo, opts))
+	m = unittest.AssertExistsAndLoadBean(t, &repo_model.RepoMaintenance{RepoID: repo.ID})
+	assert.Equal(t, repo_model.MaintenanceStatusSuccess, m.Status)
+	assert.Contains(t, m.Tasks, "commit-graph")
+	assert.Zero(t, m.PushCount)
+	assert.NotZero(t, m.LastMaintenanceUnix)
+}
+
+func TestGitMaintenanceRepoLock(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	// git gc and the git maintenance of a repository wait for each other
+	release, err := globallock.Lock(t.Context(), getRepoGcLockKey(repo.ID))
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	opts := &MaintenanceOptions{PushThreshold: 2, LooseObjectsThreshold: 1000, PackThreshold: 10}
+	assert.ErrorIs(t, GitMaintenanceRepo(ctx, repo, opts), context.DeadlineExceeded)
+	assert.ErrorIs(t, GitGcRepo(ctx, repo, time.Minute, nil), context.DeadlineExceeded)
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// CommitNote is the git note of a commit in a notes namespace
+type CommitNote struct {
+	Ref       string
+	Namespace string
+	git.Note
+}
+
+// GetCommitNotes returns the notes of a commit in all notes namespaces of the repository, the default namespace first
+func GetCommitNotes(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, commitID string) ([]*CommitNote, error) {
+	refs, err := gitrepo.ListNotesRefs(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	notes := make([]*CommitNote, 0, len(refs))
+	for _, ref := range refs {
+		note := &CommitNote{Ref: ref, Namespace: git.NotesNamespace(ref)}
+		if err := git.GetNoteFromRef(ctx, gitRepo, ref, commitID, &note.Note); err != nil {
+			if git.IsErrNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if ref == git.NotesRef {
+			notes = append([]*CommitNote{note}, notes...)
+		} else {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// SetCommitNote creates or replaces the note of a commit in a notes ref
+func SetCommitNote(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, notesRef, commitID, message string) error {
+	if message == "" {
+		return util.NewInvalidArgumentErrorf("the note message must not be empty")
+	}
+	return gitrepo.SetNote(ctx, repo, notesRef, commitID, []byte(message), doer.NewGitSig())
+}
+
+// DeleteCommitNote removes the note of a commit from a notes ref
+func DeleteCommitNote(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, notesRef, commitID string) error {
+	return gitrepo.RemoveNote(ctx, repo, notesRef, commitID, doer.NewGitSig())
+}
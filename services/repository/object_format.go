@@ -0,0 +1,217 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/repository/clonebundle"
+
+	"xorm.io/builder"
+)
+
+// CheckObjectFormatConversion checks whether the repository can be converted to the object format
+func CheckObjectFormatConversion(repo *repo_model.Repository, to git.ObjectFormat) error {
+	switch {
+	case to == nil || !slices.Contains(git.DefaultFeatures().SupportedObjectFormats, to):
+		return util.NewInvalidArgumentErrorf("the object format is not supported by the git version of the server")
+	case repo.ObjectFormatName == to.Name():
+		return util.NewInvalidArgumentErrorf("the repository already uses the object format %s", to.Name())
+	case repo.Status != repo_model.RepositoryReady:
+		return util.NewInvalidArgumentErrorf("the repository is not ready")
+	case repo.IsMirror:
+		return util.NewInvalidArgumentErrorf("a mirror must use the object format of its remote repository")
+	case repo.IsFork || repo.NumForks > 0:
+		// the pull requests between forks need the same object format
+		return util.NewInvalidArgumentErrorf("a fork or a repository with forks can't be converted")
+	}
+	return nil
+}
+
+// ConvertObjectFormat rewrites the repository and its wiki with the object format "to", and replaces the commit IDs
+// stored in the database. The translation of the old commit IDs is kept so the links with them keep resolving.
+func ConvertObjectFormat(ctx context.Context, repo *repo_model.Repository, to git.ObjectFormat) (err error) {
+	if err := CheckObjectFormatConversion(repo, to); err != nil {
+		return err
+	}
+
+	// the pushes are rejected during the conversion, they would be lost
+	repo.Status = repo_model.RepositoryBeingConverted
+	if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "status"); err != nil {
+		return err
+	}
+	defer func() {
+		repo.Status = repo_model.RepositoryReady
+		if errors.Is(err, gitrepo.ErrObjectFormatNotRestored) {
+			// neither the old nor the converted repository is in place, it needs to be repaired by an administrator
+			repo.Status = repo_model.RepositoryBroken
+		}
+		if errUpdate := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "status"); errUpdate != nil {
+			log.Error("Unable to reset the status of %-v after the conversion: %v", repo, errUpdate)
+			if err == nil {
+				err = errUpdate
+			}
+		}
+	}()
+
+	fromName := repo.ObjectFormatName
+	err = clonebundle.RewriteRepo(ctx, repo, func(ctx context.Context) error {
+		return gitrepo.ConvertObjectFormat(ctx, repo, to, func(translation map[string]string, replace func() error) error {
+			return convertWikiObjectFormat(ctx, repo, to, func(replaceWiki func() error) error {
+				// the repositories are replaced in the transaction, so they are restored if it fails
+				return db.WithTx(ctx, func(ctx context.Context) error {
+					if err := translateCommitIDs(ctx, repo.ID, translation); err != nil {
+						return err
+					}
+					if err := git_model.InsertObjectIDTranslations(ctx, repo.ID, translation); err != nil {
+						return err
+					}
+					repo.ObjectFormatName = to.Name()
+					if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "object_format_name"); err != nil {
+						return err
+					}
+					if err := replace(); err != nil {
+						return err
+					}
+					return replaceWiki()
+				})
+			})
+		})
+	})
+	if err != nil {
+		repo.ObjectFormatName = fromName
+		return fmt.Errorf("convert %s: %w", repo.FullName(), err)
+	}
+	log.Info("Repository %-v has been converted from the %s to the %s object format", repo, fromName, to.Name())
+	return nil
+}
+
+// RecoverObjectFormatConversion restores the repository and its wiki after their conversion was interrupted,
+// e.g. by a crash, and makes the repository ready again
+func RecoverObjectFormatConversion(ctx context.Context, repo *repo_model.Repository) error {
+	objectFormat := git.ObjectFormatFromName(repo.ObjectFormatName)
+	err := gitrepo.RecoverObjectFormatConversion(ctx, repo, objectFormat)
+	if err == nil {
+		err = gitrepo.RecoverObjectFormatConversion(ctx, repo.WikiStorageRepo(), objectFormat)
+	}
+	repo.Status = repo_model.RepositoryReady
+	if err != nil {
+		log.Error("Unable to recover the interrupted conversion of %-v: %v", repo, err)
+		repo.Status = repo_model.RepositoryBroken
+	}
+	return repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "status")
+}
+
+// convertWikiObjectFormat converts the wiki of the repository if it exists, and calls replace with the function
+// which replaces the wiki by the converted one. There are no commit IDs of the wiki in the database.
+func convertWikiObjectFormat(ctx context.Context, repo *repo_model.Repository, to git.ObjectFormat, replace func(replaceWiki func() error) error) error {
+	exist, err := gitrepo.IsRepositoryExist(ctx, repo.WikiStorageRepo())
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return replace(func() error { return nil })
+	}
+	return gitrepo.ConvertObjectFormat(ctx, repo.WikiStorageRepo(), to, func(_ map[string]string, replaceWiki func() error) error {
+		return replace(replaceWiki)
+	})
+}
+
+// commitIDColumn is a column which stores commit IDs of a repository
+type commitIDColumn struct {
+	table, column string
+	cond          builder.Cond
+}
+
+func commitIDColumns(repoID int64) []commitIDColumn {
+	repoCond := builder.Eq{"repo_id": repoID}
+	issueCond := builder.In("issue_id", builder.Select("id").From("issue").Where(repoCond))
+	pullCond := builder.In("pull_id", builder.Select("id").From("pull_request").Where(builder.Eq{"base_repo_id": repoID}))
+	return []commitIDColumn{
+		{"pull_request", "merge_base", builder.Eq{"base_repo_id": repoID}},
+		{"pull_request", "merged_commit_id", builder.Eq{"base_repo_id": repoID}},
+		{"review_state", "commit_sha", pullCond},
+		{"review", "commit_id", issueCond},
+		{"comment", "commit_sha", issueCond},
+		{"commit_status", "sha", repoCond},
+		{"commit_status_index", "sha", repoCond},
+		{"commit_status_summary", "sha", repoCond},
+		{"release", "sha1", repoCond},
+		{"branch", "commit_id", repoCond},
+		{"language_stat", "commit_id", repoCond},
+		{"repo_archiver", "commit_id", repoCond},
+		{"repo_indexer_status", "commit_sha", repoCond},
+		{"action_run", "commit_sha", repoCond},
+		{"action_run_job", "commit_sha", repoCond},
+		{"action_task", "commit_sha", repoCond},
+	}
+}
+
+// translateCommitIDs replaces the commit IDs stored in the database for the repository with their translations
+func translateCommitIDs(ctx context.Context, repoID int64, translation map[string]string) error {
+	for _, c := range commitIDColumns(repoID) {
+		var rows []struct {
+			ID       int64
+			CommitID string
+		}
+		if err := db.GetEngine(ctx).Table(c.table).Select("id, " + c.column + " AS commit_id").
+			Where(c.cond.And(builder.Neq{c.column: ""})).Find(&rows); err != nil {
+			return fmt.Errorf("find %s.%s: %w", c.table, c.column, err)
+		}
+		for _, row := range rows {
+			newID, ok := translation[row.CommitID]
+			if !ok {
+				continue
+			}
+			if _, err := db.GetEngine(ctx).Table(c.table).Where("id = ?", row.ID).NoAutoTime().Update(map[string]any{c.column: newID}); err != nil {
+				return fmt.Errorf("update %s.%s: %w", c.table, c.column, err)
+			}
+		}
+	}
+
+	// the push comments list the pushed commits
+	var comments []*issues_model.Comment
+	if err := db.GetEngine(ctx).Where(builder.Eq{"type": issues_model.CommentTypePullRequestPush}).
+		And(builder.In("issue_id", builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID}))).
+		Cols("id", "content").Find(&comments); err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		var data issues_model.PushActionContent
+		if err := json.Unmarshal([]byte(comment.Content), &data); err != nil {
+			continue
+		}
+		for i, commitID := range data.CommitIDs {
+			if newID, ok := translation[commitID]; ok {
+				data.CommitIDs[i] = newID
+			}
+		}
+		if newID, ok := translation[data.OldCommitID]; ok {
+			data.OldCommitID = newID
+		}
+		if newID, ok := translation[data.NewCommitID]; ok {
+			data.NewCommitID = newID
+		}
+		content, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Table("comment").Where("id = ?", comment.ID).NoAutoTime().Update(map[string]any{"content": string(content)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
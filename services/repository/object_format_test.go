@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/services/repository/clonebundle"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertObjectFormat(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	assert.Error(t, CheckObjectFormatConversion(repo, git.Sha1ObjectFormat))
+	defer test.MockVariableValue(&git.DefaultFeatures().SupportedObjectFormats, []git.ObjectFormat{git.Sha1ObjectFormat, git.Sha256ObjectFormat})()
+	assert.Error(t, CheckObjectFormatConversion(unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}), git.Sha256ObjectFormat), "a fork")
+
+	bundleStorage, err := storage.NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer test.MockVariableValue(&storage.CloneBundles, bundleStorage)()
+	require.NoError(t, clonebundle.GenerateRepoBundle(t.Context(), repo))
+	oldBundles, err := repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, oldBundles, 1)
+
+	require.NoError(t, ConvertObjectFormat(t.Context(), repo, git.Sha256ObjectFormat))
+
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.Equal(t, "sha256", repo.ObjectFormatName)
+	assert.Equal(t, repo_model.RepositoryReady, repo.Status)
+
+	newID, err := git_model.GetTranslatedObjectID(t.Context(), repo.ID, "65f1bf27")
+	require.NoError(t, err)
+	assert.Len(t, newID, 64)
+	branch := unittest.AssertExistsAndLoadBean(t, &git_model.Branch{ID: 1})
+	assert.Equal(t, newID, branch.CommitID)
+
+	gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetCommit(newID)
+	require.NoError(t, err)
+	assert.Equal(t, "Initial commit", commit.Summary())
+
+	wikiRepo, err := gitrepo.OpenRepository(t.Context(), repo.WikiStorageRepo())
+	require.NoError(t, err)
+	defer wikiRepo.Close()
+	wikiFormat, err := wikiRepo.GetObjectFormat()
+	require.NoError(t, err)
+	assert.Equal(t, git.Sha256ObjectFormat, wikiFormat)
+
+	// the bundle of the old objects is replaced by a full bundle of the converted ones
+	bundles, err := repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.NotEqual(t, oldBundles[0].ID, bundles[0].ID)
+	assert.True(t, bundles[0].IsFull)
+	assert.Contains(t, bundles[0].Tips, newID)
+	_, err = storage.CloneBundles.Stat(oldBundles[0].RelativePath())
+	assert.Error(t, err)
+}
+
+func TestRecoverObjectFormatConversion(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo.Status = repo_model.RepositoryBeingConverted
+	require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo, "status"))
+
+	require.NoError(t, RecoverObjectFormatConversion(t.Context(), repo))
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.Equal(t, repo_model.RepositoryReady, repo.Status)
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/metrics"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrInvalidArguments is returned if the pack-objects hook is called with an unexpected command line
+var ErrInvalidArguments = util.NewInvalidArgumentErrorf("invalid pack-objects arguments")
+
+// parsePackObjectsArgs validates the command line passed by git upload-pack to the pack-objects hook,
+// e.g. "git --shallow-file <empty> pack-objects --revs --thin --stdout --progress --delta-base-offset",
+// and returns the git arguments without the leading "git" and the "--progress" option:
+// the progress can not be passed back to the client through the pack cache
+func parsePackObjectsArgs(hookArgs []string) ([]string, error) {
+	if len(hookArgs) < 2 || hookArgs[0] != "git" {
+		return nil, ErrInvalidArguments
+	}
+	args := hookArgs[1:]
+	ret := make([]string, 0, len(args))
+	if args[0] == "--shallow-file" {
+		// upload-pack passes the shallow commits by the input, the shallow file must be empty
+		if len(args) < 2 || args[1] != "" {
+			return nil, ErrInvalidArguments
+		}
+		ret = append(ret, "--shallow-file", "")
+		args = args[2:]
+	}
+	if len(args) == 0 || args[0] != "pack-objects" {
+		return nil, ErrInvalidArguments
+	}
+	ret = append(ret, "pack-objects")
+	for _, arg := range args[1:] {
+		// only options are accepted, a base name would make pack-objects write the pack to the filesystem
+		if !strings.HasPrefix(arg, "--") {
+			return nil, ErrInvalidArguments
+		}
+		if arg == "--progress" {
+			continue
+		}
+		ret = append(ret, arg)
+	}
+	if !slices.Contains(ret, "--stdout") {
+		return nil, ErrInvalidArguments
+	}
+	return ret, nil
+}
+
+// cacheKey returns the key of a pack, the input contains the wanted and the known objects,
+// so the same input always produces the same pack as long as the objects exist
+func cacheKey(repo gitrepo.Repository, args []string, input []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, repo.RelativePath())
+	for _, arg := range args {
+		_, _ = h.Write([]byte{0})
+		_, _ = io.WriteString(h, arg)
+	}
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(input)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func cachePath(key string) string {
+	return path.Join(key[0:2], key[2:4], key)
+}
+
+// serveFromCache writes the cached pack if it exists and has not expired
+func serveFromCache(key string, w io.Writer) (bool, error) {
+	p := cachePath(key)
+	fi, err := storage.PackCache.Stat(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if time.Since(fi.ModTime()) > setting.PackCache.TTL {
+		return false, nil
+	}
+	obj, err := storage.PackCache.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer obj.Close()
+	if _, err = io.Copy(w, obj); err != nil {
+		return true, err
+	}
+	metrics.IncPackCacheHits()
+	return true, nil
+}
+
+// cacheWriter writes the pack into a temporary file until it grows over the max entry size or the file can't be written,
+// then the pack won't be cached and onDiscard is called. Writing to the cache never fails, so the client still gets the pack.
+type cacheWriter struct {
+	file      *os.File
+	size      int64
+	maxSize   int64
+	onDiscard func()
+}
+
+func (w *cacheWriter) discard() {
+	w.file = nil
+	w.onDiscard()
+}
+
+func (w *cacheWriter) Write(p []byte) (int, error) {
+	if w.file == nil {
+		return len(p), nil
+	}
+	if w.maxSize >= 0 && w.size+int64(len(p)) > w.maxSize {
+		w.discard()
+		return len(p), nil
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	if err != nil {
+		log.Warn("Unable to write the pack to the pack cache file %s: %v", w.file.Name(), err)
+		w.discard()
+	}
+	return len(p), nil
+}
+
+func runPackObjects(ctx context.Context, repo gitrepo.Repository, args []string, input []byte, w io.Writer) error {
+	cmd := gitcmd.NewCommand(gitcmd.ToTrustedCmdArgs(args)...).WithStdinBytes(input).WithStdoutCopy(w)
+	if err := gitrepo.RunCmdWithStderr(ctx, repo, cmd); err != nil {
+		return fmt.Errorf("unable to run pack-objects in %s: %w", repo.RelativePath(), err)
+	}
+	return nil
+}
+
+// ServePack writes the pack for git upload-pack generated by git pack-objects with the hook arguments and the input.
+// If the pack cache is enabled, an identical pack generated recently is served from the cache, and concurrent
+// requests for the same pack wait for the first one to generate it, so a clone storm only packs the objects once.
+func ServePack(ctx context.Context, repo gitrepo.Repository, hookArgs []string, input []byte, w io.Writer) error {
+	args, err := parsePackObjectsArgs(hookArgs)
+	if err != nil {
+		return err
+	}
+	if !setting.PackCache.Enabled {
+		return runPackObjects(ctx, repo, args, input, w)
+	}
+
+	key := cacheKey(repo, args, input)
+	if ok, err := serveFromCache(key, w); ok || err != nil {
+		return err
+	}
+
+	release, err := globallock.Lock(ctx, "pack_cache_"+key)
+	if err != nil {
+		return err
+	}
+	var releaseOnce sync.Once
+	releaseLock := func() { releaseOnce.Do(release) }
+	defer releaseLock()
+
+	// another request may have generated the pack while waiting for the lock
+	if ok, err := serveFromCache(key, w); ok || err != nil {
+		return err
+	}
+	metrics.IncPackCacheMisses()
+
+	tmpFile, cleanup, err := setting.AppDataTempDir("pack-cache").CreateTempFileRandom("pack")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// the pack is streamed to the client while it is being generated, if it is too large to be cached,
+	// the other requests don't need to wait for it anymore
+	cw := &cacheWriter{file: tmpFile, maxSize: setting.PackCache.MaxEntrySize, onDiscard: releaseLock}
+	if err := runPackObjects(ctx, repo, args, input, io.MultiWriter(w, cw)); err != nil {
+		return err
+	}
+	if cw.file == nil {
+		return nil
+	}
+
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := storage.PackCache.Save(cachePath(key), tmpFile, cw.size); err != nil {
+		// the pack has been served, failing to cache it only affects the following requests
+		log.Error("Unable to save pack %s of %s to the pack cache: %v", key, repo.RelativePath(), err)
+	}
+	return nil
+}
+
+// Cleanup deletes the expired packs, then deletes the oldest packs until the pack cache fits in the max size
+func Cleanup(ctx context.Context) error {
+	type cachedPack struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var packs []cachedPack
+	var totalSize int64
+	deleted := 0
+	if err := storage.PackCache.IterateObjects("", func(p string, obj storage.Object) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		if time.Since(fi.ModTime()) > setting.PackCache.TTL {
+			if err := storage.PackCache.Delete(p); err != nil {
+				return err
+			}
+			deleted++
+			return nil
+		}
+		packs = append(packs, cachedPack{path: p, size: fi.Size(), modTime: fi.ModTime()})
+		totalSize += fi.Size()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if setting.PackCache.MaxSize >= 0 && totalSize > setting.PackCache.MaxSize {
+		slices.SortFunc(packs, func(a, b cachedPack) int { return a.modTime.Compare(b.modTime) })
+		for _, pack := range packs {
+			if totalSize <= setting.PackCache.MaxSize {
+				break
+			}
+			if err := storage.PackCache.Delete(pack.path); err != nil {
+				return err
+			}
+			totalSize -= pack.size
+			deleted++
+		}
+	}
+	log.Trace("Deleted %d packs from the pack cache", deleted)
+	return nil
+}
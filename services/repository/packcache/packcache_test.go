@@ -0,0 +1,128 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packcache
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestParsePackObjectsArgs(t *testing.T) {
+	args, err := parsePackObjectsArgs([]string{"git", "pack-objects", "--revs", "--thin", "--stdout", "--progress", "--delta-base-offset"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pack-objects", "--revs", "--thin", "--stdout", "--delta-base-offset"}, args)
+
+	args, err = parsePackObjectsArgs([]string{"git", "--shallow-file", "", "pack-objects", "--revs", "--stdout", "--filter=blob:none"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--shallow-file", "", "pack-objects", "--revs", "--stdout", "--filter=blob:none"}, args)
+
+	for _, hookArgs := range [][]string{
+		{},
+		{"git"},
+		{"sh", "pack-objects", "--stdout"},
+		{"git", "upload-pack", "--stdout"},
+		{"git", "--shallow-file", "/tmp/shallow", "pack-objects", "--stdout"},
+		{"git", "pack-objects", "--revs"},
+		{"git", "pack-objects", "--revs", "/tmp/pack"},
+	} {
+		_, err = parsePackObjectsArgs(hookArgs)
+		assert.ErrorIs(t, err, ErrInvalidArguments, "args: %v", hookArgs)
+	}
+}
+
+func TestCacheWriter(t *testing.T) {
+	tmpFile, err := os.CreateTemp(t.TempDir(), "pack")
+	require.NoError(t, err)
+	discarded := 0
+	cw := &cacheWriter{file: tmpFile, maxSize: 10, onDiscard: func() { discarded++ }}
+
+	var client bytes.Buffer
+	w := io.MultiWriter(&client, cw)
+	_, err = w.Write([]byte("12345"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, cw.size)
+
+	// a failed write to the cache file only discards the cache
+	require.NoError(t, tmpFile.Close())
+	_, err = w.Write([]byte("678"))
+	require.NoError(t, err)
+	assert.Nil(t, cw.file)
+	assert.Equal(t, 1, discarded)
+
+	_, err = w.Write([]byte("90abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890abc", client.String())
+	assert.Equal(t, 1, discarded)
+
+	// a too large pack isn't cached
+	tmpFile, err = os.CreateTemp(t.TempDir(), "pack")
+	require.NoError(t, err)
+	defer tmpFile.Close()
+	cw = &cacheWriter{file: tmpFile, maxSize: 4, onDiscard: func() { discarded++ }}
+	_, err = cw.Write([]byte("12345"))
+	require.NoError(t, err)
+	assert.Nil(t, cw.file)
+	assert.Equal(t, 2, discarded)
+}
+
+func TestServePack(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	packStorage, err := storage.NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer test.MockVariableValue(&storage.PackCache, packStorage)()
+	defer test.MockVariableValue(&setting.PackCache.Enabled, true)()
+
+	hookArgs := []string{"git", "pack-objects", "--revs", "--thin", "--stdout", "--progress", "--delta-base-offset"}
+	input := []byte("65f1bf27bc3bf70f64657658635e66094edbcb4d\n")
+
+	var first bytes.Buffer
+	require.NoError(t, ServePack(t.Context(), repo, hookArgs, input, &first))
+	assert.True(t, bytes.HasPrefix(first.Bytes(), []byte("PACK")))
+
+	args, _ := parsePackObjectsArgs(hookArgs)
+	key := cacheKey(repo, args, input)
+	fi, err := storage.PackCache.Stat(cachePath(key))
+	require.NoError(t, err)
+	assert.EqualValues(t, first.Len(), fi.Size())
+
+	// the second request is served from the cache
+	var second bytes.Buffer
+	require.NoError(t, ServePack(t.Context(), repo, hookArgs, input, &second))
+	assert.Equal(t, first.Bytes(), second.Bytes())
+
+	// the pack is too large to be cached
+	defer test.MockVariableValue(&setting.PackCache.MaxEntrySize, 16)()
+	var third bytes.Buffer
+	require.NoError(t, ServePack(t.Context(), repo, hookArgs, []byte("65f1bf27bc3bf70f64657658635e66094edbcb4d\n\n"), &third))
+	assert.Equal(t, first.Bytes(), third.Bytes())
+	cnt := 0
+	require.NoError(t, storage.PackCache.IterateObjects("", func(string, storage.Object) error {
+		cnt++
+		return nil
+	}))
+	assert.Equal(t, 1, cnt)
+
+	// the cache is cleaned up if it grows over the max size
+	defer test.MockVariableValue(&setting.PackCache.MaxSize, 0)()
+	require.NoError(t, Cleanup(t.Context()))
+	_, err = storage.PackCache.Stat(cachePath(key))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -97,6 +97,10 @@ func pushUpdates(optsList []*repo_module.PushUpdateOptions) error {
 		return fmt.Errorf("Failed to update size for repository: %v", err)
 	}
 
+	if err = repo_model.IncreaseRepoMaintenancePushCount(ctx, repo.ID, int64(len(optsList))); err != nil {
+		log.Error("Failed to update maintenance state of repository %s: %v", repo.FullName(), err)
+	}
+
 	addTags := make([]string, 0, len(optsList))
 	delTags := make([]string, 0, len(optsList))
 	var pusher *user_model.User
@@ -0,0 +1,225 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/mailer"
+)
+
+const (
+	// the issues updated a while before the last check are checked again, because the issue indexer
+	// could index them after the check, the reported issues are never reported again
+	recheckDuration = time.Hour
+	// the maximum number of the new matches reported at once
+	maxNewMatches = 50
+	// the maximum number of the existing matches recorded when subscribing, they are not reported as new matches
+	maxExistingMatches = 1000
+)
+
+// SearchOptions returns the options to search the issues matching the saved search for the user.
+// The repositories of the organization of the saved search, or the ones owned by the user or which the user
+// collaborates on if there is no organization, are searched like the "your repositories" filter of the dashboard.
+// The open issues are searched unless the query has an "is:closed" qualifier.
+func SearchOptions(ctx context.Context, search *issues_model.SavedSearch, user *user_model.User) (*issue_indexer.SearchOptions, error) {
+	query, err := issue_indexer.ParseQuery(search.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, repo_model.SearchRepoOptions{
+		Actor:       user,
+		OwnerID:     util.Iif(search.OrgID > 0, search.OrgID, user.ID),
+		Private:     true,
+		Collaborate: optional.None[bool](),
+		UnitType:    util.Iif(search.IsPull, unit.TypePullRequests, unit.TypeIssues),
+		Archived:    optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(repoIDs) == 0 {
+		// no repos found, don't let the indexer return all repos
+		repoIDs = []int64{0}
+	}
+
+	opts := &issue_indexer.SearchOptions{
+		RepoIDs:  repoIDs,
+		IsPull:   optional.Some(search.IsPull),
+		IsClosed: optional.Some(false),
+		SortBy:   issue_indexer.SortByUpdatedDesc,
+	}
+	if err := query.Apply(ctx, opts, user); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// CreateSavedSearch validates the query and the scope of the saved search and creates it
+func CreateSavedSearch(ctx context.Context, search *issues_model.SavedSearch) error {
+	if _, err := issue_indexer.ParseQuery(search.Query); err != nil {
+		return err
+	}
+	if search.OrgID > 0 {
+		isMember, err := organization.IsOrganizationMember(ctx, search.OrgID, search.OwnerID)
+		if err != nil {
+			return err
+		} else if !isMember {
+			return util.NewInvalidArgumentErrorf("the owner of the saved search is not a member of the organization")
+		}
+	}
+	if search.TeamID > 0 {
+		team, err := organization.GetTeamByID(ctx, search.TeamID)
+		if err != nil {
+			return err
+		}
+		isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, search.OwnerID)
+		if err != nil {
+			return err
+		} else if !isMember {
+			return util.NewInvalidArgumentErrorf("the owner of the saved search is not a member of the team")
+		}
+	}
+	return issues_model.CreateSavedSearch(ctx, search)
+}
+
+// Subscribe subscribes the user to the saved search or updates the subscription,
+// the issues which already match the saved search aren't reported as new matches
+func Subscribe(ctx context.Context, search *issues_model.SavedSearch, user *user_model.User, frequency issues_model.SavedSearchFrequency, method issues_model.SavedSearchNotifyMethod) error {
+	if ok, err := search.CanBeUsedBy(ctx, user); err != nil {
+		return err
+	} else if !ok {
+		return util.NewPermissionDeniedErrorf("the saved search isn't shared with the user")
+	}
+
+	checkedUnix := timeutil.TimeStampNow()
+	opts, err := SearchOptions(ctx, search, user)
+	if err != nil {
+		return err
+	}
+	opts.Paginator = &db.ListOptions{Page: 1, PageSize: maxExistingMatches}
+	issueIDs, _, err := issue_indexer.SearchIssues(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return issues_model.SubscribeSavedSearch(ctx, &issues_model.SavedSearchSubscription{
+		SavedSearchID:   search.ID,
+		UserID:          user.ID,
+		Frequency:       frequency,
+		Method:          method,
+		LastCheckedUnix: checkedUnix,
+	}, issueIDs)
+}
+
+// NotifySubscribers reports the issues which newly match the saved searches to the subscribers notified at the frequency
+func NotifySubscribers(ctx context.Context, frequency issues_model.SavedSearchFrequency) error {
+	subscriptions, err := issues_model.GetSavedSearchSubscriptionsByFrequency(ctx, frequency)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subscriptions {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := notifySubscriber(ctx, sub); err != nil {
+			log.Error("Unable to notify the subscription %d of saved search %d: %v", sub.ID, sub.SavedSearchID, err)
+		}
+	}
+	return nil
+}
+
+func notifySubscriber(ctx context.Context, sub *issues_model.SavedSearchSubscription) error {
+	search, err := issues_model.GetSavedSearchByID(ctx, sub.SavedSearchID)
+	if err != nil {
+		return err
+	}
+	user, err := user_model.GetUserByID(ctx, sub.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive || user.ProhibitLogin {
+		return nil
+	}
+	if ok, err := search.CanBeUsedBy(ctx, user); err != nil {
+		return err
+	} else if !ok {
+		// the user has left the team which the saved search is shared with
+		return issues_model.UnsubscribeSavedSearch(ctx, search.ID, user.ID)
+	}
+
+	checkedUnix := timeutil.TimeStampNow()
+	opts, err := SearchOptions(ctx, search, user)
+	if errors.Is(err, util.ErrInvalidArgument) {
+		// the query could become invalid, e.g. a label in it has been deleted
+		log.Warn("The query of saved search %d is invalid: %v", search.ID, err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	since := int64(sub.LastCheckedUnix.AddDuration(-recheckDuration))
+	if opts.UpdatedAfterUnix.Value() < since {
+		opts.UpdatedAfterUnix = optional.Some(since)
+	}
+	opts.Paginator = &db.ListOptions{Page: 1, PageSize: maxNewMatches}
+
+	issueIDs, _, err := issue_indexer.SearchIssues(ctx, opts)
+	if err != nil {
+		return err
+	}
+	issueIDs, err = issues_model.FilterNewSavedSearchMatches(ctx, sub.ID, issueIDs)
+	if err != nil {
+		return err
+	}
+	if len(issueIDs) > 0 {
+		issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs, true)
+		if err != nil {
+			return err
+		}
+		if err := report(ctx, sub, search, user, issues); err != nil {
+			return err
+		}
+		if err := issues_model.AddSavedSearchMatches(ctx, sub.ID, issueIDs); err != nil {
+			return err
+		}
+	}
+	return issues_model.UpdateSavedSearchSubscriptionLastChecked(ctx, sub.ID, checkedUnix)
+}
+
+func report(ctx context.Context, sub *issues_model.SavedSearchSubscription, search *issues_model.SavedSearch, user *user_model.User, issues issues_model.IssueList) error {
+	switch sub.Method {
+	case issues_model.SavedSearchNotifyMethodEmail:
+		if _, err := issues.LoadRepositories(ctx); err != nil {
+			return err
+		}
+		if err := search.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		return mailer.MailSavedSearchMatches(ctx, user, search, issues, sub.Frequency == issues_model.SavedSearchFrequencyDaily)
+	default:
+		for _, issue := range issues {
+			if err := activities_model.CreateOrUpdateIssueNotifications(ctx, issue.ID, 0, 0, user.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
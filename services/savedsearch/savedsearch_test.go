@@ -0,0 +1,72 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package savedsearch
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSavedSearch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	err := CreateSavedSearch(t.Context(), &issues_model.SavedSearch{OwnerID: 2, Name: "invalid", Query: "is:unknown"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	// user5 isn't a member of org3 nor of its team 2
+	err = CreateSavedSearch(t.Context(), &issues_model.SavedSearch{OwnerID: 5, Name: "org", OrgID: 3})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = CreateSavedSearch(t.Context(), &issues_model.SavedSearch{OwnerID: 5, Name: "team", TeamID: 2})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, CreateSavedSearch(t.Context(), &issues_model.SavedSearch{OwnerID: 4, Name: "team", OrgID: 3, TeamID: 2}))
+}
+
+func TestNotifySubscribers(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	setting.Indexer.IssueType = "db"
+	issue_indexer.InitIssueIndexer(true)
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	search := &issues_model.SavedSearch{OwnerID: user2.ID, Name: "open issues"}
+	require.NoError(t, CreateSavedSearch(t.Context(), search))
+
+	// sharing the saved search with a team is required to subscribe other users
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	err := Subscribe(t.Context(), search, user5, issues_model.SavedSearchFrequencyImmediate, issues_model.SavedSearchNotifyMethodNotification)
+	assert.ErrorIs(t, err, util.ErrPermissionDenied)
+
+	require.NoError(t, Subscribe(t.Context(), search, user2, issues_model.SavedSearchFrequencyImmediate, issues_model.SavedSearchNotifyMethodNotification))
+	sub := unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearchSubscription{SavedSearchID: search.ID, UserID: user2.ID})
+	// the open issue 1 matched when subscribing, so it isn't notified
+	unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearchMatch{SubscriptionID: sub.ID, IssueID: 1})
+
+	require.NoError(t, NotifySubscribers(t.Context(), issues_model.SavedSearchFrequencyImmediate))
+	unittest.AssertNotExistsBean(t, &activities_model.Notification{UserID: user2.ID, IssueID: 1})
+
+	// pretend that issue 1 newly matches the saved search
+	_, err = db.GetEngine(t.Context()).Delete(&issues_model.SavedSearchMatch{SubscriptionID: sub.ID, IssueID: 1})
+	require.NoError(t, err)
+	_, err = db.GetEngine(t.Context()).ID(1).Cols("updated_unix").NoAutoTime().Update(&issues_model.Issue{UpdatedUnix: timeutil.TimeStampNow()})
+	require.NoError(t, err)
+
+	// the daily digests aren't sent with the immediate notifications
+	require.NoError(t, NotifySubscribers(t.Context(), issues_model.SavedSearchFrequencyDaily))
+	unittest.AssertNotExistsBean(t, &activities_model.Notification{UserID: user2.ID, IssueID: 1})
+
+	require.NoError(t, NotifySubscribers(t.Context(), issues_model.SavedSearchFrequencyImmediate))
+	unittest.AssertExistsAndLoadBean(t, &activities_model.Notification{UserID: user2.ID, IssueID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearchMatch{SubscriptionID: sub.ID, IssueID: 1})
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"context"
+	"fmt"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// convertObjectFormatPayload is the payload of the task converting a repository to another object format
+type convertObjectFormatPayload struct {
+	ObjectFormat string `json:"object_format"`
+}
+
+// ConvertRepositoryObjectFormat adds the conversion of the repository to the object format to the tasks
+func ConvertRepositoryObjectFormat(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, to git.ObjectFormat) error {
+	if err := repo_service.CheckObjectFormatConversion(repo, to); err != nil {
+		return err
+	}
+	if task, err := admin_model.GetLatestRepoTask(ctx, repo.ID, structs.TaskTypeConvertObjectFormat); err == nil &&
+		(task.Status == structs.TaskStatusQueued || task.Status == structs.TaskStatusRunning) {
+		return nil
+	} else if err != nil && !admin_model.IsErrTaskDoesNotExist(err) {
+		return err
+	}
+
+	bs, err := json.Marshal(&convertObjectFormatPayload{ObjectFormat: to.Name()})
+	if err != nil {
+		return err
+	}
+	task := &admin_model.Task{
+		DoerID:         doer.ID,
+		OwnerID:        repo.OwnerID,
+		RepoID:         repo.ID,
+		Type:           structs.TaskTypeConvertObjectFormat,
+		Status:         structs.TaskStatusQueued,
+		PayloadContent: string(bs),
+	}
+	if err := admin_model.CreateTask(ctx, task); err != nil {
+		return err
+	}
+	return taskQueue.Push(task)
+}
+
+// recoverConvertObjectFormatTasks recovers the repositories whose conversion was interrupted, e.g. by a crash,
+// and fails their tasks, so the conversion can be started again
+func recoverConvertObjectFormatTasks(ctx context.Context) error {
+	repos, err := repo_model.GetRepositoriesByStatus(ctx, repo_model.RepositoryBeingConverted)
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		if err := repo_service.RecoverObjectFormatConversion(ctx, repo); err != nil {
+			return err
+		}
+		task, err := admin_model.GetLatestRepoTask(ctx, repo.ID, structs.TaskTypeConvertObjectFormat)
+		if err != nil {
+			if admin_model.IsErrTaskDoesNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if task.Status != structs.TaskStatusRunning {
+			continue
+		}
+		task.EndTime = timeutil.TimeStampNow()
+		task.Status = structs.TaskStatusFailed
+		task.Message = "the conversion was interrupted"
+		if err := task.UpdateCols(ctx, "status", "message", "end_time"); err != nil {
+			return err
+		}
+		log.Warn("The interrupted conversion of %-v has been recovered", repo)
+	}
+	return nil
+}
+
+func runConvertObjectFormatTask(ctx context.Context, t *admin_model.Task) (err error) {
+	defer func(ctx context.Context) {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("PANIC whilst trying to do convert object format task: %v", e)
+			log.Critical("PANIC during runConvertObjectFormatTask[%d] by DoerID[%d] to RepoID[%d]: %v\nStacktrace: %v", t.ID, t.DoerID, t.RepoID, e, log.Stack(2))
+		}
+		t.EndTime = timeutil.TimeStampNow()
+		t.Status = structs.TaskStatusFinished
+		if err != nil {
+			log.Error("runConvertObjectFormatTask[%d] by DoerID[%d] to RepoID[%d] failed: %v", t.ID, t.DoerID, t.RepoID, err)
+			t.Status = structs.TaskStatusFailed
+			t.Message = err.Error()
+		}
+		if err := t.UpdateCols(ctx, "status", "message", "end_time"); err != nil {
+			log.Error("Task UpdateCols failed: %v", err)
+		}
+	}(graceful.GetManager().ShutdownContext()) // even if the parent ctx is canceled, this defer-function still needs to update the task record in database
+
+	if err = t.LoadRepo(ctx); err != nil {
+		return err
+	}
+	var payload convertObjectFormatPayload
+	if err = json.Unmarshal([]byte(t.PayloadContent), &payload); err != nil {
+		return err
+	}
+
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("ConvertObjectFormatTask: %s to %s", t.Repo.FullName(), payload.ObjectFormat))
+	defer finished()
+
+	t.StartTime = timeutil.TimeStampNow()
+	t.Status = structs.TaskStatusRunning
+	if err = t.UpdateCols(ctx, "start_time", "status"); err != nil {
+		return err
+	}
+	return repo_service.ConvertObjectFormat(ctx, t.Repo, git.ObjectFormatFromName(payload.ObjectFormat))
+}
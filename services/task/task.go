@@ -32,6 +32,8 @@ func Run(ctx context.Context, t *admin_model.Task) error {
 	switch t.Type {
 	case structs.TaskTypeMigrateRepo:
 		return runMigrateTask(ctx, t)
+	case structs.TaskTypeConvertObjectFormat:
+		return runConvertObjectFormatTask(ctx, t)
 	default:
 		return fmt.Errorf("Unknown task type: %d", t.Type)
 	}
@@ -39,6 +41,9 @@ func Run(ctx context.Context, t *admin_model.Task) error {
 
 // Init will start the service to get all unfinished tasks and run them
 func Init() error {
+	if err := recoverConvertObjectFormatTasks(graceful.GetManager().ShutdownContext()); err != nil {
+		return err
+	}
 	taskQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "task", handler)
 	if taskQueue == nil {
 		return errors.New("unable to create task queue")
@@ -183,6 +183,10 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		return fmt.Errorf("clear assignee: %w", err)
 	}
 
+	if err = issues_model.DeleteSavedSearchesByUser(ctx, u.ID); err != nil {
+		return fmt.Errorf("DeleteSavedSearchesByUser: %w", err)
+	}
+
 	// ***** START: ExternalLoginUser *****
 	if err = user_model.RemoveAllAccountLinks(ctx, u); err != nil {
 		return fmt.Errorf("ExternalLoginUser: %w", err)
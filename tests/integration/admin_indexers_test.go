@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	indexer_service "code.gitea.io/gitea/services/indexer"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminIndexers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	session := loginUser(t, "user1")
+
+	t.Run("Page", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/-/admin/monitor/indexers?q="+repo.Name)
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		var names []string
+		htmlDoc.doc.Find("[hx-get] table tbody tr").Each(func(_ int, row *goquery.Selection) {
+			names = append(names, row.Find("td").First().Text())
+		})
+		assert.Equal(t, []string{"code", "issues", "contents"}, names)
+		assert.Contains(t, htmlDoc.doc.Find(".admin-setting-content > .table.segment").Last().Text(), repo.FullName())
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/-/admin/monitor/indexers/status")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, 3, NewHTMLParser(t, resp.Body).doc.Find("tbody tr").Length())
+	})
+
+	t.Run("ReindexRepo", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/-/admin/monitor/indexers/reindex", map[string]string{
+			"indexer": "issues",
+			"repo_id": "1",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		flashMsg := session.GetCookieFlashMessage()
+		assert.Contains(t, flashMsg.SuccessMsg, repo.FullName())
+
+		req = NewRequestWithValues(t, "POST", "/-/admin/monitor/indexers/reindex", map[string]string{
+			"indexer": "unknown",
+			"repo_id": "1",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		assert.NotEmpty(t, session.GetCookieFlashMessage().ErrorMsg)
+	})
+
+	t.Run("ReindexAll", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/-/admin/monitor/indexers/reindex", map[string]string{
+			"indexer": "issues",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		assert.NotEmpty(t, session.GetCookieFlashMessage().SuccessMsg)
+
+		assert.Eventually(t, func() bool {
+			progress := indexer_service.GetReindexProgress("issues")
+			return progress != nil && !progress.IsRunning()
+		}, 10*time.Second, 100*time.Millisecond)
+		progress := indexer_service.GetReindexProgress("issues")
+		assert.EqualValues(t, progress.Total, progress.Queued+progress.Failed)
+		assert.Equal(t, 100, progress.Percent())
+
+		// the queued repositories are indexed later, the remaining depth of the queue is shown
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/monitor/indexers"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "left in the queue to be indexed")
+	})
+
+	t.Run("NoPermission", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/-/admin/monitor/indexers")
+		loginUser(t, "user2").MakeRequest(t, req, http.StatusForbidden)
+	})
+}
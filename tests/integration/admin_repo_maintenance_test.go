@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminRepoMaintenance(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo_model.IncreaseRepoMaintenancePushCount(t.Context(), repo.ID, 1))
+	require.NoError(t, repo_service.GitMaintenanceRepo(t.Context(), repo, &repo_service.MaintenanceOptions{PushThreshold: 1}))
+
+	session := loginUser(t, "user1")
+	req := NewRequest(t, "GET", "/-/admin/repos/maintenance")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	row := htmlDoc.doc.Find("table tbody tr").First()
+	assert.Equal(t, repo.FullName(), row.Find("td a").First().Text())
+	assert.Contains(t, row.Text(), "commit-graph")
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminSSHUserCAs(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const caKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKwn0Dq+VkDVvVI7kKsF/C2CIEdKvqc6FEbPAZ9AnQU9"
+
+	session := loginUser(t, "user1")
+	req := NewRequestWithValues(t, "POST", "/-/admin/ssh_user_cas", map[string]string{
+		"name":              "corp",
+		"content":           caKey + " corp-ca",
+		"principal_mapping": "email",
+		"max_validity":      "3600",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	ca := unittest.AssertExistsAndLoadBean(t, &asymkey_model.TrustedUserCAKey{Name: "corp"})
+	assert.Equal(t, caKey, ca.Content)
+	assert.Equal(t, asymkey_model.PrincipalMappingEmail, ca.PrincipalMapping)
+	assert.EqualValues(t, 3600, ca.MaxValidity)
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/ssh_user_cas"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), ca.Fingerprint)
+
+	// the same key can't be added twice
+	req = NewRequestWithValues(t, "POST", "/-/admin/ssh_user_cas", map[string]string{
+		"name":              "other",
+		"content":           caKey,
+		"principal_mapping": "username",
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &asymkey_model.TrustedUserCAKey{Name: "other"})
+
+	req = NewRequest(t, "POST", fmt.Sprintf("/-/admin/ssh_user_cas/delete?id=%d", ca.ID))
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &asymkey_model.TrustedUserCAKey{ID: ca.ID})
+
+	// only the admins can manage the certificate authorities
+	session = loginUser(t, "user2")
+	session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/ssh_user_cas"), http.StatusForbidden)
+}
@@ -23,6 +23,7 @@ import (
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAPIListIssues(t *testing.T) {
@@ -420,3 +421,40 @@ func TestAPISearchIssuesWithLabels(t *testing.T) {
 	DecodeJSON(t, resp, &apiIssues)
 	assert.Len(t, apiIssues, 2)
 }
+
+func TestAPISearchIssuesWithQuery(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadIssue)
+	search := func(q string, expectedStatus int) []*api.Issue {
+		link := "/api/v1/repos/issues/search?state=all&q=" + url.QueryEscape(q)
+		resp := MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(token), expectedStatus)
+		var apiIssues []*api.Issue
+		if expectedStatus == http.StatusOK {
+			DecodeJSON(t, resp, &apiIssues)
+		}
+		return apiIssues
+	}
+
+	apiIssues := search("is:closed author:user2", http.StatusOK)
+	require.NotEmpty(t, apiIssues)
+	for _, issue := range apiIssues {
+		assert.Equal(t, api.StateClosed, issue.State)
+		assert.Equal(t, "user2", issue.Poster.UserName)
+	}
+
+	apiIssues = search("author:@me assignee:user1", http.StatusOK)
+	for _, issue := range apiIssues {
+		assert.Equal(t, "user2", issue.Poster.UserName)
+	}
+
+	search("updated:yesterday", http.StatusUnprocessableEntity)
+	search("author:no-such-user", http.StatusUnprocessableEntity)
+
+	// the repository issue list accepts the query too
+	link := "/api/v1/repos/user2/repo1/issues?state=all&q=" + url.QueryEscape("label:label1 milestone:milestone1")
+	resp := MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(token), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssues)
+	require.Len(t, apiIssues, 1)
+	assert.EqualValues(t, 2, apiIssues[0].Index)
+}
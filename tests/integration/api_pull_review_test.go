@@ -381,7 +381,7 @@ func TestAPIPullReviewCommentResolveEndpoints(t *testing.T) {
 	latestCommitID, err := gitRepo.GetRefCommitID(pullIssue.PullRequest.GetGitHeadRefName())
 	require.NoError(t, err)
 
-	codeComment, err := pull_service.CreateCodeComment(ctx, doer, gitRepo, pullIssue, 1, "resolve comment", "README.md", false, 0, latestCommitID, nil)
+	codeComment, err := pull_service.CreateCodeComment(ctx, doer, gitRepo, pullIssue, 0, 1, "resolve comment", "README.md", false, 0, latestCommitID, nil)
 	require.NoError(t, err)
 	require.NotNil(t, codeComment)
 
@@ -561,3 +561,48 @@ func reviewsCountCheck(t *testing.T, name string, issueID, reviewerID int64, exp
 		}, approvalCount)
 	})
 }
+
+func TestAPIPullReviewMultiLineComment(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	pullIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	assert.NoError(t, pullIssue.LoadAttributes(t.Context()))
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pullIssue.RepoID})
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+	reviewsURL := fmt.Sprintf("/api/v1/repos/%s/%s/pulls/%d/reviews", repo.OwnerName, repo.Name, pullIssue.Index)
+
+	// the first line must be before the line on the same side of the diff
+	for _, c := range []api.CreatePullReviewComment{
+		{Path: "README.md", Body: "a", NewStartLineNum: 2, NewLineNum: 2},
+		{Path: "README.md", Body: "a", NewStartLineNum: 3, NewLineNum: 2},
+		{Path: "README.md", Body: "a", NewStartLineNum: 1, OldLineNum: 2},
+		{Path: "README.md", Body: "a", OldStartLineNum: 1, NewLineNum: 2},
+		{Path: "README.md", Body: "a", NewStartLineNum: -1, NewLineNum: 2},
+	} {
+		req := NewRequestWithJSON(t, http.MethodPost, reviewsURL, &api.CreatePullReviewOptions{
+			Event:    "COMMENT",
+			Comments: []api.CreatePullReviewComment{c},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	}
+
+	req := NewRequestWithJSON(t, http.MethodPost, reviewsURL, &api.CreatePullReviewOptions{
+		Event: "COMMENT",
+		Comments: []api.CreatePullReviewComment{
+			{Path: "README.md", Body: "first two new lines", NewStartLineNum: 1, NewLineNum: 2},
+		},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var review api.PullReview
+	DecodeJSON(t, resp, &review)
+	assert.Equal(t, 1, review.CodeCommentsCount)
+
+	req = NewRequestf(t, http.MethodGet, "%s/%d/comments", reviewsURL, review.ID).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var reviewComments []*api.PullReviewComment
+	DecodeJSON(t, resp, &reviewComments)
+	require.Len(t, reviewComments, 1)
+	assert.EqualValues(t, 1, reviewComments[0].StartLineNum)
+	assert.EqualValues(t, 2, reviewComments[0].LineNum)
+}
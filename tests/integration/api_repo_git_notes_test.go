@@ -44,3 +44,60 @@ func TestAPIReposGitNotes(t *testing.T) {
 		assert.NotNil(t, apiData.Commit.RepoCommit.Verification)
 	})
 }
+
+func TestAPIReposGitNotesWrite(t *testing.T) {
+	onGiteaRun(t, func(*testing.T, *url.URL) {
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, user.Name)
+		readToken := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeReadRepository)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		const sha = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+
+		req := NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=ci", &api.CreateNoteOption{Message: "build: ok"}).
+			AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=a..b", &api.CreateNoteOption{Message: "build: ok"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// create a note in a new namespace
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=ci", &api.CreateNoteOption{Message: "build: ok"}).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var apiNote api.Note
+		DecodeJSON(t, resp, &apiNote)
+		assert.Equal(t, "refs/notes/ci", apiNote.Ref)
+		assert.Equal(t, "build: ok\n", apiNote.Message)
+		assert.Equal(t, user.NewGitSig().Name, apiNote.Commit.RepoCommit.Author.Name)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/git/notes").AddTokenAuth(readToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var refs []*api.NotesRef
+		DecodeJSON(t, resp, &refs)
+		assert.Equal(t, []*api.NotesRef{{Name: "ci", Ref: "refs/notes/ci"}, {Name: "commits", Ref: "refs/notes/commits"}}, refs)
+
+		// update the note
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=refs/notes/ci", &api.CreateNoteOption{Message: "build: failed"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=ci").AddTokenAuth(readToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &apiNote)
+		assert.Equal(t, "build: failed\n", apiNote.Message)
+
+		// the default namespace is not changed
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/git/notes/"+sha).AddTokenAuth(readToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &apiNote)
+		assert.Equal(t, "This is a test note\n", apiNote.Message)
+
+		// delete the note
+		req = NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=ci").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=ci").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/git/notes/"+sha+"?ref=ci").AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -6,18 +6,26 @@ package integration
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/lfs"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAPILFSLocksNotStarted(t *testing.T) {
@@ -179,3 +187,122 @@ func TestAPILFSLocksLogged(t *testing.T) {
 		assert.Empty(t, lfsLocks.Locks)
 	}
 }
+
+func TestLFSLocksEnforced(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, _ *url.URL) {
+		defer test.MockVariableValue(&setting.LFS.StartServer, true)()
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2}) // admin of repo3
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4}) // writer of repo3
+		repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+		lock2, err := git_model.CreateLFSLock(t.Context(), repo3, &git_model.LFSLock{OwnerID: user2.ID, Path: "other.bin"})
+		require.NoError(t, err)
+		lock4, err := git_model.CreateLFSLock(t.Context(), repo3, &git_model.LFSLock{OwnerID: user4.ID, Path: "locked.bin"})
+		require.NoError(t, err)
+
+		// the locks aren't enforced by default
+		_, err = createFileInBranch(user2, repo3, createFileInBranchOptions{OldBranch: "master"}, map[string]string{"locked.bin": "a"})
+		require.NoError(t, err)
+
+		session2 := loginUser(t, user2.Name)
+		session2.MakeRequest(t, NewRequestWithValues(t, "POST", "/org3/repo3/settings/lfs/locks/enforce", map[string]string{
+			"enforce": "on",
+		}), http.StatusSeeOther)
+		repo3 = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+		assert.True(t, repo3.EnforceLFSLocks)
+
+		_, err = deleteFileInBranch(user2, repo3, "locked.bin", "master")
+		require.Error(t, err)
+		require.True(t, git.IsErrPushRejected(err))
+		assert.Contains(t, err.(*git.ErrPushRejected).Message, "file locked.bin is locked by user4")
+
+		// the owner of the lock can change the file
+		_, err = deleteFileInBranch(user4, repo3, "locked.bin", "master")
+		require.NoError(t, err)
+
+		// only the administrators can break the locks of other users
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/%s.git/info/lfs/locks/%d/unlock", repo3.FullName(), lock2.ID), map[string]bool{"force": true})
+		req.Header.Set("Accept", lfs.AcceptHeader)
+		req.Header.Set("Content-Type", lfs.MediaType)
+		loginUser(t, user4.Name).MakeRequest(t, req, http.StatusForbidden)
+		unittest.AssertExistsAndLoadBean(t, &git_model.LFSLock{ID: lock2.ID})
+
+		session2.MakeRequest(t, NewRequestWithValues(t, "POST", fmt.Sprintf("/org3/repo3/settings/lfs/locks/%d/unlock", lock4.ID), map[string]string{
+			"reason": "not needed anymore",
+		}), http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &git_model.LFSLock{ID: lock4.ID})
+		unittest.AssertExistsAndLoadBean(t, &git_model.LFSLockBreak{RepoID: repo3.ID, Path: "locked.bin", OwnerID: user4.ID, BreakerID: user2.ID, Reason: "not needed anymore"})
+
+		resp := session2.MakeRequest(t, NewRequest(t, "GET", "/org3/repo3/settings/lfs/locks"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "not needed anymore")
+	})
+}
+
+func TestLFSLocksEnforcedPush(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		defer test.MockVariableValue(&setting.LFS.StartServer, true)()
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		u.Path = repo1.FullName() + ".git"
+		u.User = url.UserPassword("user2", userPassword)
+		dstPath := t.TempDir()
+		doGitClone(dstPath, u)(t)
+
+		// the branches are pushed before the file is locked
+		doGitCheckoutWriteFileCommit(localGitAddCommitOptions{LocalRepoPath: dstPath, CheckoutBranch: "master", TreeFilePath: "locked.bin", TreeFileContent: "a"})(t)
+		doGitPushTestRepository(dstPath, "origin", "master")(t)
+		doGitCreateBranch(dstPath, "locked-change")(t)
+		doGitCheckoutWriteFileCommit(localGitAddCommitOptions{LocalRepoPath: dstPath, CheckoutBranch: "locked-change", TreeFilePath: "locked.bin", TreeFileContent: "b"})(t)
+		doGitPushTestRepository(dstPath, "origin", "locked-change")(t)
+		doGitCheckoutBranch(dstPath, "master")(t)
+		doGitCreateBranch(dstPath, "side")(t)
+		doGitCheckoutWriteFileCommit(localGitAddCommitOptions{LocalRepoPath: dstPath, CheckoutBranch: "side", TreeFilePath: "side.txt", TreeFileContent: "side"})(t)
+		doGitPushTestRepository(dstPath, "origin", "side")(t)
+
+		_, err := git_model.CreateLFSLock(t.Context(), repo1, &git_model.LFSLock{OwnerID: user4.ID, Path: "locked.bin"})
+		require.NoError(t, err)
+		repo1.EnforceLFSLocks = true
+		require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo1, "enforce_lfs_locks"))
+
+		resetMaster := func(t *testing.T) {
+			doGitCheckoutBranch(dstPath, "-f", "master")(t)
+			_, _, err := gitcmd.NewCommand("reset", "--hard", "origin/master").WithDir(dstPath).RunStdString(t.Context())
+			require.NoError(t, err)
+		}
+
+		t.Run("NewCommit", func(t *testing.T) {
+			resetMaster(t)
+			doGitCheckoutWriteFileCommit(localGitAddCommitOptions{LocalRepoPath: dstPath, CheckoutBranch: "master", TreeFilePath: "locked.bin", TreeFileContent: "d"})(t)
+			doGitPushTestRepositoryFail(dstPath, "origin", "master")(t)
+		})
+
+		t.Run("MergeCommit", func(t *testing.T) {
+			resetMaster(t)
+			// the merge commit changes the locked file itself
+			_, _, err := gitcmd.NewCommand("merge", "--no-ff", "--no-commit", "side").WithDir(dstPath).RunStdString(t.Context())
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(dstPath, "locked.bin"), []byte("c"), 0o644))
+			require.NoError(t, git.AddChanges(t.Context(), dstPath, true))
+			signature := git.Signature{Email: "test@test.test", Name: "test"}
+			require.NoError(t, git.CommitChanges(t.Context(), dstPath, git.CommitChangesOptions{Committer: &signature, Author: &signature, Message: "merge side"}))
+			doGitPushTestRepositoryFail(dstPath, "origin", "master")(t)
+		})
+
+		t.Run("Allowed", func(t *testing.T) {
+			resetMaster(t)
+			// the commit changing the locked file was pushed to another branch before the file was locked
+			doGitMerge(dstPath, "--ff-only", "locked-change")(t)
+			doGitPushTestRepository(dstPath, "origin", "master")(t)
+			// a new branch contains the locked file but doesn't change it
+			doGitPushTestRepository(dstPath, "origin", "master:refs/heads/new-branch")(t)
+		})
+
+		t.Run("MergeDefaultBranch", func(t *testing.T) {
+			// merging the default branch which changed the locked file into a branch doesn't change the file itself
+			doGitCheckoutBranch(dstPath, "side")(t)
+			doGitMerge(dstPath, "--no-ff", "-m", "merge master", "master")(t)
+			doGitPushTestRepository(dstPath, "origin", "side")(t)
+		})
+	})
+}
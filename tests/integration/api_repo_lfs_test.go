@@ -5,7 +5,9 @@ package integration
 
 import (
 	"bytes"
+	"io"
 	"net/http"
+	"net/url"
 	"path"
 	"strconv"
 	"strings"
@@ -18,6 +20,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
@@ -430,6 +433,130 @@ func TestAPILFSUpload(t *testing.T) {
 	})
 }
 
+func TestAPILFSMultipartUpload(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	setting.LFS.StartServer = true
+	defer test.MockVariableValue(&setting.LFS.MultipartPartSize, 4)()
+
+	repo := createLFSTestRepository(t, "lfs-multipart-repo")
+	session := loginUser(t, "user2")
+
+	content := []byte("0123456789")
+	p, err := lfs.GeneratePointer(bytes.NewReader(content))
+	require.NoError(t, err)
+
+	batch := func(t *testing.T, p lfs.Pointer) *lfs.ObjectResponse {
+		req := NewRequestWithJSON(t, "POST", "/user2/lfs-multipart-repo.git/info/lfs/objects/batch", &lfs.BatchRequest{
+			Operation: "upload",
+			Transfers: []string{"basic", "multipart"},
+			Objects:   []lfs.Pointer{p},
+		}).SetHeader("Accept", lfs.AcceptHeader).SetHeader("Content-Type", lfs.MediaType)
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		var br lfs.BatchResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &br))
+		assert.Equal(t, "multipart", br.Transfer)
+		require.Len(t, br.Objects, 1)
+		return br.Objects[0]
+	}
+	linkPath := func(t *testing.T, href string) string {
+		u, err := url.Parse(href)
+		require.NoError(t, err)
+		return u.Path
+	}
+	commit := func(t *testing.T, object *lfs.ObjectResponse, expectedStatus int) {
+		req := NewRequestWithJSON(t, "POST", linkPath(t, object.Actions["commit"].Href), object.Pointer).
+			SetHeader("Accept", lfs.AcceptHeader).
+			SetHeader("Content-Type", lfs.MediaType)
+		session.MakeRequest(t, req, expectedStatus)
+	}
+	// the committed objects are verified in the background
+	flushVerification := func(t *testing.T) {
+		require.NoError(t, queue.GetManager().FlushAll(t.Context(), 0))
+	}
+
+	t.Run("Resume", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		object := batch(t, p)
+		assert.NotContains(t, object.Actions, "upload")
+		assert.Contains(t, object.Actions, "verify")
+		require.NotNil(t, object.Multipart)
+		require.Len(t, object.Multipart.Parts, 3)
+		assert.EqualValues(t, 4, object.Multipart.PartSize)
+
+		// the upload is interrupted after the first and the last parts
+		for _, part := range []*lfs.PartLink{object.Multipart.Parts[0], object.Multipart.Parts[2]} {
+			req := NewRequestWithBody(t, "PUT", linkPath(t, part.Href), bytes.NewReader(content[part.Pos:part.Pos+part.Size]))
+			session.MakeRequest(t, req, http.StatusOK)
+		}
+		commit(t, object, http.StatusUnprocessableEntity)
+
+		// only the missing part is requested when the upload is resumed
+		object = batch(t, p)
+		require.Len(t, object.Multipart.Parts, 1)
+		part := object.Multipart.Parts[0]
+		assert.Equal(t, 2, part.Number)
+		req := NewRequestWithBody(t, "PUT", linkPath(t, part.Href), bytes.NewReader(content[part.Pos:part.Pos+part.Size-1]))
+		session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+		req = NewRequestWithBody(t, "PUT", linkPath(t, part.Href), bytes.NewReader(content[part.Pos:part.Pos+part.Size]))
+		session.MakeRequest(t, req, http.StatusOK)
+		commit(t, object, http.StatusAccepted)
+
+		// the client waits for the verification by committing the upload again
+		flushVerification(t)
+		commit(t, object, http.StatusOK)
+
+		meta, err := git_model.GetLFSMetaObjectByOid(t.Context(), repo.ID, p.Oid)
+		require.NoError(t, err)
+		assert.Equal(t, p.Size, meta.Size)
+		obj, err := lfs.NewContentStore().Get(p)
+		require.NoError(t, err)
+		defer obj.Close()
+		stored, err := io.ReadAll(obj)
+		require.NoError(t, err)
+		assert.Equal(t, content, stored)
+		unittest.AssertNotExistsBean(t, &git_model.LFSUpload{RepoID: repo.ID, Pointer: lfs.Pointer{Oid: p.Oid}})
+
+		object = batch(t, p)
+		assert.Empty(t, object.Actions)
+		assert.Nil(t, object.Multipart)
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		p := lfs.Pointer{Oid: "d6f175817f886ec6fbbc1515326465fa96c3bfd54a4ea06cfd6dbbd8340e0153", Size: 3}
+		object := batch(t, p)
+		require.Len(t, object.Multipart.Parts, 1)
+		req := NewRequestWithBody(t, "PUT", linkPath(t, object.Multipart.Parts[0].Href), strings.NewReader("abc"))
+		session.MakeRequest(t, req, http.StatusOK)
+		commit(t, object, http.StatusAccepted)
+		flushVerification(t)
+		commit(t, object, http.StatusNotFound)
+
+		_, err := git_model.GetLFSMetaObjectByOid(t.Context(), repo.ID, p.Oid)
+		assert.ErrorIs(t, err, git_model.ErrLFSObjectNotExist)
+		unittest.AssertNotExistsBean(t, &git_model.LFSUpload{RepoID: repo.ID, Pointer: lfs.Pointer{Oid: p.Oid}})
+		exists, err := lfs.NewContentStore().Exists(p)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		p := lfs.Pointer{Oid: "d6f175817f886ec6fbbc1515326465fa96c3bfd54a4ea06cfd6dbbd8340e0153", Size: 3}
+		object := batch(t, p)
+		req := NewRequestWithBody(t, "PUT", linkPath(t, object.Multipart.Parts[0].Href), strings.NewReader("abc"))
+		session.MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "DELETE", linkPath(t, object.Actions["abort"].Href))
+		session.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertNotExistsBean(t, &git_model.LFSUpload{RepoID: repo.ID, Pointer: lfs.Pointer{Oid: p.Oid}})
+		session.MakeRequest(t, NewRequest(t, "DELETE", linkPath(t, object.Actions["abort"].Href)), http.StatusNotFound)
+	})
+}
+
 func TestAPILFSVerify(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExploreFiles(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Indexer.IncludePatterns, nil)()
+	defer test.MockVariableValue(&setting.Indexer.ExcludePatterns, nil)()
+
+	repo, err := repo_model.GetRepositoryByOwnerAndName(t.Context(), "org42", "search-by-path")
+	require.NoError(t, err)
+	code_indexer.UpdateRepoIndexer(repo)
+
+	const commitLink = "/org42/search-by-path/src/commit/9f894b61946fd2f7b8b9d8e370e4d62f915522f5/"
+	resultPaths := func(t *testing.T, link string) []string {
+		resp := MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+		var paths []string
+		NewHTMLParser(t, resp.Body).Find(".file-path-search-results a.full-path").Each(func(_ int, a *goquery.Selection) {
+			paths = append(paths, strings.TrimPrefix(a.AttrOr("href", ""), commitLink))
+		})
+		return paths
+	}
+
+	t.Run("Explore", func(t *testing.T) {
+		assert.Equal(t, []string{"potato/ham.md"}, resultPaths(t, "/explore/files?q=pthm"))
+		assert.Equal(t, []string{"ham.md", "potato/ham.md"}, resultPaths(t, "/explore/files?q=ham&org=org42&ext=md"))
+		assert.Empty(t, resultPaths(t, "/explore/files?q=ham&org=org42&ext=js"))
+		assert.Empty(t, resultPaths(t, "/explore/files?q=ham&org=user2"))
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/explore/files?q=pthm"), http.StatusOK)
+		matched := NewHTMLParser(t, resp.Body).Find(".file-path-search-results a.full-path span:nth-child(even)")
+		assert.Equal(t, "pthm", matched.Text())
+	})
+
+	t.Run("API", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/search/files?q=ham&org=org42&extension=.md")
+		resp := MakeRequest(t, req, http.StatusOK)
+		var results []*api.FileSearchResult
+		DecodeJSON(t, resp, &results)
+		require.Len(t, results, 2)
+		assert.Equal(t, "org42/search-by-path", results[0].Repository.FullName)
+		assert.Equal(t, "ham.md", results[0].Path)
+		assert.Equal(t, []string{"refs/heads/master"}, results[0].Refs)
+		assert.Equal(t, setting.AppURL+strings.TrimPrefix(commitLink, "/")+"ham.md", results[0].HTMLURL)
+		assert.Equal(t, "potato/ham.md", results[1].Path)
+		assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+		assert.Equal(t, "false", resp.Header().Get("X-Results-Truncated"))
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/search/files"), http.StatusUnprocessableEntity)
+	})
+}
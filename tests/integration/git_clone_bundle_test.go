@@ -0,0 +1,73 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/services/repository/clonebundle"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitCloneBundle(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	bundleStorage, err := storage.NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer test.MockVariableValue(&storage.CloneBundles, bundleStorage)()
+	defer test.MockVariableValue(&setting.CloneBundle.Enabled, true)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	// no bundle, no capability
+	req := NewRequest(t, "GET", "/user2/repo1.git/info/refs?service=git-upload-pack").SetHeader("Git-Protocol", "version=2")
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "version 2\n")
+	assert.NotContains(t, resp.Body.String(), "bundle-uri")
+
+	require.NoError(t, clonebundle.GenerateRepoBundle(t.Context(), repo))
+	bundles, err := repo_model.GetCloneBundles(t.Context(), repo.ID)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	bundleLink := fmt.Sprintf("/user2/repo1.git/bundles/%d.bundle", bundles[0].ID)
+
+	req = NewRequest(t, "GET", "/user2/repo1.git/info/refs?service=git-upload-pack").SetHeader("Git-Protocol", "version=2")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.True(t, strings.HasSuffix(resp.Body.String(), "000fbundle-uri\n0000"), resp.Body.String())
+
+	// protocol v0 doesn't support bundle-uri
+	req = NewRequest(t, "GET", "/user2/repo1.git/info/refs?service=git-upload-pack")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "bundle-uri")
+
+	req = NewRequestWithBody(t, "POST", "/user2/repo1.git/git-upload-pack", strings.NewReader("0017command=bundle-uri\n00010000")).
+		SetHeader("Content-Type", "application/x-git-upload-pack-request").
+		SetHeader("Git-Protocol", "version=2")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "bundle.mode=all\n")
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf("bundle.%d.uri=%s\n", bundles[0].ID, strings.TrimSuffix(setting.AppURL, "/")+bundleLink))
+	assert.True(t, strings.HasSuffix(resp.Body.String(), "0000"))
+
+	req = NewRequest(t, "GET", bundleLink)
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.True(t, strings.HasPrefix(resp.Body.String(), "# v2 git bundle\n"))
+
+	req = NewRequest(t, "GET", "/user2/repo1.git/bundles/9999.bundle")
+	MakeRequest(t, req, http.StatusNotFound)
+
+	// the bundles of private repositories require the permission
+	req = NewRequest(t, "GET", "/user2/repo2.git/bundles/1.bundle")
+	MakeRequest(t, req, http.StatusUnauthorized)
+}
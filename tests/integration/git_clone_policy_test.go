@@ -0,0 +1,72 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitClonePolicy(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		session := loginUser(t, "user2")
+		req := NewRequest(t, "GET", "/user2/repo1/settings/clone")
+		session.MakeRequest(t, req, http.StatusOK)
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/settings/clone", map[string]string{
+			"partial_clone":       "3",
+			"anonymous_max_depth": "1",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		policy := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoClonePolicy{RepoID: 1})
+		assert.Equal(t, repo_model.PartialCloneRequired, policy.PartialClone)
+		assert.Equal(t, 1, policy.AnonymousMaxDepth)
+
+		httpURL := u.JoinPath("user2/repo1.git").String()
+		t.Run("AnonymousHTTP", func(t *testing.T) {
+			err := git.Clone(t.Context(), httpURL, t.TempDir(), git.CloneRepoOptions{Depth: 1})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "this repository only allows partial clones")
+
+			err = git.Clone(t.Context(), httpURL, t.TempDir(), git.CloneRepoOptions{Filter: "blob:none"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "anonymous fetches of this repository must be shallow")
+
+			require.NoError(t, git.Clone(t.Context(), httpURL, t.TempDir(), git.CloneRepoOptions{Filter: "blob:none", Depth: 1}))
+		})
+
+		t.Run("SSH", func(t *testing.T) {
+			apiTestContext := NewAPITestContext(t, "user2", "repo1", auth_model.AccessTokenScopeWriteUser)
+			withKeyFile(t, "my-testing-key", func(keyFile string) {
+				t.Run("CreateUserKey", doAPICreateUserKey(apiTestContext, "test-key", keyFile))
+				sshURL := createSSHUrl("user2/repo1.git", u).String()
+
+				err := git.Clone(t.Context(), sshURL, t.TempDir(), git.CloneRepoOptions{})
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "this repository only allows partial clones")
+
+				// the depth is only limited for the anonymous users
+				require.NoError(t, git.Clone(t.Context(), sshURL, t.TempDir(), git.CloneRepoOptions{Filter: "blob:none"}))
+			})
+		})
+
+		stats, err := repo_model.GetRepoCloneStats(t.Context(), 1, 0)
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.EqualValues(t, 2, stats[0].PartialCount)
+		assert.Zero(t, stats[0].FullCount)
+
+		req = NewRequest(t, "GET", "/user2/repo1/settings/clone")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Clones in the last 30 days")
+	})
+}
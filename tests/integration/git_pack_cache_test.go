@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitPackCache(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		packStorage, err := storage.NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+		require.NoError(t, err)
+		defer test.MockVariableValue(&storage.PackCache, packStorage)()
+		defer test.MockVariableValue(&setting.PackCache.Enabled, true)()
+
+		countPacks := func() (cnt int) {
+			require.NoError(t, storage.PackCache.IterateObjects("", func(string, storage.Object) error {
+				cnt++
+				return nil
+			}))
+			return cnt
+		}
+
+		u.Path = "user2/repo1.git"
+		dstPath := t.TempDir()
+		t.Run("Clone", doGitClone(filepath.Join(dstPath, "first"), u))
+		assert.Equal(t, 1, countPacks())
+
+		// the same clone is served from the cache
+		t.Run("CloneAgain", doGitClone(filepath.Join(dstPath, "second"), u))
+		assert.Equal(t, 1, countPacks())
+	})
+}
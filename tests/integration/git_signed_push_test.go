@@ -0,0 +1,93 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGitSignedPush(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		// the signing key of user2
+		keyDir := t.TempDir()
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		sshPubKey, err := ssh.NewPublicKey(pub)
+		require.NoError(t, err)
+		block, err := ssh.MarshalPrivateKey(priv, "")
+		require.NoError(t, err)
+		keyFile := filepath.Join(keyDir, "id_ed25519")
+		require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600))
+		key, err := asymkey_model.AddPublicKey(t.Context(), user2.ID, "signing", string(ssh.MarshalAuthorizedKey(sshPubKey)), 0, true)
+		require.NoError(t, err)
+
+		require.NoError(t, git_model.UpdateProtectBranch(t.Context(), repo1, &git_model.ProtectedBranch{
+			RepoID:              repo1.ID,
+			RuleName:            "master",
+			CanPush:             true,
+			RequireSignedPushes: true,
+		}, git_model.WhitelistOptions{}))
+
+		dstPath := t.TempDir()
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword(user2.Name, userPassword)
+		doGitClone(dstPath, u)(t)
+		doGitCheckoutWriteFileCommit(localGitAddCommitOptions{
+			LocalRepoPath:   dstPath,
+			CheckoutBranch:  "master",
+			TreeFilePath:    "signed-push.txt",
+			TreeFileContent: "signed push",
+		})(t)
+		for k, v := range map[string]string{"gpg.format": "ssh", "user.signingkey": keyFile} {
+			_, _, err := gitcmd.NewCommand("config").AddDynamicArguments(k, v).WithDir(dstPath).RunStdString(t.Context())
+			require.NoError(t, err)
+		}
+
+		t.Run("Unsigned", doGitPushTestRepositoryFail(dstPath, "origin", "master"))
+		t.Run("Signed", doGitPushTestRepository(dstPath, "--signed", "origin", "master"))
+
+		t.Run("ListPushCertificates", func(t *testing.T) {
+			token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeReadRepository)
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/push_certificates").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var certs []*api.PushCertificate
+			DecodeJSON(t, resp, &certs)
+			require.Len(t, certs, 1)
+			assert.True(t, certs[0].Verified, certs[0].Reason)
+			assert.Equal(t, "OK", certs[0].NonceStatus)
+			assert.Equal(t, key.Fingerprint, certs[0].SigningKey)
+			assert.Equal(t, user2.Name, certs[0].Pusher.UserName)
+			require.Len(t, certs[0].Updates, 1)
+			assert.Equal(t, "refs/heads/master", certs[0].Updates[0].Ref)
+
+			// only repository admins can audit the pushes
+			token = getUserToken(t, "user4", auth_model.AccessTokenScopeReadRepository)
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/push_certificates").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusForbidden)
+		})
+	})
+}
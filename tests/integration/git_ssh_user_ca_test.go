@@ -0,0 +1,100 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGitSSHUserCA(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		caPub, caPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		caSigner, err := ssh.NewSignerFromKey(caPriv)
+		require.NoError(t, err)
+		caKey, err := ssh.NewPublicKey(caPub)
+		require.NoError(t, err)
+		ca := &asymkey_model.TrustedUserCAKey{Name: "corp", Content: string(ssh.MarshalAuthorizedKey(caKey)), MaxValidity: 3600}
+		require.NoError(t, asymkey_model.AddTrustedUserCAKey(t.Context(), ca))
+
+		sshURL := createSSHUrl("user2/repo1.git", u)
+		withKeyFile(t, "my-testing-key", func(keyFile string) {
+			pubContent, err := os.ReadFile(keyFile + ".pub")
+			require.NoError(t, err)
+			pubKey, _, _, _, err := ssh.ParseAuthorizedKey(pubContent)
+			require.NoError(t, err)
+
+			signCert := func(t *testing.T, principal string, validAfter, validBefore time.Time, criticalOptions map[string]string) {
+				cert := &ssh.Certificate{
+					Key:             pubKey,
+					Serial:          42,
+					CertType:        ssh.UserCert,
+					KeyId:           "ticket-1234",
+					ValidPrincipals: []string{principal},
+					ValidAfter:      uint64(validAfter.Unix()),
+					ValidBefore:     uint64(validBefore.Unix()),
+					Permissions: ssh.Permissions{
+						CriticalOptions: criticalOptions,
+						Extensions:      map[string]string{"permit-pty": ""},
+					},
+				}
+				require.NoError(t, cert.SignCert(rand.Reader, caSigner))
+				require.NoError(t, os.WriteFile(keyFile+"-cert.pub", ssh.MarshalAuthorizedKey(cert), 0o600))
+			}
+
+			// the key is not registered, without a certificate it is rejected
+			t.Run("NoCertificate", doGitCloneFail(sshURL))
+
+			t.Run("Valid", func(t *testing.T) {
+				signCert(t, "user2", time.Now().Add(-time.Minute), time.Now().Add(30*time.Minute), nil)
+				doGitClone(t.TempDir(), sshURL)(t)
+				ca = unittest.AssertExistsAndLoadBean(t, &asymkey_model.TrustedUserCAKey{ID: ca.ID})
+				assert.NotZero(t, ca.LastUsedUnix)
+			})
+
+			t.Run("UnknownPrincipal", func(t *testing.T) {
+				signCert(t, "no-such-user", time.Now().Add(-time.Minute), time.Now().Add(30*time.Minute), nil)
+				doGitCloneFail(sshURL)(t)
+			})
+
+			t.Run("Expired", func(t *testing.T) {
+				signCert(t, "user2", time.Now().Add(-time.Hour), time.Now().Add(-time.Minute), nil)
+				doGitCloneFail(sshURL)(t)
+			})
+
+			t.Run("TooLong", func(t *testing.T) {
+				signCert(t, "user2", time.Now().Add(-time.Minute), time.Now().Add(24*time.Hour), nil)
+				doGitCloneFail(sshURL)(t)
+			})
+
+			t.Run("UnsupportedCriticalOption", func(t *testing.T) {
+				signCert(t, "user2", time.Now().Add(-time.Minute), time.Now().Add(30*time.Minute), map[string]string{"force-command": "/bin/true"})
+				doGitCloneFail(sshURL)(t)
+			})
+
+			t.Run("SourceAddress", func(t *testing.T) {
+				signCert(t, "user2", time.Now().Add(-time.Minute), time.Now().Add(30*time.Minute), map[string]string{"source-address": "192.0.2.1/32"})
+				doGitCloneFail(sshURL)(t)
+			})
+
+			t.Run("RemovedCA", func(t *testing.T) {
+				require.NoError(t, asymkey_model.DeleteTrustedUserCAKey(t.Context(), ca.ID))
+				signCert(t, "user2", time.Now().Add(-time.Minute), time.Now().Add(30*time.Minute), nil)
+				doGitCloneFail(sshURL)(t)
+			})
+		})
+	})
+}
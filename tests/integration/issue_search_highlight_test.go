@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueSearchHighlight(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the keyword is in the comment 2 of the issue 1 of user2/repo1
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issues.UpdateIssueIndexer(t.Context(), issue.ID)
+	time.Sleep(time.Second * 1)
+
+	t.Run("Web", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues?q=good"), http.StatusOK)
+		highlights := map[string]string{}
+		NewHTMLParser(t, resp.Body).Find("#issue-list a.issue-search-highlight").Each(func(_ int, a *goquery.Selection) {
+			highlights[a.AttrOr("href", "")] = a.Find(".fragment > :nth-child(even)").Text()
+		})
+		assert.Equal(t, "good", highlights["/user2/repo1/issues/1#issuecomment-2"])
+	})
+
+	t.Run("API", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all&q=good"), http.StatusOK)
+		var apiIssues []*api.Issue
+		DecodeJSON(t, resp, &apiIssues)
+		var found *api.Issue
+		for _, apiIssue := range apiIssues {
+			if apiIssue.ID == issue.ID {
+				found = apiIssue
+			}
+		}
+		require.NotNil(t, found)
+		require.NotNil(t, found.Highlight)
+		assert.EqualValues(t, 2, found.Highlight.CommentID)
+		assert.Equal(t, "good work!", found.Highlight.Fragment)
+		assert.Equal(t, []*api.IssueSearchHighlightMatch{{Start: 0, End: 4}}, found.Highlight.Matches)
+		assert.Equal(t, setting.AppURL+"user2/repo1/issues/1#issuecomment-2", found.Highlight.HTMLURL)
+
+		// the issues listed without a keyword have no highlight
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all"), http.StatusOK)
+		DecodeJSON(t, resp, &apiIssues)
+		for _, apiIssue := range apiIssues {
+			assert.Nil(t, apiIssue.Highlight)
+		}
+	})
+}
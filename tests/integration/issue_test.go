@@ -115,6 +115,26 @@ func TestViewIssuesKeyword(t *testing.T) {
 	})
 }
 
+func TestViewIssuesQuery(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	req := NewRequestf(t, "GET", "%s/issues?q=%s", repo.Link(), url.QueryEscape("label:label1 is:open"))
+	resp := MakeRequest(t, req, http.StatusOK)
+	issuesSelection := getIssuesSelection(t, NewHTMLParser(t, resp.Body))
+	assert.Equal(t, 1, issuesSelection.Length())
+	issuesSelection.Each(func(_ int, selection *goquery.Selection) {
+		issue := getIssue(t, repo.ID, selection)
+		assert.EqualValues(t, 1, issue.Index)
+	})
+
+	req = NewRequestf(t, "GET", "%s/issues?q=%s", repo.Link(), url.QueryEscape("label:no-such-label"))
+	resp = MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.Equal(t, 0, getIssuesSelection(t, htmlDoc).Length())
+	assert.Contains(t, htmlDoc.doc.Find(".ui.error.message").Text(), `label "no-such-label" doesn't exist`)
+}
+
 func TestNoLoginViewIssue(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -15,6 +15,8 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/migrations"
@@ -70,6 +72,13 @@ func testMirrorPush(t *testing.T, u *url.URL) {
 
 	assert.Equal(t, srcCommit.ID, mirrorCommit.ID)
 
+	// the git notes are mirrored too
+	srcNotesCommit, err := srcGitRepo.GetCommit(git.NotesRef)
+	assert.NoError(t, err)
+	mirrorNotesCommit, err := mirrorGitRepo.GetCommit(git.NotesRef)
+	assert.NoError(t, err)
+	assert.Equal(t, srcNotesCommit.ID, mirrorNotesCommit.ID)
+
 	// Cleanup
 	assert.True(t, doRemovePushMirror(t, session, user.Name, srcRepo.Name, mirrors[0].ID))
 	mirrors, _, err = repo_model.GetPushMirrorsByRepoID(t.Context(), srcRepo.ID, db.ListOptions{})
@@ -126,11 +135,22 @@ func TestRepoSettingPushMirrorUpdate(t *testing.T) {
 	assert.EqualValues(t, 1, cnt)
 	assert.Equal(t, 24*time.Hour, pushMirrors[0].Interval)
 	repo2PushMirrorID := pushMirrors[0].ID
+	pushRefspecsKey := "remote." + pushMirrors[0].RemoteName + ".push"
+	refspecs, err := gitrepo.GitConfigGetAll(t.Context(), repo2, pushRefspecsKey)
+	assert.NoError(t, err)
+	assert.Contains(t, refspecs, "+refs/notes/*:refs/notes/*")
+
+	// a remote added before the git notes were mirrored gets the notes refspec when the push mirror is edited
+	_, _, err = gitcmd.NewCommand("config", "--unset").AddDynamicArguments(pushRefspecsKey, `^\+refs/notes/`).WithDir(repo2.RepoPath()).RunStdString(t.Context())
+	assert.NoError(t, err)
 
 	// update repo2 push mirror
 	assert.True(t, doUpdatePushMirror(t, session, "user2", "repo2", repo2PushMirrorID, "10m0s"))
 	pushMirror := unittest.AssertExistsAndLoadBean(t, &repo_model.PushMirror{ID: repo2PushMirrorID})
 	assert.Equal(t, 10*time.Minute, pushMirror.Interval)
+	updatedRefspecs, err := gitrepo.GitConfigGetAll(t.Context(), repo2, pushRefspecsKey)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, refspecs, updatedRefspecs)
 
 	// avoid updating repo2 push mirror from repo1
 	assert.False(t, doUpdatePushMirror(t, session, "user2", "repo1", repo2PushMirrorID, "20m0s"))
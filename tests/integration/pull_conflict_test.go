@@ -0,0 +1,159 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	pull_service "code.gitea.io/gitea/services/pull"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullResolveConflicts(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		session := loginUser(t, "user1")
+		testRepoFork(t, session, "user2", "repo1", "user1", "repo1", "")
+		testEditFileToNewBranch(t, session, "user1", "repo1", "master", "conflict", "README.md", "Hello, World (Edited Once)\n")
+		testEditFileToNewBranch(t, session, "user1", "repo1", "master", "base", "README.md", "Hello, World (Edited Twice)\n")
+
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		req := NewRequestWithJSON(t, http.MethodPost, "/api/v1/repos/user1/repo1/pulls", &api.CreatePullRequestOption{
+			Head:  "conflict",
+			Base:  "base",
+			Title: "create a conflicting pr",
+		}).AddTokenAuth(token)
+		session.MakeRequest(t, req, http.StatusCreated)
+
+		user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "user1"})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerID: user1.ID, Name: "repo1"})
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{
+			HeadRepoID: repo1.ID,
+			BaseRepoID: repo1.ID,
+			HeadBranch: "conflict",
+			BaseBranch: "base",
+		})
+		require.NoError(t, pr.LoadIssue(t.Context()))
+
+		files, err := pull_service.GetConflictFiles(t.Context(), pr, user1)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, "README.md", files[0].Path)
+		assert.True(t, files[0].IsResolvable)
+		assert.Equal(t, "Hello, World (Edited Once)\n", files[0].Ours)
+		assert.Equal(t, "Hello, World (Edited Twice)\n", files[0].Theirs)
+		assert.Contains(t, files[0].Merged, "<<<<<<< ")
+
+		conflictsLink := fmt.Sprintf("/user1/repo1/pulls/%d/conflicts", pr.Issue.Index)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", conflictsLink), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		headCommitID, _ := htmlDoc.doc.Find(`input[name="head_commit_id"]`).Attr("value")
+		assert.NotEmpty(t, headCommitID)
+		assert.Equal(t, "README.md", htmlDoc.doc.Find(`input[name="path"]`).AttrOr("value", ""))
+		assert.Equal(t, "0", htmlDoc.doc.Find(`input[name="index"]`).AttrOr("value", ""))
+
+		// conflict markers are rejected
+		req = NewRequestWithURLValues(t, "POST", conflictsLink, url.Values{
+			"head_commit_id": {headCommitID},
+			"path":           {"README.md"},
+			"index":          {"0"},
+			"content":        {files[0].Merged},
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		diffCount, err := gitrepo.GetDivergingCommits(t.Context(), repo1, "base", "conflict")
+		require.NoError(t, err)
+		assert.Equal(t, 1, diffCount.Behind)
+
+		req = NewRequestWithURLValues(t, "POST", conflictsLink, url.Values{
+			"head_commit_id": {headCommitID},
+			"path":           {"README.md"},
+			"index":          {"0"},
+			"content":        {"Hello, World (Edited Once and Twice)\r\n"},
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		diffCount, err = gitrepo.GetDivergingCommits(t.Context(), repo1, "base", "conflict")
+		require.NoError(t, err)
+		assert.Equal(t, 0, diffCount.Behind)
+		assert.Equal(t, 2, diffCount.Ahead)
+
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commit, err := gitRepo.GetBranchCommit("conflict")
+		require.NoError(t, err)
+		assert.Equal(t, 2, commit.ParentCount())
+		content, err := commit.GetFileContent("README.md", 1024)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, World (Edited Once and Twice)\n", content)
+
+		t.Run("Hunks", func(t *testing.T) {
+			// both branches add the file, the line endings of the file are kept
+			testCreateFileInBranch(t, user1, repo1, createFileInBranchOptions{OldBranch: "master", NewBranch: "crlf-head"}, map[string]string{"crlf.txt": "a\r\nours\r\nc\r\n"})
+			testCreateFileInBranch(t, user1, repo1, createFileInBranchOptions{OldBranch: "master", NewBranch: "crlf-base"}, map[string]string{"crlf.txt": "a\r\ntheirs\r\nc\r\n"})
+			req := NewRequestWithJSON(t, http.MethodPost, "/api/v1/repos/user1/repo1/pulls", &api.CreatePullRequestOption{
+				Head:  "crlf-head",
+				Base:  "crlf-base",
+				Title: "create a conflicting pr with crlf",
+			}).AddTokenAuth(token)
+			session.MakeRequest(t, req, http.StatusCreated)
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{HeadRepoID: repo1.ID, HeadBranch: "crlf-head", BaseBranch: "crlf-base"})
+			require.NoError(t, pr.LoadIssue(t.Context()))
+
+			files, err := pull_service.GetConflictFiles(t.Context(), pr, user1)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+			require.NotEmpty(t, files[0].Hunks)
+
+			conflictsLink := fmt.Sprintf("/user1/repo1/pulls/%d/conflicts", pr.Issue.Index)
+			resp := session.MakeRequest(t, NewRequest(t, "GET", conflictsLink), http.StatusOK)
+			htmlDoc := NewHTMLParser(t, resp.Body)
+			headCommitID := htmlDoc.doc.Find(`input[name="head_commit_id"]`).AttrOr("value", "")
+			assert.Equal(t, 4, htmlDoc.doc.Find(`input[name="hunk-0-0"]`).Length())
+
+			// the conflicts without a chosen version are not resolved
+			req = NewRequestWithURLValues(t, "POST", conflictsLink, url.Values{
+				"head_commit_id": {headCommitID},
+				"path":           {"crlf.txt"},
+				"index":          {"0"},
+				"content":        {files[0].Merged},
+				"mode-0":         {"hunks"},
+			})
+			session.MakeRequest(t, req, http.StatusSeeOther)
+			diffCount, err := gitrepo.GetDivergingCommits(t.Context(), repo1, "crlf-base", "crlf-head")
+			require.NoError(t, err)
+			assert.Equal(t, 1, diffCount.Behind)
+
+			req = NewRequestWithURLValues(t, "POST", conflictsLink, url.Values{
+				"head_commit_id": {headCommitID},
+				"path":           {"crlf.txt"},
+				"index":          {"0"},
+				"content":        {files[0].Merged},
+				"mode-0":         {"hunks"},
+				"hunk-0-0":       {"theirs"},
+			})
+			session.MakeRequest(t, req, http.StatusSeeOther)
+			diffCount, err = gitrepo.GetDivergingCommits(t.Context(), repo1, "crlf-base", "crlf-head")
+			require.NoError(t, err)
+			assert.Equal(t, 0, diffCount.Behind)
+
+			commit, err := gitRepo.GetBranchCommit("crlf-head")
+			require.NoError(t, err)
+			content, err := commit.GetFileContent("crlf.txt", 1024)
+			require.NoError(t, err)
+			assert.Equal(t, "a\r\ntheirs\r\nc\r\n", content)
+		})
+	})
+}
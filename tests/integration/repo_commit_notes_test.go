@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoCommitNotes(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	const commitLink = "/user2/repo1/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d"
+
+	// the readers can see the notes but can't edit them
+	session := loginUser(t, "user4")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", commitLink), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.doc.Find(".git-notes .commit-body").Text(), "This is a test note")
+	assert.Zero(t, htmlDoc.doc.Find("#git-note-form-new").Length())
+	session.MakeRequest(t, NewRequestWithValues(t, "POST", commitLink+"/notes", map[string]string{"ref": "ci", "message": "test"}), http.StatusNotFound)
+
+	session = loginUser(t, "user2")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", commitLink), http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Equal(t, 1, htmlDoc.doc.Find("#git-note-form-new").Length())
+	assert.Equal(t, "This is a test note\n", htmlDoc.doc.Find("#git-note-form-0 textarea").Text())
+
+	// add a note to another namespace
+	resp = session.MakeRequest(t, NewRequestWithValues(t, "POST", commitLink+"/notes", map[string]string{"ref": "ci", "message": "tests passed"}), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), commitLink)
+	resp = session.MakeRequest(t, NewRequest(t, "GET", commitLink), http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	notes := htmlDoc.doc.Find(".git-notes .commit-body")
+	assert.Equal(t, 2, notes.Length())
+	assert.Contains(t, notes.Last().Text(), "tests passed")
+
+	session.MakeRequest(t, NewRequestWithValues(t, "POST", commitLink+"/notes", map[string]string{"ref": "a..b", "message": "test"}), http.StatusBadRequest)
+
+	// remove it
+	session.MakeRequest(t, NewRequest(t, "POST", commitLink+"/notes/delete?ref=refs/notes/ci"), http.StatusOK)
+	resp = session.MakeRequest(t, NewRequest(t, "GET", commitLink), http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Equal(t, 1, htmlDoc.doc.Find(".git-notes .commit-body").Length())
+}
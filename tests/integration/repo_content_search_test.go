@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	content_indexer "code.gitea.io/gitea/modules/indexer/content"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepoContent(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	content_indexer.UpdateRepoIndexer(1, "")
+
+	resultLinks := func(t *testing.T, link string) []string {
+		resp := MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+		var links []string
+		NewHTMLParser(t, resp.Body).Find(".content-search-results .flex-item-title a.text").Each(func(_ int, a *goquery.Selection) {
+			links = append(links, a.AttrOr("href", ""))
+		})
+		return links
+	}
+
+	t.Run("Repo", func(t *testing.T) {
+		assert.Equal(t, []string{"/user2/repo1/wiki/Page-With-Spaced-Name"}, resultLinks(t, "/user2/repo1/search/wiki?q=spaces"))
+		assert.Equal(t, []string{"/user2/repo1/releases/tag/v1.0"}, resultLinks(t, "/user2/repo1/search/releases?q=pre+release"))
+		assert.Equal(t, []string{"/user2/repo1/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d"}, resultLinks(t, "/user2/repo1/search/commits?q=initial"))
+		assert.Empty(t, resultLinks(t, "/user2/repo1/search/wiki?q=initial"))
+	})
+
+	t.Run("Explore", func(t *testing.T) {
+		assert.Equal(t, []string{"/user2/repo1", "/user2/repo1/releases/tag/v1.0"}, resultLinks(t, "/explore/releases?q=pre+release"))
+	})
+
+	t.Run("API", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		req := NewRequest(t, "GET", "/api/v1/repos/search/commits?q=initial").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var results []*api.ContentSearchResult
+		DecodeJSON(t, resp, &results)
+		require.NotEmpty(t, results)
+		found := false
+		for _, result := range results {
+			assert.Equal(t, "commit", result.Kind)
+			if result.Repository.FullName == "user2/repo1" {
+				found = true
+				assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", result.Ref)
+				assert.Equal(t, "Initial commit", result.Title)
+			}
+		}
+		assert.True(t, found)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/search/commits").AddTokenAuth(token), http.StatusUnprocessableEntity)
+	})
+}
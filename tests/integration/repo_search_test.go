@@ -5,11 +5,15 @@ package integration
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/PuerkitoBio/goquery"
@@ -51,6 +55,60 @@ func TestSearchRepo(t *testing.T) {
 	testSearch(t, "/user2/glob/search?q=file5&page=1&t=match", []string{"x/b.txt", "a.txt"})
 }
 
+func TestSearchRepoRegexp(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Indexer.IncludePatterns, nil)()
+	defer test.MockVariableValue(&setting.Indexer.ExcludePatterns, nil)()
+
+	repo, err := repo_model.GetRepositoryByOwnerAndName(t.Context(), "user2", "glob")
+	assert.NoError(t, err)
+
+	code_indexer.UpdateRepoIndexer(repo)
+
+	testSearch(t, "/user2/glob/search?q=^file[3]$&search_mode=regexp", []string{"x/b.txt"})
+	testSearch(t, "/user2/glob/search?q=file[35]+path:x/y/*&search_mode=regexp", []string{"x/y/z/a.txt"})
+	testSearch(t, "/user2/glob/search?q=file[12]+path:*.txt&search_mode=regexp", []string{"a.txt"})
+	testSearch(t, "/user2/glob/search?q=loren+ipsum+path:*.txt&search_mode=exact", []string{"a.txt"})
+
+	req := NewRequest(t, "GET", "/user2/glob/search?q=file(&search_mode=regexp")
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, NewHTMLParser(t, resp.Body).Find(".ui.error.message").Text(), "The regular expression is invalid")
+}
+
+func TestSearchRepoSymbols(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, _ *url.URL) {
+		defer test.MockVariableValue(&setting.Indexer.IncludePatterns, nil)()
+		defer test.MockVariableValue(&setting.Indexer.ExcludePatterns, nil)()
+
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, err := repo_model.GetRepositoryByOwnerAndName(t.Context(), "user2", "repo1")
+		assert.NoError(t, err)
+		assert.NoError(t, createOrReplaceFileInBranch(user2, repo, "log/parse.go", repo.DefaultBranch,
+			"package log\n\n// ParseLog parses a log line\nfunc ParseLog(line string) string {\n\treturn line\n}\n"))
+		assert.NoError(t, createOrReplaceFileInBranch(user2, repo, "main.go", repo.DefaultBranch,
+			"package main\n\nimport \"log\"\n\nfunc main() {\n\tprintln(log.ParseLog(\"ParseLogs\"))\n}\n"))
+
+		code_indexer.UpdateRepoIndexer(repo)
+
+		testSearch(t, "/user2/repo1/search?q=sym:ParseLog&page=1", []string{"log/parse.go"})
+		testSearch(t, "/user2/repo1/search?q=ref:ParseLog&page=1", []string{"main.go"})
+		testSearch(t, "/user2/repo1/search?q=sym:main&page=1", []string{"main.go"})
+		testSearch(t, "/user2/repo1/search?q=sym:parselog&page=1", []string{})
+
+		t.Run("FileView", func(t *testing.T) {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/src/branch/master/log/parse.go"), http.StatusOK)
+			doc := NewHTMLParser(t, resp.Body)
+			assert.Equal(t, "/user2/repo1/search", doc.Find(".code-view").AttrOr("data-symbol-search-url", ""))
+			assert.Equal(t, "#L4", doc.Find(".file-symbols-dropdown .menu .item a").First().AttrOr("href", ""))
+			assert.Equal(t, "/user2/repo1/search?q=ref%3AParseLog", doc.Find(".file-symbols-dropdown .menu .item a").Last().AttrOr("href", ""))
+
+			resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/src/branch/master/README.md?display=source"), http.StatusOK)
+			doc = NewHTMLParser(t, resp.Body)
+			assert.Zero(t, doc.Find(".file-symbols-dropdown").Length())
+		})
+	})
+}
+
 func testSearch(t *testing.T, url string, expected []string) {
 	req := NewRequest(t, "GET", url)
 	resp := MakeRequest(t, req, http.StatusOK)
@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSavedSearches(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session2 := loginUser(t, "user2")
+	session4 := loginUser(t, "user4")
+
+	t.Run("SaveFromDashboard", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/issues?type=created_by&state=closed&q=bug")
+		resp := session2.MakeRequest(t, req, http.StatusOK)
+		link, ok := NewHTMLParser(t, resp.Body).Find(".list-header a[href*='/user/settings/saved_searches?']").Attr("href")
+		assert.True(t, ok)
+		u, err := url.Parse(link)
+		assert.NoError(t, err)
+		assert.Equal(t, "is:closed author:@me bug", u.Query().Get("q"))
+		assert.Equal(t, "issues", u.Query().Get("type"))
+
+		req = NewRequest(t, "GET", link)
+		resp = session2.MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "is:closed author:@me bug", NewHTMLParser(t, resp.Body).Find("input[name=q]").AttrOr("value", ""))
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"name": "org3 assigned",
+			"q":    "assignee:@me",
+			"type": "issues",
+			"org":  "3",
+			"team": "2",
+		})
+		session2.MakeRequest(t, req, http.StatusSeeOther)
+		assert.NotEmpty(t, session2.GetCookieFlashMessage().SuccessMsg)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearch{OwnerID: 2, Name: "org3 assigned", OrgID: 3, TeamID: 2})
+
+		req = NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"name": "invalid",
+			"q":    "is:unknown",
+			"type": "issues",
+		})
+		session2.MakeRequest(t, req, http.StatusSeeOther)
+		assert.NotEmpty(t, session2.GetCookieFlashMessage().ErrorMsg)
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{OwnerID: 2, Name: "invalid"})
+	})
+
+	search := unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearch{OwnerID: 2, Name: "org3 assigned"})
+	searchLink := "/user/settings/saved_searches/" + strconv.FormatInt(search.ID, 10)
+
+	t.Run("SharedWithTeam", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/user/settings/saved_searches")
+		resp := session4.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, NewHTMLParser(t, resp.Body).Find(".flex-item-title").Text(), "org3 assigned")
+
+		req = NewRequest(t, "GET", "/org/org3/issues?type=your_repositories")
+		resp = session4.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, NewHTMLParser(t, resp.Body).Find(".list-header").Text(), "org3 assigned")
+	})
+
+	t.Run("Subscribe", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", searchLink+"/subscribe", map[string]string{
+			"frequency": "daily",
+			"method":    "email",
+		})
+		session4.MakeRequest(t, req, http.StatusSeeOther)
+		assert.NotEmpty(t, session4.GetCookieFlashMessage().SuccessMsg)
+		sub := unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearchSubscription{SavedSearchID: search.ID, UserID: 4})
+		assert.Equal(t, issues_model.SavedSearchFrequencyDaily, sub.Frequency)
+		assert.Equal(t, issues_model.SavedSearchNotifyMethodEmail, sub.Method)
+
+		// user5 isn't a member of the team which the saved search is shared with
+		session5 := loginUser(t, "user5")
+		req = NewRequestWithValues(t, "POST", searchLink+"/subscribe", map[string]string{
+			"frequency": "daily",
+			"method":    "email",
+		})
+		session5.MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "POST", searchLink+"/unsubscribe")
+		session4.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearchSubscription{SavedSearchID: search.ID, UserID: 4})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		// only the owner could delete the saved search
+		req := NewRequest(t, "POST", searchLink+"/delete")
+		session4.MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "POST", searchLink+"/delete")
+		session2.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{ID: search.ID})
+	})
+}
@@ -130,6 +130,16 @@ export function initRepoCodeView() {
     showLineButton();
   });
 
+  // ctrl-click (or cmd-click) on an identifier goes to its definitions, with shift it finds its references
+  addDelegatedEventListener(document, 'click', '.code-view[data-symbol-search-url] .lines-code span', (el: HTMLElement, e: MouseEvent) => {
+    if (!e.ctrlKey && !e.metaKey) return;
+    const name = el.textContent.trim();
+    if (!/^[\p{L}_][\p{L}\p{N}_]*$/u.test(name)) return;
+    e.preventDefault();
+    const searchUrl = el.closest('.code-view')!.getAttribute('data-symbol-search-url');
+    window.location.href = `${searchUrl}?q=${encodeURIComponent(`${e.shiftKey ? 'ref' : 'sym'}:${name}`)}`;
+  });
+
   // apply the selected range from the URL hash
   const onHashChange = () => {
     if (!window.location.hash) return;
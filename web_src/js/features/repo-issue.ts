@@ -17,6 +17,7 @@ import {initRepoIssueSidebar} from './repo-issue-sidebar.ts';
 import {fomanticQuery} from '../modules/fomantic/base.ts';
 import {ignoreAreYouSure} from '../vendor/jquery.are-you-sure.ts';
 import {registerGlobalInitFunc} from '../modules/observer.ts';
+import {debounce} from 'throttle-debounce';
 
 const {appSubUrl} = window.config;
 
@@ -320,6 +321,8 @@ export function initRepoPullRequestReview() {
     elReviewPanel.querySelector('.close')!.addEventListener('click', () => tippy.hide());
   }
 
+  // the last clicked line, shift-clicking a later line on the same side of the file comments on the range of lines
+  let lastCodeCommentLine: {path: string, side: string, idx: number} | null = null;
   addDelegatedEventListener(document, 'click', '.add-code-comment', async (el, e) => {
     e.preventDefault();
 
@@ -327,6 +330,19 @@ export function initRepoPullRequestReview() {
     const side = el.getAttribute('data-side')!;
     const idx = el.getAttribute('data-idx')!;
     const path = el.closest('[data-path]')?.getAttribute('data-path');
+    let startIdx = 0;
+    const last = lastCodeCommentLine;
+    if (e.shiftKey && last && last.path === path && last.side === side && last.idx < Number(idx)) {
+      startIdx = last.idx;
+    }
+    lastCodeCommentLine = {path: String(path), side, idx: Number(idx)};
+    for (const row of document.querySelectorAll('tr.code-comment-range')) row.classList.remove('code-comment-range');
+    if (startIdx) {
+      for (const btn of document.querySelectorAll(`[data-path="${path}"] .add-code-comment[data-side="${side}"]`)) {
+        const btnIdx = Number(btn.getAttribute('data-idx'));
+        if (btnIdx >= startIdx && btnIdx <= Number(idx)) btn.closest('tr')!.classList.add('code-comment-range');
+      }
+    }
     const tr = el.closest('tr')!;
     const lineType = tr.getAttribute('data-line-type')!;
 
@@ -354,6 +370,8 @@ export function initRepoPullRequestReview() {
       const editor = await initComboMarkdownEditor(td.querySelector<HTMLElement>('.combo-markdown-editor')!);
       editor.focus();
     }
+    const startLineInput = td.querySelector<HTMLInputElement>("input[name='start_line']");
+    if (startLineInput) startLineInput.value = startIdx ? String(startIdx) : '';
   });
 }
 
@@ -414,6 +432,32 @@ export function initRepoIssueWipNewTitle() {
   }));
 }
 
+export function initRepoIssueSimilarIssues() {
+  // Show the issues similar to the title of a new issue
+  registerGlobalInitFunc('initRepoIssueSimilarIssues', (el) => {
+    const titleInput = document.querySelector<HTMLInputElement>('#issue_title')!;
+    const url = el.getAttribute('data-url')!;
+    let requestCount = 0;
+    const update = debounce(500, async () => {
+      const title = titleInput.value.trim();
+      const current = ++requestCount;
+      if (!title) {
+        el.innerHTML = '';
+        return;
+      }
+      try {
+        const response = await GET(`${url}?${new URLSearchParams({title})}`);
+        if (current !== requestCount) return; // a newer title is being searched
+        el.innerHTML = response.ok ? await response.text() : '';
+      } catch (error) {
+        console.error('Error fetching similar issues:', error);
+      }
+    });
+    titleInput.addEventListener('input', update);
+    if (titleInput.value) update();
+  });
+}
+
 export function initRepoIssueWipToggle() {
   // Toggle WIP for existing PR
   registerGlobalInitFunc('initPullRequestWipToggle', (toggleWip) => toggleWip.addEventListener('click', async (e) => {
@@ -3,7 +3,7 @@ import {
   initRepoCommentFormAndSidebar,
   initRepoIssueBranchSelect, initRepoIssueCodeCommentCancel, initRepoIssueCommentDelete,
   initRepoIssueComments, initRepoIssueReferenceIssue,
-  initRepoIssueTitleEdit, initRepoIssueWipNewTitle, initRepoIssueWipToggle,
+  initRepoIssueTitleEdit, initRepoIssueWipNewTitle, initRepoIssueWipToggle, initRepoIssueSimilarIssues,
 } from './repo-issue.ts';
 import {initUnicodeEscapeButton} from './repo-unicode-escape.ts';
 import {initRepoCloneButtons} from './repo-common.ts';
@@ -53,6 +53,7 @@ export function initRepository() {
   initCitationFileCopyContent();
   initRepoSettings();
   initRepoIssueWipNewTitle();
+  initRepoIssueSimilarIssues();
 
   // Issues
   if (pageContent.matches('.page-content.repository.view.issue')) {
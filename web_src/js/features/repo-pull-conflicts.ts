@@ -0,0 +1,18 @@
+import {registerGlobalInitFunc} from '../modules/observer.ts';
+
+export function initRepoPullConflicts() {
+  registerGlobalInitFunc('initRepoPullConflictFile', (el: HTMLElement) => {
+    // editing the whole file or choosing the version of a conflict switches the mode of the file,
+    // otherwise the edits or the choices would be ignored when the other mode is submitted
+    const modeFile = el.querySelector<HTMLInputElement>('input.conflict-mode[value="file"]')!;
+    const modeHunks = el.querySelector<HTMLInputElement>('input.conflict-mode[value="hunks"]')!;
+    el.querySelector('textarea.conflict-editor')!.addEventListener('input', () => {
+      modeFile.checked = true;
+    });
+    for (const input of el.querySelectorAll<HTMLInputElement>('.conflict-hunk input[type="radio"]')) {
+      input.addEventListener('change', () => {
+        modeHunks.checked = true;
+      });
+    }
+  });
+}
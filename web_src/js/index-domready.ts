@@ -17,6 +17,7 @@ import {initNotificationCount} from './features/notification.ts';
 import {initRepoIssueContentHistory} from './features/repo-issue-content.ts';
 import {initStopwatch} from './features/stopwatch.ts';
 import {initRepoFileSearch} from './features/repo-findfile.ts';
+import {initRepoPullConflicts} from './features/repo-pull-conflicts.ts';
 import {initMarkupContent} from './markup/content.ts';
 import {initRepoFileView} from './features/file-view.ts';
 import {initUserAuthOauth2, initUserCheckAppUrl} from './features/user-auth.ts';
@@ -131,6 +132,7 @@ const initPerformanceTracer = callInitFunctions([
   initRepoMigration,
   initRepoMigrationStatusChecker,
   initRepoProject,
+  initRepoPullConflicts,
   initRepoPullRequestAllowMaintainerEdit,
   initRepoPullRequestReview,
   initRepoReleaseNew,